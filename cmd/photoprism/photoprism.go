@@ -59,7 +59,9 @@ func main() {
 		commands.ImportCommand,
 		commands.FacesCommand,
 		commands.MomentsCommand,
+		commands.OcrCommand,
 		commands.OptimizeCommand,
+		commands.TimeShiftCommand,
 		commands.PurgeCommand,
 		commands.CleanUpCommand,
 		commands.CopyCommand,
@@ -71,6 +73,7 @@ func main() {
 		commands.ResetCommand,
 		commands.ConfigCommand,
 		commands.UsersCommand,
+		commands.AuditCommand,
 		commands.PasswdCommand,
 		commands.VersionCommand,
 		commands.StatusCommand,
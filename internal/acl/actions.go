@@ -19,4 +19,6 @@ const (
 	ActionComment    Action = "comment"
 	ActionExport     Action = "export"
 	ActionImport     Action = "import"
+	ActionLogin      Action = "login"
+	ActionLogout     Action = "logout"
 )
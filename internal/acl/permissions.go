@@ -5,8 +5,11 @@ var Permissions = ACL{
 		RoleAdmin: Actions{ActionDefault: true},
 	},
 	ResourceConfig: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionRead: true},
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionRead: true},
+		RoleChild:  Actions{ActionRead: true},
+		RoleFriend: Actions{ActionRead: true},
+		RoleGuest:  Actions{ActionRead: true},
 	},
 	ResourceConfigOptions: Roles{
 		RoleAdmin: Actions{ActionDefault: true},
@@ -15,14 +18,27 @@ var Permissions = ACL{
 		RoleAdmin: Actions{ActionDefault: true},
 	},
 	ResourceAlbums: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionSearch: true, ActionRead: true},
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true, ActionUpdate: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true},
+		RoleGuest:  Actions{ActionSearch: true, ActionRead: true},
 	},
 	ResourcePhotos: Roles{
-		RoleAdmin: Actions{ActionDefault: true},
-		RoleGuest: Actions{ActionSearch: true, ActionRead: true, ActionDownload: true},
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionSearch: true, ActionRead: true, ActionDownload: true, ActionUpdate: true},
+		RoleChild:  Actions{ActionSearch: true, ActionRead: true, ActionDownload: true},
+		RoleFriend: Actions{ActionSearch: true, ActionRead: true, ActionDownload: true},
+		RoleGuest:  Actions{ActionSearch: true, ActionRead: true, ActionDownload: true},
 	},
 	ResourceUsers: Roles{
 		RoleDefault: Actions{ActionUpdateSelf: true},
 	},
+	ResourceWebDAV: Roles{
+		RoleAdmin:  Actions{ActionDefault: true},
+		RoleFamily: Actions{ActionRead: true, ActionUpdate: true},
+		RoleChild:  Actions{ActionRead: true},
+		RoleFriend: Actions{ActionRead: true},
+		RoleGuest:  Actions{ActionRead: true},
+	},
 }
@@ -28,6 +28,30 @@ func TestACL_Allow(t *testing.T) {
 	t.Run("albums/guest/default", func(t *testing.T) {
 		assert.False(t, Permissions.Allow(ResourceAlbums, RoleGuest, ActionDefault))
 	})
+	t.Run("photos/family/update", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourcePhotos, RoleFamily, ActionUpdate))
+	})
+	t.Run("photos/child/download", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourcePhotos, RoleChild, ActionDownload))
+	})
+	t.Run("photos/friend/update", func(t *testing.T) {
+		assert.False(t, Permissions.Allow(ResourcePhotos, RoleFriend, ActionUpdate))
+	})
+	t.Run("albums/friend/search", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourceAlbums, RoleFriend, ActionSearch))
+	})
+	t.Run("config/child/read", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourceConfig, RoleChild, ActionRead))
+	})
+	t.Run("webdav/family/update", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourceWebDAV, RoleFamily, ActionUpdate))
+	})
+	t.Run("webdav/guest/read", func(t *testing.T) {
+		assert.True(t, Permissions.Allow(ResourceWebDAV, RoleGuest, ActionRead))
+	})
+	t.Run("webdav/guest/update", func(t *testing.T) {
+		assert.False(t, Permissions.Allow(ResourceWebDAV, RoleGuest, ActionUpdate))
+	})
 }
 
 func TestACL_Deny(t *testing.T) {
@@ -17,6 +17,7 @@ const (
 	ResourceFiles         Resource = "files"
 	ResourceFolders       Resource = "folders"
 	ResourceLabels        Resource = "labels"
+	ResourceTags          Resource = "tags"
 	ResourceLenses        Resource = "lenses"
 	ResourceLinks         Resource = "links"
 	ResourceGeo           Resource = "geo"
@@ -25,4 +26,6 @@ const (
 	ResourcePhotos        Resource = "photos"
 	ResourcePlaces        Resource = "places"
 	ResourceFeedback      Resource = "feedback"
+	ResourceWebDAV        Resource = "webdav"
+	ResourceWebhooks      Resource = "webhooks"
 )
@@ -0,0 +1,67 @@
+/*
+
+Package acme obtains and renews TLS certificates from ACME providers like Let's Encrypt.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package acme
+
+import (
+	"github.com/photoprism/photoprism/internal/event"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+var log = event.Log
+
+// Config represents ACME account and certificate settings.
+type Config struct {
+	Domains   []string
+	Email     string
+	Directory string
+	CachePath string
+}
+
+// NewManager returns a certificate manager for the configured domains,
+// certificates are requested on first use and renewed before they expire.
+func NewManager(c Config) *autocert.Manager {
+	m := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(c.Domains...),
+		Email:      c.Email,
+		Client:     &acme.Client{DirectoryURL: c.Directory},
+	}
+
+	if c.CachePath != "" {
+		m.Cache = autocert.DirCache(c.CachePath)
+	}
+
+	log.Debugf("acme: using directory %s for %d domains", c.Directory, len(c.Domains))
+
+	return m
+}
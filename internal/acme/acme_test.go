@@ -0,0 +1,66 @@
+package acme
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewManager(t *testing.T) {
+	srv := NewTestServer()
+	defer srv.Close()
+
+	cachePath, err := ioutil.TempDir("", "acme")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(cachePath)
+
+	m := NewManager(Config{
+		Domains:   []string{"photos.example.com"},
+		Email:     "admin@example.com",
+		Directory: srv.URL(),
+		CachePath: cachePath,
+	})
+
+	hello := func(name string) *tls.ClientHelloInfo {
+		return &tls.ClientHelloInfo{ServerName: name, CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256}}
+	}
+
+	t.Run("Issue", func(t *testing.T) {
+		cert, err := m.GetCertificate(hello("photos.example.com"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"photos.example.com"}, leaf.DNSNames)
+		assert.NoError(t, leaf.CheckSignatureFrom(srv.CA()))
+		assert.Equal(t, 1, srv.Issued())
+	})
+	t.Run("Cached", func(t *testing.T) {
+		if _, err := m.GetCertificate(hello("photos.example.com")); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, srv.Issued())
+	})
+	t.Run("UnknownDomain", func(t *testing.T) {
+		_, err := m.GetCertificate(hello("www.example.com"))
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, srv.Issued())
+	})
+}
@@ -0,0 +1,308 @@
+package acme
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TestServer is an in-process ACME server stand-in for tests, similar to Pebble
+// with challenge validation disabled: orders are ready right away.
+type TestServer struct {
+	Server *httptest.Server
+	caCert *x509.Certificate
+	caKey  *ecdsa.PrivateKey
+	caDER  []byte
+	orders map[string]*testOrder
+	mutex  sync.Mutex
+}
+
+type testOrder struct {
+	ID          string
+	Identifiers []testIdentifier
+	Chain       []byte
+}
+
+type testIdentifier struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// NewTestServer starts a new test server with a self-signed CA.
+func NewTestServer() *TestServer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		panic(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test ACME CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+
+	if err != nil {
+		panic(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+
+	if err != nil {
+		panic(err)
+	}
+
+	s := &TestServer{caCert: cert, caKey: key, caDER: der, orders: make(map[string]*testOrder)}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// URL returns the directory URL.
+func (s *TestServer) URL() string {
+	return s.Server.URL + "/directory"
+}
+
+// CA returns the certificate authority that signs issued certificates.
+func (s *TestServer) CA() *x509.Certificate {
+	return s.caCert
+}
+
+// Issued returns the number of issued certificates.
+func (s *TestServer) Issued() (count int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, o := range s.orders {
+		if len(o.Chain) > 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Close shuts down the test server.
+func (s *TestServer) Close() {
+	s.Server.Close()
+}
+
+func (s *TestServer) handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Replay-Nonce", base64.RawURLEncoding.EncodeToString(big.NewInt(time.Now().UnixNano()).Bytes()))
+
+	base := s.Server.URL
+	p := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case p[0] == "directory":
+		writeJson(w, http.StatusOK, map[string]string{
+			"newNonce":   base + "/nonce",
+			"newAccount": base + "/account",
+			"newOrder":   base + "/order",
+			"revokeCert": base + "/revoke",
+			"keyChange":  base + "/key-change",
+		})
+	case p[0] == "nonce":
+		w.WriteHeader(http.StatusOK)
+	case p[0] == "account" && r.Method == http.MethodPost:
+		w.Header().Set("Location", base+"/account/1")
+		writeJson(w, http.StatusCreated, map[string]string{"status": "valid"})
+	case p[0] == "order" && len(p) == 1 && r.Method == http.MethodPost:
+		var req struct {
+			Identifiers []testIdentifier `json:"identifiers"`
+		}
+
+		if err := decodePayload(r, &req); err != nil {
+			writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+			return
+		}
+
+		s.mutex.Lock()
+		o := &testOrder{ID: fmt.Sprintf("%d", len(s.orders)+1), Identifiers: req.Identifiers}
+		s.orders[o.ID] = o
+		s.mutex.Unlock()
+
+		w.Header().Set("Location", base+"/order/"+o.ID)
+		writeJson(w, http.StatusCreated, s.order(o))
+	case p[0] == "order" && len(p) == 2:
+		o := s.find(p[1])
+
+		if o == nil {
+			writeProblem(w, http.StatusNotFound, "malformed", "order not found")
+			return
+		}
+
+		w.Header().Set("Location", base+"/order/"+o.ID)
+		writeJson(w, http.StatusOK, s.order(o))
+	case p[0] == "finalize" && len(p) == 2:
+		o := s.find(p[1])
+
+		if o == nil {
+			writeProblem(w, http.StatusNotFound, "malformed", "order not found")
+			return
+		}
+
+		var req struct {
+			CSR string `json:"csr"`
+		}
+
+		if err := decodePayload(r, &req); err != nil {
+			writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+			return
+		}
+
+		if err := s.issue(o, req.CSR); err != nil {
+			writeProblem(w, http.StatusBadRequest, "badCSR", err.Error())
+			return
+		}
+
+		w.Header().Set("Location", base+"/order/"+o.ID)
+		writeJson(w, http.StatusOK, s.order(o))
+	case p[0] == "cert" && len(p) == 2:
+		o := s.find(p[1])
+
+		if o == nil || len(o.Chain) == 0 {
+			writeProblem(w, http.StatusNotFound, "malformed", "certificate not found")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(o.Chain)
+	default:
+		writeProblem(w, http.StatusNotFound, "malformed", "not found")
+	}
+}
+
+// find returns the order with the given id, nil if not found.
+func (s *TestServer) find(id string) *testOrder {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.orders[id]
+}
+
+// order returns the order resource.
+func (s *TestServer) order(o *testOrder) map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	result := map[string]interface{}{
+		"status":         "ready",
+		"expires":        time.Now().Add(time.Hour).Format(time.RFC3339),
+		"identifiers":    o.Identifiers,
+		"authorizations": []string{},
+		"finalize":       s.Server.URL + "/finalize/" + o.ID,
+	}
+
+	if len(o.Chain) > 0 {
+		result["status"] = "valid"
+		result["certificate"] = s.Server.URL + "/cert/" + o.ID
+	}
+
+	return result
+}
+
+// issue signs the certificate request of an order.
+func (s *TestServer) issue(o *testOrder, csr string) error {
+	der, err := base64.RawURLEncoding.DecodeString(csr)
+
+	if err != nil {
+		return err
+	}
+
+	req, err := x509.ParseCertificateRequest(der)
+
+	if err != nil {
+		return err
+	} else if err = req.CheckSignature(); err != nil {
+		return err
+	}
+
+	names := make(map[string]bool)
+
+	for _, id := range o.Identifiers {
+		names[id.Value] = true
+	}
+
+	for _, name := range req.DNSNames {
+		if !names[name] {
+			return fmt.Errorf("%s is not part of the order", name)
+		}
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: req.Subject.CommonName},
+		DNSNames:     req.DNSNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	leaf, err := x509.CreateCertificate(rand.Reader, tmpl, s.caCert, req.PublicKey, s.caKey)
+
+	if err != nil {
+		return err
+	}
+
+	chain := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf})
+	chain = append(chain, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.caDER})...)
+
+	s.mutex.Lock()
+	o.Chain = chain
+	s.mutex.Unlock()
+
+	return nil
+}
+
+// decodePayload decodes the payload of a JWS request, signatures are not verified.
+func decodePayload(r *http.Request, v interface{}) error {
+	var jws struct {
+		Payload string `json:"payload"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&jws); err != nil {
+		return err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(payload, v)
+}
+
+func writeJson(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeProblem(w http.ResponseWriter, status int, problem, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"type": "urn:ietf:params:acme:error:" + problem, "detail": detail})
+}
@@ -244,10 +244,13 @@ func CreateAccount(router *gin.RouterGroup) {
 
 		if err != nil {
 			log.Error(err)
+			Audit(c, s, acl.ActionCreate, acl.ResourceAccounts, "", err)
 			AbortBadRequest(c)
 			return
 		}
 
+		Audit(c, s, acl.ActionCreate, acl.ResourceAccounts, fmt.Sprint(m.ID), nil)
+
 		event.SuccessMsg(i18n.MsgAccountCreated)
 
 		c.JSON(http.StatusOK, m)
@@ -353,10 +356,13 @@ func DeleteAccount(router *gin.RouterGroup) {
 		}
 
 		if err := m.Delete(); err != nil {
+			Audit(c, s, acl.ActionDelete, acl.ResourceAccounts, fmt.Sprint(m.ID), err)
 			Error(c, http.StatusInternalServerError, err, i18n.ErrDeleteFailed)
 			return
 		}
 
+		Audit(c, s, acl.ActionDelete, acl.ResourceAccounts, fmt.Sprint(m.ID), nil)
+
 		event.SuccessMsg(i18n.MsgAccountDeleted)
 
 		c.JSON(http.StatusOK, m)
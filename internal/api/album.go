@@ -122,6 +122,11 @@ func CreateAlbum(router *gin.RouterGroup) {
 		a := entity.NewAlbum(f.AlbumTitle, entity.AlbumDefault)
 		a.AlbumFavorite = f.AlbumFavorite
 
+		if err := a.SetParent(f.ParentUID); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
 		log.Debugf("album: creating %+v %+v", f, a)
 
 		if res := entity.Db().Create(a); res.Error != nil {
@@ -173,6 +178,14 @@ func UpdateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Validate parent album changes.
+		if f.ParentUID != a.ParentUID {
+			if err := a.SetParent(f.ParentUID); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+		}
+
 		if err := a.SaveForm(f); err != nil {
 			log.Error(err)
 			AbortSaveFailed(c)
@@ -197,6 +210,7 @@ func DeleteAlbum(router *gin.RouterGroup) {
 		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionDelete)
 
 		if s.Invalid() {
+			AuditDenied(c, s, acl.ActionDelete, acl.ResourceAlbums, c.Param("uid"))
 			AbortUnauthorized(c)
 			return
 		}
@@ -210,12 +224,16 @@ func DeleteAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Nested albums are moved to the parent album.
 		if err := a.Delete(); err != nil {
 			log.Errorf("album: %s (delete)", err)
+			Audit(c, s, acl.ActionDelete, acl.ResourceAlbums, id, err)
 			AbortDeleteFailed(c)
 			return
 		}
 
+		Audit(c, s, acl.ActionDelete, acl.ResourceAlbums, id, nil)
+
 		PublishAlbumEvent(EntityDeleted, id, c)
 
 		UpdateClientConfig()
@@ -300,6 +318,61 @@ func DislikeAlbum(router *gin.RouterGroup) {
 	})
 }
 
+// POST /api/v1/albums/:uid/follow
+//
+// Parameters:
+//   uid: string Album UID
+func FollowAlbum(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/follow", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionRead)
+
+		if s.Invalid() || !s.User.Registered() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		f, err := entity.FollowAlbum(a.AlbumUID, s.User.UserUID)
+
+		if err != nil {
+			log.Errorf("album: %s (follow)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, f)
+	})
+}
+
+// DELETE /api/v1/albums/:uid/follow
+//
+// Parameters:
+//   uid: string Album UID
+func UnfollowAlbum(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/follow", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionRead)
+
+		if s.Invalid() || !s.User.Registered() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if err := entity.UnfollowAlbum(c.Param("uid"), s.User.UserUID); err != nil {
+			log.Errorf("album: %s (unfollow)", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgChangesSaved))
+	})
+}
+
 // POST /api/v1/albums/:uid/clone
 func CloneAlbums(router *gin.RouterGroup) {
 	router.POST("/albums/:uid/clone", func(c *gin.Context) {
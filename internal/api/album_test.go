@@ -303,3 +303,39 @@ func TestCloneAlbums(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 }
+
+func TestFollowAlbum(t *testing.T) {
+	t.Run("not existing album", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		FollowAlbum(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+		r := AuthenticatedRequest(app, "POST", "/api/v1/albums/xxx/follow", sessId)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("follow and unfollow", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		FollowAlbum(router)
+		UnfollowAlbum(router)
+		sessId := AuthenticateUser(app, router, "alice", "Alice123!")
+
+		r := AuthenticatedRequest(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/follow", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "at9lxuqxpogaaba7", gjson.Get(r.Body.String(), "AlbumUID").String())
+		assert.Equal(t, "uqxetse3cy5eo9z2", gjson.Get(r.Body.String(), "UserUID").String())
+
+		r = AuthenticatedRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba7/follow", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		FollowAlbum(router)
+		r := PerformRequest(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/follow")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+}
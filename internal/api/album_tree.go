@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GET /api/v1/albums/:uid/children
+//
+// Parameters:
+//   uid: string Album UID
+func GetAlbumChildren(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/children", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := c.Param("uid")
+
+		if _, err := query.AlbumByUID(uid); err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		f := form.AlbumSearch{Parent: uid, Count: query.MaxResults, Order: "slug"}
+
+		// Guest permissions are limited to shared albums.
+		if s.Guest() {
+			if !s.HasShare(uid) {
+				AbortUnauthorized(c)
+				return
+			}
+
+			f.ID = s.Shares.Join(query.Or)
+		}
+
+		result, err := query.AlbumSearch(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddTokenHeaders(c)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GET /api/v1/albums/:uid/breadcrumbs
+//
+// Returns the parent albums starting at the top level, followed by the album itself.
+//
+// Parameters:
+//   uid: string Album UID
+func GetAlbumBreadcrumbs(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/breadcrumbs", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		a, err := query.AlbumByUID(c.Param("uid"))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+			return
+		}
+
+		result := append(a.Ancestors(), a)
+
+		// Guests can only see shared albums.
+		if s.Guest() {
+			shared := entity.Albums{}
+
+			for _, album := range result {
+				if s.HasShare(album.AlbumUID) {
+					shared = append(shared, album)
+				}
+			}
+
+			result = shared
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
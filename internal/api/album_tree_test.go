@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetAlbumChildren(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		GetAlbumChildren(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Christmas Child", "ParentUID": "at9lxuqxpogaaba7"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		uid := gjson.Get(r.Body.String(), "UID").String()
+		r = PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba7/children")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, uid, gjson.Get(r.Body.String(), "0.UID").String())
+	})
+	t.Run("invalid parent", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Orphan", "ParentUID": "at9lxuqxpogaaxxx"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumChildren(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/999000/children")
+		assert.Equal(t, "Album not found", gjson.Get(r.Body.String(), "error").String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetAlbumBreadcrumbs(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		GetAlbumBreadcrumbs(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Berlin Child", "ParentUID": "at9lxuqxpogaaba9"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		uid := gjson.Get(r.Body.String(), "UID").String()
+		r = PerformRequest(app, "GET", "/api/v1/albums/"+uid+"/breadcrumbs")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "at9lxuqxpogaaba9", gjson.Get(r.Body.String(), "0.UID").String())
+		assert.Equal(t, uid, gjson.Get(r.Body.String(), "1.UID").String())
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumBreadcrumbs(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/999000/breadcrumbs")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
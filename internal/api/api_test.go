@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
@@ -97,3 +98,12 @@ func TestMain(m *testing.M) {
 
 	os.Exit(code)
 }
+
+// PerformForm submits form values with a POST request.
+func PerformForm(r http.Handler, path string, values url.Values) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", path, strings.NewReader(values.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Audit adds an action of the session user to the audit log, err must be nil if it was successful.
+func Audit(c *gin.Context, s session.Data, action acl.Action, resource acl.Resource, uid string, err error) {
+	m := entity.AuditLog{
+		ActorUID:     s.User.UserUID,
+		ActorName:    s.User.UserName,
+		ClientIP:     ClientIP(c),
+		AuditAction:  string(action),
+		Resource:     string(resource),
+		ResourceUID:  uid,
+		AuditOutcome: entity.AuditSuccess,
+	}
+
+	if err != nil {
+		m.AuditOutcome = entity.AuditFailed
+		m.AuditMessage = err.Error()
+	}
+
+	entity.Audit(m)
+}
+
+// AuditDenied adds an unauthorized action to the audit log.
+func AuditDenied(c *gin.Context, s session.Data, action acl.Action, resource acl.Resource, uid string) {
+	entity.Audit(entity.AuditLog{
+		ActorUID:     s.User.UserUID,
+		ActorName:    s.User.UserName,
+		ClientIP:     ClientIP(c),
+		AuditAction:  string(action),
+		Resource:     string(resource),
+		ResourceUID:  uid,
+		AuditOutcome: entity.AuditDenied,
+	})
+}
+
+// GetAuditLogs searches the audit log.
+//
+// GET /api/v1/audit
+//
+// Parameters:
+//   actor: string User UID or name
+//   resource: string Resource type, e.g. files
+//   uid: string Resource UID
+//   action: string Action, e.g. delete
+//   outcome: string success, failed or denied
+//   after: string Date, e.g. 2021-06-01
+//   before: string Date, e.g. 2021-06-30
+//   count: int Max result count
+//   offset: int Result offset
+func GetAuditLogs(router *gin.RouterGroup) {
+	router.GET("/audit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceLogs, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.AuditSearch
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		result, err := query.AuditLogs(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, f.Count)
+		AddOffsetHeader(c, f.Offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetAuditLogs(t *testing.T) {
+	t.Run("failed login", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateSession(router)
+		GetAuditLogs(router)
+
+		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", `{"username": "admin", "password": "xxx"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/audit?actor=admin&action=login&outcome=denied&count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "denied", gjson.Get(r.Body.String(), "0.Outcome").String())
+		assert.Equal(t, "users", gjson.Get(r.Body.String(), "0.Resource").String())
+	})
+	t.Run("invalid date", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAuditLogs(router)
+		r := PerformRequest(app, "GET", "/api/v1/audit?after=xxx")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
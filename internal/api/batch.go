@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/photoprism/photoprism/internal/photoprism"
@@ -13,7 +14,9 @@ import (
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/meta"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // BatchPhotosArchive moves multiple photos to the archive.
@@ -24,6 +27,7 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionDelete)
 
 		if s.Invalid() {
+			AuditDenied(c, s, acl.ActionDelete, acl.ResourcePhotos, "")
 			AbortUnauthorized(c)
 			return
 		}
@@ -187,6 +191,231 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 	})
 }
 
+// BatchPhotosRating sets the star rating, pick and reject flags, and color label of multiple photos.
+//
+// POST /api/v1/batch/photos/rating
+func BatchPhotosRating(router *gin.RouterGroup) {
+	router.POST("/batch/photos/rating", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.BatchRating
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		} else if f.NoValues() {
+			AbortBadRequest(c)
+			return
+		}
+
+		if f.Rating != nil && (*f.Rating < 0 || *f.Rating > entity.RatingMax) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Rating must be between 0 and %d", entity.RatingMax)})
+			return
+		}
+
+		colorLabel := ""
+
+		if f.ColorLabel != nil && *f.ColorLabel != "" {
+			if colorLabel = meta.ColorLabel(*f.ColorLabel); colorLabel == "" {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Unknown color label %s", txt.Quote(*f.ColorLabel))})
+				return
+			}
+		}
+
+		log.Infof("photos: updating rating for %s", f.String())
+
+		photos, err := query.PhotoSelection(f.Selection)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		var updated entity.Photos
+
+		for _, p := range photos {
+			p.EditedBy = s.User.UserUID
+			rating, pick, reject, label := int(p.PhotoRating), p.PhotoPick, p.PhotoReject, p.PhotoColorLabel
+
+			if f.Rating != nil {
+				rating = *f.Rating
+			}
+
+			if f.Pick != nil {
+				pick = *f.Pick
+
+				// Picking a rejected photo clears the reject flag.
+				if pick && f.Reject == nil {
+					reject = false
+				}
+			}
+
+			if f.Reject != nil {
+				reject = *f.Reject
+			}
+
+			if f.ColorLabel != nil {
+				label = colorLabel
+			}
+
+			if err := p.UpdateRating(rating, pick, reject, label); err != nil {
+				log.Errorf("rating: %s", err)
+			} else {
+				updated = append(updated, p)
+				SavePhotoAsYaml(p)
+				SavePhotoAsXmp(p)
+			}
+		}
+
+		event.EntitiesUpdated("photos", updated)
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgChangesSaved))
+	})
+}
+
+// BatchPhotosEdit applies a partial photo edit to multiple photos in the background.
+//
+// POST /api/v1/batch/photos/edit
+func BatchPhotosEdit(router *gin.RouterGroup) {
+	router.POST("/batch/photos/edit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.BatchEdit
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		} else if f.NoValues() {
+			AbortBadRequest(c)
+			return
+		} else if err := f.Validate(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		photos, err := query.PhotoSelection(f.Selection)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		log.Infof("photos: batch editing %s", f.String())
+
+		for i := range photos {
+			photos[i].EditedBy = s.User.UserUID
+		}
+
+		w := service.BatchEdit()
+
+		if err := w.Start(photos, f); err != nil {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, w.Status())
+	})
+}
+
+// GetBatchPhotosEdit returns the progress and failures of the current or last batch edit.
+//
+// GET /api/v1/batch/photos/edit
+func GetBatchPhotosEdit(router *gin.RouterGroup) {
+	router.GET("/batch/photos/edit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, service.BatchEdit().Status())
+	})
+}
+
+// CancelBatchPhotosEdit cancels the current batch edit.
+//
+// DELETE /api/v1/batch/photos/edit
+func CancelBatchPhotosEdit(router *gin.RouterGroup) {
+	router.DELETE("/batch/photos/edit", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		w := service.BatchEdit()
+
+		w.Cancel()
+
+		c.JSON(http.StatusOK, w.Status())
+	})
+}
+
+// BatchPhotosTimeShift corrects the date taken of photos in a selection or matching a search filter.
+//
+// POST /api/v1/batch/photos/timeshift
+func BatchPhotosTimeShift(router *gin.RouterGroup) {
+	router.POST("/batch/photos/timeshift", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.TimeShift
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := f.Validate(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		if f.Filter != "" {
+			log.Infof("photos: shifting date taken of photos matching %s", txt.Quote(f.Filter))
+		} else {
+			log.Infof("photos: shifting date taken of %s", f.String())
+		}
+
+		f.UserUID = s.User.UserUID
+
+		result, err := service.TimeShift().Start(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
 // BatchAlbumsDelete permanently deletes multiple albums.
 //
 // POST /api/v1/batch/albums/delete
@@ -195,6 +424,7 @@ func BatchAlbumsDelete(router *gin.RouterGroup) {
 		s := Auth(SessionID(c), acl.ResourceAlbums, acl.ActionDelete)
 
 		if s.Invalid() {
+			AuditDenied(c, s, acl.ActionDelete, acl.ResourceAlbums, "")
 			AbortUnauthorized(c)
 			return
 		}
@@ -213,12 +443,38 @@ func BatchAlbumsDelete(router *gin.RouterGroup) {
 
 		log.Infof("albums: deleting %s", f.String())
 
-		entity.Db().Where("album_uid IN (?)", f.Albums).Delete(&entity.Album{})
-		entity.Db().Where("album_uid IN (?)", f.Albums).Delete(&entity.PhotoAlbum{})
+		var deleted []string
+
+		for _, uid := range f.Albums {
+			// Albums are loaded one by one, as their parent may have been deleted before.
+			a, err := query.AlbumByUID(uid)
+
+			if err != nil {
+				Audit(c, s, acl.ActionDelete, acl.ResourceAlbums, uid, err)
+				continue
+			}
+
+			// Nested albums are moved to the parent album.
+			if err := a.Delete(); err != nil {
+				log.Errorf("album: %s (delete)", err)
+				Audit(c, s, acl.ActionDelete, acl.ResourceAlbums, uid, err)
+				continue
+			}
+
+			Audit(c, s, acl.ActionDelete, acl.ResourceAlbums, uid, nil)
+
+			SaveAlbumAsYaml(a)
+
+			deleted = append(deleted, uid)
+		}
+
+		if len(deleted) > 0 {
+			entity.Db().Where("album_uid IN (?)", deleted).Delete(&entity.PhotoAlbum{})
+		}
 
 		UpdateClientConfig()
 
-		event.EntitiesDeleted("albums", f.Albums)
+		event.EntitiesDeleted("albums", deleted)
 
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgAlbumsDeleted))
 	})
@@ -283,6 +539,7 @@ func BatchLabelsDelete(router *gin.RouterGroup) {
 		s := Auth(SessionID(c), acl.ResourceLabels, acl.ActionDelete)
 
 		if s.Invalid() {
+			AuditDenied(c, s, acl.ActionDelete, acl.ResourceLabels, "")
 			AbortUnauthorized(c)
 			return
 		}
@@ -310,7 +567,9 @@ func BatchLabelsDelete(router *gin.RouterGroup) {
 		}
 
 		for _, label := range labels {
-			logError("labels", label.Delete())
+			err := label.Delete()
+			logError("labels", err)
+			Audit(c, s, acl.ActionDelete, acl.ResourceLabels, label.LabelUID, err)
 		}
 
 		UpdateClientConfig()
@@ -367,8 +626,10 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 		for _, p := range photos {
 			if err := photoprism.Delete(p); err != nil {
 				log.Errorf("delete: %s", err)
+				Audit(c, s, acl.ActionDelete, acl.ResourcePhotos, p.PhotoUID, err)
 			} else {
 				deleted = append(deleted, p)
+				Audit(c, s, acl.ActionDelete, acl.ResourcePhotos, p.PhotoUID, nil)
 			}
 		}
 
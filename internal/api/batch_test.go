@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/stretchr/testify/assert"
@@ -120,6 +121,23 @@ func TestBatchAlbumsDelete(t *testing.T) {
 		assert.Equal(t, i18n.Msg(i18n.ErrAlbumNotFound), val3.String())
 		assert.Equal(t, http.StatusNotFound, r3.Code)
 	})
+	t.Run("nested albums", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		GetAlbum(router)
+		BatchAlbumsDelete(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "BatchDelete Parent"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		parentUID := gjson.Get(r.Body.String(), "UID").String()
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums", fmt.Sprintf(`{"Title": "BatchDelete Child", "ParentUID": "%s"}`, parentUID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		childUID := gjson.Get(r.Body.String(), "UID").String()
+		r = PerformRequestWithBody(app, "POST", "/api/v1/batch/albums/delete", fmt.Sprintf(`{"albums": ["%s"]}`, parentUID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		r = PerformRequest(app, "GET", "/api/v1/albums/"+childUID)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "ParentUID").String())
+	})
 	t.Run("no albums selected", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		BatchAlbumsDelete(router)
@@ -175,6 +193,143 @@ func TestBatchPhotosPrivate(t *testing.T) {
 	})
 }
 
+func TestBatchPhotosRating(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		// Register routes.
+		GetPhoto(router)
+		BatchPhotosRating(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rating", `{"photos": ["pt9jtdre2lvl0y17"], "Rating": 4, "ColorLabel": "Magenta"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r2 := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y17")
+		assert.Equal(t, http.StatusOK, r2.Code)
+		assert.Equal(t, int64(4), gjson.Get(r2.Body.String(), "Rating").Int())
+		assert.Equal(t, "purple", gjson.Get(r2.Body.String(), "ColorLabel").String())
+		assert.Equal(t, "manual", gjson.Get(r2.Body.String(), "RatingSrc").String())
+
+		r3 := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rating", `{"photos": ["pt9jtdre2lvl0y17"], "Reject": true}`)
+		assert.Equal(t, http.StatusOK, r3.Code)
+
+		r4 := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y17")
+		assert.Equal(t, int64(4), gjson.Get(r4.Body.String(), "Rating").Int())
+		assert.True(t, gjson.Get(r4.Body.String(), "Reject").Bool())
+	})
+	t.Run("invalid rating", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosRating(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rating", `{"photos": ["pt9jtdre2lvl0y17"], "Rating": 6}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid color label", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosRating(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rating", `{"photos": ["pt9jtdre2lvl0y17"], "ColorLabel": "pink"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("no items selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosRating(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rating", `{"photos": [], "Rating": 3}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestBatchPhotosEdit(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		// Register routes.
+		GetPhoto(router)
+		BatchPhotosEdit(router)
+		GetBatchPhotosEdit(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtdre2lvl0y18"], "Copyright": "Batch Copyright"}`)
+		assert.Equal(t, http.StatusAccepted, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "Total").Int())
+
+		for i := 0; i < 100; i++ {
+			r = PerformRequest(app, "GET", "/api/v1/batch/photos/edit")
+
+			if !gjson.Get(r.Body.String(), "Running").Bool() {
+				break
+			}
+
+			time.Sleep(50 * time.Millisecond)
+		}
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "Processed").Int())
+
+		r2 := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y18")
+		assert.Equal(t, "Batch Copyright", gjson.Get(r2.Body.String(), "Details.Copyright").String())
+	})
+	t.Run("invalid offset", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtdre2lvl0y18"], "TakenOffset": "one hour"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("no values", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtdre2lvl0y18"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("no items selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": [], "Title": "Foo"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestCancelBatchPhotosEdit(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CancelBatchPhotosEdit(router)
+	r := PerformRequest(app, "DELETE", "/api/v1/batch/photos/edit")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.False(t, gjson.Get(r.Body.String(), "Running").Bool())
+}
+
+func TestBatchPhotosTimeShift(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		// Register routes.
+		GetPhoto(router)
+		BatchPhotosTimeShift(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y17")
+		assert.Equal(t, http.StatusOK, r.Code)
+		takenAt := gjson.Get(r.Body.String(), "TakenAtLocal").Time()
+
+		r2 := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/timeshift", `{"photos": ["pt9jtdre2lvl0y17"], "Offset": "2h"}`)
+		assert.Equal(t, http.StatusOK, r2.Code)
+		assert.Equal(t, int64(1), gjson.Get(r2.Body.String(), "Shifted").Int())
+
+		r3 := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y17")
+		assert.Equal(t, takenAt.Add(2*time.Hour), gjson.Get(r3.Body.String(), "TakenAtLocal").Time())
+		assert.Equal(t, "manual", gjson.Get(r3.Body.String(), "TakenSrc").String())
+	})
+	t.Run("invalid zone", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTimeShift(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/timeshift", `{"photos": ["pt9jtdre2lvl0y17"], "TimeZone": "Mars/Olympus"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("no items selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosTimeShift(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/timeshift", `{"photos": [], "Offset": "1h"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
 func TestBatchLabelsDelete(t *testing.T) {
 	t.Run("successful request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// remoteHost returns the address of the connection peer without port.
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// trustedPeer tests if the connection comes from a trusted reverse proxy. Connections through a Unix
+// socket are trusted, since only proxies with access to the socket file can connect.
+func trustedPeer(r *http.Request) bool {
+	conf := service.Config()
+
+	if conf.HttpSocket() != "" {
+		return true
+	}
+
+	return conf.TrustedProxy(net.ParseIP(remoteHost(r)))
+}
+
+// ClientIP returns the client address e.g. for audit logs and rate limits. X-Forwarded-For is
+// only used if the connection comes from a trusted proxy, so that clients can't fake their address.
+func ClientIP(c *gin.Context) string {
+	conf := service.Config()
+	host := remoteHost(c.Request)
+
+	if !trustedPeer(c.Request) {
+		return host
+	}
+
+	// Proxies append the address of their peer, so the last address that wasn't added by a trusted proxy is the client.
+	forwarded := strings.Split(c.GetHeader("X-Forwarded-For"), ",")
+
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(forwarded[i]))
+
+		if ip == nil {
+			break
+		}
+
+		host = ip.String()
+
+		if !conf.TrustedProxy(ip) {
+			break
+		}
+	}
+
+	return host
+}
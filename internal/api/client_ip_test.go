@@ -0,0 +1,72 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	conf := service.Config()
+	conf.Options().TrustedProxies = "172.16.0.0/12"
+	defer func() { conf.Options().TrustedProxies = "" }()
+
+	clientIP := func(remoteAddr, forwarded string) string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+		c.Request.RemoteAddr = remoteAddr
+
+		if forwarded != "" {
+			c.Request.Header.Set("X-Forwarded-For", forwarded)
+		}
+
+		return ClientIP(c)
+	}
+
+	t.Run("untrusted", func(t *testing.T) {
+		assert.Equal(t, "192.0.2.10", clientIP("192.0.2.10:41234", "198.51.100.7"))
+	})
+	t.Run("trusted", func(t *testing.T) {
+		assert.Equal(t, "198.51.100.7", clientIP("172.18.0.2:41234", "198.51.100.7"))
+	})
+	t.Run("spoofed", func(t *testing.T) {
+		// Addresses added by the client before the proxy are ignored.
+		assert.Equal(t, "198.51.100.7", clientIP("172.18.0.2:41234", "203.0.113.1, 198.51.100.7, 172.18.0.3"))
+	})
+	t.Run("no header", func(t *testing.T) {
+		assert.Equal(t, "172.18.0.2", clientIP("172.18.0.2:41234", ""))
+	})
+	t.Run("unix socket", func(t *testing.T) {
+		conf.Options().HttpSocket = "/run/photoprism/photoprism.sock"
+		defer func() { conf.Options().HttpSocket = "" }()
+
+		// Connections through the socket have no peer address.
+		assert.Equal(t, "198.51.100.7", clientIP("@", "198.51.100.7"))
+	})
+}
+
+func TestProxyUserName_Socket(t *testing.T) {
+	conf := service.Config()
+	conf.Options().ProxyUserHeader = "Remote-User"
+	conf.Options().HttpSocket = "/run/photoprism/photoprism.sock"
+
+	defer func() {
+		conf.Options().ProxyUserHeader = ""
+		conf.Options().HttpSocket = ""
+	}()
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/status", nil)
+	c.Request.RemoteAddr = "@"
+	c.Request.Header.Set("Remote-User", "alice")
+
+	assert.Equal(t, "alice", ProxyUserName(c))
+
+	conf.Options().HttpSocket = ""
+
+	assert.Equal(t, "", ProxyUserName(c))
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/gin-gonic/gin"
@@ -53,9 +52,9 @@ func GetDownload(router *gin.RouterGroup) {
 			return
 		}
 
-		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+		fileName, err := photoprism.LocalFileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		if err != nil {
 			log.Errorf("download: file %s is missing", txt.Quote(f.FileName))
 			c.Data(404, "image/svg+xml", brokenIconSvg)
 
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SplitFace moves markers of a face cluster that belong to a different person to a new cluster.
+//
+// POST /api/v1/faces/:id/split
+//
+// Parameters:
+//   id: string Face ID
+func SplitFace(router *gin.RouterGroup) {
+	router.POST("/faces/:id/split", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceSubjects, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if !conf.Settings().Features.People || !conf.Settings().Features.Edit {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.FaceSplit
+
+		if err := c.BindJSON(&f); err != nil || len(f.Markers) == 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		m := entity.FindFace(c.Param("id"))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if f.SubjUID != "" && entity.FindSubject(f.SubjUID) == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+			return
+		}
+
+		split, remaining, err := query.SplitFace(m, f.Markers, f.SubjUID)
+
+		if err != nil {
+			log.Errorf("faces: %s (split)", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		log.Infof("faces: moved %d markers of %s to %s", len(f.Markers), m.ID, split.ID)
+
+		// Update subject names of markers, and photo counts.
+		var subjUIDs []string
+
+		for _, uid := range []string{m.SubjUID, f.SubjUID} {
+			if subj := entity.FindSubject(uid); subj == nil {
+				continue
+			} else if err := subj.UpdateMarkerNames(); err != nil {
+				log.Errorf("faces: %s (update marker names)", err)
+			} else {
+				subjUIDs = append(subjUIDs, uid)
+			}
+		}
+
+		updateFaceCounts(nil, []string{split.ID, remaining.ID})
+
+		// Notify clients.
+		if m.ID != split.ID && m.ID != remaining.ID {
+			event.EntitiesDeleted("faces", []string{m.ID})
+		}
+
+		event.EntitiesCreated("faces", entity.Faces{*split, *remaining})
+
+		for _, uid := range subjUIDs {
+			PublishSubjectEvent(EntityUpdated, uid, c)
+		}
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, gin.H{"Split": split, "Remaining": remaining})
+	})
+}
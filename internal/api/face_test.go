@@ -0,0 +1,70 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestSplitFace(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		SplitFace(router)
+
+		var markers entity.Markers
+
+		for _, fixture := range []string{"actor-a-1", "actor-a-2", "1000003-4"} {
+			m := entity.NewMarker(entity.FileFixtures.Get("exampleFileName.jpg"), crop.Area{X: 0.3, Y: 0.3, W: 0.2, H: 0.2}, "jqy1y111h1njaaac", entity.SrcImage, entity.MarkerFace)
+			m.SubjSrc = entity.SrcAuto
+			m.EmbeddingsJSON = entity.MarkerFixtures.Get(fixture).EmbeddingsJSON
+			markers = append(markers, *m)
+		}
+
+		f := entity.NewFace("jqy1y111h1njaaac", entity.SrcAuto, markers.Embeddings())
+
+		if err := f.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		for i := range markers {
+			markers[i].FaceID = f.ID
+
+			if err := markers[i].Create(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		body := fmt.Sprintf(`{"Markers": ["%s"]}`, markers[2].MarkerUID)
+		r := PerformRequestWithBody(app, "POST", fmt.Sprintf("/api/v1/faces/%s/split", f.ID), body)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "Split.Samples").Int())
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "Remaining.Samples").Int())
+		assert.Equal(t, "jqy1y111h1njaaac", gjson.Get(r.Body.String(), "Remaining.SubjUID").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		SplitFace(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/faces/XXXXXXXXXXXXXXXX/split", `{"Markers": ["mt9k3pw1wowuy222"]}`)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		SplitFace(router)
+
+		r := PerformRequestWithBody(app, "POST", fmt.Sprintf("/api/v1/faces/%s/split", entity.FaceFixtures.Get("actor-1").ID), `{"Markers": []}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
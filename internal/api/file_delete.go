@@ -24,6 +24,7 @@ func DeleteFile(router *gin.RouterGroup) {
 		s := Auth(SessionID(c), acl.ResourceFiles, acl.ActionDelete)
 
 		if s.Invalid() {
+			AuditDenied(c, s, acl.ActionDelete, acl.ResourceFiles, c.Param("file_uid"))
 			AbortUnauthorized(c)
 			return
 		}
@@ -52,27 +53,27 @@ func DeleteFile(router *gin.RouterGroup) {
 			return
 		}
 
-		fileName := photoprism.FileName(file.FileRoot, file.FileName)
-		baseName := filepath.Base(fileName)
+		baseName := filepath.Base(file.FileName)
 
-		mediaFile, err := photoprism.NewMediaFile(fileName)
-
-		if err != nil {
-			log.Errorf("photo: %s (delete %s)", err, txt.Quote(baseName))
+		if !photoprism.StorageFileExists(file.FileRoot, file.FileName) {
+			log.Errorf("photo: file %s not found", txt.Quote(baseName))
 			AbortEntityNotFound(c)
 			return
 		}
 
-		if err := mediaFile.Remove(); err != nil {
+		if err := photoprism.RemoveFile(file.FileRoot, file.FileName); err != nil {
 			log.Errorf("photo: %s (delete %s from folder)", err, txt.Quote(baseName))
 		}
 
 		if err := file.Delete(true); err != nil {
 			log.Errorf("photo: %s (delete %s from index)", err, txt.Quote(baseName))
+			Audit(c, s, acl.ActionDelete, acl.ResourceFiles, fileUID, err)
 			AbortDeleteFailed(c)
 			return
 		}
 
+		Audit(c, s, acl.ActionDelete, acl.ResourceFiles, fileUID, nil)
+
 		// Notify clients by publishing events.
 		PublishPhotoEvent(EntityUpdated, photoUID, c)
 
@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"net/mail"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -10,7 +11,9 @@ import (
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mailer"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -19,6 +22,7 @@ func UpdateLink(c *gin.Context) {
 	s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionUpdate)
 
 	if s.Invalid() {
+		AuditDenied(c, s, acl.ActionUpdate, acl.ResourceLinks, c.Param("link"))
 		AbortUnauthorized(c)
 		return
 	}
@@ -48,10 +52,13 @@ func UpdateLink(c *gin.Context) {
 	}
 
 	if err := link.Save(); err != nil {
+		Audit(c, s, acl.ActionUpdate, acl.ResourceLinks, link.LinkUID, err)
 		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
 		return
 	}
 
+	Audit(c, s, acl.ActionUpdate, acl.ResourceLinks, link.LinkUID, nil)
+
 	UpdateClientConfig()
 
 	event.SuccessMsg(i18n.MsgAlbumSaved)
@@ -66,6 +73,7 @@ func DeleteLink(c *gin.Context) {
 	s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionDelete)
 
 	if s.Invalid() {
+		AuditDenied(c, s, acl.ActionDelete, acl.ResourceLinks, c.Param("link"))
 		AbortUnauthorized(c)
 		return
 	}
@@ -73,10 +81,13 @@ func DeleteLink(c *gin.Context) {
 	link := entity.FindLink(c.Param("link"))
 
 	if err := link.Delete(); err != nil {
+		Audit(c, s, acl.ActionDelete, acl.ResourceLinks, link.LinkUID, err)
 		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
 		return
 	}
 
+	Audit(c, s, acl.ActionDelete, acl.ResourceLinks, link.LinkUID, nil)
+
 	UpdateClientConfig()
 
 	event.SuccessMsg(i18n.MsgAlbumSaved)
@@ -91,6 +102,7 @@ func CreateLink(c *gin.Context) {
 	s := Auth(SessionID(c), acl.ResourceLinks, acl.ActionCreate)
 
 	if s.Invalid() {
+		AuditDenied(c, s, acl.ActionCreate, acl.ResourceLinks, c.Param("uid"))
 		AbortUnauthorized(c)
 		return
 	}
@@ -102,6 +114,17 @@ func CreateLink(c *gin.Context) {
 		return
 	}
 
+	var recipients []*mail.Address
+
+	if f.Email = strings.TrimSpace(f.Email); f.Email != "" {
+		var err error
+
+		if recipients, err = mail.ParseAddressList(f.Email); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+	}
+
 	link := entity.NewLink(c.Param("uid"), f.CanComment, f.CanEdit)
 
 	link.SetSlug(f.ShareSlug)
@@ -116,10 +139,17 @@ func CreateLink(c *gin.Context) {
 	}
 
 	if err := link.Save(); err != nil {
+		Audit(c, s, acl.ActionCreate, acl.ResourceLinks, link.LinkUID, err)
 		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UcFirst(err.Error())})
 		return
 	}
 
+	Audit(c, s, acl.ActionCreate, acl.ResourceLinks, link.LinkUID, nil)
+
+	if len(recipients) > 0 && service.Config().MailEnabled() {
+		go SendShareEmail(s.User, link, recipients)
+	}
+
 	UpdateClientConfig()
 
 	event.SuccessMsg(i18n.MsgAlbumSaved)
@@ -129,6 +159,52 @@ func CreateLink(c *gin.Context) {
 	c.JSON(http.StatusOK, link)
 }
 
+// SendShareEmail notifies recipients of a new share link.
+func SendShareEmail(sender entity.User, link entity.Link, recipients []*mail.Address) {
+	conf := service.Config()
+
+	share := mailer.Share{
+		Sender:   sender.FullName,
+		Title:    link.ShareSlug,
+		Link:     conf.SiteUrl() + "s/" + link.LinkToken,
+		Password: link.HasPassword,
+	}
+
+	if share.Sender == "" {
+		share.Sender = sender.UserName
+	}
+
+	if share.Sender == "" {
+		share.Sender = conf.SiteTitle()
+	}
+
+	if link.ShareSlug != "" {
+		share.Link += "/" + link.ShareSlug
+	}
+
+	if link.LinkExpires > 0 {
+		share.Expires = link.ModifiedAt.Add(entity.Seconds(link.LinkExpires))
+	}
+
+	if a, err := query.AlbumByUID(link.ShareUID); err == nil {
+		share.Title = a.AlbumTitle
+	} else if p, err := query.PhotoByUID(link.ShareUID); err == nil {
+		share.Title = p.PhotoTitle
+	} else if l, err := query.LabelByUID(link.ShareUID); err == nil {
+		share.Title = l.LabelName
+	}
+
+	to := make([]string, len(recipients))
+
+	for i, addr := range recipients {
+		to[i] = addr.Address
+	}
+
+	if err := mailer.SendShare(conf, share, to); err != nil {
+		log.Errorf("share: %s (send email)", err)
+	}
+}
+
 // POST /api/v1/albums/:uid/links
 func CreateAlbumLink(router *gin.RouterGroup) {
 	router.POST("/albums/:uid/links", func(c *gin.Context) {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/tidwall/gjson"
 
@@ -37,6 +38,37 @@ func TestCreateAlbumLink(t *testing.T) {
 		assert.False(t, link.CanComment)
 		assert.True(t, link.CanEdit)
 	})
+	t.Run("email recipients", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		s, stop := StartMailServer(conf)
+		defer stop()
+
+		CreateAlbumLink(router)
+
+		resp := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/links", `{"Slug": "christmas", "Email": "Bob <bob@example.com>, carol@example.com"}`)
+
+		if resp.Code != http.StatusOK {
+			t.Fatal(resp.Body.String())
+		}
+
+		messages := s.Wait(1, time.Second)
+
+		if len(messages) != 1 {
+			t.Fatalf("expected one message, got %d", len(messages))
+		}
+
+		token := gjson.Get(resp.Body.String(), "Token").String()
+
+		assert.Equal(t, []string{"bob@example.com", "carol@example.com"}, messages[0].To)
+		assert.Contains(t, messages[0].Header("Subject"), "Christmas 2030")
+		assert.Contains(t, messages[0].Text(), conf.SiteUrl()+"s/"+token+"/christmas")
+	})
+	t.Run("invalid email", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbumLink(router)
+		resp := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba7/links", `{"Email": "foo"}`)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
 	t.Run("album does not exist", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		CreateAlbumLink(router)
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/ldap"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrLocalLoginDisabled = errors.New("login with local password disabled")
+)
+
+// LoginUser verifies user credentials with the LDAP directory, if configured, and local passwords.
+// The user may be returned along with an error so that failed logins can be audited.
+func LoginUser(userName, password string) (*entity.User, error) {
+	conf := service.Config()
+
+	if client := service.LDAP(); client != nil {
+		result, err := client.Authenticate(userName, password)
+
+		switch err {
+		case nil:
+			// Create or update the local account.
+			return entity.LoginExternalUser(entity.ExternalUser{
+				AuthSrc:  entity.AuthLDAP,
+				AuthID:   result.ID,
+				UserName: result.UserName,
+				FullName: result.FullName,
+				Email:    result.Email,
+				Role:     conf.LDAPRole(result.Groups),
+			}, true)
+		case ldap.ErrInvalidCredentials:
+			return entity.FindUserByName(userName), ErrInvalidCredentials
+		case ldap.ErrUserNotFound:
+			// Continue with local accounts.
+		default:
+			// Local accounts can still log in if the directory is not available.
+			log.Errorf("ldap: %s", err)
+		}
+	}
+
+	if conf.DisableLocalLogin() {
+		return nil, ErrLocalLoginDisabled
+	}
+
+	user := entity.FindUserByName(userName)
+
+	if user == nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	// Accounts of external users don't have a local password.
+	if user.AuthSrc != entity.AuthLocal || user.InvalidPassword(password) {
+		return user, ErrInvalidCredentials
+	}
+
+	return user, nil
+}
+
+// cachedLogin represents verified credentials.
+type cachedLogin struct {
+	UserUID  string
+	Password string
+}
+
+// passwordHash returns the local password hash of a user, empty if none.
+func passwordHash(uid string) string {
+	if pw := entity.FindPassword(uid); pw != nil {
+		return pw.Hash
+	}
+
+	return ""
+}
+
+// CacheLogin caches verified credentials so that the password doesn't have to be checked on each request.
+func CacheLogin(key string, user *entity.User) {
+	service.AuthCache().SetDefault(key, cachedLogin{UserUID: user.UserUID, Password: passwordHash(user.UserUID)})
+}
+
+// CachedLogin returns the user of cached credentials, or nil if they are unknown, expired or no longer valid,
+// e.g. because the password was changed, or the user was disabled or deleted in the meantime.
+func CachedLogin(key string) *entity.User {
+	cache := service.AuthCache()
+
+	hit, ok := cache.Get(key)
+
+	if !ok {
+		return nil
+	}
+
+	login := hit.(cachedLogin)
+	user := entity.FindUserByUID(login.UserUID)
+
+	if user == nil || user.UserDisabled || user.Deleted() || passwordHash(user.UserUID) != login.Password {
+		cache.Delete(key)
+		return nil
+	}
+
+	return user
+}
+
+// FlushCachedLogins removes all cached credentials of a user, e.g. after the password was changed.
+func FlushCachedLogins(uid string) {
+	cache := service.AuthCache()
+
+	for key, item := range cache.Items() {
+		if login, ok := item.Object.(cachedLogin); ok && strings.EqualFold(login.UserUID, uid) {
+			cache.Delete(key)
+		}
+	}
+}
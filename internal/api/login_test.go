@@ -0,0 +1,153 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/ldap/ldaptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestLoginUser(t *testing.T) {
+	t.Run("local", func(t *testing.T) {
+		user, err := LoginUser("alice", "Alice123!")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "uqxetse3cy5eo9z2", user.UserUID)
+	})
+	t.Run("ldap", func(t *testing.T) {
+		_, _, conf := NewApiTest()
+
+		s := ldaptest.NewTestServer(
+			ldaptest.TestEntry{DN: "cn=service,dc=example,dc=com", Password: "service"},
+			ldaptest.TestEntry{DN: "uid=ldap.anna,ou=people,dc=example,dc=com", Password: "Anna123!", Attributes: map[string][]string{
+				"objectClass": {"person"},
+				"uid":         {"ldap.anna"},
+				"cn":          {"Anna"},
+				"mail":        {"ldap.anna@example.com"},
+				"memberOf":    {"cn=family,ou=groups,dc=example,dc=com"},
+			}},
+		)
+
+		defer s.Close()
+
+		conf.Options().LDAPUri = s.URI()
+		conf.Options().LDAPBindDN = "cn=service,dc=example,dc=com"
+		conf.Options().LDAPBindPassword = "service"
+		conf.Options().LDAPBaseDN = "dc=example,dc=com"
+		conf.Options().LDAPRoles = "family:family"
+
+		defer func() {
+			conf.Options().LDAPUri = ""
+			conf.Options().LDAPBindDN = ""
+			conf.Options().LDAPBindPassword = ""
+			conf.Options().LDAPBaseDN = ""
+			conf.Options().LDAPRoles = ""
+		}()
+
+		user, err := LoginUser("ldap.anna", "Anna123!")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, entity.AuthLDAP, user.AuthSrc)
+		assert.Equal(t, "uid=ldap.anna,ou=people,dc=example,dc=com", user.AuthID)
+		assert.Equal(t, "ldap.anna@example.com", user.PrimaryEmail)
+		assert.Equal(t, acl.RoleFamily, user.Role())
+
+		// The same account is used on subsequent logins.
+		again, err := LoginUser("ldap.anna", "Anna123!")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, user.UserUID, again.UserUID)
+
+		// Invalid directory password.
+		_, err = LoginUser("ldap.anna", "wrong")
+		assert.Equal(t, ErrInvalidCredentials, err)
+
+		// Local users can still log in.
+		if local, err := LoginUser("alice", "Alice123!"); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, "uqxetse3cy5eo9z2", local.UserUID)
+		}
+
+		// Local logins are disabled.
+		conf.Options().DisableLocalLogin = true
+		defer func() { conf.Options().DisableLocalLogin = false }()
+
+		_, err = LoginUser("alice", "Alice123!")
+		assert.Equal(t, ErrLocalLoginDisabled, err)
+
+		app, router, _ := NewApiTest()
+		CreateSession(router)
+
+		r := PerformRequestWithBody(app, http.MethodPost, "/api/v1/session", `{"username": "ldap.anna", "password": "Anna123!"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "ldap.anna", gjson.Get(r.Body.String(), "data.user.UserName").String())
+	})
+	t.Run("invalid password", func(t *testing.T) {
+		user, err := LoginUser("alice", "wrong")
+
+		assert.Equal(t, ErrInvalidCredentials, err)
+		assert.Equal(t, "uqxetse3cy5eo9z2", user.UserUID)
+	})
+}
+
+func TestCachedLogin(t *testing.T) {
+	user := &entity.User{UserName: "cached.login", RoleFamily: true}
+
+	if err := user.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := user.SetPassword("Cached123!"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		CacheLogin("cached-valid", user)
+
+		if result := CachedLogin("cached-valid"); result == nil {
+			t.Fatal("result should not be nil")
+		} else {
+			assert.Equal(t, user.UserUID, result.UserUID)
+		}
+
+		assert.Nil(t, CachedLogin("cached-unknown"))
+	})
+	t.Run("flush", func(t *testing.T) {
+		CacheLogin("cached-flush", user)
+		FlushCachedLogins(user.UserUID)
+
+		assert.Nil(t, CachedLogin("cached-flush"))
+	})
+	t.Run("password changed", func(t *testing.T) {
+		CacheLogin("cached-password", user)
+
+		if err := user.SetPassword("Changed123!"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, CachedLogin("cached-password"))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		CacheLogin("cached-disabled", user)
+
+		if err := entity.Db().Model(user).UpdateColumn("user_disabled", true).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, CachedLogin("cached-disabled"))
+	})
+}
@@ -13,8 +13,12 @@ import (
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// MarkerSuggestionsLimit is the default number of subject suggestions for a face marker.
+const MarkerSuggestionsLimit = 5
+
 // findFileMarker returns a file and marker entity matching the api request.
 func findFileMarker(c *gin.Context) (file *entity.File, marker *entity.Marker, err error) {
 	// Check authorization.
@@ -100,6 +104,10 @@ func UpdateMarker(router *gin.RouterGroup) {
 				} else if res.Merged > 0 {
 					log.Infof("faces: %d clusters merged", res.Merged)
 				}
+
+				if _, err := service.Faces().UpdateRadius(); err != nil {
+					log.Errorf("faces: %s (update radius)", err)
+				}
 			}
 
 			if err := query.UpdateSubjectPreviews(); err != nil {
@@ -154,6 +162,11 @@ func ClearMarkerSubject(router *gin.RouterGroup) {
 			log.Errorf("faces: %s (update counts)", err)
 		}
 
+		// Rejected samples must no longer match.
+		if _, err := service.Faces().UpdateRadius(); err != nil {
+			log.Errorf("faces: %s (update radius)", err)
+		}
+
 		// Update photo metadata.
 		if p, err := query.PhotoByUID(file.PhotoUID); err != nil {
 			log.Errorf("faces: %s (find photo))", err)
@@ -169,3 +182,55 @@ func ClearMarkerSubject(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, marker)
 	})
 }
+
+// GetMarkerSuggestions returns the subjects that most likely match a face marker.
+//
+// GET /api/v1/markers/:marker_uid/suggestions
+//
+// Parameters:
+//   marker_uid: string Marker UID as returned by the API
+//   count: int Max number of suggestions (default 5)
+func GetMarkerSuggestions(router *gin.RouterGroup) {
+	router.GET("/markers/:marker_uid/suggestions", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceSubjects, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if !service.Config().Settings().Features.People {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		marker, err := query.MarkerByUID(c.Param("marker_uid"))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		} else if marker.MarkerType != entity.MarkerFace {
+			AbortBadRequest(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+
+		if limit <= 0 {
+			limit = MarkerSuggestionsLimit
+		}
+
+		results, err := query.MarkerSuggestions(marker, limit)
+
+		if err != nil {
+			log.Errorf("faces: %s (marker suggestions)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(results))
+		AddLimitHeader(c, limit)
+
+		c.JSON(http.StatusOK, results)
+	})
+}
@@ -219,3 +219,31 @@ func TestClearMarkerSubject(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
 }
+
+func TestGetMarkerSuggestions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetMarkerSuggestions(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/markers/mt9k3pw1wowuy222/suggestions?count=2")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		results := gjson.Parse(r.Body.String()).Array()
+
+		assert.GreaterOrEqual(t, len(results), 1)
+		assert.LessOrEqual(t, len(results), 2)
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "0.SubjUID").String())
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "0.Name").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetMarkerSuggestions(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/markers/mt9k3pw1wowuxxxx/suggestions")
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"crypto/subtle"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/metrics"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var registerLibraryMetrics sync.Once
+
+// libraryCollector exports library counts and database connection pool stats when metrics are scraped.
+type libraryCollector struct {
+	conf  *config.Config
+	items *prometheus.Desc
+	db    *prometheus.Desc
+	wait  *prometheus.Desc
+}
+
+func newLibraryCollector(conf *config.Config) *libraryCollector {
+	return &libraryCollector{
+		conf:  conf,
+		items: prometheus.NewDesc(metrics.Namespace+"_library_items", "Number of items in the library by type.", []string{"type"}, nil),
+		db:    prometheus.NewDesc(metrics.Namespace+"_db_connections", "Number of database connections by state.", []string{"state"}, nil),
+		wait:  prometheus.NewDesc(metrics.Namespace+"_db_wait_seconds_total", "Total time spent waiting for a database connection.", nil, nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (l *libraryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- l.items
+	ch <- l.db
+	ch <- l.wait
+}
+
+// Collect implements prometheus.Collector.
+func (l *libraryCollector) Collect(ch chan<- prometheus.Metric) {
+	counts := query.Counts{}
+	counts.Refresh()
+
+	people, err := query.PeopleCount()
+
+	if err != nil {
+		log.Errorf("metrics: %s", err)
+	}
+
+	for name, n := range map[string]int{
+		"photos":    counts.Photos,
+		"videos":    counts.Videos,
+		"hidden":    counts.Hidden,
+		"favorites": counts.Favorites,
+		"private":   counts.Private,
+		"review":    counts.Review,
+		"albums":    counts.Albums,
+		"folders":   counts.Folders,
+		"moments":   counts.Moments,
+		"files":     counts.Files,
+		"places":    counts.Places,
+		"labels":    counts.Labels,
+		"cameras":   counts.Cameras,
+		"lenses":    counts.Lenses,
+		"countries": counts.Countries,
+		"people":    people,
+	} {
+		ch <- prometheus.MustNewConstMetric(l.items, prometheus.GaugeValue, float64(n), name)
+	}
+
+	stats := l.conf.Db().DB().Stats()
+
+	ch <- prometheus.MustNewConstMetric(l.db, prometheus.GaugeValue, float64(stats.MaxOpenConnections), "max")
+	ch <- prometheus.MustNewConstMetric(l.db, prometheus.GaugeValue, float64(stats.OpenConnections), "open")
+	ch <- prometheus.MustNewConstMetric(l.db, prometheus.GaugeValue, float64(stats.InUse), "in_use")
+	ch <- prometheus.MustNewConstMetric(l.db, prometheus.GaugeValue, float64(stats.Idle), "idle")
+	ch <- prometheus.MustNewConstMetric(l.wait, prometheus.CounterValue, stats.WaitDuration.Seconds())
+}
+
+// MetricsToken returns the token sent as bearer token or query parameter.
+func MetricsToken(c *gin.Context) string {
+	if auth := c.GetHeader("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+
+	return c.Query("token")
+}
+
+// GET /api/v1/metrics
+func GetMetrics(router *gin.RouterGroup) {
+	handler := promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})
+
+	router.GET("/metrics", func(c *gin.Context) {
+		conf := service.Config()
+
+		if token := conf.MetricsToken(); token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(MetricsToken(c))) != 1 {
+			AbortUnauthorized(c)
+			return
+		}
+
+		registerLibraryMetrics.Do(func() {
+			metrics.Registry.MustRegister(newLibraryCollector(conf))
+		})
+
+		handler.ServeHTTP(c.Writer, c.Request)
+	})
+}
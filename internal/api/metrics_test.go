@@ -0,0 +1,45 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMetrics(t *testing.T) {
+	t.Run("Public", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetMetrics(router)
+		r := PerformRequest(app, "GET", "/api/v1/metrics")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "photoprism_worker_busy{worker=\"main\"} 0")
+		assert.Contains(t, r.Body.String(), "photoprism_library_items{type=\"photos\"}")
+		assert.Contains(t, r.Body.String(), "photoprism_db_connections{state=\"open\"}")
+	})
+	t.Run("Token", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.Options().MetricsToken = "xyz123"
+		defer func() { conf.Options().MetricsToken = "" }()
+
+		GetMetrics(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/metrics")
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+
+		r = PerformRequest(app, "GET", "/api/v1/metrics?token=xyz123")
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		req, _ := http.NewRequest("GET", "/api/v1/metrics", nil)
+		req.Header.Set("Authorization", "Bearer xyz123")
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		req.Header.Set("Authorization", "Bearer foo")
+		w = httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+)
+
+// OIDCStateCookie is the name of the cookie that binds a login to the browser that started it.
+const OIDCStateCookie = "oidc_state"
+
+// oidcTemplate stores the new session in the browser and opens the app.
+var oidcTemplate = template.Must(template.New("oidc").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>PhotoPrism</title></head>
+<body>
+{{if .Error}}<p>{{.Error}}</p><p><a href="{{.Url}}">Continue</a></p>{{else}}<script>
+var storage = window.localStorage.getItem("session_storage") === "true" ? window.sessionStorage : window.localStorage;
+storage.setItem("session_id", {{.ID}});
+storage.setItem("data", JSON.stringify({{.Data}}));
+window.location.replace({{.Url}});
+</script>{{end}}
+</body>
+</html>`))
+
+// oidcPage represents the data of the page that completes a login.
+type oidcPage struct {
+	ID    string
+	Data  session.Data
+	Url   string
+	Error string
+}
+
+// OIDCLogin redirects to the OpenID Connect provider.
+//
+// GET /api/v1/oidc/login
+func OIDCLogin(router *gin.RouterGroup) {
+	router.GET("/oidc/login", func(c *gin.Context) {
+		client := service.OIDC()
+
+		if client == nil {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		authUrl, state, err := client.AuthURL()
+
+		if err != nil {
+			log.Errorf("oidc: %s", err)
+			Abort(c, http.StatusBadGateway, i18n.ErrConnectionFailed)
+			return
+		}
+
+		conf := service.Config()
+
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(OIDCStateCookie, state, 600, conf.BaseUri("/api/v1/oidc"), "", strings.HasPrefix(conf.SiteUrl(), "https://"), true)
+
+		c.Redirect(http.StatusFound, authUrl)
+	})
+}
+
+// OIDCRedirect completes the login after the user was authenticated by the provider.
+//
+// GET /api/v1/oidc/redirect
+func OIDCRedirect(router *gin.RouterGroup) {
+	router.GET("/oidc/redirect", func(c *gin.Context) {
+		client := service.OIDC()
+
+		if client == nil {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		conf := service.Config()
+		page := oidcPage{Url: conf.SiteUrl()}
+
+		state := c.Query("state")
+		cookie, _ := c.Cookie(OIDCStateCookie)
+
+		c.SetCookie(OIDCStateCookie, "", -1, conf.BaseUri("/api/v1/oidc"), "", strings.HasPrefix(conf.SiteUrl(), "https://"), true)
+
+		render := func(code int) {
+			c.Header("Content-Type", "text/html; charset=utf-8")
+			c.Header("Cache-Control", "no-store")
+			c.Status(code)
+
+			if err := oidcTemplate.Execute(c.Writer, page); err != nil {
+				log.Errorf("oidc: %s", err)
+			}
+		}
+
+		fail := func(user entity.User, err error) {
+			log.Errorf("oidc: %s", err)
+			AuditDenied(c, session.Data{User: user}, acl.ActionLogin, acl.ResourceUsers, user.UserUID)
+			page.Error = i18n.Msg(i18n.ErrInvalidCredentials)
+			render(http.StatusUnauthorized)
+		}
+
+		if e := c.Query("error"); e != "" {
+			fail(entity.User{}, errors.New(strings.TrimSpace(e+" "+c.Query("error_description"))))
+			return
+		} else if state == "" || cookie != state {
+			fail(entity.User{}, errors.New("state does not match"))
+			return
+		}
+
+		claims, err := client.Callback(state, c.Query("code"))
+
+		if err != nil {
+			fail(entity.User{}, err)
+			return
+		}
+
+		user, err := entity.LoginExternalUser(entity.ExternalUser{
+			AuthSrc:  entity.AuthOIDC,
+			AuthID:   claims.Subject,
+			UserName: claims.UserName(),
+			FullName: claims.Name,
+			Email:    claims.Email,
+			Role:     conf.OIDCRole(claims.GroupNames()),
+		}, conf.OIDCRegister())
+
+		if err != nil {
+			fail(entity.User{UserName: claims.UserName()}, err)
+			return
+		}
+
+		page.Data = session.Data{User: *user}
+		page.ID = service.Session().Create(page.Data)
+
+		Audit(c, page.Data, acl.ActionLogin, acl.ResourceUsers, user.UserUID, nil)
+
+		render(http.StatusOK)
+	})
+}
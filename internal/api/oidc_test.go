@@ -0,0 +1,89 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/oidc"
+	"github.com/photoprism/photoprism/internal/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		OIDCLogin(router)
+		r := PerformRequest(app, "GET", "/api/v1/oidc/login")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("success", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		p := oidctest.NewTestProvider("photoprism", oidc.Claims{Subject: "api-123", PreferredUsername: "oidc.anna", Name: "Anna", Groups: []string{"/family"}})
+		defer p.Close()
+
+		conf.Options().OIDCUri = p.Issuer()
+		conf.Options().OIDCClient = "photoprism"
+		conf.Options().OIDCRoles = "family:family"
+		conf.Options().OIDCRegister = true
+
+		defer func() {
+			conf.Options().OIDCUri = ""
+			conf.Options().OIDCClient = ""
+			conf.Options().OIDCRoles = ""
+			conf.Options().OIDCRegister = false
+		}()
+
+		OIDCLogin(router)
+		OIDCRedirect(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/oidc/login")
+		assert.Equal(t, http.StatusFound, r.Code)
+
+		cookie := r.Result().Cookies()[0]
+		assert.Equal(t, OIDCStateCookie, cookie.Name)
+
+		// Authenticate with the provider.
+		client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}}
+
+		resp, err := client.Get(r.Header().Get("Location"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp.Body.Close()
+
+		callback, err := url.Parse(resp.Header.Get("Location"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run("state mismatch", func(t *testing.T) {
+			r := PerformRequest(app, "GET", "/api/v1/oidc/redirect?"+callback.RawQuery)
+			assert.Equal(t, http.StatusUnauthorized, r.Code)
+		})
+
+		req, _ := http.NewRequest("GET", "/api/v1/oidc/redirect?"+callback.RawQuery, nil)
+		req.AddCookie(cookie)
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "session_id")
+
+		user := entity.FindUserByAuth(entity.AuthOIDC, "api-123")
+
+		if assert.NotNil(t, user) {
+			assert.Equal(t, "oidc.anna", user.UserName)
+			assert.True(t, user.RoleFamily)
+		}
+	})
+}
@@ -35,6 +35,29 @@ func SavePhotoAsYaml(p entity.Photo) {
 	}
 }
 
+// SavePhotoAsXmp writes the star rating, pick flag and color label to an XMP sidecar file.
+func SavePhotoAsXmp(p entity.Photo) {
+	c := service.Config()
+
+	// Write XMP sidecar file (optional).
+	if !c.ExportXmp() {
+		return
+	}
+
+	fileName := p.XmpFileName(c.OriginalsPath(), c.SidecarPath())
+
+	// Existing files are fetched from sidecar storage first, so that metadata from other apps is kept.
+	if err := photoprism.FetchSidecarFile(fileName); err != nil {
+		log.Errorf("photo: %s (update xmp)", err)
+	} else if err := p.SaveAsXmp(fileName); err != nil {
+		log.Errorf("photo: %s (update xmp)", err)
+	} else if err := photoprism.StoreSidecarFile(fileName); err != nil {
+		log.Errorf("photo: %s (store xmp)", err)
+	} else {
+		log.Debugf("photo: updated xmp file %s", txt.Quote(filepath.Base(fileName)))
+	}
+}
+
 // GET /api/v1/photos/:uid
 //
 // Parameters:
@@ -93,6 +116,8 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		}
 
 		// 3) Save model with values from form
+		m.EditedBy = s.User.UserUID
+
 		if err := entity.SavePhotoForm(m, f); err != nil {
 			Abort(c, http.StatusInternalServerError, i18n.ErrSaveFailed)
 			return
@@ -112,6 +137,7 @@ func UpdatePhoto(router *gin.RouterGroup) {
 		}
 
 		SavePhotoAsYaml(p)
+		SavePhotoAsXmp(p)
 
 		UpdateClientConfig()
 
@@ -137,9 +163,9 @@ func GetPhotoDownload(router *gin.RouterGroup) {
 			return
 		}
 
-		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+		fileName, err := photoprism.LocalFileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		if err != nil {
 			log.Errorf("photo: file %s is missing", txt.Quote(f.FileName))
 			c.Data(http.StatusNotFound, "image/svg+xml", photoIconSvg)
 
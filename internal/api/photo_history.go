@@ -0,0 +1,158 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetPhotoHistory returns the recorded metadata changes of a photo, newest first.
+//
+// GET /api/v1/photos/:uid/history
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+func GetPhotoHistory(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/history", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := c.Param("uid")
+
+		if _, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		result, count, err := query.PhotoEdits(uid, limit, offset)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		AddCountHeader(c, count)
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// RevertPhotoEdit restores the previous value of a single metadata change.
+//
+// POST /api/v1/photos/:uid/history/:id/revert
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+//   id: int Edit ID as returned by the API
+func RevertPhotoEdit(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/history/:id/revert", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		uid := c.Param("uid")
+		id, err := strconv.Atoi(c.Param("id"))
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		edit := entity.FindPhotoEdit(uint(id))
+
+		if edit == nil || edit.PhotoUID != uid {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := edit.Revert(s.User.UserUID); err != nil {
+			log.Errorf("photo: %s (revert edit %d)", err, edit.ID)
+			Abort(c, http.StatusInternalServerError, i18n.ErrSaveFailed)
+			return
+		}
+
+		p, err := query.PhotoPreloadByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		SavePhotoAsYaml(p)
+		SavePhotoAsXmp(p)
+
+		PublishPhotoEvent(EntityUpdated, uid, c)
+
+		c.JSON(http.StatusOK, p)
+	})
+}
+
+// RevertPhotoEdits restores the values of all fields changed after a point in time.
+//
+// POST /api/v1/photos/:uid/history/revert
+//
+// Parameters:
+//   uid: string PhotoUID as returned by the API
+func RevertPhotoEdits(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/history/revert", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.RevertEdits
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		uid := c.Param("uid")
+
+		if _, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if _, err := entity.RevertPhotoEdits(uid, f.After, s.User.UserUID); err != nil {
+			log.Errorf("photo: %s (revert edits)", err)
+			Abort(c, http.StatusInternalServerError, i18n.ErrSaveFailed)
+			return
+		}
+
+		p, err := query.PhotoPreloadByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		SavePhotoAsYaml(p)
+		SavePhotoAsXmp(p)
+
+		PublishPhotoEvent(EntityUpdated, uid, c)
+
+		c.JSON(http.StatusOK, p)
+	})
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPhotoHistory(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		// Register routes.
+		UpdatePhoto(router)
+		GetPhotoHistory(router)
+		RevertPhotoEdit(router)
+		RevertPhotoEdits(router)
+
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y16", `{"Title": "History Title", "TitleSrc": "manual"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		originalTitle := ""
+
+		r2 := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y16/history")
+		assert.Equal(t, http.StatusOK, r2.Code)
+
+		for _, edit := range gjson.Get(r2.Body.String(), "@this").Array() {
+			if edit.Get("Field").String() == "PhotoTitle" {
+				originalTitle = edit.Get("OldValue").String()
+				assert.Equal(t, "History Title", edit.Get("NewValue").String())
+
+				r3 := PerformRequestWithBody(app, "POST", fmt.Sprintf("/api/v1/photos/pt9jtdre2lvl0y16/history/%d/revert", edit.Get("ID").Int()), "")
+				assert.Equal(t, http.StatusOK, r3.Code)
+				assert.Equal(t, originalTitle, gjson.Get(r3.Body.String(), "Title").String())
+				break
+			}
+		}
+
+		assert.NotEqual(t, "History Title", originalTitle)
+
+		r4 := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0y16", `{"Title": "History Title 2", "TitleSrc": "manual"}`)
+		assert.Equal(t, http.StatusOK, r4.Code)
+
+		r5 := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y16/history/revert", `{"After": "2000-01-01T00:00:00Z"}`)
+		assert.Equal(t, http.StatusOK, r5.Code)
+		assert.Equal(t, originalTitle, gjson.Get(r5.Body.String(), "Title").String())
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoHistory(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/history")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("edit not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RevertPhotoEdit(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0y16/history/999999/revert", "")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
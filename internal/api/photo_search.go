@@ -26,6 +26,9 @@ import (
 //   before:    date   Find photos taken before (format: "2006-01-02")
 //   after:     date   Find photos taken after (format: "2006-01-02")
 //   favorite:  bool   Find favorites only
+//   similar:   string Find photos similar to the photo with this UID (requires embeddings)
+//   semantic:  string Find photos matching a natural language description (requires embeddings)
+//   text:      string Find photos containing this text, e.g. receipts and signs (requires OCR)
 func GetPhotos(router *gin.RouterGroup) {
 	router.GET("/photos", func(c *gin.Context) {
 		s := Auth(SessionID(c), acl.ResourcePhotos, acl.ActionSearch)
@@ -83,7 +83,7 @@ func GetThumb(router *gin.RouterGroup) {
 
 		// Return existing thumbs straight away.
 		if !download {
-			if fileName, err := thumb.FileName(fileHash, conf.ThumbPath(), size.Width, size.Height, size.Options...); err == nil && fs.FileExists(fileName) {
+			if fileName, err := thumb.FileName(fileHash, conf.ThumbPath(), size.Width, size.Height, size.Options...); err == nil && photoprism.CachedFile(fileName) {
 				c.File(fileName)
 				return
 			}
@@ -113,9 +113,9 @@ func GetThumb(router *gin.RouterGroup) {
 			return
 		}
 
-		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+		fileName, err := photoprism.LocalFileName(f.FileRoot, f.FileName)
 
-		if !fs.FileExists(fileName) {
+		if err != nil {
 			log.Errorf("%s: file %s is missing", logPrefix, txt.Quote(f.FileName))
 			c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
 
@@ -141,6 +141,11 @@ func GetThumb(router *gin.RouterGroup) {
 			return
 		}
 
+		// Fetch existing thumbnail from cache storage, or store it once it has been created.
+		if cacheName, err := thumb.FileName(f.FileHash, conf.ThumbPath(), size.Width, size.Height, size.Options...); err == nil && !photoprism.CachedFile(cacheName) {
+			defer photoprism.StoreCachedFile(cacheName)
+		}
+
 		var thumbnail string
 
 		if conf.ThumbUncached() || size.Uncached() {
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/session"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ProxyAuthKey is set in the request context if the user was authenticated by a trusted reverse proxy.
+const ProxyAuthKey = "proxy_auth"
+
+// proxySessions maps user UIDs to their current session ids and user names to the reason
+// their last login was denied, so that rejected users are not logged on every request.
+var proxySessions = struct {
+	id     map[string]string
+	denied map[string]string
+	mutex  sync.Mutex
+}{id: make(map[string]string), denied: make(map[string]string)}
+
+// ProxyUserName returns the user name set by a trusted reverse proxy, empty if none.
+func ProxyUserName(c *gin.Context) string {
+	conf := service.Config()
+
+	if !conf.ProxyAuthEnabled() {
+		return ""
+	}
+
+	name := strings.TrimSpace(c.GetHeader(conf.ProxyUserHeader()))
+
+	if name == "" {
+		return ""
+	}
+
+	// Only the proxy itself may set identity headers, so the address of the client
+	// connection is checked instead of X-Forwarded-For.
+	if !trustedPeer(c.Request) {
+		log.Warnf("proxy: ignored %s header from untrusted address %s", conf.ProxyUserHeader(), txt.Quote(remoteHost(c.Request)))
+		return ""
+	}
+
+	return name
+}
+
+// ProxyGroups returns the groups set by a trusted reverse proxy.
+func ProxyGroups(c *gin.Context) (groups []string) {
+	header := service.Config().ProxyGroupsHeader()
+
+	if header == "" {
+		return groups
+	}
+
+	for _, g := range strings.Split(c.GetHeader(header), ",") {
+		if g = strings.Trim(strings.TrimSpace(g), "/"); g != "" {
+			groups = append(groups, g)
+		}
+	}
+
+	return groups
+}
+
+// ProxyUser returns the user authenticated by a trusted reverse proxy, nil if none.
+func ProxyUser(c *gin.Context) (*entity.User, error) {
+	name := ProxyUserName(c)
+
+	if name == "" {
+		return nil, nil
+	}
+
+	conf := service.Config()
+
+	ext := entity.ExternalUser{
+		AuthSrc:  entity.AuthProxy,
+		AuthID:   strings.ToLower(name),
+		UserName: name,
+		Role:     conf.ProxyRole(ProxyGroups(c)),
+	}
+
+	if h := conf.ProxyNameHeader(); h != "" {
+		ext.FullName = strings.TrimSpace(c.GetHeader(h))
+	}
+
+	if h := conf.ProxyEmailHeader(); h != "" {
+		ext.Email = strings.TrimSpace(c.GetHeader(h))
+	}
+
+	user := entity.FindUserByName(name)
+
+	switch {
+	case user == nil:
+		return entity.LoginExternalUser(ext, conf.ProxyRegister())
+	case user.AuthSrc == entity.AuthProxy:
+		// Update the role and profile of accounts created by the proxy.
+		return entity.LoginExternalUser(ext, false)
+	case user.UserDisabled:
+		return nil, fmt.Errorf("user: %s is disabled", txt.Quote(user.UserName))
+	default:
+		return user, nil
+	}
+}
+
+// ProxySession resumes or creates the session of a user authenticated by a trusted reverse proxy.
+// It returns false if the request doesn't contain a trusted identity.
+func ProxySession(c *gin.Context) bool {
+	name := ProxyUserName(c)
+
+	if name == "" {
+		return false
+	}
+
+	id := SessionID(c)
+
+	// Resume the current session.
+	if s := service.Session().Get(id); s.Valid() && strings.EqualFold(s.User.UserName, name) {
+		c.Set(ProxyAuthKey, true)
+		return true
+	}
+
+	user, err := ProxyUser(c)
+
+	proxySessions.mutex.Lock()
+	defer proxySessions.mutex.Unlock()
+
+	if err != nil || user == nil {
+		reason := fmt.Sprintf("user %s not found", txt.Quote(name))
+
+		if err != nil {
+			reason = err.Error()
+		}
+
+		// Only report the first denied login, the proxy sends the same headers with every request.
+		if proxySessions.denied[name] == reason {
+			log.Debugf("proxy: %s", reason)
+		} else {
+			proxySessions.denied[name] = reason
+			log.Warnf("proxy: %s", reason)
+			AuditDenied(c, session.Data{User: entity.User{UserName: name}}, acl.ActionLogin, acl.ResourceUsers, "")
+		}
+
+		return false
+	}
+
+	delete(proxySessions.denied, name)
+
+	// Resume the last session of the user if the client didn't send it.
+	if last, ok := proxySessions.id[user.UserUID]; ok && service.Session().Get(last).Valid() {
+		id = last
+	} else {
+		data := session.Data{User: *user}
+		id = service.Session().Create(data)
+		proxySessions.id[user.UserUID] = id
+
+		Audit(c, data, acl.ActionLogin, acl.ResourceUsers, user.UserUID, nil)
+	}
+
+	c.Request.Header.Set("X-Session-ID", id)
+	AddSessionHeader(c, id)
+	c.Set(ProxyAuthKey, true)
+
+	return true
+}
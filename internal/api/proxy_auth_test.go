@@ -0,0 +1,134 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+// proxyRequest performs a request with identity headers sent from the given address.
+func proxyRequest(r http.Handler, method, path, remoteAddr string, header map[string]string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader("{}"))
+	req.RemoteAddr = remoteAddr
+
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestProxySession(t *testing.T) {
+	app, router, conf := NewApiTest()
+
+	conf.SetPublic(false)
+	conf.Options().ProxyUserHeader = "Remote-User"
+	conf.Options().ProxyGroupsHeader = "Remote-Groups"
+	conf.Options().ProxyEmailHeader = "Remote-Email"
+	conf.Options().TrustedProxies = "172.16.0.0/12"
+	conf.Options().ProxyRoles = "photo-family:family"
+
+	defer func() {
+		conf.SetPublic(true)
+		conf.Options().ProxyUserHeader = ""
+		conf.Options().ProxyGroupsHeader = ""
+		conf.Options().ProxyEmailHeader = ""
+		conf.Options().TrustedProxies = ""
+		conf.Options().ProxyRoles = ""
+		conf.Options().ProxyRegister = false
+	}()
+
+	router.Use(func(c *gin.Context) {
+		ProxySession(c)
+	})
+
+	CreateSession(router)
+
+	t.Run("existing user", func(t *testing.T) {
+		r := proxyRequest(app, http.MethodPost, "/api/v1/session", "172.18.0.2:41234", map[string]string{"Remote-User": "alice"})
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "ok", gjson.Get(r.Body.String(), "status").String())
+
+		id := r.Header().Get("X-Session-ID")
+		assert.Equal(t, "uqxetse3cy5eo9z2", service.Session().Get(id).User.UserUID)
+
+		// The session is resumed.
+		r = proxyRequest(app, http.MethodPost, "/api/v1/session", "172.18.0.2:41234", map[string]string{"Remote-User": "alice"})
+		assert.Equal(t, id, r.Header().Get("X-Session-ID"))
+	})
+	t.Run("untrusted address", func(t *testing.T) {
+		r := proxyRequest(app, http.MethodPost, "/api/v1/session", "192.168.1.10:41234", map[string]string{"Remote-User": "alice"})
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Empty(t, r.Header().Get("X-Session-ID"))
+	})
+	t.Run("unknown user", func(t *testing.T) {
+		r := proxyRequest(app, http.MethodPost, "/api/v1/session", "172.18.0.2:41234", map[string]string{"Remote-User": "proxy.anna", "Remote-Groups": "photo-family"})
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Nil(t, entity.FindUserByName("proxy.anna"))
+	})
+	t.Run("register", func(t *testing.T) {
+		conf.Options().ProxyRegister = true
+
+		r := proxyRequest(app, http.MethodPost, "/api/v1/session", "172.18.0.2:41234", map[string]string{"Remote-User": "proxy.anna", "Remote-Groups": "photo-family", "Remote-Email": "proxy.anna@example.com"})
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		user := entity.FindUserByName("proxy.anna")
+
+		if user == nil {
+			t.Fatal("user not registered")
+		}
+
+		assert.Equal(t, entity.AuthProxy, user.AuthSrc)
+		assert.Equal(t, acl.RoleFamily, user.Role())
+		assert.Equal(t, "proxy.anna@example.com", user.PrimaryEmail)
+	})
+	t.Run("no matching group", func(t *testing.T) {
+		r := proxyRequest(app, http.MethodPost, "/api/v1/session", "172.18.0.2:41234", map[string]string{"Remote-User": "proxy.bob", "Remote-Groups": "photo-friends"})
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Nil(t, entity.FindUserByName("proxy.bob"))
+
+		// Repeated requests of the same user are denied without new audit log entries.
+		var count int
+
+		entity.Db().Model(&entity.AuditLog{}).Where("actor_name = ?", "proxy.bob").Count(&count)
+
+		r = proxyRequest(app, http.MethodPost, "/api/v1/session", "172.18.0.2:41234", map[string]string{"Remote-User": "proxy.bob", "Remote-Groups": "photo-friends"})
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		var repeated int
+
+		entity.Db().Model(&entity.AuditLog{}).Where("actor_name = ?", "proxy.bob").Count(&repeated)
+
+		assert.Equal(t, 1, count)
+		assert.Equal(t, count, repeated)
+	})
+}
+
+func TestProxyGroups(t *testing.T) {
+	_, _, conf := NewApiTest()
+
+	conf.Options().ProxyGroupsHeader = "Remote-Groups"
+	defer func() { conf.Options().ProxyGroupsHeader = "" }()
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+	c.Request.Header.Set("Remote-Groups", "admins, /family,,")
+
+	assert.Equal(t, []string{"admins", "family"}, ProxyGroups(c))
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/acl"
@@ -39,14 +40,18 @@ func CreateSession(router *gin.RouterGroup) {
 			links := entity.FindValidLinks(f.Token, "")
 
 			if len(links) == 0 {
+				AuditDenied(c, data, acl.ActionLogin, acl.ResourceLinks, "")
 				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidLink)})
+				return
 			}
 
 			data.Tokens = []string{f.Token}
 
 			for _, link := range links {
-				data.Shares = append(data.Shares, link.ShareUID)
+				data.Shares = append(data.Shares, link.SharedUIDs()...)
 				link.Redeem()
+
+				Audit(c, data, acl.ActionLogin, acl.ResourceLinks, link.LinkUID, nil)
 			}
 
 			// Upgrade from anonymous to guest. Don't downgrade.
@@ -54,19 +59,35 @@ func CreateSession(router *gin.RouterGroup) {
 				data.User = entity.Guest
 			}
 		} else if f.HasCredentials() {
-			user := entity.FindUserByName(f.UserName)
-
-			if user == nil {
-				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidCredentials)})
+			if conf.DisableLocalLogin() && !conf.LDAPEnabled() {
+				Abort(c, http.StatusForbidden, i18n.ErrFeatureDisabled)
 				return
 			}
 
-			if user.InvalidPassword(f.Password) {
+			user, err := LoginUser(f.UserName, f.Password)
+
+			if err != nil {
+				log.Debugf("session: %s", err)
+
+				if user == nil {
+					user = &entity.User{UserName: f.UserName}
+				}
+
+				AuditDenied(c, session.Data{User: *user}, acl.ActionLogin, acl.ResourceUsers, user.UserUID)
 				c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidCredentials)})
 				return
 			}
 
+			// Notifications are sent in the language of the browser the user signed in with.
+			if err := user.SetLocale(ClientLocale(c)); err != nil {
+				log.Errorf("session: %s (update locale)", err)
+			}
+
 			data.User = *user
+
+			Audit(c, data, acl.ActionLogin, acl.ResourceUsers, user.UserUID, nil)
+		} else if c.GetBool(ProxyAuthKey) {
+			// Already authenticated by a trusted reverse proxy.
 		} else {
 			c.AbortWithStatusJSON(400, gin.H{"error": i18n.Msg(i18n.ErrInvalidPassword)})
 			return
@@ -91,12 +112,31 @@ func DeleteSession(router *gin.RouterGroup) {
 	router.DELETE("/session/:id", func(c *gin.Context) {
 		id := c.Param("id")
 
+		if s := service.Session().Get(id); s.Valid() {
+			Audit(c, s, acl.ActionLogout, acl.ResourceUsers, s.User.UserUID, nil)
+		}
+
 		service.Session().Delete(id)
 
 		c.JSON(http.StatusOK, gin.H{"status": "ok", "id": id})
 	})
 }
 
+// ClientLocale returns the preferred language of the client, e.g. "de" or "pt-BR".
+func ClientLocale(c *gin.Context) string {
+	s := c.GetHeader("Accept-Language")
+
+	if i := strings.IndexAny(s, ",;"); i >= 0 {
+		s = s[:i]
+	}
+
+	if s = strings.TrimSpace(s); s == "*" {
+		return ""
+	}
+
+	return s
+}
+
 // Gets session id from HTTP header.
 func SessionID(c *gin.Context) string {
 	return c.GetHeader("X-Session-ID")
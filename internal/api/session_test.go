@@ -2,8 +2,10 @@ package api
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
@@ -101,3 +103,18 @@ func TestDeleteSession(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestClientLocale(t *testing.T) {
+	locale := func(header string) string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/session", nil)
+		c.Request.Header.Set("Accept-Language", header)
+
+		return ClientLocale(c)
+	}
+
+	assert.Equal(t, "de-DE", locale("de-DE,de;q=0.9,en;q=0.8"))
+	assert.Equal(t, "fr", locale(" fr;q=0.9"))
+	assert.Equal(t, "", locale("*"))
+	assert.Equal(t, "", locale(""))
+}
@@ -39,14 +39,26 @@ func Shares(router *gin.RouterGroup) {
 
 		links := entity.FindValidLinks(token, share)
 
-		if len(links) < 1 {
+		var uid string
+
+		if len(links) > 0 {
+			uid = links[0].ShareUID
+		} else {
+			// Links to albums also grant access to nested albums.
+			for _, link := range entity.FindValidLinks(token, "") {
+				if link.Shares(share) {
+					uid = share
+					break
+				}
+			}
+		}
+
+		if uid == "" {
 			log.Warn("share: invalid token or share")
 			c.Redirect(http.StatusTemporaryRedirect, "/")
 			return
 		}
 
-		uid := links[0].ShareUID
-
 		if uid != share {
 			c.Redirect(http.StatusPermanentRedirect, fmt.Sprintf("/s/%s/%s", token, uid))
 			return
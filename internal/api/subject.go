@@ -11,6 +11,7 @@ import (
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
@@ -177,3 +178,89 @@ func DislikeSubject(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, http.Response{})
 	})
 }
+
+// MergeSubjects merges other subjects into a subject, e.g. if the same person was added twice.
+//
+// POST /api/v1/subjects/:uid/merge
+//
+// Parameters:
+//   uid: string Subject UID
+func MergeSubjects(router *gin.RouterGroup) {
+	router.POST("/subjects/:uid/merge", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceSubjects, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		conf := service.Config()
+
+		if !conf.Settings().Features.People || !conf.Settings().Features.Edit {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.SubjectMerge
+
+		if err := c.BindJSON(&f); err != nil || len(f.Subjects) == 0 {
+			AbortBadRequest(c)
+			return
+		}
+
+		uid := c.Param("uid")
+		subj := entity.FindSubject(uid)
+
+		if subj == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+			return
+		}
+
+		var merge entity.Subjects
+
+		for _, mergeUID := range f.Subjects {
+			if mergeUID == uid {
+				AbortBadRequest(c)
+				return
+			} else if m := entity.FindSubject(mergeUID); m == nil {
+				Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+				return
+			} else {
+				merge = append(merge, *m)
+			}
+		}
+
+		for i := range merge {
+			if err := merge[i].MergeWith(subj); err != nil {
+				log.Errorf("subject: %s (merge)", err)
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UcFirst(err.Error())})
+				return
+			}
+
+			log.Infof("subject: merged %s into %s", txt.Quote(merge[i].SubjName), txt.Quote(subj.SubjName))
+		}
+
+		updateFaceCounts([]string{uid}, nil)
+
+		PublishSubjectEvent(EntityUpdated, uid, c)
+
+		event.SuccessMsg(i18n.MsgSubjectSaved)
+
+		c.JSON(http.StatusOK, subj)
+	})
+}
+
+// updateFaceCounts updates subject previews and file counts, and the face counts of related photos.
+func updateFaceCounts(subjUIDs, faceIDs []string) {
+	if err := query.UpdateSubjectPreviews(); err != nil {
+		log.Errorf("faces: %s (update previews)", err)
+	}
+
+	if err := entity.UpdateSubjectFileCounts(); err != nil {
+		log.Errorf("faces: %s (update counts)", err)
+	}
+
+	if err := entity.UpdatePhotoFaceCounts(subjUIDs, faceIDs); err != nil {
+		log.Errorf("faces: %s (update photo counts)", err)
+	}
+}
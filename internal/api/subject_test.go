@@ -1,11 +1,14 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
 
 	"github.com/tidwall/gjson"
 
+	"github.com/photoprism/photoprism/internal/entity"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -111,3 +114,66 @@ func TestDislikeSubject(t *testing.T) {
 		assert.Equal(t, "false", val4.String())
 	})
 }
+
+func TestMergeSubjects(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		MergeSubjects(router)
+
+		subj := entity.NewSubject("Merge Api Target", entity.SubjPerson, entity.SrcManual)
+		other := entity.NewSubject("Merge Api Source", entity.SubjPerson, entity.SrcManual)
+
+		if err := subj.Save(); err != nil {
+			t.Fatal(err)
+		} else if err := other.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		body := fmt.Sprintf(`{"Subjects": ["%s"]}`, other.SubjUID)
+		r := PerformRequestWithBody(app, "POST", fmt.Sprintf("/api/v1/subjects/%s/merge", subj.SubjUID), body)
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, subj.SubjUID, gjson.Get(r.Body.String(), "UID").String())
+		assert.Nil(t, entity.FindSubject(other.SubjUID))
+	})
+	t.Run("FeatureDisabled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		MergeSubjects(router)
+
+		conf.Settings().Features.Edit = false
+		defer func() { conf.Settings().Features.Edit = true }()
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/subjects/jqy3y652h8njw0sx/merge", `{"Subjects": ["jqy3y652h8njxxxx"]}`)
+
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		MergeSubjects(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/subjects/jqy3y652h8njw0sx/merge", `{"Subjects": ["jqy3y652h8njxxxx"]}`)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("Self", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		MergeSubjects(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/subjects/jqy3y652h8njw0sx/merge", `{"Subjects": ["jqy3y652h8njw0sx"]}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		MergeSubjects(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/subjects/jqy3y652h8njw0sx/merge", `{"Subjects": []}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// GetTags finds and returns tags as JSON, use the parent parameter to browse nested tags.
+//
+// GET /api/v1/tags
+func GetTags(router *gin.RouterGroup) {
+	router.GET("/tags", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceTags, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.TagSearch
+
+		err := c.MustBindWith(&f, binding.Form)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		result, err := query.Tags(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, f.Count)
+		AddOffsetHeader(c, f.Offset)
+		AddTokenHeaders(c)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GetTag returns a tag as JSON.
+//
+// GET /api/v1/tags/:uid
+func GetTag(router *gin.RouterGroup) {
+	router.GET("/tags/:uid", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceTags, acl.ActionRead)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if m := entity.FindTag(c.Param("uid")); m == nil {
+			AbortEntityNotFound(c)
+			return
+		} else {
+			c.JSON(http.StatusOK, gin.H{"Tag": m, "Path": m.Path(), "Ancestors": m.Ancestors()})
+		}
+	})
+}
+
+// UpdateTag renames a tag or moves it to a different parent tag.
+//
+// PUT /api/v1/tags/:uid
+func UpdateTag(router *gin.RouterGroup) {
+	router.PUT("/tags/:uid", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceTags, acl.ActionUpdate)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m := entity.FindTag(c.Param("uid"))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// Keep current values for fields that were not submitted.
+		f := form.Tag{TagName: m.TagName, ParentUID: m.ParentUID}
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Name and parent are validated before any change is saved.
+		if err := m.Change(f.TagName, f.ParentUID); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetTags(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		kids := entity.FirstOrCreateTagPath("Api|Family|Kids")
+		app, router, _ := NewApiTest()
+		GetTags(router)
+		r := PerformRequest(app, "GET", "/api/v1/tags?count=10&parent="+kids.ParentUID)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, kids.TagUID, gjson.Get(r.Body.String(), "0.UID").String())
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetTags(router)
+		r := PerformRequest(app, "GET", "/api/v1/tags?xxx=10")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGetTag(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		m := entity.FirstOrCreateTagPath("Api|Places|Bergen")
+		app, router, _ := NewApiTest()
+		GetTag(router)
+		r := PerformRequest(app, "GET", "/api/v1/tags/"+m.TagUID)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Api|Places|Bergen", gjson.Get(r.Body.String(), "Path").String())
+		assert.Equal(t, int64(2), gjson.Get(r.Body.String(), "Ancestors.#").Int())
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetTag(router)
+		r := PerformRequest(app, "GET", "/api/v1/tags/t000000000000001")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestUpdateTag(t *testing.T) {
+	t.Run("rename", func(t *testing.T) {
+		m := entity.FirstOrCreateTagPath("Api|Update|Kids")
+		app, router, _ := NewApiTest()
+		UpdateTag(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/tags/"+m.TagUID, `{"Name": "Children"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Children", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, m.ParentUID, gjson.Get(r.Body.String(), "ParentUID").String())
+	})
+	t.Run("move", func(t *testing.T) {
+		m := entity.FirstOrCreateTagPath("Api|Move|Kids")
+		parent := entity.FirstOrCreateTagPath("Api|People")
+		app, router, _ := NewApiTest()
+		UpdateTag(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/tags/"+m.TagUID, `{"ParentUID": "`+parent.TagUID+`"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Api|People|Kids", entity.FindTag(m.TagUID).Path())
+	})
+	t.Run("rename and move", func(t *testing.T) {
+		m := entity.FirstOrCreateTagPath("Api|Both|Kids")
+		parent := entity.FirstOrCreateTagPath("Api|Family")
+		entity.FirstOrCreateTagPath("Api|Family|Children")
+		app, router, _ := NewApiTest()
+		UpdateTag(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/tags/"+m.TagUID, `{"Name": "Children", "ParentUID": "`+parent.TagUID+`"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "Api|Both|Kids", entity.FindTag(m.TagUID).Path())
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/tags/"+m.TagUID, `{"Name": "Sons", "ParentUID": "`+parent.TagUID+`"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Api|Family|Sons", entity.FindTag(m.TagUID).Path())
+	})
+	t.Run("invalid parent", func(t *testing.T) {
+		m := entity.FirstOrCreateTagPath("Api|Invalid|Kids")
+		app, router, _ := NewApiTest()
+		UpdateTag(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/tags/"+m.TagUID, `{"ParentUID": "`+m.TagUID+`"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateTag(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/tags/t000000000000001", `{"Name": "Foo"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// Throttle limits the number of requests per key, e.g. an email address or client IP, within an interval.
+type Throttle struct {
+	Interval time.Duration
+	Limit    int
+	mutex    sync.Mutex
+	requests map[string][]time.Time
+}
+
+// NewThrottle returns a new throttle that allows limit requests per key and interval.
+func NewThrottle(interval time.Duration, limit int) *Throttle {
+	return &Throttle{Interval: interval, Limit: limit, requests: make(map[string][]time.Time)}
+}
+
+// Allow tests if another request is allowed for the key and counts it if so.
+func (t *Throttle) Allow(key string) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := time.Now()
+	since := now.Add(-1 * t.Interval)
+
+	// Forget keys without recent requests.
+	for k, times := range t.requests {
+		if len(times) == 0 || times[len(times)-1].Before(since) {
+			delete(t.requests, k)
+		}
+	}
+
+	var recent []time.Time
+
+	for _, at := range t.requests[key] {
+		if at.After(since) {
+			recent = append(recent, at)
+		}
+	}
+
+	if len(recent) >= t.Limit {
+		t.requests[key] = recent
+		return false
+	}
+
+	t.requests[key] = append(recent, now)
+
+	return true
+}
+
+// Reset forgets all counted requests.
+func (t *Throttle) Reset() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.requests = make(map[string][]time.Time)
+}
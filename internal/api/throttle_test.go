@@ -0,0 +1,34 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottle_Allow(t *testing.T) {
+	t.Run("limit", func(t *testing.T) {
+		th := NewThrottle(time.Hour, 2)
+
+		assert.True(t, th.Allow("192.0.2.1"))
+		assert.True(t, th.Allow("192.0.2.1"))
+		assert.False(t, th.Allow("192.0.2.1"))
+		assert.True(t, th.Allow("192.0.2.2"))
+
+		th.Reset()
+
+		assert.True(t, th.Allow("192.0.2.1"))
+	})
+	t.Run("expired", func(t *testing.T) {
+		th := NewThrottle(10*time.Millisecond, 1)
+
+		assert.True(t, th.Allow("alice@example.com"))
+		assert.False(t, th.Allow("alice@example.com"))
+
+		time.Sleep(20 * time.Millisecond)
+
+		assert.True(t, th.Allow("alice@example.com"))
+		assert.Len(t, th.requests, 1)
+	})
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mailer"
+	"github.com/photoprism/photoprism/internal/service"
+)
+
+// POST /api/v1/users/:uid/confirm
+func SendEmailConfirmation(router *gin.RouterGroup) {
+	router.POST("/users/:uid/confirm", func(c *gin.Context) {
+		conf := service.Config()
+
+		if !conf.MailEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		s := Auth(SessionID(c), acl.ResourceUsers, acl.ActionUpdateSelf)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m := entity.FindUserByUID(c.Param("uid"))
+
+		if m == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrUserNotFound)
+			return
+		} else if s.User.UserUID != m.UserUID && !s.User.Admin() {
+			AbortUnauthorized(c)
+			return
+		} else if m.PrimaryEmail == "" {
+			AbortBadRequest(c)
+			return
+		}
+
+		token, err := m.NewConfirmToken()
+
+		if err != nil {
+			AbortSaveFailed(c)
+			return
+		}
+
+		if err := mailer.SendConfirm(conf, m, token); err != nil {
+			Error(c, http.StatusBadGateway, err, i18n.ErrConnectionFailed)
+			return
+		}
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgConfirmationSent))
+	})
+}
+
+// GET /api/v1/users/confirm/:token
+func ConfirmEmail(router *gin.RouterGroup) {
+	router.GET("/users/confirm/:token", func(c *gin.Context) {
+		m := entity.FindUserByConfirmToken(c.Param("token"))
+
+		if m == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrInvalidLink)
+			return
+		}
+
+		if err := m.ConfirmEmail(); err != nil {
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("users: confirmed email address of %s", m.String())
+
+		c.Redirect(http.StatusTemporaryRedirect, service.Config().SiteUrl())
+	})
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mailer/mailertest"
+	"github.com/stretchr/testify/assert"
+)
+
+// StartMailServer configures a local SMTP server stand-in as outgoing mail server until stopped.
+func StartMailServer(conf *config.Config) (s *mailertest.TestServer, stop func()) {
+	s = mailertest.NewTestServer()
+	o := conf.Options()
+	o.SMTPHost, o.SMTPPort, o.SMTPTLS = s.Host(), s.Port(), config.SMTPNone
+
+	return s, func() {
+		o.SMTPHost, o.SMTPPort, o.SMTPTLS = "", 0, ""
+		s.Close()
+	}
+}
+
+func TestSendEmailConfirmation(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SendEmailConfirmation(router)
+		r := PerformRequest(app, "POST", "/api/v1/users/uqxc08w3d0ej2283/confirm")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("bob: other user", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		_, stop := StartMailServer(conf)
+		defer stop()
+		SendEmailConfirmation(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "POST", "/api/v1/users/uqxetse3cy5eo9z2/confirm", sessId)
+		assert.Equal(t, http.StatusUnauthorized, r.Code)
+	})
+	t.Run("bob: confirm", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		s, stop := StartMailServer(conf)
+		defer stop()
+		SendEmailConfirmation(router)
+		ConfirmEmail(router)
+		sessId := AuthenticateUser(app, router, "bob", "Bobbob123!")
+		r := AuthenticatedRequest(app, "POST", "/api/v1/users/uqxc08w3d0ej2283/confirm", sessId)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		messages := s.Wait(1, time.Second)
+
+		if len(messages) != 1 {
+			t.Fatalf("expected one message, got %d", len(messages))
+		}
+
+		assert.Equal(t, []string{"bob@example.com"}, messages[0].To)
+
+		token := regexp.MustCompile(`/users/confirm/([0-9a-f]{32})`).FindStringSubmatch(messages[0].Text())
+
+		if len(token) != 2 {
+			t.Fatalf("confirmation link not found in %s", messages[0].Text())
+		}
+
+		r = PerformRequest(app, "GET", "/api/v1/users/confirm/"+token[1])
+		assert.Equal(t, http.StatusTemporaryRedirect, r.Code)
+		assert.Equal(t, conf.SiteUrl(), r.Header().Get("Location"))
+
+		r = PerformRequest(app, "GET", "/api/v1/users/confirm/"+token[1])
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestConfirmEmail(t *testing.T) {
+	t.Run("invalid token", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ConfirmEmail(router)
+		r := PerformRequest(app, "GET", "/api/v1/users/confirm/xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
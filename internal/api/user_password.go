@@ -2,13 +2,17 @@ package api
 
 import (
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/mailer"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // PUT /api/v1/users/:uid/password
@@ -49,15 +53,129 @@ func ChangePassword(router *gin.RouterGroup) {
 		}
 
 		if m.InvalidPassword(f.OldPassword) {
+			AuditDenied(c, s, acl.ActionUpdateSelf, acl.ResourcePasswords, m.UserUID)
 			Abort(c, http.StatusBadRequest, i18n.ErrInvalidPassword)
 			return
 		}
 
 		if err := m.SetPassword(f.NewPassword); err != nil {
+			Audit(c, s, acl.ActionUpdateSelf, acl.ResourcePasswords, m.UserUID, err)
 			Error(c, http.StatusBadRequest, err, i18n.ErrInvalidPassword)
 			return
 		}
 
+		Audit(c, s, acl.ActionUpdateSelf, acl.ResourcePasswords, m.UserUID, nil)
+
+		// Cached WebDAV credentials are no longer valid.
+		FlushCachedLogins(m.UserUID)
+
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgPasswordChanged))
 	})
 }
+
+// ResetAddressThrottle limits password reset emails to the same address.
+var ResetAddressThrottle = NewThrottle(15*time.Minute, 1)
+
+// ResetClientThrottle limits password reset requests from the same client IP.
+var ResetClientThrottle = NewThrottle(time.Hour, 10)
+
+// POST /api/v1/password/reset
+func RequestPasswordReset(router *gin.RouterGroup) {
+	router.POST("/password/reset", func(c *gin.Context) {
+		conf := service.Config()
+
+		if conf.Public() {
+			Abort(c, http.StatusForbidden, i18n.ErrPublic)
+			return
+		} else if !conf.MailEnabled() {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		if !ResetClientThrottle.Allow(ClientIP(c)) {
+			Abort(c, http.StatusTooManyRequests, i18n.ErrTooManyRequests)
+			return
+		}
+
+		f := form.PasswordReset{}
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		// Respond the same way whether the address is registered or not, and
+		// whether an email was sent or throttled.
+		if !ResetAddressThrottle.Allow(strings.ToLower(strings.TrimSpace(f.Email))) {
+			log.Warnf("password: too many reset requests for the same address")
+		} else if m := entity.FindUserByEmail(f.Email); m != nil {
+			if token, err := m.NewResetToken(); err != nil {
+				log.Errorf("password: %s (create reset token)", err)
+			} else {
+				go func() {
+					if err := mailer.SendReset(conf, m, token); err != nil {
+						log.Errorf("password: %s (send reset email)", err)
+					}
+				}()
+			}
+		}
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgPasswordResetSent))
+	})
+}
+
+// GET /reset/:token
+// POST /reset/:token
+func ResetPassword(router *gin.RouterGroup) {
+	render := func(c *gin.Context, code int, data gin.H) {
+		conf := service.Config()
+
+		data["siteTitle"] = conf.SiteTitle()
+		data["siteUrl"] = conf.SiteUrl()
+		data["action"] = conf.BaseUri("/reset/" + c.Param("token"))
+
+		c.Header("Referrer-Policy", "no-referrer")
+		c.HTML(code, "reset.tmpl", data)
+	}
+
+	invalid := func(c *gin.Context) {
+		render(c, http.StatusNotFound, gin.H{"invalid": true, "error": i18n.Msg(i18n.ErrInvalidLink)})
+	}
+
+	router.GET("/:token", func(c *gin.Context) {
+		if entity.FindUserByResetToken(c.Param("token")) == nil {
+			invalid(c)
+			return
+		}
+
+		render(c, http.StatusOK, gin.H{})
+	})
+
+	router.POST("/:token", func(c *gin.Context) {
+		m := entity.FindUserByResetToken(c.Param("token"))
+
+		if m == nil {
+			invalid(c)
+			return
+		}
+
+		password := c.PostForm("password")
+
+		if password != c.PostForm("confirm") {
+			render(c, http.StatusBadRequest, gin.H{"error": "Passwords do not match"})
+			return
+		}
+
+		if err := m.ResetPassword(password); err != nil {
+			render(c, http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		log.Infof("password: %s changed by reset link", txt.Quote(m.UserName))
+
+		// Cached WebDAV credentials are no longer valid.
+		FlushCachedLogins(m.UserUID)
+
+		render(c, http.StatusOK, gin.H{"success": i18n.Msg(i18n.MsgPasswordChanged)})
+	})
+}
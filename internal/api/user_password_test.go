@@ -2,9 +2,15 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 
 	"github.com/stretchr/testify/assert"
@@ -138,3 +144,118 @@ func TestChangeUserPasswords(t *testing.T) {
 	})
 
 }
+
+func TestRequestPasswordReset(t *testing.T) {
+	ResetAddressThrottle.Reset()
+	ResetClientThrottle.Reset()
+
+	t.Run("public", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RequestPasswordReset(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/password/reset", `{"email": "bob@example.com"}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		RequestPasswordReset(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/password/reset", `{"email": "bob@example.com"}`)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("unknown email", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		s, stop := StartMailServer(conf)
+		defer stop()
+		RequestPasswordReset(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/password/reset", `{"email": "nobody@example.com"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Empty(t, s.Wait(1, 100*time.Millisecond))
+	})
+	t.Run("reset", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		s, stop := StartMailServer(conf)
+		defer stop()
+
+		m := &entity.User{UserName: "reset-api", PrimaryEmail: "reset-api@example.com"}
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		app.LoadHTMLFiles(filepath.Join(conf.TemplatesPath(), "reset.tmpl"))
+		RequestPasswordReset(router)
+		ResetPassword(app.Group("/reset"))
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/password/reset", `{"email": "reset-api@example.com"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		messages := s.Wait(1, time.Second)
+
+		if len(messages) != 1 {
+			t.Fatalf("expected one message, got %d", len(messages))
+		}
+
+		assert.Equal(t, []string{"reset-api@example.com"}, messages[0].To)
+
+		token := regexp.MustCompile(`/reset/([0-9a-f]{32})`).FindStringSubmatch(messages[0].Text())
+
+		if len(token) != 2 {
+			t.Fatalf("reset link not found in %s", messages[0].Text())
+		}
+
+		r = PerformRequest(app, "GET", "/reset/"+token[1])
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `name="password"`)
+
+		r = PerformForm(app, "/reset/"+token[1], url.Values{"password": {"resetapi123"}, "confirm": {"foo"}})
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Contains(t, r.Body.String(), "Passwords do not match")
+
+		r = PerformForm(app, "/reset/"+token[1], url.Values{"password": {"resetapi123"}, "confirm": {"resetapi123"}})
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, entity.FindUserByName("reset-api").InvalidPassword("resetapi123"))
+
+		r = PerformRequest(app, "GET", "/reset/"+token[1])
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("throttled", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetPublic(false)
+		defer conf.SetPublic(true)
+		s, stop := StartMailServer(conf)
+		defer stop()
+
+		defer ResetAddressThrottle.Reset()
+		defer ResetClientThrottle.Reset()
+
+		m := &entity.User{UserName: "reset-throttle", PrimaryEmail: "reset-throttle@example.com"}
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		RequestPasswordReset(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/password/reset", `{"email": "reset-throttle@example.com"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Len(t, s.Wait(1, time.Second), 1)
+
+		// Repeated requests for the same address don't send another email.
+		r = PerformRequestWithBody(app, "POST", "/api/v1/password/reset", `{"email": " Reset-Throttle@example.com"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Len(t, s.Wait(2, 100*time.Millisecond), 1)
+
+		// Forwarded addresses from untrusted clients are ignored, so they can't be rotated to avoid the limit.
+		for i := 0; i < ResetClientThrottle.Limit; i++ {
+			proxyRequest(app, "POST", "/api/v1/password/reset", "192.0.2.1:1234", map[string]string{"X-Forwarded-For": fmt.Sprintf("198.51.100.%d", i)})
+		}
+
+		r = proxyRequest(app, "POST", "/api/v1/password/reset", "192.0.2.1:1234", map[string]string{"X-Forwarded-For": "198.51.100.200"})
+		assert.Equal(t, http.StatusTooManyRequests, r.Code)
+	})
+}
@@ -59,7 +59,17 @@ func GetVideo(router *gin.RouterGroup) {
 			return
 		}
 
-		fileName := photoprism.FileName(f.FileRoot, f.FileName)
+		fileName, err := photoprism.LocalFileName(f.FileRoot, f.FileName)
+
+		if err != nil {
+			log.Errorf("video: file %s is missing", txt.Quote(f.FileName))
+			c.Data(http.StatusOK, "image/svg+xml", videoIconSvg)
+
+			// Set missing flag so that the file doesn't show up in search results anymore.
+			logError("video", f.Update("FileMissing", true))
+
+			return
+		}
 
 		if mf, err := photoprism.NewMediaFile(fileName); err != nil {
 			log.Errorf("video: file %s is missing", txt.Quote(f.FileName))
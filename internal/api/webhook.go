@@ -0,0 +1,259 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/webhook"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// webhooksDisabled tests if webhooks can't be managed.
+func webhooksDisabled() bool {
+	conf := service.Config()
+
+	return conf.DisableWebhooks() || conf.DisableSettings()
+}
+
+// GetWebhooks returns all webhooks.
+//
+// GET /api/v1/webhooks
+func GetWebhooks(router *gin.RouterGroup) {
+	router.GET("/webhooks", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceWebhooks, acl.ActionSearch)
+
+		if s.Invalid() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if webhooksDisabled() {
+			c.JSON(http.StatusOK, entity.Webhooks{})
+			return
+		}
+
+		result, err := query.Webhooks()
+
+		if err != nil {
+			log.Errorf("webhook: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GetWebhook returns a single webhook.
+//
+// GET /api/v1/webhooks/:uid
+//
+// Parameters:
+//   uid: string Webhook UID
+func GetWebhook(router *gin.RouterGroup) {
+	router.GET("/webhooks/:uid", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceWebhooks, acl.ActionRead)
+
+		if s.Invalid() || webhooksDisabled() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		if m, err := query.WebhookByUID(c.Param("uid")); err == nil {
+			c.JSON(http.StatusOK, m)
+		} else {
+			AbortEntityNotFound(c)
+		}
+	})
+}
+
+// GetWebhookDeliveries returns the delivery log of a webhook, newest first.
+//
+// GET /api/v1/webhooks/:uid/deliveries
+//
+// Parameters:
+//   uid: string Webhook UID
+//   count: int Max result count
+//   offset: int Result offset
+func GetWebhookDeliveries(router *gin.RouterGroup) {
+	router.GET("/webhooks/:uid/deliveries", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceWebhooks, acl.ActionRead)
+
+		if s.Invalid() || webhooksDisabled() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m, err := query.WebhookByUID(c.Param("uid"))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		count := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		result, err := query.WebhookDeliveries(m.WebhookUID, count, offset)
+
+		if err != nil {
+			log.Errorf("webhook: %s", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, count)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// CreateWebhook adds a new webhook.
+//
+// POST /api/v1/webhooks
+func CreateWebhook(router *gin.RouterGroup) {
+	router.POST("/webhooks", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceWebhooks, acl.ActionCreate)
+
+		if s.Invalid() || webhooksDisabled() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		var f form.Webhook
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := f.Validate(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		m, err := entity.CreateWebhook(f)
+
+		if err != nil {
+			log.Errorf("webhook: %s", err)
+			Audit(c, s, acl.ActionCreate, acl.ResourceWebhooks, "", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		Audit(c, s, acl.ActionCreate, acl.ResourceWebhooks, m.WebhookUID, nil)
+
+		webhook.Flush()
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// UpdateWebhook changes webhook settings, the secret remains unchanged if it is empty.
+//
+// PUT /api/v1/webhooks/:uid
+//
+// Parameters:
+//   uid: string Webhook UID
+func UpdateWebhook(router *gin.RouterGroup) {
+	router.PUT("/webhooks/:uid", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceWebhooks, acl.ActionUpdate)
+
+		if s.Invalid() || webhooksDisabled() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m, err := query.WebhookByUID(c.Param("uid"))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// 1) Init form with model values
+		f, err := form.NewWebhook(m)
+
+		if err != nil {
+			log.Errorf("webhook: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		// 2) Update form with values from request
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := f.Validate(); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UcFirst(err.Error())})
+			return
+		}
+
+		// 3) Save model with values from form
+		if err := m.SaveForm(f); err != nil {
+			log.Errorf("webhook: %s", err)
+			Audit(c, s, acl.ActionUpdate, acl.ResourceWebhooks, m.WebhookUID, err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		Audit(c, s, acl.ActionUpdate, acl.ResourceWebhooks, m.WebhookUID, nil)
+
+		webhook.Flush()
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteWebhook removes a webhook.
+//
+// DELETE /api/v1/webhooks/:uid
+//
+// Parameters:
+//   uid: string Webhook UID
+func DeleteWebhook(router *gin.RouterGroup) {
+	router.DELETE("/webhooks/:uid", func(c *gin.Context) {
+		s := Auth(SessionID(c), acl.ResourceWebhooks, acl.ActionDelete)
+
+		if s.Invalid() || webhooksDisabled() {
+			AbortUnauthorized(c)
+			return
+		}
+
+		m, err := query.WebhookByUID(c.Param("uid"))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			Audit(c, s, acl.ActionDelete, acl.ResourceWebhooks, m.WebhookUID, err)
+			Error(c, http.StatusInternalServerError, err, i18n.ErrDeleteFailed)
+			return
+		}
+
+		Audit(c, s, acl.ActionDelete, acl.ResourceWebhooks, m.WebhookUID, nil)
+
+		webhook.Flush()
+
+		c.JSON(http.StatusOK, m)
+	})
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	t.Run("invalid request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateWebhook(router)
+		r := PerformRequest(app, "POST", "/api/v1/webhooks")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrBadRequest), val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("invalid url", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateWebhook(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/webhooks", `{"Name": "Invalid", "URL": "ftp://example.com", "Topics": "photos.created"}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, "Url must start with http:// or https://", val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateWebhook(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/webhooks", `{"Name": "Home Assistant", "URL": "http://homeassistant.local:8123/api/webhook/photos", "Topics": "photos.created, albums.photos.added", "Enabled": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Home Assistant", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, int64(entity.WebhookRetryLimit), gjson.Get(r.Body.String(), "RetryLimit").Int())
+		assert.Len(t, gjson.Get(r.Body.String(), "Secret").String(), 32)
+		assert.True(t, gjson.Get(r.Body.String(), "Enabled").Bool())
+	})
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateWebhook(router)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/webhooks", `{"Name": "Matrix", "URL": "https://matrix.example.com/hook", "Topics": "people.photos.added", "Secret": "foo"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateWebhook(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/webhooks/"+uid, `{"Filter": "jqu0xs11qekk9jx8", "Enabled": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Matrix", gjson.Get(r.Body.String(), "Name").String())
+		assert.Equal(t, "jqu0xs11qekk9jx8", gjson.Get(r.Body.String(), "Filter").String())
+		assert.Equal(t, "foo", gjson.Get(r.Body.String(), "Secret").String())
+		assert.True(t, gjson.Get(r.Body.String(), "Enabled").Bool())
+	})
+	t.Run("invalid topics", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateWebhook(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/webhooks/"+uid, `{"Topics": ""}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateWebhook(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/webhooks/wxxx", `{"Enabled": true}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrEntityNotFound), val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetWebhooks(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateWebhook(router)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/webhooks", `{"Name": "List", "URL": "https://example.com/list", "Topics": "albums.*"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("list", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetWebhooks(router)
+		r := PerformRequest(app, "GET", "/api/v1/webhooks")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.GreaterOrEqual(t, len(gjson.Parse(r.Body.String()).Array()), 1)
+	})
+	t.Run("single", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetWebhook(router)
+		r := PerformRequest(app, "GET", "/api/v1/webhooks/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "List", gjson.Get(r.Body.String(), "Name").String())
+	})
+	t.Run("deliveries", func(t *testing.T) {
+		m := entity.WebhookDelivery{WebhookUID: uid, EventName: "albums.updated", StatusCode: 204, DeliveryAttempts: 1}
+		assert.NoError(t, m.Create())
+
+		app, router, _ := NewApiTest()
+		GetWebhookDeliveries(router)
+		r := PerformRequest(app, "GET", "/api/v1/webhooks/"+uid+"/deliveries?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "albums.updated", gjson.Get(r.Body.String(), "0.Event").String())
+		assert.Equal(t, int64(204), gjson.Get(r.Body.String(), "0.StatusCode").Int())
+	})
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	app, router, _ := NewApiTest()
+	CreateWebhook(router)
+	r := PerformRequestWithBody(app, "POST", "/api/v1/webhooks", `{"Name": "Delete", "URL": "https://example.com/delete", "Topics": "photos.*"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteWebhook(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/webhooks/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		GetWebhook(router)
+		r2 := PerformRequest(app, "GET", "/api/v1/webhooks/"+uid)
+		assert.Equal(t, http.StatusNotFound, r2.Code)
+	})
+	t.Run("not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		DeleteWebhook(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/webhooks/wxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -102,7 +102,7 @@ func CreateZip(router *gin.RouterGroup) {
 				continue
 			}
 
-			fileName := photoprism.FileName(file.FileRoot, file.FileName)
+			fileName, err := photoprism.LocalFileName(file.FileRoot, file.FileName)
 			alias := file.DownloadName(dlName, 0)
 			key := strings.ToLower(alias)
 
@@ -112,7 +112,7 @@ func CreateZip(router *gin.RouterGroup) {
 
 			aliases[key] += 1
 
-			if fs.FileExists(fileName) {
+			if err == nil {
 				if err := addFileToZip(zipWriter, fileName, alias); err != nil {
 					Error(c, http.StatusInternalServerError, err, i18n.ErrZipFailed)
 					return
@@ -0,0 +1,8 @@
+package classify
+
+// Classifier represents an image classification backend that returns matching labels for JPEG images.
+type Classifier interface {
+	Init() error
+	File(filename string) (result Labels, err error)
+	Labels(img []byte) (result Labels, err error)
+}
@@ -0,0 +1,62 @@
+package classify
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Prediction represents a single label candidate and its probability as returned by a model.
+type Prediction struct {
+	Label       string  `json:"label"`
+	Probability float32 `json:"probability"`
+}
+
+// Predictions represents a list of label candidates.
+type Predictions []Prediction
+
+// Labels applies the label rules and returns the best 5 labels (if enough high probability labels).
+func (p Predictions) Labels() Labels {
+	var result Labels
+
+	for _, prediction := range p {
+		// discard labels with low probabilities
+		if prediction.Probability < 0.1 {
+			continue
+		}
+
+		labelText := strings.ToLower(strings.TrimSpace(prediction.Label))
+
+		if labelText == "" {
+			continue
+		}
+
+		rule, _ := rules.Find(labelText)
+
+		// discard labels that don't met the threshold
+		if prediction.Probability < rule.Threshold {
+			continue
+		}
+
+		// Get rule label name instead of model label name if it exists
+		if rule.Label != "" {
+			labelText = rule.Label
+		}
+
+		labelText = strings.TrimSpace(labelText)
+
+		uncertainty := 100 - int(math.Round(float64(prediction.Probability*100)))
+
+		result = append(result, Label{Name: labelText, Source: SrcImage, Uncertainty: uncertainty, Priority: rule.Priority, Categories: rule.Categories})
+	}
+
+	// Sort by probability
+	sort.Sort(result)
+
+	// Return the best labels only.
+	if l := len(result); l < 5 {
+		return result[:l]
+	} else {
+		return result[:5]
+	}
+}
@@ -0,0 +1,51 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredictions_Labels(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		var p Predictions
+
+		assert.Empty(t, p.Labels())
+	})
+	t.Run("rules", func(t *testing.T) {
+		p := Predictions{
+			{Label: "African Elephant", Probability: 0.8},
+			{Label: "abacus", Probability: 0.9},
+			{Label: "airliner", Probability: 0.3},
+			{Label: "unknown thing", Probability: 0.05},
+		}
+
+		result := p.Labels()
+
+		if len(result) != 1 {
+			t.Fatalf("one label expected: %+v", result)
+		}
+
+		assert.Equal(t, "elephant", result[0].Name)
+		assert.Equal(t, SrcImage, result[0].Source)
+		assert.Equal(t, 20, result[0].Uncertainty)
+		assert.Equal(t, 2, result[0].Priority)
+		assert.Equal(t, []string{"animal", "wildlife"}, result[0].Categories)
+	})
+	t.Run("best five", func(t *testing.T) {
+		p := Predictions{
+			{Label: "cat", Probability: 0.9},
+			{Label: "dog", Probability: 0.8},
+			{Label: "bird", Probability: 0.7},
+			{Label: "fish", Probability: 0.6},
+			{Label: "horse", Probability: 0.5},
+			{Label: "cow", Probability: 0.4},
+		}
+
+		result := p.Labels()
+
+		assert.Len(t, result, 5)
+		assert.Equal(t, "cat", result[0].Name)
+		assert.Equal(t, 10, result[0].Uncertainty)
+	})
+}
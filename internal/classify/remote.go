@@ -0,0 +1,103 @@
+package classify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RemoteTimeout is the maximum duration of a single classification request.
+var RemoteTimeout = 60 * time.Second
+
+// Remote is a client for an external inference server that returns label/probability pairs
+// for JPEG images, e.g. a CLIP-style model running on another host.
+//
+// Images are sent as "image/jpeg" request body via POST, the server must respond with a
+// JSON array of predictions like [{"label": "cat", "probability": 0.92}, ...].
+type Remote struct {
+	uri      string
+	disabled bool
+	client   *http.Client
+}
+
+// NewRemote returns a new remote classifier for the given inference server URL.
+func NewRemote(uri string, disabled bool) *Remote {
+	return &Remote{uri: uri, disabled: disabled, client: &http.Client{Timeout: RemoteTimeout}}
+}
+
+// Init validates the inference server URL if not disabled.
+func (r *Remote) Init() error {
+	if r.disabled {
+		return nil
+	}
+
+	if u, err := url.Parse(r.uri); err != nil {
+		return fmt.Errorf("classify: %s (invalid service url)", err)
+	} else if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("classify: invalid service url %s", r.uri)
+	}
+
+	return nil
+}
+
+// File returns matching labels for a jpeg media file.
+func (r *Remote) File(filename string) (result Labels, err error) {
+	if r.disabled {
+		return result, nil
+	}
+
+	imageBuffer, err := ioutil.ReadFile(filename)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Labels(imageBuffer)
+}
+
+// Labels returns matching labels for a jpeg media string.
+func (r *Remote) Labels(img []byte) (result Labels, err error) {
+	if r.disabled {
+		return result, nil
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.uri, bytes.NewReader(img))
+
+	if err != nil {
+		return nil, fmt.Errorf("classify: %s (create request)", err)
+	}
+
+	req.Header.Set("Content-Type", "image/jpeg")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.client.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("classify: %s (http request)", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("classify: request failed with code %d", resp.StatusCode)
+	}
+
+	var predictions Predictions
+
+	if err := json.NewDecoder(resp.Body).Decode(&predictions); err != nil {
+		return nil, fmt.Errorf("classify: %s (decode json)", err)
+	}
+
+	// Apply label rules and return best labels.
+	result = predictions.Labels()
+
+	if len(result) > 0 {
+		log.Tracef("classify: image classified as %+v", result)
+	}
+
+	return result, nil
+}
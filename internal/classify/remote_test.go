@@ -0,0 +1,118 @@
+package classify
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServer returns a stand-in inference server that responds with the given predictions.
+func newTestServer(t *testing.T, predictions Predictions) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if r.Header.Get("Content-Type") != "image/jpeg" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		if body, err := ioutil.ReadAll(r.Body); err != nil || len(body) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(predictions); err != nil {
+			t.Error(err)
+		}
+	}))
+}
+
+func TestRemote_Init(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.Nil(t, NewRemote("http://localhost:8080/predict", false).Init())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Error(t, NewRemote("localhost", false).Init())
+		assert.Error(t, NewRemote("ftp://localhost/", false).Init())
+	})
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, NewRemote("", true).Init())
+	})
+}
+
+func TestRemote_Labels(t *testing.T) {
+	server := newTestServer(t, Predictions{
+		{Label: "tabby cat", Probability: 0.92},
+		{Label: "abacus", Probability: 0.5},
+	})
+
+	defer server.Close()
+
+	t.Run("success", func(t *testing.T) {
+		r := NewRemote(server.URL, false)
+
+		result, err := r.Labels([]byte("jpeg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "cat", result[0].Name)
+		assert.Equal(t, 8, result[0].Uncertainty)
+		assert.Equal(t, SrcImage, result[0].Source)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		r := NewRemote(server.URL, true)
+
+		result, err := r.Labels([]byte("jpeg"))
+
+		assert.Nil(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := NewRemote(server.URL, false)
+
+		result, err := r.Labels(nil)
+
+		assert.EqualError(t, err, "classify: request failed with code 400")
+		assert.Empty(t, result)
+	})
+}
+
+func TestRemote_File(t *testing.T) {
+	server := newTestServer(t, Predictions{{Label: "chameleon", Probability: 0.93}})
+
+	defer server.Close()
+
+	t.Run("chameleon_lime.jpg", func(t *testing.T) {
+		r := NewRemote(server.URL, false)
+
+		result, err := r.File(examplesPath + "/chameleon_lime.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "chameleon", result[0].Name)
+		assert.Equal(t, 7, result[0].Uncertainty)
+	})
+	t.Run("not existing file", func(t *testing.T) {
+		r := NewRemote(server.URL, false)
+
+		result, err := r.File(examplesPath + "/notexisting.jpg")
+
+		assert.Contains(t, err.Error(), "no such file or directory")
+		assert.Empty(t, result)
+	})
+}
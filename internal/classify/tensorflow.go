@@ -6,13 +6,10 @@ import (
 	"fmt"
 	"image"
 	"io/ioutil"
-	"math"
 	"os"
 	"path"
 	"path/filepath"
 	"runtime/debug"
-	"sort"
-	"strings"
 
 	"github.com/disintegration/imaging"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -165,7 +162,7 @@ func (t *TensorFlow) loadModel() error {
 
 // bestLabels returns the best 5 labels (if enough high probability labels) from the prediction of the model
 func (t *TensorFlow) bestLabels(probabilities []float32) Labels {
-	var result Labels
+	var predictions Predictions
 
 	for i, p := range probabilities {
 		if i >= len(t.labels) {
@@ -173,41 +170,10 @@ func (t *TensorFlow) bestLabels(probabilities []float32) Labels {
 			break
 		}
 
-		// discard labels with low probabilities
-		if p < 0.1 {
-			continue
-		}
-
-		labelText := strings.ToLower(t.labels[i])
-
-		rule, _ := rules.Find(labelText)
-
-		// discard labels that don't met the threshold
-		if p < rule.Threshold {
-			continue
-		}
-
-		// Get rule label name instead of t.labels name if it exists
-		if rule.Label != "" {
-			labelText = rule.Label
-		}
-
-		labelText = strings.TrimSpace(labelText)
-
-		uncertainty := 100 - int(math.Round(float64(p*100)))
-
-		result = append(result, Label{Name: labelText, Source: SrcImage, Uncertainty: uncertainty, Priority: rule.Priority, Categories: rule.Categories})
+		predictions = append(predictions, Prediction{Label: t.labels[i], Probability: p})
 	}
 
-	// Sort by probability
-	sort.Sort(result)
-
-	// Return the best labels only.
-	if l := len(result); l < 5 {
-		return result[:l]
-	} else {
-		return result[:5]
-	}
+	return predictions.Labels()
 }
 
 // createTensor converts bytes jpeg image in a tensor object required as tensorflow model input
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/urfave/cli"
+)
+
+// AuditCommand registers audit log commands.
+var AuditCommand = cli.Command{
+	Name:  "audit",
+	Usage: "Audit log sub-commands",
+	Subcommands: []cli.Command{
+		{
+			Name:   "export",
+			Usage:  "exports the audit log as JSON lines",
+			Action: auditExportAction,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "output, o",
+					Usage: "output `FILENAME`, default is stdout",
+				},
+				cli.StringFlag{
+					Name:  "actor",
+					Usage: "user `UID` or name",
+				},
+				cli.StringFlag{
+					Name:  "resource",
+					Usage: "resource `TYPE`, e.g. files",
+				},
+				cli.StringFlag{
+					Name:  "uid",
+					Usage: "resource `UID`",
+				},
+				cli.StringFlag{
+					Name:  "after",
+					Usage: "logged after `DATE`, e.g. 2021-06-01",
+				},
+				cli.StringFlag{
+					Name:  "before",
+					Usage: "logged before `DATE`, e.g. 2021-06-30",
+				},
+			},
+		},
+	},
+}
+
+// auditExportAction writes matching audit log entries to a file or stdout.
+func auditExportAction(ctx *cli.Context) error {
+	return callWithDependencies(ctx, func(conf *config.Config) error {
+		f := form.AuditSearch{
+			Actor:    ctx.String("actor"),
+			Resource: ctx.String("resource"),
+			UID:      ctx.String("uid"),
+			Count:    query.MaxResults,
+		}
+
+		var err error
+
+		if f.After, err = auditDate(ctx.String("after")); err != nil {
+			return err
+		}
+
+		if f.Before, err = auditDate(ctx.String("before")); err != nil {
+			return err
+		}
+
+		var out io.Writer = os.Stdout
+
+		if fileName := ctx.String("output"); fileName != "" {
+			file, err := os.Create(fileName)
+
+			if err != nil {
+				return err
+			}
+
+			defer file.Close()
+
+			out = file
+		}
+
+		enc := json.NewEncoder(out)
+		exported := 0
+
+		for {
+			results, err := query.AuditLogs(f)
+
+			if err != nil {
+				return err
+			}
+
+			for _, r := range results {
+				if err := enc.Encode(r); err != nil {
+					return err
+				}
+			}
+
+			exported += len(results)
+
+			if len(results) < f.Count {
+				break
+			}
+
+			f.Offset += f.Count
+		}
+
+		if out != os.Stdout {
+			log.Infof("audit: exported %d entries to %s", exported, txt.Quote(ctx.String("output")))
+		}
+
+		return nil
+	})
+}
+
+// auditDate parses an optional date flag.
+func auditDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse("2006-01-02", s)
+
+	if err != nil {
+		return t, fmt.Errorf("audit: invalid date %s", txt.Quote(s))
+	}
+
+	return t, nil
+}
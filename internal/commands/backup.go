@@ -13,6 +13,7 @@ import (
 
 	"github.com/photoprism/photoprism/pkg/fs"
 
+	"github.com/photoprism/photoprism/internal/mailer"
 	"github.com/photoprism/photoprism/internal/service"
 
 	"github.com/photoprism/photoprism/internal/photoprism"
@@ -181,6 +182,8 @@ func backupAction(ctx *cli.Context) error {
 		log.Infof("backing up albums to %s", txt.Quote(albumsPath))
 
 		if count, err := photoprism.BackupAlbums(albumsPath, true); err != nil {
+			mailer.Alert(conf, "backup", err)
+			mailer.WaitAlerts()
 			return err
 		} else {
 			log.Infof("%d albums saved as yaml files", count)
@@ -48,6 +48,14 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "backup-path", conf.BackupPath())
 	fmt.Printf("%-25s %s\n", "assets-path", conf.AssetsPath())
 
+	// Storage backend.
+	fmt.Printf("%-25s %s\n", "storage-backend", conf.StorageBackend())
+	fmt.Printf("%-25s %s\n", "s3-endpoint", conf.S3Endpoint())
+	fmt.Printf("%-25s %s\n", "s3-bucket", conf.S3Bucket())
+	fmt.Printf("%-25s %s\n", "s3-prefix", conf.S3Prefix())
+	fmt.Printf("%-25s %s\n", "s3-region", conf.S3Region())
+	fmt.Printf("%-25s %d\n", "s3-cache-size", conf.S3CacheSize())
+
 	// Asset path and file names.
 	fmt.Printf("%-25s %s\n", "static-path", conf.StaticPath())
 	fmt.Printf("%-25s %s\n", "build-path", conf.BuildPath())
@@ -59,10 +67,13 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %d\n", "wakeup-interval", conf.WakeupInterval()/time.Second)
 	fmt.Printf("%-25s %d\n", "auto-index", conf.AutoIndex()/time.Second)
 	fmt.Printf("%-25s %d\n", "auto-import", conf.AutoImport()/time.Second)
+	fmt.Printf("%-25s %d\n", "audit-retention", conf.AuditRetention()/(24*time.Hour))
+	fmt.Printf("%-25s %d\n", "audit-limit", conf.AuditLimit())
 
 	// Disable features.
 	fmt.Printf("%-25s %t\n", "disable-backups", conf.DisableBackups())
 	fmt.Printf("%-25s %t\n", "disable-settings", conf.DisableSettings())
+	fmt.Printf("%-25s %t\n", "disable-webhooks", conf.DisableWebhooks())
 	fmt.Printf("%-25s %t\n", "disable-places", conf.DisablePlaces())
 	fmt.Printf("%-25s %t\n", "disable-exiftool", conf.DisableExifTool())
 	fmt.Printf("%-25s %t\n", "disable-tensorflow", conf.DisableTensorFlow())
@@ -75,6 +86,10 @@ func configAction(ctx *cli.Context) error {
 	// Everything related to TensorFlow.
 	fmt.Printf("%-25s %s\n", "tensorflow-version", conf.TensorFlowVersion())
 	fmt.Printf("%-25s %s\n", "tensorflow-model-path", conf.TensorFlowModelPath())
+	fmt.Printf("%-25s %s\n", "classify-url", conf.ClassifyUrl())
+	fmt.Printf("%-25s %s\n", "embed-url", conf.EmbedUrl())
+	fmt.Printf("%-25s %s\n", "embed-model", conf.EmbedModel())
+	fmt.Printf("%-25s %t\n", "enable-ocr", conf.EnableOCR())
 	fmt.Printf("%-25s %t\n", "detect-nsfw", conf.DetectNSFW())
 	fmt.Printf("%-25s %t\n", "upload-nsfw", conf.UploadNSFW())
 
@@ -100,9 +115,61 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "http-host", conf.HttpHost())
 	fmt.Printf("%-25s %d\n", "http-port", conf.HttpPort())
 	fmt.Printf("%-25s %s\n", "http-mode", conf.HttpMode())
+	fmt.Printf("%-25s %d\n", "http-redirect-port", conf.HttpRedirectPort())
+	fmt.Printf("%-25s %s\n", "http-socket", conf.HttpSocket())
+	fmt.Printf("%-25s %s\n", "tls-cert", conf.TLSCert())
+	fmt.Printf("%-25s %s\n", "tls-key", conf.TLSKey())
+	fmt.Printf("%-25s %s\n", "tls-min-version", conf.TLSMinVersionName())
+	fmt.Printf("%-25s %s\n", "acme-domains", strings.Join(conf.ACMEDomains(), ","))
+	fmt.Printf("%-25s %s\n", "acme-email", conf.ACMEEmail())
+	fmt.Printf("%-25s %s\n", "acme-directory", conf.ACMEDirectory())
+	fmt.Printf("%-25s %s\n", "acme-cache-path", conf.ACMECachePath())
+	fmt.Printf("%-25s %s\n", "shutdown-timeout", conf.ShutdownTimeout())
+	fmt.Printf("%-25s %s\n", "metrics-token", strings.Repeat("*", utf8.RuneCountInString(conf.MetricsToken())))
+
+	// Email notifications.
+	fmt.Printf("%-25s %s\n", "smtp-host", conf.SMTPHost())
+	fmt.Printf("%-25s %d\n", "smtp-port", conf.SMTPPort())
+	fmt.Printf("%-25s %s\n", "smtp-user", conf.SMTPUser())
+	fmt.Printf("%-25s %s\n", "smtp-password", strings.Repeat("*", utf8.RuneCountInString(conf.SMTPPassword())))
+	fmt.Printf("%-25s %s\n", "smtp-from", conf.SMTPFrom())
+	fmt.Printf("%-25s %s\n", "smtp-tls", conf.SMTPTLS())
+	fmt.Printf("%-25s %s\n", "alert-email", strings.Join(conf.AlertEmail(), ","))
 
 	// Passwords.
 	fmt.Printf("%-25s %s\n", "admin-password", strings.Repeat("*", utf8.RuneCountInString(conf.AdminPassword())))
+	fmt.Printf("%-25s %t\n", "disable-local-login", conf.DisableLocalLogin())
+
+	// Single sign-on.
+	fmt.Printf("%-25s %s\n", "oidc-uri", conf.OIDCUri())
+	fmt.Printf("%-25s %s\n", "oidc-client", conf.OIDCClient())
+	fmt.Printf("%-25s %s\n", "oidc-secret", strings.Repeat("*", utf8.RuneCountInString(conf.OIDCSecret())))
+	fmt.Printf("%-25s %s\n", "oidc-scopes", strings.Join(conf.OIDCScopes(), " "))
+	fmt.Printf("%-25s %s\n", "oidc-roles", conf.OIDCRoles())
+	fmt.Printf("%-25s %s\n", "oidc-role", conf.OIDCDefaultRole())
+	fmt.Printf("%-25s %t\n", "oidc-register", conf.OIDCRegister())
+
+	// LDAP.
+	fmt.Printf("%-25s %s\n", "ldap-uri", conf.LDAPUri())
+	fmt.Printf("%-25s %t\n", "ldap-starttls", conf.LDAPStartTLS())
+	fmt.Printf("%-25s %t\n", "ldap-insecure", conf.LDAPInsecure())
+	fmt.Printf("%-25s %s\n", "ldap-bind-dn", conf.LDAPBindDN())
+	fmt.Printf("%-25s %s\n", "ldap-bind-password", strings.Repeat("*", utf8.RuneCountInString(conf.LDAPBindPassword())))
+	fmt.Printf("%-25s %s\n", "ldap-base-dn", conf.LDAPBaseDN())
+	fmt.Printf("%-25s %s\n", "ldap-user-filter", conf.LDAPUserFilter())
+	fmt.Printf("%-25s %s\n", "ldap-group-filter", conf.LDAPGroupFilter())
+	fmt.Printf("%-25s %s\n", "ldap-roles", conf.LDAPRoles())
+	fmt.Printf("%-25s %s\n", "ldap-role", conf.LDAPDefaultRole())
+
+	// Reverse proxy authentication.
+	fmt.Printf("%-25s %s\n", "proxy-user-header", conf.ProxyUserHeader())
+	fmt.Printf("%-25s %s\n", "proxy-groups-header", conf.ProxyGroupsHeader())
+	fmt.Printf("%-25s %s\n", "proxy-email-header", conf.ProxyEmailHeader())
+	fmt.Printf("%-25s %s\n", "proxy-name-header", conf.ProxyNameHeader())
+	fmt.Printf("%-25s %s\n", "trusted-proxy", conf.TrustedProxies())
+	fmt.Printf("%-25s %s\n", "proxy-roles", conf.ProxyRoles())
+	fmt.Printf("%-25s %s\n", "proxy-role", conf.ProxyDefaultRole())
+	fmt.Printf("%-25s %t\n", "proxy-register", conf.ProxyRegister())
 
 	// Database configuration.
 	fmt.Printf("%-25s %s\n", "database-driver", dbDriver)
@@ -121,11 +188,14 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %s\n", "rawtherapee-bin", conf.RawtherapeeBin())
 	fmt.Printf("%-25s %s\n", "sips-bin", conf.SipsBin())
 	fmt.Printf("%-25s %s\n", "heifconvert-bin", conf.HeifConvertBin())
+	fmt.Printf("%-25s %s\n", "tesseract-bin", conf.TesseractBin())
+	fmt.Printf("%-25s %s\n", "ocr-languages", conf.OCRLanguages())
 	fmt.Printf("%-25s %s\n", "ffmpeg-bin", conf.FFmpegBin())
 	fmt.Printf("%-25s %s\n", "ffmpeg-encoder", conf.FFmpegEncoder())
 	fmt.Printf("%-25s %d\n", "ffmpeg-bitrate", conf.FFmpegBitrate())
 	fmt.Printf("%-25s %d\n", "ffmpeg-buffers", conf.FFmpegBuffers())
 	fmt.Printf("%-25s %s\n", "exiftool-bin", conf.ExifToolBin())
+	fmt.Printf("%-25s %t\n", "export-xmp", conf.ExportXmp())
 
 	// Thumbs, resampling and download security token.
 	fmt.Printf("%-25s %s\n", "download-token", conf.DownloadToken())
@@ -135,6 +205,7 @@ func configAction(ctx *cli.Context) error {
 	fmt.Printf("%-25s %d\n", "thumb-size", conf.ThumbSizePrecached())
 	fmt.Printf("%-25s %d\n", "thumb-size-uncached", conf.ThumbSizeUncached())
 	fmt.Printf("%-25s %s\n", "thumb-path", conf.ThumbPath())
+	fmt.Printf("%-25s %s\n", "webdav-thumbs", conf.WebDAVThumbs())
 	fmt.Printf("%-25s %d\n", "jpeg-size", conf.JpegSize())
 	fmt.Printf("%-25s %d\n", "jpeg-quality", conf.JpegQuality())
 
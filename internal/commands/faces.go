@@ -44,6 +44,11 @@ var FacesCommand = cli.Command{
 			Usage:  "Optimizes face clusters",
 			Action: facesOptimizeAction,
 		},
+		{
+			Name:   "retrain",
+			Usage:  "Rebuilds face clusters from confirmed faces only",
+			Action: facesRetrainAction,
+		},
 		{
 			Name:  "update",
 			Usage: "Performs facial recognition",
@@ -191,6 +196,43 @@ func facesOptimizeAction(ctx *cli.Context) error {
 	return nil
 }
 
+// facesRetrainAction rebuilds face clusters from manually confirmed markers.
+func facesRetrainAction(ctx *cli.Context) error {
+	actionPrompt := promptui.Prompt{
+		Label:     "Replace all face clusters with clusters learned from confirmed faces?",
+		IsConfirm: true,
+	}
+
+	if _, err := actionPrompt.Run(); err != nil {
+		return nil
+	}
+
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	w := service.Faces()
+
+	if res, err := w.Retrain(); err != nil {
+		return err
+	} else {
+		elapsed := time.Since(start)
+
+		log.Infof("%d face clusters of %d subjects learned from %d samples, %d faces recognized in %s", res.Added, res.Subjects, res.Samples, res.Recognized, elapsed)
+	}
+
+	conf.Shutdown()
+
+	return nil
+}
+
 // facesUpdateAction performs face clustering and matching.
 func facesUpdateAction(ctx *cli.Context) error {
 	start := time.Now()
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mailer"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/urfave/cli"
@@ -71,6 +72,10 @@ func importAction(ctx *cli.Context) error {
 	elapsed := time.Since(start)
 
 	log.Infof("import completed in %s", elapsed)
+
+	// Wait until failure alerts have been sent.
+	mailer.WaitAlerts()
+
 	conf.Shutdown()
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/photoprism/photoprism/pkg/fs"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mailer"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -108,6 +109,9 @@ func indexAction(ctx *cli.Context) error {
 
 	log.Infof("indexed %d files in %s", len(indexed), elapsed)
 
+	// Wait until failure alerts have been sent.
+	mailer.WaitAlerts()
+
 	conf.Shutdown()
 
 	return nil
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mailer"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/urfave/cli"
 )
@@ -46,6 +47,9 @@ func momentsAction(ctx *cli.Context) error {
 		log.Infof("completed in %s", elapsed)
 	}
 
+	// Wait until failure alerts have been sent.
+	mailer.WaitAlerts()
+
 	conf.Shutdown()
 
 	return nil
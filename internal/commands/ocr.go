@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/urfave/cli"
+)
+
+// OcrCommand registers the ocr cli command.
+var OcrCommand = cli.Command{
+	Name:  "ocr",
+	Usage: "Recognizes text in existing pictures so that it can be found by search",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "force, f",
+			Usage: "process pictures again that already have been processed",
+		},
+	},
+	Action: ocrAction,
+}
+
+// ocrAction recognizes text in the primary JPEG files of existing pictures.
+func ocrAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	w := service.OCR()
+
+	if _, err := w.Start(ctx.Bool("force")); err != nil {
+		log.Error(err)
+		return err
+	} else {
+		log.Infof("completed in %s", time.Since(start))
+	}
+
+	conf.Shutdown()
+
+	return nil
+}
@@ -7,15 +7,16 @@ import (
 	"os/signal"
 	"strconv"
 	"syscall"
-	"time"
 
 	"github.com/photoprism/photoprism/internal/auto"
 
 	"github.com/photoprism/photoprism/internal/photoprism"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/server"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/webhook"
 	"github.com/photoprism/photoprism/internal/workers"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -108,8 +109,16 @@ func startAction(ctx *cli.Context) error {
 		log.Infof("start: read-only mode enabled")
 	}
 
+	// send library events to webhooks
+	webhook.Start(conf)
+
 	// start web server
-	go server.Start(cctx, conf)
+	serverDone := make(chan struct{})
+
+	go func() {
+		server.Start(cctx, conf)
+		close(serverDone)
+	}()
 
 	if count, err := photoprism.RestoreAlbums(conf.AlbumsPath(), false); err != nil {
 		log.Errorf("restore: %s", err)
@@ -122,25 +131,38 @@ func startAction(ctx *cli.Context) error {
 	auto.Start(conf)
 
 	// set up proper shutdown of daemon and web server
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
 	<-quit
 
-	// stop share & sync workers
+	log.Info("shutting down...")
+
+	// don't start new jobs and ask running workers to stop at the next checkpoint
+	mutex.Shutdown()
 	workers.Stop()
 	auto.Stop()
 
-	log.Info("shutting down...")
-	conf.Shutdown()
+	// wait for running requests
 	cancel()
-	err := dctx.Release()
+	<-serverDone
+
+	// wait for background jobs so that no files are left half written
+	if mutex.WaitWorkers(conf.ShutdownTimeout()) {
+		log.Info("shutdown: all workers stopped")
+	} else {
+		log.Warnf("shutdown: workers still busy after %s", conf.ShutdownTimeout())
+	}
+
+	webhook.Stop()
+	service.Shutdown()
+	conf.Shutdown()
 
-	if err != nil {
+	if err := dctx.Release(); err != nil {
 		log.Error(err)
 	}
 
-	time.Sleep(3 * time.Second)
+	log.Info("shutdown complete")
 
 	return nil
 }
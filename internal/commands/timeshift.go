@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/urfave/cli"
+)
+
+// TimeShiftCommand registers the timeshift cli command.
+var TimeShiftCommand = cli.Command{
+	Name:      "timeshift",
+	Usage:     "Corrects the date taken of photos, e.g. after camera clock errors",
+	UsageText: `Photos may be selected by UID and/or by camera, date range and folder.`,
+	Flags:     timeShiftFlags,
+	Action:    timeShiftAction,
+}
+
+var timeShiftFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "offset, o",
+		Usage: "time `OFFSET` to add, e.g. \"-1h30m\"",
+	},
+	cli.StringFlag{
+		Name:  "zone, z",
+		Usage: "new time `ZONE`, e.g. \"Europe/Berlin\"",
+	},
+	cli.StringSliceFlag{
+		Name:  "photo, p",
+		Usage: "photo `UID`, may be passed multiple times",
+	},
+	cli.StringFlag{
+		Name:  "camera, c",
+		Usage: "camera `ID` or name, e.g. \"Canon EOS 5D\"",
+	},
+	cli.StringFlag{
+		Name:  "after",
+		Usage: "taken after `DATE`, e.g. 2021-06-01",
+	},
+	cli.StringFlag{
+		Name:  "before",
+		Usage: "taken before `DATE`, e.g. 2021-06-30",
+	},
+	cli.StringFlag{
+		Name:  "folder, f",
+		Usage: "originals sub `FOLDER`",
+	},
+	cli.StringFlag{
+		Name:  "reference, r",
+		Usage: "`UID` of a photo that shows a clock, derives the offset from --reference-time",
+	},
+	cli.StringFlag{
+		Name:  "reference-time",
+		Usage: "`TIME` shown on the clock, e.g. \"2021-06-01 14:03:00\"",
+	},
+	cli.BoolFlag{
+		Name:  "sidecar, s",
+		Usage: "write the result to YAML sidecar files",
+	},
+}
+
+// timeShiftAction corrects the date taken of matching photos.
+func timeShiftAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf := config.NewConfig(ctx)
+	service.SetConfig(conf)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := conf.Init(); err != nil {
+		return err
+	}
+
+	conf.InitDb()
+
+	filter, err := timeShiftFilter(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	f := form.TimeShift{
+		Selection:     form.Selection{Photos: ctx.StringSlice("photo")},
+		Filter:        filter,
+		Offset:        ctx.String("offset"),
+		TimeZone:      ctx.String("zone"),
+		Reference:     ctx.String("reference"),
+		ReferenceTime: ctx.String("reference-time"),
+		Sidecar:       ctx.Bool("sidecar"),
+	}
+
+	result, err := service.TimeShift().Start(f)
+
+	if err != nil {
+		return err
+	}
+
+	for _, failure := range result.Failures {
+		log.Errorf("timeshift: %s (%s)", failure.Error, failure.PhotoUID)
+	}
+
+	log.Infof("timeshift: shifted %d of %d photos by %s", result.Shifted, result.Total, result.Offset)
+
+	elapsed := time.Since(start)
+
+	log.Infof("completed in %s", elapsed)
+
+	conf.Shutdown()
+
+	return nil
+}
+
+// timeShiftFilter returns a photo search query based on the command flags.
+func timeShiftFilter(ctx *cli.Context) (string, error) {
+	var q []string
+
+	if s := strings.TrimSpace(ctx.String("camera")); s != "" {
+		if id, err := strconv.Atoi(s); err == nil {
+			q = append(q, fmt.Sprintf("camera:%d", id))
+		} else if camera := entity.FindCamera(slug.Make(s)); camera != nil {
+			q = append(q, fmt.Sprintf("camera:%d", camera.ID))
+		} else {
+			return "", fmt.Errorf("timeshift: camera %s not found", txt.Quote(s))
+		}
+	}
+
+	if s := ctx.String("after"); s != "" {
+		q = append(q, "after:"+s)
+	}
+
+	if s := ctx.String("before"); s != "" {
+		q = append(q, "before:"+s)
+	}
+
+	if s := ctx.String("folder"); s != "" {
+		q = append(q, fmt.Sprintf("path:%q", strings.Trim(s, "/")))
+	}
+
+	return strings.Join(q, " "), nil
+}
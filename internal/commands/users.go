@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/manifoldco/promptui"
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
@@ -65,6 +66,22 @@ var UsersCommand = cli.Command{
 					Name:  "email, m",
 					Usage: "sets the users email",
 				},
+				cli.StringFlag{
+					Name:  "role, r",
+					Usage: "sets the users role: admin, family, child, friend or guest",
+				},
+				cli.StringFlag{
+					Name:  "webdav, w",
+					Usage: "allows WebDAV access: yes or no",
+				},
+				cli.StringFlag{
+					Name:  "storage-path, s",
+					Usage: "restricts WebDAV access to a folder in originals and import",
+				},
+				cli.StringFlag{
+					Name:  "disabled, d",
+					Usage: "disables the user: yes or no",
+				},
 			},
 		},
 		{
@@ -206,10 +223,10 @@ func usersListAction(ctx *cli.Context) error {
 		users := query.RegisteredUsers()
 		log.Infof("found %d users", len(users))
 
-		fmt.Printf("%-4s %-16s %-16s %-16s\n", "ID", "LOGIN", "NAME", "EMAIL")
+		fmt.Printf("%-4s %-16s %-16s %-16s %-8s %-8s %-16s\n", "ID", "LOGIN", "NAME", "EMAIL", "ROLE", "WEBDAV", "STORAGE PATH")
 
 		for _, user := range users {
-			fmt.Printf("%-4d %-16s %-16s %-16s", user.ID, user.UserName, user.FullName, user.PrimaryEmail)
+			fmt.Printf("%-4d %-16s %-16s %-16s %-8s %-8t %-16s", user.ID, user.UserName, user.FullName, user.PrimaryEmail, user.Role(), user.CanUseWebDAV(), user.StoragePath)
 			fmt.Printf("\n")
 		}
 
@@ -247,8 +264,27 @@ func usersUpdateAction(ctx *cli.Context) error {
 			u.FullName = uc.FullName
 		}
 
-		if ctx.IsSet("email") && len(uc.Email) > 0 {
+		if ctx.IsSet("email") && len(uc.Email) > 0 && uc.Email != u.PrimaryEmail {
 			u.PrimaryEmail = uc.Email
+			u.EmailConfirmed = false
+		}
+
+		if ctx.IsSet("role") {
+			if err := u.SetRole(acl.Role(strings.ToLower(strings.TrimSpace(ctx.String("role"))))); err != nil {
+				return err
+			}
+		}
+
+		if ctx.IsSet("webdav") {
+			u.WebDAV = txt.Bool(ctx.String("webdav"))
+		}
+
+		if ctx.IsSet("storage-path") {
+			u.StoragePath = strings.Trim(strings.TrimSpace(ctx.String("storage-path")), "/")
+		}
+
+		if ctx.IsSet("disabled") {
+			u.UserDisabled = txt.Bool(ctx.String("disabled"))
 		}
 
 		if err := u.Validate(); err != nil {
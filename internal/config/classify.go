@@ -0,0 +1,22 @@
+package config
+
+import "strings"
+
+// ClassifyUrl returns the external image classification service URL (empty if the built-in model should be used).
+func (c *Config) ClassifyUrl() string {
+	return strings.TrimSpace(c.options.ClassifyUrl)
+}
+
+// ClassifyRemote tests if an external service should be used for image classification instead of TensorFlow.
+func (c *Config) ClassifyRemote() bool {
+	return c.ClassifyUrl() != ""
+}
+
+// DisableClassify tests if image classification should be disabled.
+func (c *Config) DisableClassify() bool {
+	if c.ClassifyRemote() {
+		return false
+	}
+
+	return c.DisableTensorFlow()
+}
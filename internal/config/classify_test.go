@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ClassifyUrl(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.ClassifyUrl())
+	assert.False(t, c.ClassifyRemote())
+
+	c.options.ClassifyUrl = " http://localhost:8080/predict "
+
+	assert.Equal(t, "http://localhost:8080/predict", c.ClassifyUrl())
+	assert.True(t, c.ClassifyRemote())
+}
+
+func TestConfig_DisableClassify(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.DisableClassify())
+
+	c.options.DisableTensorFlow = true
+	assert.True(t, c.DisableClassify())
+
+	c.options.ClassifyUrl = "http://localhost:8080/predict"
+	assert.False(t, c.DisableClassify())
+}
@@ -37,6 +37,8 @@ type ClientConfig struct {
 	UploadNSFW      bool                `json:"uploadNSFW"`
 	Public          bool                `json:"public"`
 	Experimental    bool                `json:"experimental"`
+	OIDC            bool                `json:"oidc"`
+	ProxyAuth       bool                `json:"proxyAuth"`
 	AlbumCategories []string            `json:"albumCategories"`
 	Albums          entity.Albums       `json:"albums"`
 	Cameras         entity.Cameras      `json:"cameras"`
@@ -78,6 +80,7 @@ type ClientDisable struct {
 	HeifConvert bool `json:"heifconvert"`
 	FFmpeg      bool `json:"ffmpeg"`
 	TensorFlow  bool `json:"tensorflow"`
+	LocalLogin  bool `json:"localLogin"`
 }
 
 // ClientCounts represents photo, video and album counts for the client UI.
@@ -170,6 +173,7 @@ func (c *Config) PublicConfig() ClientConfig {
 			WebDAV:      true,
 			Settings:    c.DisableSettings(),
 			Places:      c.DisablePlaces(),
+			LocalLogin:  c.DisableLocalLogin(),
 			ExifTool:    true,
 			TensorFlow:  true,
 			Darktable:   true,
@@ -200,6 +204,8 @@ func (c *Config) PublicConfig() ClientConfig {
 		ReadOnly:        c.ReadOnly(),
 		Public:          c.Public(),
 		Experimental:    c.Experimental(),
+		OIDC:            c.OIDCEnabled(),
+		ProxyAuth:       c.ProxyAuthEnabled(),
 		Status:          "",
 		MapKey:          "",
 		Thumbs:          Thumbs,
@@ -231,6 +237,7 @@ func (c *Config) GuestConfig() ClientConfig {
 			WebDAV:      c.DisableWebDAV(),
 			Settings:    c.DisableSettings(),
 			Places:      c.DisablePlaces(),
+			LocalLogin:  c.DisableLocalLogin(),
 			ExifTool:    true,
 			TensorFlow:  true,
 			Darktable:   true,
@@ -262,6 +269,8 @@ func (c *Config) GuestConfig() ClientConfig {
 		UploadNSFW:      c.UploadNSFW(),
 		Public:          true,
 		Experimental:    false,
+		OIDC:            c.OIDCEnabled(),
+		ProxyAuth:       c.ProxyAuthEnabled(),
 		Colors:          colors.All.List(),
 		Thumbs:          Thumbs,
 		Status:          c.Hub().Status,
@@ -286,6 +295,7 @@ func (c *Config) UserConfig() ClientConfig {
 			WebDAV:      c.DisableWebDAV(),
 			Settings:    c.DisableSettings(),
 			Places:      c.DisablePlaces(),
+			LocalLogin:  c.DisableLocalLogin(),
 			ExifTool:    c.DisableExifTool(),
 			TensorFlow:  c.DisableTensorFlow(),
 			Darktable:   c.DisableDarktable(),
@@ -317,6 +327,8 @@ func (c *Config) UserConfig() ClientConfig {
 		UploadNSFW:      c.UploadNSFW(),
 		Public:          c.Public(),
 		Experimental:    c.Experimental(),
+		OIDC:            c.OIDCEnabled(),
+		ProxyAuth:       c.ProxyAuthEnabled(),
 		Colors:          colors.All.List(),
 		Thumbs:          Thumbs,
 		Status:          c.Hub().Status,
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io/ioutil"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -20,6 +21,7 @@ import (
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 
+	"github.com/photoprism/photoprism/internal/embed"
 	"github.com/photoprism/photoprism/internal/entity"
 
 	"github.com/jinzhu/gorm"
@@ -30,6 +32,7 @@ import (
 	"github.com/photoprism/photoprism/internal/hub"
 	"github.com/photoprism/photoprism/internal/hub/places"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/storage"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/sirupsen/logrus"
@@ -65,6 +68,15 @@ type Config struct {
 	hub      *hub.Config
 	token    string
 	serial   string
+	storage  struct {
+		sync.Mutex
+		roots map[string]storage.Storage
+	}
+	proxies struct {
+		sync.Mutex
+		value string
+		nets  []*net.IPNet
+	}
 }
 
 func init() {
@@ -138,6 +150,12 @@ func (c *Config) Propagate() {
 	places.UserAgent = c.UserAgent()
 	entity.GeoApi = c.GeoApi()
 
+	if c.EmbedEnabled() {
+		embed.SetEncoder(embed.NewRemote(c.EmbedUrl(), c.EmbedModel()))
+	} else {
+		embed.SetEncoder(nil)
+	}
+
 	c.Settings().Propagate()
 	c.Hub().Propagate()
 }
@@ -152,6 +170,8 @@ func (c *Config) Init() error {
 		return err
 	}
 
+	c.initStorageBackends()
+
 	if insensitive, err := c.CaseInsensitive(); err != nil {
 		return err
 	} else if insensitive {
@@ -408,10 +428,7 @@ func (c *Config) LogLevel() logrus.Level {
 
 // Shutdown services and workers.
 func (c *Config) Shutdown() {
-	mutex.MainWorker.Cancel()
-	mutex.ShareWorker.Cancel()
-	mutex.SyncWorker.Cancel()
-	mutex.MetaWorker.Cancel()
+	mutex.CancelWorkers()
 
 	if err := c.CloseDb(); err != nil {
 		log.Errorf("could not close database connection: %s", err)
@@ -486,6 +503,28 @@ func (c *Config) AutoImport() time.Duration {
 	return time.Duration(c.options.AutoImport) * time.Second
 }
 
+// AuditRetention returns the max age of audit log entries, zero if they should be kept.
+func (c *Config) AuditRetention() time.Duration {
+	if c.options.AuditRetention < 0 {
+		return time.Duration(0)
+	} else if c.options.AuditRetention == 0 {
+		return 90 * 24 * time.Hour
+	}
+
+	return time.Duration(c.options.AuditRetention) * 24 * time.Hour
+}
+
+// AuditLimit returns the max number of audit log entries, zero if there is no limit.
+func (c *Config) AuditLimit() int {
+	if c.options.AuditLimit < 0 {
+		return 0
+	} else if c.options.AuditLimit == 0 {
+		return 100000
+	}
+
+	return c.options.AuditLimit
+}
+
 // GeoApi returns the preferred geo coding api (none or places).
 func (c *Config) GeoApi() string {
 	if c.options.DisablePlaces {
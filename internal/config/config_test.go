@@ -272,6 +272,25 @@ func TestConfig_WakeupInterval(t *testing.T) {
 	assert.Equal(t, time.Duration(900000000000), c.WakeupInterval())
 }
 
+func TestConfig_AuditRetention(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, 90*24*time.Hour, c.AuditRetention())
+
+	c.options.AuditRetention = 7
+	assert.Equal(t, 7*24*time.Hour, c.AuditRetention())
+
+	c.options.AuditRetention = -1
+	assert.Equal(t, time.Duration(0), c.AuditRetention())
+}
+
+func TestConfig_AuditLimit(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, 100000, c.AuditLimit())
+
+	c.options.AuditLimit = -1
+	assert.Equal(t, 0, c.AuditLimit())
+}
+
 func TestConfig_AutoIndex(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, time.Duration(0), c.AutoIndex())
@@ -23,6 +23,15 @@ func (c *Config) DisableSettings() bool {
 	return c.options.DisableSettings
 }
 
+// DisableWebhooks tests if library events should not be sent to webhooks.
+func (c *Config) DisableWebhooks() bool {
+	if c.Demo() {
+		return true
+	}
+
+	return c.options.DisableWebhooks
+}
+
 // DisablePlaces tests if geocoding and maps should be disabled.
 func (c *Config) DisablePlaces() bool {
 	return c.options.DisablePlaces
@@ -20,6 +20,18 @@ func TestConfig_DisableWebDAV(t *testing.T) {
 	assert.True(t, c.DisableWebDAV())
 }
 
+func TestConfig_DisableWebhooks(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.False(t, c.DisableWebhooks())
+
+	c.options.DisableWebhooks = true
+	assert.True(t, c.DisableWebhooks())
+
+	c.options.DisableWebhooks = false
+	c.options.Demo = true
+	assert.True(t, c.DisableWebhooks())
+}
+
 func TestConfig_DisableExifTool(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.False(t, c.DisableExifTool())
@@ -0,0 +1,25 @@
+package config
+
+import "strings"
+
+// DefaultEmbedModel is the default image embedding model name.
+const DefaultEmbedModel = "clip"
+
+// EmbedUrl returns the image embedding service URL (empty if disabled).
+func (c *Config) EmbedUrl() string {
+	return strings.TrimSpace(c.options.EmbedUrl)
+}
+
+// EmbedModel returns the image embedding model name.
+func (c *Config) EmbedModel() string {
+	if s := strings.ToLower(strings.TrimSpace(c.options.EmbedModel)); s != "" {
+		return s
+	}
+
+	return DefaultEmbedModel
+}
+
+// EmbedEnabled tests if image embeddings should be created for semantic and similarity search.
+func (c *Config) EmbedEnabled() bool {
+	return c.EmbedUrl() != ""
+}
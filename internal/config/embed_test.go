@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_EmbedUrl(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.EmbedUrl())
+	assert.False(t, c.EmbedEnabled())
+
+	c.options.EmbedUrl = " http://localhost:8080/embed "
+
+	assert.Equal(t, "http://localhost:8080/embed", c.EmbedUrl())
+	assert.True(t, c.EmbedEnabled())
+}
+
+func TestConfig_EmbedModel(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.EmbedModel = ""
+	assert.Equal(t, DefaultEmbedModel, c.EmbedModel())
+
+	c.options.EmbedModel = " CLIP-ViT-B32 "
+	assert.Equal(t, "clip-vit-b32", c.EmbedModel())
+}
@@ -49,6 +49,138 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "initial admin `PASSWORD`, min 4 characters",
 		EnvVar: "PHOTOPRISM_ADMIN_PASSWORD",
 	},
+	cli.BoolFlag{
+		Name:   "disable-local-login",
+		Usage:  "disables login with local passwords if single sign-on or LDAP is configured",
+		EnvVar: "PHOTOPRISM_DISABLE_LOCAL_LOGIN",
+	},
+	cli.StringFlag{
+		Name:   "oidc-uri",
+		Usage:  "OpenID Connect issuer `URL` for single sign-on",
+		EnvVar: "PHOTOPRISM_OIDC_URI",
+	},
+	cli.StringFlag{
+		Name:   "oidc-client",
+		Usage:  "OpenID Connect client `ID`",
+		EnvVar: "PHOTOPRISM_OIDC_CLIENT",
+	},
+	cli.StringFlag{
+		Name:   "oidc-secret",
+		Usage:  "OpenID Connect client `SECRET`",
+		EnvVar: "PHOTOPRISM_OIDC_SECRET",
+	},
+	cli.StringFlag{
+		Name:   "oidc-scopes",
+		Usage:  "OpenID Connect `SCOPES`, e.g. \"openid email profile groups\"",
+		Value:  "openid email profile",
+		EnvVar: "PHOTOPRISM_OIDC_SCOPES",
+	},
+	cli.StringFlag{
+		Name:   "oidc-roles",
+		Usage:  "maps OpenID Connect groups to `ROLES`, e.g. \"admins:admin,family:family\"",
+		EnvVar: "PHOTOPRISM_OIDC_ROLES",
+	},
+	cli.StringFlag{
+		Name:   "oidc-role",
+		Usage:  "`ROLE` of users without matching group, empty to deny access",
+		EnvVar: "PHOTOPRISM_OIDC_ROLE",
+	},
+	cli.BoolFlag{
+		Name:   "oidc-register",
+		Usage:  "creates accounts for new OpenID Connect users on their first login",
+		EnvVar: "PHOTOPRISM_OIDC_REGISTER",
+	},
+	cli.StringFlag{
+		Name:   "ldap-uri",
+		Usage:  "LDAP server `URI` for authentication, e.g. ldaps://ldap.example.com",
+		EnvVar: "PHOTOPRISM_LDAP_URI",
+	},
+	cli.BoolFlag{
+		Name:   "ldap-starttls",
+		Usage:  "upgrades unencrypted LDAP connections with StartTLS",
+		EnvVar: "PHOTOPRISM_LDAP_STARTTLS",
+	},
+	cli.BoolFlag{
+		Name:   "ldap-insecure",
+		Usage:  "don't verify the LDAP server certificate",
+		EnvVar: "PHOTOPRISM_LDAP_INSECURE",
+	},
+	cli.StringFlag{
+		Name:   "ldap-bind-dn",
+		Usage:  "`DN` of the LDAP service account used to search users",
+		EnvVar: "PHOTOPRISM_LDAP_BIND_DN",
+	},
+	cli.StringFlag{
+		Name:   "ldap-bind-password",
+		Usage:  "LDAP service account `PASSWORD`",
+		EnvVar: "PHOTOPRISM_LDAP_BIND_PASSWORD",
+	},
+	cli.StringFlag{
+		Name:   "ldap-base-dn",
+		Usage:  "LDAP search base `DN`, e.g. \"dc=example,dc=com\"",
+		EnvVar: "PHOTOPRISM_LDAP_BASE_DN",
+	},
+	cli.StringFlag{
+		Name:   "ldap-user-filter",
+		Usage:  "LDAP user search `FILTER`, %s is replaced with the login name",
+		Value:  "(&(objectClass=person)(uid=%s))",
+		EnvVar: "PHOTOPRISM_LDAP_USER_FILTER",
+	},
+	cli.StringFlag{
+		Name:   "ldap-group-filter",
+		Usage:  "optional LDAP group search `FILTER`, %s is replaced with the user DN",
+		EnvVar: "PHOTOPRISM_LDAP_GROUP_FILTER",
+	},
+	cli.StringFlag{
+		Name:   "ldap-roles",
+		Usage:  "maps LDAP groups to `ROLES`, e.g. \"admins:admin,family:family\"",
+		EnvVar: "PHOTOPRISM_LDAP_ROLES",
+	},
+	cli.StringFlag{
+		Name:   "ldap-role",
+		Usage:  "`ROLE` of LDAP users without matching group, empty to deny access",
+		EnvVar: "PHOTOPRISM_LDAP_ROLE",
+	},
+	cli.StringFlag{
+		Name:   "proxy-user-header",
+		Usage:  "request `HEADER` with the name of users authenticated by a trusted reverse proxy or through the unix socket, e.g. Remote-User",
+		EnvVar: "PHOTOPRISM_PROXY_USER_HEADER",
+	},
+	cli.StringFlag{
+		Name:   "proxy-groups-header",
+		Usage:  "optional request `HEADER` with comma-separated groups, e.g. Remote-Groups",
+		EnvVar: "PHOTOPRISM_PROXY_GROUPS_HEADER",
+	},
+	cli.StringFlag{
+		Name:   "proxy-email-header",
+		Usage:  "optional request `HEADER` with the user's email, e.g. Remote-Email",
+		EnvVar: "PHOTOPRISM_PROXY_EMAIL_HEADER",
+	},
+	cli.StringFlag{
+		Name:   "proxy-name-header",
+		Usage:  "optional request `HEADER` with the user's full name, e.g. Remote-Name",
+		EnvVar: "PHOTOPRISM_PROXY_NAME_HEADER",
+	},
+	cli.StringFlag{
+		Name:   "trusted-proxy",
+		Usage:  "comma-separated `CIDR` ranges of reverse proxies allowed to set identity headers",
+		EnvVar: "PHOTOPRISM_TRUSTED_PROXY",
+	},
+	cli.StringFlag{
+		Name:   "proxy-roles",
+		Usage:  "maps reverse proxy groups to `ROLES`, e.g. \"admins:admin,family:family\"",
+		EnvVar: "PHOTOPRISM_PROXY_ROLES",
+	},
+	cli.StringFlag{
+		Name:   "proxy-role",
+		Usage:  "`ROLE` of new reverse proxy users without matching group, empty to deny access",
+		EnvVar: "PHOTOPRISM_PROXY_ROLE",
+	},
+	cli.BoolFlag{
+		Name:   "proxy-register",
+		Usage:  "creates accounts for unknown users authenticated by the reverse proxy",
+		EnvVar: "PHOTOPRISM_PROXY_REGISTER",
+	},
 	cli.StringFlag{
 		Name:   "config-file, c",
 		Usage:  "load initial config options from `FILENAME`",
@@ -105,6 +237,48 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "assets `PATH` for static resources like models and templates",
 		EnvVar: "PHOTOPRISM_ASSETS_PATH",
 	},
+	cli.StringFlag{
+		Name:   "storage-backend",
+		Usage:  "storage `BACKEND` for originals, sidecar and cache files (local, s3)",
+		Value:  "local",
+		EnvVar: "PHOTOPRISM_STORAGE_BACKEND",
+	},
+	cli.StringFlag{
+		Name:   "s3-endpoint",
+		Usage:  "S3-compatible object storage endpoint `URL`",
+		EnvVar: "PHOTOPRISM_S3_ENDPOINT",
+	},
+	cli.StringFlag{
+		Name:   "s3-bucket",
+		Usage:  "object storage bucket `NAME`",
+		EnvVar: "PHOTOPRISM_S3_BUCKET",
+	},
+	cli.StringFlag{
+		Name:   "s3-prefix",
+		Usage:  "object storage key `PREFIX`",
+		EnvVar: "PHOTOPRISM_S3_PREFIX",
+	},
+	cli.StringFlag{
+		Name:   "s3-region",
+		Usage:  "object storage `REGION`",
+		EnvVar: "PHOTOPRISM_S3_REGION",
+	},
+	cli.StringFlag{
+		Name:   "s3-access-key",
+		Usage:  "object storage access `KEY`",
+		EnvVar: "PHOTOPRISM_S3_ACCESS_KEY",
+	},
+	cli.StringFlag{
+		Name:   "s3-secret-key",
+		Usage:  "object storage secret `KEY`",
+		EnvVar: "PHOTOPRISM_S3_SECRET_KEY",
+	},
+	cli.IntFlag{
+		Name:   "s3-cache-size",
+		Value:  10000,
+		Usage:  "local object storage cache size limit in `MB` (0 for unlimited)",
+		EnvVar: "PHOTOPRISM_S3_CACHE_SIZE",
+	},
 	cli.IntFlag{
 		Name:   "workers, w",
 		Usage:  "limits `NUMBER` of indexing workers",
@@ -126,6 +300,21 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "auto importing safety delay in `SECONDS` (WebDAV)",
 		EnvVar: "PHOTOPRISM_AUTO_IMPORT",
 	},
+	cli.IntFlag{
+		Name:   "audit-retention",
+		Usage:  "audit log retention in `DAYS` (-1 to keep all entries)",
+		EnvVar: "PHOTOPRISM_AUDIT_RETENTION",
+	},
+	cli.IntFlag{
+		Name:   "audit-limit",
+		Usage:  "max `NUMBER` of audit log entries (-1 for no limit)",
+		EnvVar: "PHOTOPRISM_AUDIT_LIMIT",
+	},
+	cli.BoolFlag{
+		Name:   "export-xmp",
+		Usage:  "writes star ratings, pick flags and color labels to XMP sidecar files",
+		EnvVar: "PHOTOPRISM_EXPORT_XMP",
+	},
 	cli.BoolFlag{
 		Name:   "disable-backups",
 		Usage:  "disables creating YAML metadata backup sidecar files",
@@ -136,11 +325,21 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "disables built-in WebDAV server",
 		EnvVar: "PHOTOPRISM_DISABLE_WEBDAV",
 	},
+	cli.StringFlag{
+		Name:   "webdav-thumbs",
+		Usage:  "thumbnail `TYPE` of images in the virtual WebDAV library e.g. fit_1920, originals if empty",
+		EnvVar: "PHOTOPRISM_WEBDAV_THUMBS",
+	},
 	cli.BoolFlag{
 		Name:   "disable-settings",
 		Usage:  "disables settings UI and API",
 		EnvVar: "PHOTOPRISM_DISABLE_SETTINGS",
 	},
+	cli.BoolFlag{
+		Name:   "disable-webhooks",
+		Usage:  "disables sending library events to webhooks",
+		EnvVar: "PHOTOPRISM_DISABLE_WEBHOOKS",
+	},
 	cli.BoolFlag{
 		Name:   "disable-places",
 		Usage:  "disables reverse geocoding and maps",
@@ -191,6 +390,27 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "allow uploads that may be offensive",
 		EnvVar: "PHOTOPRISM_UPLOAD_NSFW",
 	},
+	cli.StringFlag{
+		Name:   "classify-url",
+		Usage:  "external image classification service `URL` (optional, replaces built-in TensorFlow model)",
+		EnvVar: "PHOTOPRISM_CLASSIFY_URL",
+	},
+	cli.StringFlag{
+		Name:   "embed-url",
+		Usage:  "image embedding service `URL` for semantic and similarity search (optional)",
+		EnvVar: "PHOTOPRISM_EMBED_URL",
+	},
+	cli.StringFlag{
+		Name:   "embed-model",
+		Usage:  "embedding model `NAME`, embeddings of different models are not compared",
+		Value:  "clip",
+		EnvVar: "PHOTOPRISM_EMBED_MODEL",
+	},
+	cli.BoolFlag{
+		Name:   "enable-ocr",
+		Usage:  "enables text recognition in images with Tesseract (OCR)",
+		EnvVar: "PHOTOPRISM_ENABLE_OCR",
+	},
 	cli.StringFlag{
 		Name:   "log-level, l",
 		Usage:  "trace, debug, info, warning, error, fatal or panic",
@@ -267,6 +487,95 @@ var GlobalFlags = []cli.Flag{
 		Usage:  "improves transfer speed and bandwidth utilization (none or gzip)",
 		EnvVar: "PHOTOPRISM_HTTP_COMPRESSION",
 	},
+	cli.IntFlag{
+		Name:   "http-redirect-port",
+		Usage:  "redirects plain http requests on this port `NUMBER` to https if tls is enabled",
+		EnvVar: "PHOTOPRISM_HTTP_REDIRECT_PORT",
+	},
+	cli.StringFlag{
+		Name:   "http-socket",
+		Usage:  "listens on a unix socket `FILENAME` instead of host and port, reverse proxies connecting through it are trusted to set identity and forwarding headers",
+		EnvVar: "PHOTOPRISM_HTTP_SOCKET",
+	},
+	cli.StringFlag{
+		Name:   "tls-cert",
+		Usage:  "tls certificate `FILENAME` (pem)",
+		EnvVar: "PHOTOPRISM_TLS_CERT",
+	},
+	cli.StringFlag{
+		Name:   "tls-key",
+		Usage:  "tls private key `FILENAME` (pem)",
+		EnvVar: "PHOTOPRISM_TLS_KEY",
+	},
+	cli.StringFlag{
+		Name:   "tls-min-version",
+		Usage:  "minimum tls `VERSION` (1.2 or 1.3)",
+		Value:  "1.2",
+		EnvVar: "PHOTOPRISM_TLS_MIN_VERSION",
+	},
+	cli.StringFlag{
+		Name:   "acme-domains",
+		Usage:  "comma-separated `DOMAINS` for automatic certificates from an acme provider like let's encrypt",
+		EnvVar: "PHOTOPRISM_ACME_DOMAINS",
+	},
+	cli.StringFlag{
+		Name:   "acme-email",
+		Usage:  "acme account contact `EMAIL`",
+		EnvVar: "PHOTOPRISM_ACME_EMAIL",
+	},
+	cli.StringFlag{
+		Name:   "acme-directory",
+		Usage:  "acme directory `URL`",
+		Value:  DefaultACMEDirectory,
+		EnvVar: "PHOTOPRISM_ACME_DIRECTORY",
+	},
+	cli.IntFlag{
+		Name:   "shutdown-timeout",
+		Usage:  "max time in `SECONDS` for finishing requests and background jobs on shutdown",
+		Value:  60,
+		EnvVar: "PHOTOPRISM_SHUTDOWN_TIMEOUT",
+	},
+	cli.StringFlag{
+		Name:   "metrics-token",
+		Usage:  "bearer `TOKEN` required for accessing prometheus metrics, public if empty",
+		EnvVar: "PHOTOPRISM_METRICS_TOKEN",
+	},
+	cli.StringFlag{
+		Name:   "smtp-host",
+		Usage:  "outgoing mail server `HOST`, disables email notifications if empty",
+		EnvVar: "PHOTOPRISM_SMTP_HOST",
+	},
+	cli.IntFlag{
+		Name:   "smtp-port",
+		Usage:  "outgoing mail server `PORT`, 587 or 465 for implicit tls by default",
+		EnvVar: "PHOTOPRISM_SMTP_PORT",
+	},
+	cli.StringFlag{
+		Name:   "smtp-user",
+		Usage:  "outgoing mail server `USERNAME`",
+		EnvVar: "PHOTOPRISM_SMTP_USER",
+	},
+	cli.StringFlag{
+		Name:   "smtp-password",
+		Usage:  "outgoing mail server `PASSWORD`",
+		EnvVar: "PHOTOPRISM_SMTP_PASSWORD",
+	},
+	cli.StringFlag{
+		Name:   "smtp-from",
+		Usage:  "sender `ADDRESS` of email notifications, e.g. PhotoPrism <photos@example.com>",
+		EnvVar: "PHOTOPRISM_SMTP_FROM",
+	},
+	cli.StringFlag{
+		Name:   "smtp-tls",
+		Usage:  "outgoing mail encryption `MODE` (starttls, tls or none), mail is not sent if starttls is not supported",
+		Value:  "starttls",
+		EnvVar: "PHOTOPRISM_SMTP_TLS",
+	},
+	cli.StringFlag{
+		Name:   "alert-email",
+		Usage:  "additional `ADDRESSES` for failure alerts, separated by commas",
+		EnvVar: "PHOTOPRISM_ALERT_EMAIL",
+	},
 	cli.StringFlag{
 		Name:   "database-driver",
 		Usage:  "database driver `NAME` (sqlite or mysql)",
@@ -339,6 +648,18 @@ var GlobalFlags = []cli.Flag{
 		Value:  "heif-convert",
 		EnvVar: "PHOTOPRISM_HEIFCONVERT_BIN",
 	},
+	cli.StringFlag{
+		Name:   "tesseract-bin",
+		Usage:  "Tesseract OCR `COMMAND` for text recognition",
+		Value:  "tesseract",
+		EnvVar: "PHOTOPRISM_TESSERACT_BIN",
+	},
+	cli.StringFlag{
+		Name:   "ocr-languages",
+		Usage:  "Tesseract OCR `LANGUAGES` like eng+deu",
+		Value:  "eng",
+		EnvVar: "PHOTOPRISM_OCR_LANGUAGES",
+	},
 	cli.StringFlag{
 		Name:   "ffmpeg-bin",
 		Usage:  "FFmpeg `COMMAND` for video transcoding and cover images",
@@ -231,6 +231,11 @@ func (c *Config) BackupYaml() bool {
 	return !c.DisableBackups()
 }
 
+// ExportXmp tests if star ratings, pick flags and color labels should be written to XMP sidecar files.
+func (c *Config) ExportXmp() bool {
+	return c.SidecarWritable() && c.options.ExportXmp
+}
+
 // SidecarPath returns the storage path for generated sidecar files (relative or absolute).
 func (c *Config) SidecarPath() string {
 	if c.options.SidecarPath == "" {
@@ -334,6 +339,11 @@ func (c *Config) LocalesPath() string {
 	return filepath.Join(c.AssetsPath(), "locales")
 }
 
+// MailTemplatesPath returns the email templates path.
+func (c *Config) MailTemplatesPath() string {
+	return filepath.Join(c.AssetsPath(), "mail")
+}
+
 // ExamplesPath returns the example files path.
 func (c *Config) ExamplesPath() string {
 	return filepath.Join(c.AssetsPath(), "examples")
@@ -35,6 +35,16 @@ func TestConfig_SidecarYaml(t *testing.T) {
 	assert.Equal(t, c.DisableBackups(), !c.BackupYaml())
 }
 
+func TestConfig_ExportXmp(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.ExportXmp())
+
+	c.options.ExportXmp = true
+
+	assert.True(t, c.ExportXmp())
+}
+
 func TestConfig_SidecarPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
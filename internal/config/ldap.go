@@ -0,0 +1,86 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/ldap"
+)
+
+// LDAPEnabled tests if users should be authenticated with an LDAP directory.
+func (c *Config) LDAPEnabled() bool {
+	return c.LDAPUri() != "" && c.LDAPBaseDN() != ""
+}
+
+// LDAPUri returns the directory server URI, e.g. ldaps://ldap.example.com.
+func (c *Config) LDAPUri() string {
+	return strings.TrimSpace(c.options.LDAPUri)
+}
+
+// LDAPStartTLS tests if unencrypted connections should be upgraded with StartTLS.
+func (c *Config) LDAPStartTLS() bool {
+	return c.options.LDAPStartTLS
+}
+
+// LDAPInsecure tests if the server certificate should not be verified.
+func (c *Config) LDAPInsecure() bool {
+	return c.options.LDAPInsecure
+}
+
+// LDAPBindDN returns the distinguished name of the service account used to search users.
+func (c *Config) LDAPBindDN() string {
+	return strings.TrimSpace(c.options.LDAPBindDN)
+}
+
+// LDAPBindPassword returns the password of the service account.
+func (c *Config) LDAPBindPassword() string {
+	return c.options.LDAPBindPassword
+}
+
+// LDAPBaseDN returns the distinguished name below which users and groups are searched.
+func (c *Config) LDAPBaseDN() string {
+	return strings.TrimSpace(c.options.LDAPBaseDN)
+}
+
+// LDAPUserFilter returns the search filter for users, %s is replaced with the login name.
+func (c *Config) LDAPUserFilter() string {
+	if f := strings.TrimSpace(c.options.LDAPUserFilter); f != "" {
+		return f
+	}
+
+	return ldap.DefaultUserFilter
+}
+
+// LDAPGroupFilter returns the optional search filter for groups, %s is replaced with the user DN.
+func (c *Config) LDAPGroupFilter() string {
+	return strings.TrimSpace(c.options.LDAPGroupFilter)
+}
+
+// LDAPRoles returns the group to role mapping, e.g. "admins:admin,family:family".
+func (c *Config) LDAPRoles() string {
+	return strings.TrimSpace(c.options.LDAPRoles)
+}
+
+// LDAPDefaultRole returns the role of users without matching group, empty if they are denied access.
+func (c *Config) LDAPDefaultRole() acl.Role {
+	return acl.Role(strings.ToLower(strings.TrimSpace(c.options.LDAPRole)))
+}
+
+// LDAPRole returns the role of a user with the given groups, the first matching mapping wins.
+func (c *Config) LDAPRole(groups []string) acl.Role {
+	return groupRole(c.LDAPRoles(), groups, c.LDAPDefaultRole())
+}
+
+// LDAP returns the directory server settings.
+func (c *Config) LDAP() ldap.Config {
+	return ldap.Config{
+		URI:          c.LDAPUri(),
+		StartTLS:     c.LDAPStartTLS(),
+		Insecure:     c.LDAPInsecure(),
+		BindDN:       c.LDAPBindDN(),
+		BindPassword: c.LDAPBindPassword(),
+		BaseDN:       c.LDAPBaseDN(),
+		UserFilter:   c.LDAPUserFilter(),
+		GroupFilter:  c.LDAPGroupFilter(),
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/ldap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_LDAPEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.LDAPEnabled())
+
+	c.options.DisableLocalLogin = true
+	assert.False(t, c.DisableLocalLogin())
+
+	c.options.LDAPUri = "ldaps://ldap.example.com"
+	c.options.LDAPBaseDN = "dc=example,dc=com"
+
+	assert.True(t, c.LDAPEnabled())
+	assert.True(t, c.DisableLocalLogin())
+}
+
+func TestConfig_LDAP(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.LDAPUri = "ldap://ldap.example.com"
+	c.options.LDAPStartTLS = true
+	c.options.LDAPBindDN = "cn=photoprism,dc=example,dc=com"
+	c.options.LDAPBaseDN = "dc=example,dc=com"
+
+	result := c.LDAP()
+
+	assert.Equal(t, "ldap://ldap.example.com", result.URI)
+	assert.True(t, result.StartTLS)
+	assert.False(t, result.Insecure)
+	assert.Equal(t, "cn=photoprism,dc=example,dc=com", result.BindDN)
+	assert.Equal(t, ldap.DefaultUserFilter, result.UserFilter)
+	assert.Equal(t, "", result.GroupFilter)
+}
+
+func TestConfig_LDAPRole(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.LDAPRoles = "admins:admin,family:family"
+
+	assert.Equal(t, acl.RoleAdmin, c.LDAPRole([]string{"family", "admins"}))
+	assert.Equal(t, acl.RoleFamily, c.LDAPRole([]string{"Family"}))
+	assert.Equal(t, acl.Role(""), c.LDAPRole([]string{"friends"}))
+
+	c.options.LDAPRole = "friend"
+	assert.Equal(t, acl.RoleFriend, c.LDAPRole(nil))
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/ocr"
+)
+
+// EnableOCR tests if text recognition in images has been enabled.
+func (c *Config) EnableOCR() bool {
+	return c.options.EnableOCR
+}
+
+// TesseractBin returns the Tesseract OCR executable file name.
+func (c *Config) TesseractBin() string {
+	return findExecutable(c.options.TesseractBin, "tesseract")
+}
+
+// OCRLanguages returns the Tesseract OCR languages, e.g. "eng+deu".
+func (c *Config) OCRLanguages() string {
+	if s := strings.TrimSpace(c.options.OCRLanguages); s != "" {
+		return s
+	}
+
+	return ocr.DefaultLanguages
+}
+
+// DisableOCR tests if text recognition is disabled, it is disabled by default and requires Tesseract.
+func (c *Config) DisableOCR() bool {
+	return !c.EnableOCR() || c.TesseractBin() == ""
+}
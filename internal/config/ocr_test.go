@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_EnableOCR(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.EnableOCR())
+	assert.True(t, c.DisableOCR())
+
+	c.options.EnableOCR = true
+	assert.True(t, c.EnableOCR())
+
+	c.options.TesseractBin = "/usr/bin/tesseract-missing"
+	assert.True(t, c.DisableOCR())
+
+	c.options.TesseractBin = "/bin/sh"
+	assert.False(t, c.DisableOCR())
+}
+
+func TestConfig_TesseractBin(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.TesseractBin = "/usr/bin/tesseract-missing"
+	assert.Equal(t, "", c.TesseractBin())
+
+	c.options.TesseractBin = "/bin/sh"
+	assert.Equal(t, "/bin/sh", c.TesseractBin())
+}
+
+func TestConfig_OCRLanguages(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.OCRLanguages = ""
+	assert.Equal(t, "eng", c.OCRLanguages())
+
+	c.options.OCRLanguages = " eng+deu "
+	assert.Equal(t, "eng+deu", c.OCRLanguages())
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/acl"
+)
+
+// OIDCEnabled tests if single sign-on with OpenID Connect is configured.
+func (c *Config) OIDCEnabled() bool {
+	return c.OIDCUri() != "" && c.OIDCClient() != ""
+}
+
+// OIDCUri returns the OpenID Connect issuer URL.
+func (c *Config) OIDCUri() string {
+	return strings.TrimRight(strings.TrimSpace(c.options.OIDCUri), "/")
+}
+
+// OIDCClient returns the OpenID Connect client ID.
+func (c *Config) OIDCClient() string {
+	return strings.TrimSpace(c.options.OIDCClient)
+}
+
+// OIDCSecret returns the OpenID Connect client secret.
+func (c *Config) OIDCSecret() string {
+	return c.options.OIDCSecret
+}
+
+// OIDCScopes returns the requested OpenID Connect scopes, "openid" is always included.
+func (c *Config) OIDCScopes() []string {
+	scopes := []string{"openid"}
+
+	for _, s := range strings.Fields(strings.ReplaceAll(c.options.OIDCScopes, ",", " ")) {
+		if s != "openid" {
+			scopes = append(scopes, s)
+		}
+	}
+
+	if len(scopes) == 1 && c.options.OIDCScopes == "" {
+		return []string{"openid", "email", "profile"}
+	}
+
+	return scopes
+}
+
+// OIDCRedirectUrl returns the callback URL that must be registered with the provider.
+func (c *Config) OIDCRedirectUrl() string {
+	return c.SiteUrl() + "api/v1/oidc/redirect"
+}
+
+// OIDCRoles returns the group to role mapping, e.g. "admins:admin,family:family".
+func (c *Config) OIDCRoles() string {
+	return strings.TrimSpace(c.options.OIDCRoles)
+}
+
+// OIDCDefaultRole returns the role of users without matching group, empty if they are denied access.
+func (c *Config) OIDCDefaultRole() acl.Role {
+	return acl.Role(strings.ToLower(strings.TrimSpace(c.options.OIDCRole)))
+}
+
+// OIDCRole returns the role of a user with the given groups, the first matching mapping wins.
+func (c *Config) OIDCRole(groups []string) acl.Role {
+	return groupRole(c.OIDCRoles(), groups, c.OIDCDefaultRole())
+}
+
+// OIDCRegister tests if accounts should be created for new users on their first login.
+func (c *Config) OIDCRegister() bool {
+	return c.options.OIDCRegister
+}
+
+// DisableLocalLogin tests if logging in with local passwords is disabled, this requires single sign-on or LDAP.
+func (c *Config) DisableLocalLogin() bool {
+	return c.options.DisableLocalLogin && (c.OIDCEnabled() || c.LDAPEnabled())
+}
+
+// groupRole returns the role for the given groups based on a mapping like "admins:admin,family:family".
+func groupRole(mapping string, groups []string, defaultRole acl.Role) acl.Role {
+	for _, m := range strings.Split(mapping, ",") {
+		parts := strings.SplitN(m, ":", 2)
+
+		if len(parts) != 2 {
+			continue
+		}
+
+		group := strings.Trim(strings.TrimSpace(parts[0]), "/")
+
+		for _, g := range groups {
+			if strings.EqualFold(g, group) {
+				return acl.Role(strings.ToLower(strings.TrimSpace(parts[1])))
+			}
+		}
+	}
+
+	return defaultRole
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_OIDCEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.OIDCEnabled())
+	assert.False(t, c.DisableLocalLogin())
+
+	c.options.OIDCUri = "https://id.example.com/realms/family/"
+	c.options.OIDCClient = "photoprism"
+	c.options.DisableLocalLogin = true
+
+	assert.True(t, c.OIDCEnabled())
+	assert.True(t, c.DisableLocalLogin())
+	assert.Equal(t, "https://id.example.com/realms/family", c.OIDCUri())
+}
+
+func TestConfig_OIDCScopes(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, []string{"openid", "email", "profile"}, c.OIDCScopes())
+
+	c.options.OIDCScopes = "email,groups"
+	assert.Equal(t, []string{"openid", "email", "groups"}, c.OIDCScopes())
+}
+
+func TestConfig_OIDCRedirectUrl(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "http://localhost:2342/api/v1/oidc/redirect", c.OIDCRedirectUrl())
+}
+
+func TestConfig_OIDCRole(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.OIDCRoles = "photo-admins:admin, /family:Family"
+
+	assert.Equal(t, acl.RoleAdmin, c.OIDCRole([]string{"family", "photo-admins"}))
+	assert.Equal(t, acl.RoleFamily, c.OIDCRole([]string{"family"}))
+	assert.Equal(t, acl.Role(""), c.OIDCRole([]string{"friends"}))
+
+	c.options.OIDCRole = "guest"
+	assert.Equal(t, acl.RoleGuest, c.OIDCRole(nil))
+}
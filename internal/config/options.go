@@ -43,6 +43,32 @@ type Options struct {
 	ConfigPath         string `yaml:"ConfigPath" json:"-" flag:"config-path"`
 	ConfigFile         string `json:"-"`
 	AdminPassword      string `yaml:"AdminPassword" json:"-" flag:"admin-password"`
+	DisableLocalLogin  bool   `yaml:"DisableLocalLogin" json:"-" flag:"disable-local-login"`
+	OIDCUri            string `yaml:"OIDCUri" json:"-" flag:"oidc-uri"`
+	OIDCClient         string `yaml:"OIDCClient" json:"-" flag:"oidc-client"`
+	OIDCSecret         string `yaml:"OIDCSecret" json:"-" flag:"oidc-secret"`
+	OIDCScopes         string `yaml:"OIDCScopes" json:"-" flag:"oidc-scopes"`
+	OIDCRoles          string `yaml:"OIDCRoles" json:"-" flag:"oidc-roles"`
+	OIDCRole           string `yaml:"OIDCRole" json:"-" flag:"oidc-role"`
+	OIDCRegister       bool   `yaml:"OIDCRegister" json:"-" flag:"oidc-register"`
+	LDAPUri            string `yaml:"LDAPUri" json:"-" flag:"ldap-uri"`
+	LDAPStartTLS       bool   `yaml:"LDAPStartTLS" json:"-" flag:"ldap-starttls"`
+	LDAPInsecure       bool   `yaml:"LDAPInsecure" json:"-" flag:"ldap-insecure"`
+	LDAPBindDN         string `yaml:"LDAPBindDN" json:"-" flag:"ldap-bind-dn"`
+	LDAPBindPassword   string `yaml:"LDAPBindPassword" json:"-" flag:"ldap-bind-password"`
+	LDAPBaseDN         string `yaml:"LDAPBaseDN" json:"-" flag:"ldap-base-dn"`
+	LDAPUserFilter     string `yaml:"LDAPUserFilter" json:"-" flag:"ldap-user-filter"`
+	LDAPGroupFilter    string `yaml:"LDAPGroupFilter" json:"-" flag:"ldap-group-filter"`
+	LDAPRoles          string `yaml:"LDAPRoles" json:"-" flag:"ldap-roles"`
+	LDAPRole           string `yaml:"LDAPRole" json:"-" flag:"ldap-role"`
+	ProxyUserHeader    string `yaml:"ProxyUserHeader" json:"-" flag:"proxy-user-header"`
+	ProxyGroupsHeader  string `yaml:"ProxyGroupsHeader" json:"-" flag:"proxy-groups-header"`
+	ProxyEmailHeader   string `yaml:"ProxyEmailHeader" json:"-" flag:"proxy-email-header"`
+	ProxyNameHeader    string `yaml:"ProxyNameHeader" json:"-" flag:"proxy-name-header"`
+	TrustedProxies     string `yaml:"TrustedProxies" json:"-" flag:"trusted-proxy"`
+	ProxyRoles         string `yaml:"ProxyRoles" json:"-" flag:"proxy-roles"`
+	ProxyRole          string `yaml:"ProxyRole" json:"-" flag:"proxy-role"`
+	ProxyRegister      bool   `yaml:"ProxyRegister" json:"-" flag:"proxy-register"`
 	OriginalsPath      string `yaml:"OriginalsPath" json:"-" flag:"originals-path"`
 	OriginalsLimit     int64  `yaml:"OriginalsLimit" json:"OriginalsLimit" flag:"originals-limit"`
 	ImportPath         string `yaml:"ImportPath" json:"-" flag:"import-path"`
@@ -52,13 +78,26 @@ type Options struct {
 	BackupPath         string `yaml:"BackupPath" json:"-" flag:"backup-path"`
 	AssetsPath         string `yaml:"AssetsPath" json:"-" flag:"assets-path"`
 	CachePath          string `yaml:"CachePath" json:"-" flag:"cache-path"`
+	StorageBackend     string `yaml:"StorageBackend" json:"-" flag:"storage-backend"`
+	S3Endpoint         string `yaml:"S3Endpoint" json:"-" flag:"s3-endpoint"`
+	S3Bucket           string `yaml:"S3Bucket" json:"-" flag:"s3-bucket"`
+	S3Prefix           string `yaml:"S3Prefix" json:"-" flag:"s3-prefix"`
+	S3Region           string `yaml:"S3Region" json:"-" flag:"s3-region"`
+	S3AccessKey        string `yaml:"S3AccessKey" json:"-" flag:"s3-access-key"`
+	S3SecretKey        string `yaml:"S3SecretKey" json:"-" flag:"s3-secret-key"`
+	S3CacheSize        int64  `yaml:"S3CacheSize" json:"-" flag:"s3-cache-size"`
 	Workers            int    `yaml:"Workers" json:"Workers" flag:"workers"`
 	WakeupInterval     int    `yaml:"WakeupInterval" json:"WakeupInterval" flag:"wakeup-interval"`
 	AutoIndex          int    `yaml:"AutoIndex" json:"AutoIndex" flag:"auto-index"`
 	AutoImport         int    `yaml:"AutoImport" json:"AutoImport" flag:"auto-import"`
+	AuditRetention     int    `yaml:"AuditRetention" json:"AuditRetention" flag:"audit-retention"`
+	AuditLimit         int    `yaml:"AuditLimit" json:"AuditLimit" flag:"audit-limit"`
+	ExportXmp          bool   `yaml:"ExportXmp" json:"ExportXmp" flag:"export-xmp"`
 	DisableBackups     bool   `yaml:"DisableBackups" json:"DisableBackups" flag:"disable-backups"`
 	DisableWebDAV      bool   `yaml:"DisableWebDAV" json:"DisableWebDAV" flag:"disable-webdav"`
+	WebDAVThumbs       string `yaml:"WebDAVThumbs" json:"WebDAVThumbs" flag:"webdav-thumbs"`
 	DisableSettings    bool   `yaml:"DisableSettings" json:"-" flag:"disable-settings"`
+	DisableWebhooks    bool   `yaml:"DisableWebhooks" json:"DisableWebhooks" flag:"disable-webhooks"`
 	DisablePlaces      bool   `yaml:"DisablePlaces" json:"DisablePlaces" flag:"disable-places"`
 	DisableExifTool    bool   `yaml:"DisableExifTool" json:"DisableExifTool" flag:"disable-exiftool"`
 	DisableTensorFlow  bool   `yaml:"DisableTensorFlow" json:"DisableTensorFlow" flag:"disable-tensorflow"`
@@ -69,6 +108,10 @@ type Options struct {
 	DisableHeifConvert bool   `yaml:"DisableHeifConvert" json:"DisableHeifConvert" flag:"disable-heifconvert"`
 	DetectNSFW         bool   `yaml:"DetectNSFW" json:"DetectNSFW" flag:"detect-nsfw"`
 	UploadNSFW         bool   `yaml:"UploadNSFW" json:"-" flag:"upload-nsfw"`
+	ClassifyUrl        string `yaml:"ClassifyUrl" json:"-" flag:"classify-url"`
+	EmbedUrl           string `yaml:"EmbedUrl" json:"-" flag:"embed-url"`
+	EmbedModel         string `yaml:"EmbedModel" json:"-" flag:"embed-model"`
+	EnableOCR          bool   `yaml:"EnableOCR" json:"EnableOCR" flag:"enable-ocr"`
 	LogLevel           string `yaml:"LogLevel" json:"-" flag:"log-level"`
 	LogFilename        string `yaml:"LogFilename" json:"-" flag:"log-filename"`
 	PIDFilename        string `yaml:"PIDFilename" json:"-" flag:"pid-filename"`
@@ -91,11 +134,30 @@ type Options struct {
 	HttpPort           int    `yaml:"HttpPort" json:"-" flag:"http-port"`
 	HttpMode           string `yaml:"HttpMode" json:"-" flag:"http-mode"`
 	HttpCompression    string `yaml:"HttpCompression" json:"-" flag:"http-compression"`
+	HttpRedirectPort   int    `yaml:"HttpRedirectPort" json:"-" flag:"http-redirect-port"`
+	HttpSocket         string `yaml:"HttpSocket" json:"-" flag:"http-socket"`
+	TLSCert            string `yaml:"TLSCert" json:"-" flag:"tls-cert"`
+	TLSKey             string `yaml:"TLSKey" json:"-" flag:"tls-key"`
+	TLSMinVersion      string `yaml:"TLSMinVersion" json:"-" flag:"tls-min-version"`
+	ACMEDomains        string `yaml:"ACMEDomains" json:"-" flag:"acme-domains"`
+	ACMEEmail          string `yaml:"ACMEEmail" json:"-" flag:"acme-email"`
+	ACMEDirectory      string `yaml:"ACMEDirectory" json:"-" flag:"acme-directory"`
+	ShutdownTimeout    int    `yaml:"ShutdownTimeout" json:"-" flag:"shutdown-timeout"`
+	MetricsToken       string `yaml:"MetricsToken" json:"-" flag:"metrics-token"`
+	SMTPHost           string `yaml:"SMTPHost" json:"-" flag:"smtp-host"`
+	SMTPPort           int    `yaml:"SMTPPort" json:"-" flag:"smtp-port"`
+	SMTPUser           string `yaml:"SMTPUser" json:"-" flag:"smtp-user"`
+	SMTPPassword       string `yaml:"SMTPPassword" json:"-" flag:"smtp-password"`
+	SMTPFrom           string `yaml:"SMTPFrom" json:"-" flag:"smtp-from"`
+	SMTPTLS            string `yaml:"SMTPTLS" json:"-" flag:"smtp-tls"`
+	AlertEmail         string `yaml:"AlertEmail" json:"-" flag:"alert-email"`
 	RawPresets         bool   `yaml:"RawPresets" json:"RawPresets" flag:"raw-presets"`
 	DarktableBin       string `yaml:"DarktableBin" json:"-" flag:"darktable-bin"`
 	RawtherapeeBin     string `yaml:"RawtherapeeBin" json:"-" flag:"rawtherapee-bin"`
 	SipsBin            string `yaml:"SipsBin" json:"-" flag:"sips-bin"`
 	HeifConvertBin     string `yaml:"HeifConvertBin" json:"-" flag:"heifconvert-bin"`
+	TesseractBin       string `yaml:"TesseractBin" json:"-" flag:"tesseract-bin"`
+	OCRLanguages       string `yaml:"OCRLanguages" json:"-" flag:"ocr-languages"`
 	FFmpegBin          string `yaml:"FFmpegBin" json:"-" flag:"ffmpeg-bin"`
 	FFmpegEncoder      string `yaml:"FFmpegEncoder" json:"FFmpegEncoder" flag:"ffmpeg-encoder"`
 	FFmpegBitrate      int    `yaml:"FFmpegBitrate" json:"FFmpegBitrate" flag:"ffmpeg-bitrate"`
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"net"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/acl"
+)
+
+// ProxyAuthEnabled tests if users authenticated by a trusted reverse proxy should be logged in automatically,
+// reverse proxies connecting through the Unix socket are trusted.
+func (c *Config) ProxyAuthEnabled() bool {
+	return c.ProxyUserHeader() != "" && (len(c.TrustedProxies()) > 0 || c.HttpSocket() != "")
+}
+
+// ProxyUserHeader returns the name of the request header containing the user name, e.g. Remote-User.
+func (c *Config) ProxyUserHeader() string {
+	return strings.TrimSpace(c.options.ProxyUserHeader)
+}
+
+// ProxyGroupsHeader returns the name of the optional request header with comma-separated groups, e.g. Remote-Groups.
+func (c *Config) ProxyGroupsHeader() string {
+	return strings.TrimSpace(c.options.ProxyGroupsHeader)
+}
+
+// ProxyEmailHeader returns the name of the optional request header with the user's email, e.g. Remote-Email.
+func (c *Config) ProxyEmailHeader() string {
+	return strings.TrimSpace(c.options.ProxyEmailHeader)
+}
+
+// ProxyNameHeader returns the name of the optional request header with the user's full name, e.g. Remote-Name.
+func (c *Config) ProxyNameHeader() string {
+	return strings.TrimSpace(c.options.ProxyNameHeader)
+}
+
+// TrustedProxies returns the networks of reverse proxies that are allowed to set identity headers.
+// The list is parsed once and only parsed again if the option changes.
+func (c *Config) TrustedProxies() []*net.IPNet {
+	c.proxies.Lock()
+	defer c.proxies.Unlock()
+
+	if c.proxies.nets != nil && c.proxies.value == c.options.TrustedProxies {
+		return c.proxies.nets
+	}
+
+	c.proxies.value = c.options.TrustedProxies
+	c.proxies.nets = parseTrustedProxies(c.options.TrustedProxies)
+
+	return c.proxies.nets
+}
+
+// parseTrustedProxies parses a comma-separated list of trusted proxy addresses and networks.
+func parseTrustedProxies(list string) (result []*net.IPNet) {
+	result = []*net.IPNet{}
+
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+
+		if s == "" {
+			continue
+		}
+
+		// Single addresses don't require a prefix length.
+		if !strings.Contains(s, "/") {
+			if ip := net.ParseIP(s); ip == nil {
+				log.Warnf("config: invalid trusted proxy %s", s)
+				continue
+			} else if ip.To4() != nil {
+				s += "/32"
+			} else {
+				s += "/128"
+			}
+		}
+
+		if _, n, err := net.ParseCIDR(s); err != nil {
+			log.Warnf("config: invalid trusted proxy %s", s)
+		} else {
+			result = append(result, n)
+		}
+	}
+
+	return result
+}
+
+// TrustedProxy tests if the IP address belongs to a trusted reverse proxy.
+func (c *Config) TrustedProxy(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range c.TrustedProxies() {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ProxyRoles returns the group to role mapping, e.g. "admins:admin,family:family".
+func (c *Config) ProxyRoles() string {
+	return strings.TrimSpace(c.options.ProxyRoles)
+}
+
+// ProxyDefaultRole returns the role of new users without matching group, empty if they are denied access.
+func (c *Config) ProxyDefaultRole() acl.Role {
+	return acl.Role(strings.ToLower(strings.TrimSpace(c.options.ProxyRole)))
+}
+
+// ProxyRole returns the role of a user with the given groups, the first matching mapping wins.
+func (c *Config) ProxyRole(groups []string) acl.Role {
+	return groupRole(c.ProxyRoles(), groups, c.ProxyDefaultRole())
+}
+
+// ProxyRegister tests if accounts should be created for unknown users authenticated by the proxy.
+func (c *Config) ProxyRegister() bool {
+	return c.options.ProxyRegister
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"net"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_ProxyAuthEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.ProxyAuthEnabled())
+
+	c.options.ProxyUserHeader = "Remote-User"
+	assert.False(t, c.ProxyAuthEnabled())
+
+	c.options.TrustedProxies = "172.16.0.0/12"
+	assert.True(t, c.ProxyAuthEnabled())
+
+	// Proxies connecting through the Unix socket are trusted.
+	c.options.TrustedProxies = ""
+	c.options.HttpSocket = "/run/photoprism/photoprism.sock"
+	assert.True(t, c.ProxyAuthEnabled())
+}
+
+func TestConfig_TrustedProxy(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.TrustedProxies = "172.16.0.0/12, 10.1.2.3, ::1, foo"
+
+	assert.Len(t, c.TrustedProxies(), 3)
+	assert.True(t, c.TrustedProxy(net.ParseIP("172.20.0.5")))
+	assert.True(t, c.TrustedProxy(net.ParseIP("10.1.2.3")))
+	assert.True(t, c.TrustedProxy(net.ParseIP("::1")))
+	assert.False(t, c.TrustedProxy(net.ParseIP("10.1.2.4")))
+	assert.False(t, c.TrustedProxy(net.ParseIP("192.168.1.1")))
+	assert.False(t, c.TrustedProxy(nil))
+}
+
+func TestConfig_ProxyRole(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.ProxyRoles = "admins:admin,family:family"
+
+	assert.Equal(t, acl.RoleAdmin, c.ProxyRole([]string{"admins"}))
+	assert.Equal(t, acl.Role(""), c.ProxyRole([]string{"friends"}))
+
+	c.options.ProxyRole = "guest"
+	assert.Equal(t, acl.RoleGuest, c.ProxyRole(nil))
+}
+
+func TestConfig_TrustedProxies(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.TrustedProxies())
+
+	c.options.TrustedProxies = "172.16.0.0/12"
+
+	result := c.TrustedProxies()
+
+	assert.Len(t, result, 1)
+
+	// Parsed networks are reused until the option changes.
+	assert.Equal(t, &result[0], &c.TrustedProxies()[0])
+
+	c.options.TrustedProxies = "172.16.0.0/12, 10.1.2.3"
+
+	assert.Len(t, c.TrustedProxies(), 2)
+}
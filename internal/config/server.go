@@ -3,6 +3,7 @@ package config
 import (
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -48,6 +49,20 @@ func (c *Config) HttpCompression() string {
 	return strings.ToLower(strings.TrimSpace(c.options.HttpCompression))
 }
 
+// ShutdownTimeout returns the max time for finishing requests and background jobs on shutdown.
+func (c *Config) ShutdownTimeout() time.Duration {
+	if c.options.ShutdownTimeout <= 0 {
+		return 60 * time.Second
+	}
+
+	return time.Duration(c.options.ShutdownTimeout) * time.Second
+}
+
+// MetricsToken returns the bearer token for accessing metrics, empty if public.
+func (c *Config) MetricsToken() string {
+	return strings.TrimSpace(c.options.MetricsToken)
+}
+
 // TemplatesPath returns the server templates path.
 func (c *Config) TemplatesPath() string {
 	return filepath.Join(c.AssetsPath(), "templates")
@@ -2,6 +2,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,6 +33,22 @@ func TestConfig_HttpServerMode2(t *testing.T) {
 	assert.Equal(t, "test", c.HttpMode())
 }
 
+func TestConfig_ShutdownTimeout(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, time.Minute, c.ShutdownTimeout())
+	c.options.ShutdownTimeout = 5
+	assert.Equal(t, 5*time.Second, c.ShutdownTimeout())
+}
+
+func TestConfig_MetricsToken(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.MetricsToken())
+	c.options.MetricsToken = " secret "
+	assert.Equal(t, "secret", c.MetricsToken())
+}
+
 func TestConfig_TemplateName(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	c.initSettings()
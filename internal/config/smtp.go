@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+)
+
+// Outgoing mail encryption modes.
+const (
+	SMTPStartTLS = "starttls"
+	SMTPTLS      = "tls"
+	SMTPNone     = "none"
+)
+
+// SMTPHost returns the outgoing mail server hostname.
+func (c *Config) SMTPHost() string {
+	return strings.TrimSpace(c.options.SMTPHost)
+}
+
+// SMTPPort returns the outgoing mail server port.
+func (c *Config) SMTPPort() int {
+	if c.options.SMTPPort > 0 {
+		return c.options.SMTPPort
+	} else if c.SMTPTLS() == SMTPTLS {
+		return 465
+	}
+
+	return 587
+}
+
+// SMTPAddr returns the outgoing mail server address including the port.
+func (c *Config) SMTPAddr() string {
+	return fmt.Sprintf("%s:%d", c.SMTPHost(), c.SMTPPort())
+}
+
+// SMTPUser returns the outgoing mail server username.
+func (c *Config) SMTPUser() string {
+	return strings.TrimSpace(c.options.SMTPUser)
+}
+
+// SMTPPassword returns the outgoing mail server password.
+func (c *Config) SMTPPassword() string {
+	return c.options.SMTPPassword
+}
+
+// SMTPTLS returns the outgoing mail encryption mode (starttls, tls or none).
+func (c *Config) SMTPTLS() string {
+	switch strings.ToLower(strings.TrimSpace(c.options.SMTPTLS)) {
+	case SMTPTLS, "ssl":
+		return SMTPTLS
+	case SMTPNone, "off", "false":
+		return SMTPNone
+	default:
+		return SMTPStartTLS
+	}
+}
+
+// SMTPFrom returns the sender address of email notifications.
+func (c *Config) SMTPFrom() string {
+	if from := strings.TrimSpace(c.options.SMTPFrom); from != "" {
+		return from
+	}
+
+	host := "localhost"
+
+	if u, err := url.Parse(c.SiteUrl()); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+
+	return (&mail.Address{Name: c.SiteTitle(), Address: "noreply@" + host}).String()
+}
+
+// MailEnabled tests if email notifications can be sent.
+func (c *Config) MailEnabled() bool {
+	return c.SMTPHost() != "" && !c.Demo()
+}
+
+// AlertEmail returns additional recipient addresses for failure alerts.
+func (c *Config) AlertEmail() (result []string) {
+	for _, s := range strings.Split(c.options.AlertEmail, ",") {
+		if addr, err := mail.ParseAddress(strings.TrimSpace(s)); err == nil {
+			result = append(result, addr.Address)
+		}
+	}
+
+	return result
+}
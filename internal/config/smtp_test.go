@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_MailEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.MailEnabled())
+
+	c.options.SMTPHost = " mail.example.com "
+	assert.Equal(t, "mail.example.com", c.SMTPHost())
+	assert.True(t, c.MailEnabled())
+
+	c.options.Demo = true
+	assert.False(t, c.MailEnabled())
+}
+
+func TestConfig_SMTPPort(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.SMTPHost = "mail.example.com"
+
+	assert.Equal(t, SMTPStartTLS, c.SMTPTLS())
+	assert.Equal(t, 587, c.SMTPPort())
+	assert.Equal(t, "mail.example.com:587", c.SMTPAddr())
+
+	c.options.SMTPTLS = "SSL"
+	assert.Equal(t, SMTPTLS, c.SMTPTLS())
+	assert.Equal(t, 465, c.SMTPPort())
+
+	c.options.SMTPTLS = "none"
+	c.options.SMTPPort = 25
+	assert.Equal(t, SMTPNone, c.SMTPTLS())
+	assert.Equal(t, "mail.example.com:25", c.SMTPAddr())
+}
+
+func TestConfig_SMTPFrom(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.SiteUrl = "https://photos.example.com/"
+	c.options.SiteTitle = "Family Photos"
+	assert.Equal(t, `"Family Photos" <noreply@photos.example.com>`, c.SMTPFrom())
+
+	c.options.SMTPFrom = "PhotoPrism <photos@example.com>"
+	assert.Equal(t, "PhotoPrism <photos@example.com>", c.SMTPFrom())
+}
+
+func TestConfig_AlertEmail(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Empty(t, c.AlertEmail())
+
+	c.options.AlertEmail = "admin@example.com, invalid, Ops <ops@example.com>"
+	assert.Equal(t, []string{"admin@example.com", "ops@example.com"}, c.AlertEmail())
+}
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"path"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/storage"
+)
+
+// StorageBackend returns the storage backend type for originals, sidecar and cache files.
+func (c *Config) StorageBackend() string {
+	switch strings.ToLower(strings.TrimSpace(c.options.StorageBackend)) {
+	case storage.BackendS3:
+		return storage.BackendS3
+	default:
+		return storage.BackendLocal
+	}
+}
+
+// S3Endpoint returns the S3-compatible object storage endpoint URL.
+func (c *Config) S3Endpoint() string {
+	return strings.TrimSpace(c.options.S3Endpoint)
+}
+
+// S3Bucket returns the object storage bucket name.
+func (c *Config) S3Bucket() string {
+	return strings.TrimSpace(c.options.S3Bucket)
+}
+
+// S3Prefix returns the object storage key prefix.
+func (c *Config) S3Prefix() string {
+	return strings.Trim(c.options.S3Prefix, "/ ")
+}
+
+// S3Region returns the object storage region.
+func (c *Config) S3Region() string {
+	return strings.TrimSpace(c.options.S3Region)
+}
+
+// S3CacheSize returns the size limit of the local object storage cache in bytes, 0 means unlimited.
+func (c *Config) S3CacheSize() int64 {
+	if c.options.S3CacheSize <= 0 {
+		return 0
+	}
+
+	// Megabyte.
+	return c.options.S3CacheSize * 1024 * 1024
+}
+
+// newStorage returns a new storage for the root name, with path on local disk.
+func (c *Config) newStorage(root, localPath string) storage.Storage {
+	if c.StorageBackend() == storage.BackendS3 {
+		s, err := storage.NewS3(c.S3Endpoint(), c.S3Bucket(), path.Join(c.S3Prefix(), root), c.S3Region(), c.options.S3AccessKey, c.options.S3SecretKey, localPath)
+
+		if err == nil {
+			s.Cache().SetLimit(c.S3CacheSize())
+			return s
+		}
+
+		log.Errorf("config: %s, using local %s storage", err, root)
+	}
+
+	return storage.NewLocal(localPath)
+}
+
+// initStorageBackends creates the originals, sidecar and cache storage, so that their clients are reused.
+func (c *Config) initStorageBackends() {
+	c.OriginalsStorage()
+	c.SidecarStorage()
+	c.CacheStorage()
+}
+
+// rootStorage returns the storage for the root name, it is created if needed.
+func (c *Config) rootStorage(root, localPath string) storage.Storage {
+	c.storage.Lock()
+	defer c.storage.Unlock()
+
+	if c.storage.roots == nil {
+		c.storage.roots = make(map[string]storage.Storage)
+	}
+
+	// Create a new storage only if the options have changed.
+	key := strings.Join([]string{root, localPath, c.StorageBackend(), c.S3Endpoint(), c.S3Bucket(), c.S3Prefix(), c.S3Region(), c.options.S3AccessKey}, "|")
+
+	if s, ok := c.storage.roots[key]; ok {
+		return s
+	}
+
+	s := c.newStorage(root, localPath)
+	c.storage.roots[key] = s
+
+	return s
+}
+
+// OriginalsStorage returns the storage for original media files.
+func (c *Config) OriginalsStorage() storage.Storage {
+	return c.rootStorage("originals", c.OriginalsPath())
+}
+
+// SidecarStorage returns the storage for sidecar files.
+func (c *Config) SidecarStorage() storage.Storage {
+	// Relative sidecar paths are located in the originals folder.
+	if !c.SidecarPathIsAbs() {
+		return c.OriginalsStorage()
+	}
+
+	return c.rootStorage("sidecar", c.SidecarPath())
+}
+
+// CacheStorage returns the storage for cache files like thumbnails.
+func (c *Config) CacheStorage() storage.Storage {
+	return c.rootStorage("cache", c.CachePath())
+}
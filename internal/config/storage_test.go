@@ -0,0 +1,65 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/storage"
+)
+
+func TestConfig_StorageBackend(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, storage.BackendLocal, c.StorageBackend())
+
+	c.options.StorageBackend = " S3 "
+	assert.Equal(t, storage.BackendS3, c.StorageBackend())
+
+	c.options.StorageBackend = "foo"
+	assert.Equal(t, storage.BackendLocal, c.StorageBackend())
+}
+
+func TestConfig_OriginalsStorage(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	t.Run("Local", func(t *testing.T) {
+		s := c.OriginalsStorage()
+
+		assert.Equal(t, storage.BackendLocal, s.Backend())
+		assert.Equal(t, c.OriginalsPath(), s.Path())
+	})
+	t.Run("S3", func(t *testing.T) {
+		c.options.StorageBackend = "s3"
+		c.options.S3Endpoint = "https://s3.example.com"
+		c.options.S3Bucket = "photos"
+		c.options.S3Prefix = "/library/"
+
+		s := c.OriginalsStorage()
+
+		assert.Equal(t, storage.BackendS3, s.Backend())
+		assert.Equal(t, c.OriginalsPath(), s.Path())
+		assert.Equal(t, storage.BackendS3, c.CacheStorage().Backend())
+		assert.Equal(t, c.CachePath(), c.CacheStorage().Path())
+
+		// Clients are created once and reused.
+		assert.Same(t, s, c.OriginalsStorage())
+	})
+	t.Run("S3 without bucket", func(t *testing.T) {
+		c.options.S3Bucket = ""
+
+		assert.Equal(t, storage.BackendLocal, c.OriginalsStorage().Backend())
+	})
+}
+
+func TestConfig_S3CacheSize(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, int64(0), c.S3CacheSize())
+
+	c.options.S3CacheSize = 100
+	assert.Equal(t, int64(100*1024*1024), c.S3CacheSize())
+
+	c.options.S3CacheSize = -1
+	assert.Equal(t, int64(0), c.S3CacheSize())
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"crypto/tls"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// DefaultACMEDirectory is the Let's Encrypt production directory URL.
+const DefaultACMEDirectory = "https://acme-v02.api.letsencrypt.org/directory"
+
+// HttpRedirectPort returns the port for redirecting plain HTTP requests to HTTPS, 0 if disabled.
+func (c *Config) HttpRedirectPort() int {
+	if !c.TLSEnabled() || c.options.HttpRedirectPort < 0 {
+		return 0
+	}
+
+	return c.options.HttpRedirectPort
+}
+
+// HttpSocket returns the Unix socket file name, empty to listen on host and port.
+func (c *Config) HttpSocket() string {
+	return fs.Abs(c.options.HttpSocket)
+}
+
+// TLSCert returns the TLS certificate file name.
+func (c *Config) TLSCert() string {
+	return fs.Abs(c.options.TLSCert)
+}
+
+// TLSKey returns the TLS private key file name.
+func (c *Config) TLSKey() string {
+	return fs.Abs(c.options.TLSKey)
+}
+
+// TLSMinVersion returns the minimum TLS version, TLS 1.2 by default.
+func (c *Config) TLSMinVersion() uint16 {
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(c.options.TLSMinVersion)), "tls") {
+	case "1.3", "13":
+		return tls.VersionTLS13
+	default:
+		return tls.VersionTLS12
+	}
+}
+
+// TLSMinVersionName returns the minimum TLS version as string.
+func (c *Config) TLSMinVersionName() string {
+	if c.TLSMinVersion() == tls.VersionTLS13 {
+		return "1.3"
+	}
+
+	return "1.2"
+}
+
+// TLSEnabled tests if the web server should use TLS.
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCert() != "" && c.TLSKey() != "" || c.ACMEEnabled()
+}
+
+// ACMEEnabled tests if certificates should be requested from an ACME provider.
+func (c *Config) ACMEEnabled() bool {
+	return len(c.ACMEDomains()) > 0
+}
+
+// ACMEDomains returns the domain names for ACME certificates.
+func (c *Config) ACMEDomains() (result []string) {
+	for _, s := range strings.Split(c.options.ACMEDomains, ",") {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
+// ACMEEmail returns the ACME account contact email.
+func (c *Config) ACMEEmail() string {
+	return strings.TrimSpace(c.options.ACMEEmail)
+}
+
+// ACMEDirectory returns the ACME directory URL.
+func (c *Config) ACMEDirectory() string {
+	if c.options.ACMEDirectory == "" {
+		return DefaultACMEDirectory
+	}
+
+	return strings.TrimSpace(c.options.ACMEDirectory)
+}
+
+// ACMECachePath returns the path for storing ACME account keys and certificates.
+func (c *Config) ACMECachePath() string {
+	return filepath.Join(c.StoragePath(), "certificates")
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_TLSEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.TLSEnabled())
+	assert.Equal(t, 0, c.HttpRedirectPort())
+
+	c.options.HttpRedirectPort = 80
+	assert.Equal(t, 0, c.HttpRedirectPort())
+
+	c.options.TLSCert = "/etc/ssl/photoprism.crt"
+	assert.False(t, c.TLSEnabled())
+
+	c.options.TLSKey = "/etc/ssl/photoprism.key"
+	assert.True(t, c.TLSEnabled())
+	assert.Equal(t, 80, c.HttpRedirectPort())
+}
+
+func TestConfig_TLSMinVersion(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, uint16(tls.VersionTLS12), c.TLSMinVersion())
+	assert.Equal(t, "1.2", c.TLSMinVersionName())
+
+	c.options.TLSMinVersion = "TLS1.3"
+	assert.Equal(t, uint16(tls.VersionTLS13), c.TLSMinVersion())
+	assert.Equal(t, "1.3", c.TLSMinVersionName())
+
+	c.options.TLSMinVersion = "1.0"
+	assert.Equal(t, uint16(tls.VersionTLS12), c.TLSMinVersion())
+}
+
+func TestConfig_ACMEDomains(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.ACMEEnabled())
+	assert.Empty(t, c.ACMEDomains())
+	assert.Equal(t, DefaultACMEDirectory, c.ACMEDirectory())
+
+	c.options.ACMEDomains = " Photos.example.com, ,www.example.com"
+	c.options.ACMEDirectory = "https://localhost:14000/dir"
+
+	assert.True(t, c.ACMEEnabled())
+	assert.True(t, c.TLSEnabled())
+	assert.Equal(t, []string{"photos.example.com", "www.example.com"}, c.ACMEDomains())
+	assert.Equal(t, "https://localhost:14000/dir", c.ACMEDirectory())
+	assert.Contains(t, c.ACMECachePath(), "certificates")
+}
+
+func TestConfig_HttpSocket(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.HttpSocket())
+
+	c.options.HttpSocket = "/run/photoprism.sock"
+	assert.Equal(t, "/run/photoprism.sock", c.HttpSocket())
+}
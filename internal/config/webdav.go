@@ -0,0 +1,27 @@
+package config
+
+import (
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+// WebDAVThumbs returns the thumbnail size of images in the virtual WebDAV library, empty for originals.
+func (c *Config) WebDAVThumbs() thumb.Name {
+	name := thumb.Name(c.options.WebDAVThumbs)
+
+	size, ok := thumb.Sizes[name]
+
+	if !ok {
+		return ""
+	}
+
+	if size.Width > c.ThumbSizeUncached() || size.Height > c.ThumbSizeUncached() {
+		return ""
+	}
+
+	// Uncached sizes are only available if on-demand rendering is enabled.
+	if (size.Width > c.ThumbSizePrecached() || size.Height > c.ThumbSizePrecached()) && !c.ThumbUncached() {
+		return ""
+	}
+
+	return name
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_WebDAVThumbs(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.ThumbSize = 2048
+	c.options.ThumbSizeUncached = 7680
+
+	assert.Equal(t, thumb.Name(""), c.WebDAVThumbs())
+
+	c.options.WebDAVThumbs = "fit_1920"
+	assert.Equal(t, thumb.Fit1920, c.WebDAVThumbs())
+
+	c.options.WebDAVThumbs = "foo"
+	assert.Equal(t, thumb.Name(""), c.WebDAVThumbs())
+
+	c.options.ThumbUncached = false
+	c.options.WebDAVThumbs = "fit_7680"
+	assert.Equal(t, thumb.Name(""), c.WebDAVThumbs())
+
+	c.options.ThumbUncached = true
+	assert.Equal(t, thumb.Fit7680, c.WebDAVThumbs())
+}
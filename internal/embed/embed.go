@@ -0,0 +1,86 @@
+/*
+
+Package embed maps images and text to vectors for semantic search.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package embed
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// ErrDisabled is returned if no encoder has been configured.
+var ErrDisabled = errors.New("embeddings disabled")
+
+var encoder Encoder
+var encoderMutex = sync.RWMutex{}
+
+// SetEncoder sets the encoder used for indexing and semantic search, nil disables embeddings.
+func SetEncoder(e Encoder) {
+	encoderMutex.Lock()
+	defer encoderMutex.Unlock()
+
+	encoder = e
+}
+
+// Active returns the current encoder, or nil if embeddings are disabled.
+func Active() Encoder {
+	encoderMutex.RLock()
+	defer encoderMutex.RUnlock()
+
+	return encoder
+}
+
+// Enabled tests if an encoder has been configured.
+func Enabled() bool {
+	return Active() != nil
+}
+
+// Image returns the embedding of a JPEG image using the active encoder.
+func Image(img []byte) (Vector, error) {
+	if e := Active(); e == nil {
+		return nil, ErrDisabled
+	} else {
+		return e.Image(img)
+	}
+}
+
+// Text returns the embedding of a natural language search query using the active encoder.
+func Text(s string) (Vector, error) {
+	if e := Active(); e == nil {
+		return nil, ErrDisabled
+	} else {
+		return e.Text(s)
+	}
+}
@@ -0,0 +1,31 @@
+package embed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetEncoder(t *testing.T) {
+	defer SetEncoder(nil)
+
+	assert.False(t, Enabled())
+
+	_, err := Text("cat")
+	assert.Equal(t, ErrDisabled, err)
+
+	_, err = Image([]byte("jpeg"))
+	assert.Equal(t, ErrDisabled, err)
+
+	server := newTestServer()
+	defer server.Close()
+
+	SetEncoder(NewRemote(server.URL, "clip"))
+
+	assert.True(t, Enabled())
+	assert.Equal(t, "clip", Active().Model())
+
+	result, err := Text("cat")
+	assert.Nil(t, err)
+	assert.Equal(t, Vector{0, 1, 0}, result)
+}
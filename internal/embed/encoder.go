@@ -0,0 +1,14 @@
+package embed
+
+// Vector represents an embedding.
+type Vector = []float64
+
+// Encoder represents a model that maps images and text to vectors in the same embedding space.
+type Encoder interface {
+	// Model returns the model name, vectors created by different models must not be compared.
+	Model() string
+	// Image returns the embedding of a JPEG image.
+	Image(img []byte) (Vector, error)
+	// Text returns the embedding of a natural language description.
+	Text(s string) (Vector, error)
+}
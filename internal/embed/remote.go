@@ -0,0 +1,103 @@
+package embed
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RemoteTimeout is the maximum duration of a single request to the encoder service.
+var RemoteTimeout = 60 * time.Second
+
+// Remote is a client for an external encoder service, e.g. a CLIP model running on another host.
+//
+// Images are sent as "image/jpeg" request body to "<url>/image", text as {"text": "..."} JSON
+// to "<url>/text". The service must respond with {"embedding": [0.12, -0.03, ...]} in both cases.
+type Remote struct {
+	uri    string
+	model  string
+	client *http.Client
+}
+
+// remoteResponse represents an encoder service response.
+type remoteResponse struct {
+	Embedding Vector `json:"embedding"`
+}
+
+// NewRemote returns a new encoder service client.
+func NewRemote(uri, model string) *Remote {
+	return &Remote{
+		uri:    strings.TrimRight(uri, "/"),
+		model:  model,
+		client: &http.Client{Timeout: RemoteTimeout},
+	}
+}
+
+// Model returns the model name.
+func (r *Remote) Model() string {
+	return r.model
+}
+
+// Image returns the embedding of a JPEG image.
+func (r *Remote) Image(img []byte) (Vector, error) {
+	if len(img) == 0 {
+		return nil, fmt.Errorf("embed: empty image")
+	}
+
+	return r.request(r.uri+"/image", "image/jpeg", bytes.NewReader(img))
+}
+
+// Text returns the embedding of a natural language description.
+func (r *Remote) Text(s string) (Vector, error) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return nil, fmt.Errorf("embed: empty text")
+	}
+
+	body, err := json.Marshal(map[string]string{"text": s})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.request(r.uri+"/text", "application/json", bytes.NewReader(body))
+}
+
+// request sends data to the encoder service and returns the resulting vector.
+func (r *Remote) request(uri, contentType string, body io.Reader) (Vector, error) {
+	req, err := http.NewRequest(http.MethodPost, uri, body)
+
+	if err != nil {
+		return nil, fmt.Errorf("embed: %s (create request)", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.client.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("embed: %s (http request)", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("embed: request failed with code %d", resp.StatusCode)
+	}
+
+	var result remoteResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("embed: %s (decode json)", err)
+	} else if len(result.Embedding) == 0 {
+		return nil, fmt.Errorf("embed: empty result")
+	}
+
+	return result.Embedding, nil
+}
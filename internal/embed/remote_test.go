@@ -0,0 +1,107 @@
+package embed
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServer returns a stand-in encoder service.
+func newTestServer() *httptest.Server {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "image/jpeg" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(remoteResponse{Embedding: Vector{1, 0, 0}})
+	})
+
+	mux.HandleFunc("/text", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Text string `json:"text"`
+		}
+
+		if body, err := ioutil.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		} else if err := json.Unmarshal(body, &req); err != nil || req.Text == "" {
+			w.WriteHeader(http.StatusBadRequest)
+		} else if req.Text == "cat" {
+			_ = json.NewEncoder(w).Encode(remoteResponse{Embedding: Vector{0, 1, 0}})
+		} else {
+			_ = json.NewEncoder(w).Encode(remoteResponse{})
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestRemote_Model(t *testing.T) {
+	assert.Equal(t, "clip", NewRemote("http://localhost/", "clip").Model())
+}
+
+func TestRemote_Image(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	t.Run("success", func(t *testing.T) {
+		r := NewRemote(server.URL+"/", "clip")
+
+		result, err := r.Image([]byte("jpeg"))
+
+		assert.Nil(t, err)
+		assert.Equal(t, Vector{1, 0, 0}, result)
+	})
+	t.Run("empty", func(t *testing.T) {
+		r := NewRemote(server.URL, "clip")
+
+		result, err := r.Image(nil)
+
+		assert.EqualError(t, err, "embed: empty image")
+		assert.Nil(t, result)
+	})
+	t.Run("not found", func(t *testing.T) {
+		r := NewRemote(server.URL+"/foo", "clip")
+
+		result, err := r.Image([]byte("jpeg"))
+
+		assert.EqualError(t, err, "embed: request failed with code 404")
+		assert.Nil(t, result)
+	})
+}
+
+func TestRemote_Text(t *testing.T) {
+	server := newTestServer()
+	defer server.Close()
+
+	t.Run("success", func(t *testing.T) {
+		r := NewRemote(server.URL, "clip")
+
+		result, err := r.Text(" cat ")
+
+		assert.Nil(t, err)
+		assert.Equal(t, Vector{0, 1, 0}, result)
+	})
+	t.Run("empty text", func(t *testing.T) {
+		r := NewRemote(server.URL, "clip")
+
+		result, err := r.Text(" ")
+
+		assert.EqualError(t, err, "embed: empty text")
+		assert.Nil(t, result)
+	})
+	t.Run("empty result", func(t *testing.T) {
+		r := NewRemote(server.URL, "clip")
+
+		result, err := r.Text("dog")
+
+		assert.EqualError(t, err, "embed: empty result")
+		assert.Nil(t, result)
+	})
+}
@@ -2,11 +2,14 @@ package entity
 
 import (
 	"database/sql"
+	"fmt"
 	"sort"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/internal/remote/s3"
+	"github.com/photoprism/photoprism/internal/remote/sftp"
 	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/ulule/deepcopier"
@@ -31,6 +34,11 @@ type Account struct {
 	AccKey        string `gorm:"type:VARBINARY(255);"`
 	AccUser       string `gorm:"type:VARBINARY(255);"`
 	AccPass       string `gorm:"type:VARBINARY(255);"`
+	AccBucket     string `gorm:"type:VARBINARY(255);"`
+	AccPrefix     string `gorm:"type:VARBINARY(500);"`
+	AccRegion     string `gorm:"type:VARBINARY(64);"`
+	AccSSHKey     string `gorm:"type:TEXT;" json:"-"`
+	AccHostKey    string `gorm:"type:VARBINARY(255);"`
 	AccError      string `gorm:"type:VARBINARY(512);"`
 	AccErrors     int
 	AccShare      bool
@@ -74,8 +82,8 @@ func (m *Account) SaveForm(form form.Account) error {
 		return err
 	}
 
-	if m.AccType != string(remote.ServiceWebDAV) {
-		// TODO: Only WebDAV supported at the moment
+	if !remote.Supported(m.AccType) {
+		// TODO: Only WebDAV, S3 and SFTP supported at the moment
 		m.AccShare = false
 		m.AccSync = false
 	}
@@ -102,13 +110,42 @@ func (m *Account) Delete() error {
 	return Db().Delete(m).Error
 }
 
+// Client returns a client for syncing and sharing files with the remote service.
+func (m *Account) Client() (remote.Client, error) {
+	switch m.AccType {
+	case remote.ServiceWebDAV:
+		return webdav.New(m.AccURL, m.AccUser, m.AccPass), nil
+	case remote.ServiceS3:
+		if c, err := s3.New(m.AccURL, m.AccBucket, m.AccPrefix, m.AccRegion, m.AccUser, m.AccPass); err != nil {
+			return nil, err
+		} else {
+			return c, nil
+		}
+	case remote.ServiceSFTP:
+		if c, err := sftp.New(m.AccURL, m.AccUser, m.AccPass, m.AccSSHKey, m.AccHostKey); err != nil {
+			return nil, err
+		} else {
+			return c, nil
+		}
+	default:
+		return nil, fmt.Errorf("account: service type %s not supported", m.AccType)
+	}
+}
+
 // Directories returns a list of directories or albums in an account.
 func (m *Account) Directories() (result fs.FileInfos, err error) {
-	if m.AccType == remote.ServiceWebDAV {
-		c := webdav.New(m.AccURL, m.AccUser, m.AccPass)
-		result, err = c.Directories("/", true, webdav.SyncTimeout)
+	if !remote.Supported(m.AccType) {
+		return result, nil
 	}
 
+	c, err := m.Client()
+
+	if err != nil {
+		return result, err
+	}
+
+	result, err = c.Directories("/", true, remote.SyncTimeout)
+
 	sort.Sort(result)
 
 	return result, err
@@ -4,6 +4,11 @@ import (
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/internal/remote/s3"
+	"github.com/photoprism/photoprism/internal/remote/sftp"
+	"github.com/photoprism/photoprism/internal/remote/sftp/sftptest"
+	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -140,6 +145,29 @@ func TestAccount_Directories(t *testing.T) {
 		assert.Contains(t, result.Abs(), "/Photos")
 
 	})
+	t.Run("sftp", func(t *testing.T) {
+		s, err := sftptest.NewTestServer("admin", "photoprism", nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer s.Close()
+
+		if err := s.Put("/photos/Holiday/2021/beach.jpg", []byte("beach")); err != nil {
+			t.Fatal(err)
+		}
+
+		account := Account{AccName: "SFTPAccount", AccURL: s.URL("/photos"), AccType: remote.ServiceSFTP, AccUser: "admin", AccPass: "photoprism", AccHostKey: s.HostKey}
+
+		result, err := account.Directories()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, []string{"/Holiday", "/Holiday/2021"}, result.Abs())
+	})
 	t.Run("no directory", func(t *testing.T) {
 		account := Account{AccName: "DirectoriesAccount", AccOwner: "Owner", AccURL: "http://webdav-dummy/", AccType: "xxx", AccKey: "123", AccUser: "admin", AccPass: "photoprism",
 			AccError: "", AccShare: true, AccSync: true, RetryLimit: 4, SharePath: "/home", ShareSize: "500", ShareExpires: 3500, SyncPath: "/sync",
@@ -166,6 +194,58 @@ func TestAccount_Directories(t *testing.T) {
 	})
 }
 
+func TestAccount_Client(t *testing.T) {
+	t.Run("webdav", func(t *testing.T) {
+		account := Account{AccURL: "http://webdav-dummy/", AccType: remote.ServiceWebDAV, AccUser: "admin", AccPass: "photoprism"}
+
+		client, err := account.Client()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, webdav.Client{}, client)
+	})
+	t.Run("s3", func(t *testing.T) {
+		account := Account{AccURL: "http://s3-dummy/", AccType: remote.ServiceS3, AccBucket: "photos", AccUser: "key", AccPass: "secret"}
+
+		client, err := account.Client()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, s3.Client{}, client)
+	})
+	t.Run("sftp", func(t *testing.T) {
+		account := Account{AccURL: "sftp://nas.local/photos", AccType: remote.ServiceSFTP, AccUser: "admin", AccPass: "photoprism", AccHostKey: "SHA256:abc"}
+
+		client, err := account.Client()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, sftp.Client{}, client)
+	})
+	t.Run("sftp host key missing", func(t *testing.T) {
+		account := Account{AccURL: "sftp://nas.local/photos", AccType: remote.ServiceSFTP, AccUser: "admin", AccPass: "photoprism"}
+
+		client, err := account.Client()
+
+		assert.Nil(t, client)
+		assert.Equal(t, sftp.ErrHostKeyMissing, err)
+	})
+	t.Run("not supported", func(t *testing.T) {
+		account := Account{AccURL: "http://dummy/", AccType: remote.ServiceFacebook}
+
+		client, err := account.Client()
+
+		assert.Nil(t, client)
+		assert.Error(t, err)
+	})
+}
+
 func TestAccount_Updates(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		account := Account{AccName: "DeleteAccount", AccOwner: "Delete", AccURL: "test.com", AccType: "test", AccKey: "123", AccUser: "testuser", AccPass: "testpass",
@@ -99,6 +99,8 @@ func AddPhotoToAlbums(photo string, albums []string) (err error) {
 
 			if err = entry.Save(); err != nil {
 				log.Errorf("album: %s (add photo %s to albums)", err.Error(), photo)
+			} else {
+				PublishAlbumPhotos(aUID, []string{photo})
 			}
 		}
 	}
@@ -321,10 +323,22 @@ func (m *Album) SetTitle(title string) {
 
 // SaveForm updates the entity using form data and stores it in the database.
 func (m *Album) SaveForm(f form.Album) error {
+	parentUID := m.ParentUID
+
 	if err := deepcopier.Copy(m).From(f); err != nil {
 		return err
 	}
 
+	// Validate parent album changes.
+	if m.ParentUID != parentUID {
+		newParent := m.ParentUID
+		m.ParentUID = parentUID
+
+		if err := m.SetParent(newParent); err != nil {
+			return err
+		}
+	}
+
 	if f.AlbumCategory != "" {
 		m.AlbumCategory = txt.Title(txt.Clip(f.AlbumCategory, txt.ClipKeyword))
 	}
@@ -393,6 +407,11 @@ func (m *Album) Delete() error {
 		return nil
 	}
 
+	// Keep nested albums in the hierarchy.
+	if err := m.moveChildren(); err != nil {
+		return err
+	}
+
 	if err := Db().Delete(m).Error; err != nil {
 		return err
 	}
@@ -459,9 +478,27 @@ func (m *Album) AddPhotos(UIDs []string) (added PhotoAlbums) {
 		}
 	}
 
+	if len(added) > 0 {
+		uids := make([]string, len(added))
+
+		for i, entry := range added {
+			uids[i] = entry.PhotoUID
+		}
+
+		PublishAlbumPhotos(m.AlbumUID, uids)
+	}
+
 	return added
 }
 
+// PublishAlbumPhotos notifies subscribers like webhooks that photos were added to an album.
+func PublishAlbumPhotos(albumUID string, photoUIDs []string) {
+	event.Publish("albums.photos.added", event.Data{
+		"uid":    albumUID,
+		"photos": photoUIDs,
+	})
+}
+
 // RemovePhotos removes photos from an album.
 func (m *Album) RemovePhotos(UIDs []string) (removed PhotoAlbums) {
 	for _, uid := range UIDs {
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"time"
+)
+
+type AlbumFollowers []AlbumFollower
+
+// AlbumFollower represents a user who receives email digests for new photos in an album.
+type AlbumFollower struct {
+	AlbumUID  string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"AlbumUID" yaml:"AlbumUID"`
+	UserUID   string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index" json:"UserUID" yaml:"UserUID"`
+	DigestAt  *time.Time `json:"DigestAt" yaml:"-"`
+	CreatedAt time.Time  `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (AlbumFollower) TableName() string {
+	return "albums_followers"
+}
+
+// FollowAlbum subscribes a user to new photos in an album.
+func FollowAlbum(albumUID, userUID string) (*AlbumFollower, error) {
+	result := AlbumFollower{}
+
+	if err := Db().Where("album_uid = ? AND user_uid = ?", albumUID, userUID).First(&result).Error; err == nil {
+		return &result, nil
+	}
+
+	// Only photos added after following are included in the next digest.
+	result = AlbumFollower{AlbumUID: albumUID, UserUID: userUID, DigestAt: TimePointer()}
+
+	return &result, Db().Create(&result).Error
+}
+
+// UnfollowAlbum unsubscribes a user from an album.
+func UnfollowAlbum(albumUID, userUID string) error {
+	return UnscopedDb().Delete(AlbumFollower{}, "album_uid = ? AND user_uid = ?", albumUID, userUID).Error
+}
+
+// Digested updates the time of the last digest email.
+func (m *AlbumFollower) Digested(t time.Time) error {
+	m.DigestAt = &t
+
+	return Db().Model(m).UpdateColumn("DigestAt", m.DigestAt).Error
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollowAlbum(t *testing.T) {
+	albumUID := AlbumFixtures.Get("berlin-2019").AlbumUID
+	userUID := UserFixtures.Get("bob").UserUID
+
+	m, err := FollowAlbum(albumUID, userUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, albumUID, m.AlbumUID)
+	assert.NotNil(t, m.DigestAt)
+
+	t.Run("again", func(t *testing.T) {
+		again, err := FollowAlbum(albumUID, userUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.CreatedAt.Unix(), again.CreatedAt.Unix())
+	})
+	t.Run("digested", func(t *testing.T) {
+		digestAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		if err := m.Digested(digestAt); err != nil {
+			t.Fatal(err)
+		}
+
+		result := AlbumFollower{}
+
+		if err := Db().Where("album_uid = ? AND user_uid = ?", albumUID, userUID).First(&result).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, digestAt.Unix(), result.DigestAt.Unix())
+	})
+	t.Run("unfollow", func(t *testing.T) {
+		if err := UnfollowAlbum(albumUID, userUID); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(0), Db().Where("album_uid = ? AND user_uid = ?", albumUID, userUID).First(&AlbumFollower{}).RowsAffected)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 
 	"github.com/gosimple/slug"
@@ -421,6 +422,21 @@ func TestAlbum_AddPhotos(t *testing.T) {
 		added := album.AddPhotos([]string{"ab", "cd"})
 		assert.Equal(t, 2, len(added))
 	})
+	t.Run("event", func(t *testing.T) {
+		s := event.Subscribe("albums.photos.added")
+		defer event.Unsubscribe(s)
+
+		album := Album{AlbumUID: "abc124", AlbumTitle: "Event Title"}
+		album.AddPhotos([]string{"ef"})
+
+		select {
+		case msg := <-s.Receiver:
+			assert.Equal(t, "abc124", msg.Fields["uid"])
+			assert.Equal(t, []string{"ef"}, msg.Fields["photos"])
+		case <-time.After(time.Second):
+			t.Fatal("timeout")
+		}
+	})
 }
 
 func TestAlbum_RemovePhotos(t *testing.T) {
@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// AlbumMaxDepth limits the album nesting depth.
+const AlbumMaxDepth = 16
+
+// Parent returns the parent album, or nil if it's a top-level album.
+func (m *Album) Parent() *Album {
+	if m.ParentUID == "" {
+		return nil
+	}
+
+	result := Album{}
+
+	if err := Db().Where("album_uid = ?", m.ParentUID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Ancestors returns all parent albums, starting with the top-level album.
+func (m *Album) Ancestors() (result Albums) {
+	seen := map[string]bool{m.AlbumUID: true}
+	a := m
+
+	for i := 0; i < AlbumMaxDepth; i++ {
+		if a = a.Parent(); a == nil || seen[a.AlbumUID] {
+			break
+		}
+
+		seen[a.AlbumUID] = true
+		result = append(Albums{*a}, result...)
+	}
+
+	return result
+}
+
+// SetParent validates and sets the parent album UID, an empty string moves the album to the top level.
+func (m *Album) SetParent(parentUID string) error {
+	if parentUID == "" {
+		m.ParentUID = ""
+		return nil
+	}
+
+	if !rnd.IsPPID(parentUID, 'a') {
+		return fmt.Errorf("album: invalid parent uid %s", parentUID)
+	} else if m.AlbumType != AlbumDefault {
+		return fmt.Errorf("album: only regular albums can be nested")
+	} else if parentUID == m.AlbumUID {
+		return fmt.Errorf("album: can't be its own parent")
+	}
+
+	parent := Album{}
+
+	if err := Db().Where("album_uid = ?", parentUID).First(&parent).Error; err != nil {
+		return fmt.Errorf("album: parent %s not found", parentUID)
+	} else if parent.AlbumType != AlbumDefault {
+		return fmt.Errorf("album: parent %s is not a regular album", parentUID)
+	}
+
+	ancestors := parent.Ancestors()
+
+	if len(ancestors)+1 >= AlbumMaxDepth {
+		return fmt.Errorf("album: nesting depth exceeds %d", AlbumMaxDepth)
+	}
+
+	// Albums must not become descendants of themselves.
+	if m.AlbumUID != "" {
+		for _, a := range ancestors {
+			if a.AlbumUID == m.AlbumUID {
+				return fmt.Errorf("album: %s is a descendant of %s", parentUID, m.AlbumUID)
+			}
+		}
+	}
+
+	m.ParentUID = parentUID
+
+	return nil
+}
+
+// Children returns the direct child albums.
+func (m *Album) Children() (result Albums) {
+	if m.AlbumUID == "" {
+		return result
+	}
+
+	if err := Db().Where("parent_uid = ?", m.AlbumUID).Order("album_title").Find(&result).Error; err != nil {
+		log.Errorf("album: %s (find children)", err)
+	}
+
+	return result
+}
+
+// AlbumDescendantUIDs returns the UIDs of all albums nested in the album with the given UID.
+func AlbumDescendantUIDs(albumUID string) (result []string) {
+	if albumUID == "" {
+		return result
+	}
+
+	seen := map[string]bool{albumUID: true}
+	parents := []string{albumUID}
+
+	for i := 0; i < AlbumMaxDepth && len(parents) > 0; i++ {
+		var children []string
+
+		if err := Db().Model(&Album{}).Where("parent_uid IN (?)", parents).Pluck("album_uid", &children).Error; err != nil {
+			log.Errorf("album: %s (find descendants)", err)
+			break
+		}
+
+		parents = parents[:0]
+
+		for _, uid := range children {
+			if seen[uid] {
+				continue
+			}
+
+			seen[uid] = true
+			parents = append(parents, uid)
+			result = append(result, uid)
+		}
+	}
+
+	return result
+}
+
+// moveChildren moves child albums to the parent album, e.g. before the album is deleted.
+func (m *Album) moveChildren() error {
+	if m.AlbumUID == "" {
+		return nil
+	}
+
+	return UnscopedDb().Model(&Album{}).Where("parent_uid = ?", m.AlbumUID).UpdateColumn("parent_uid", m.ParentUID).Error
+}
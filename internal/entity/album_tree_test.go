@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testAlbumTree(t *testing.T, titles ...string) (result Albums) {
+	parentUID := ""
+
+	for _, title := range titles {
+		a := NewAlbum(title, AlbumDefault)
+		a.ParentUID = parentUID
+
+		if err := a.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		parentUID = a.AlbumUID
+		result = append(result, *a)
+	}
+
+	return result
+}
+
+func TestAlbum_SetParent(t *testing.T) {
+	albums := testAlbumTree(t, "Tree Root", "Tree Branch", "Tree Leaf")
+	root, branch, leaf := albums[0], albums[1], albums[2]
+
+	t.Run("success", func(t *testing.T) {
+		a := NewAlbum("Tree New", AlbumDefault)
+
+		assert.NoError(t, a.SetParent(leaf.AlbumUID))
+		assert.Equal(t, leaf.AlbumUID, a.ParentUID)
+		assert.NoError(t, a.SetParent(""))
+		assert.Equal(t, "", a.ParentUID)
+	})
+	t.Run("self", func(t *testing.T) {
+		assert.Error(t, root.SetParent(root.AlbumUID))
+	})
+	t.Run("cycle", func(t *testing.T) {
+		assert.Error(t, root.SetParent(leaf.AlbumUID))
+		assert.Error(t, branch.SetParent(leaf.AlbumUID))
+		assert.Equal(t, "", root.ParentUID)
+	})
+	t.Run("invalid uid", func(t *testing.T) {
+		assert.Error(t, leaf.SetParent("foo"))
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Error(t, leaf.SetParent("at9lxuqxpogaaba1"))
+	})
+	t.Run("not a regular album", func(t *testing.T) {
+		a := NewAlbum("Tree Moment", AlbumMoment)
+
+		assert.Error(t, a.SetParent(root.AlbumUID))
+	})
+}
+
+func TestAlbum_Ancestors(t *testing.T) {
+	albums := testAlbumTree(t, "Ancestors Root", "Ancestors Branch", "Ancestors Leaf")
+
+	t.Run("leaf", func(t *testing.T) {
+		result := albums[2].Ancestors()
+
+		if assert.Len(t, result, 2) {
+			assert.Equal(t, albums[0].AlbumUID, result[0].AlbumUID)
+			assert.Equal(t, albums[1].AlbumUID, result[1].AlbumUID)
+		}
+	})
+	t.Run("root", func(t *testing.T) {
+		assert.Empty(t, albums[0].Ancestors())
+		assert.Nil(t, albums[0].Parent())
+	})
+}
+
+func TestAlbum_Children(t *testing.T) {
+	albums := testAlbumTree(t, "Children Root", "Children Branch", "Children Leaf")
+
+	result := albums[0].Children()
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, albums[1].AlbumUID, result[0].AlbumUID)
+	}
+
+	assert.Empty(t, albums[2].Children())
+}
+
+func TestAlbumDescendantUIDs(t *testing.T) {
+	albums := testAlbumTree(t, "Descendants Root", "Descendants Branch", "Descendants Leaf")
+
+	assert.ElementsMatch(t, []string{albums[1].AlbumUID, albums[2].AlbumUID}, AlbumDescendantUIDs(albums[0].AlbumUID))
+	assert.Empty(t, AlbumDescendantUIDs(albums[2].AlbumUID))
+	assert.Empty(t, AlbumDescendantUIDs(""))
+}
+
+func TestAlbum_Delete_Nested(t *testing.T) {
+	albums := testAlbumTree(t, "Delete Root", "Delete Branch", "Delete Leaf")
+	branch := albums[1]
+
+	if err := branch.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The leaf album is moved to the root album.
+	result := albums[0].Children()
+
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, albums[2].AlbumUID, result[0].AlbumUID)
+	}
+}
@@ -39,7 +39,7 @@ func (m *Album) SaveAsYaml(fileName string) error {
 	defer albumYamlMutex.Unlock()
 
 	// Write YAML data to file.
-	if err := ioutil.WriteFile(fileName, data, os.ModePerm); err != nil {
+	if err := fs.WriteFile(fileName, data, os.ModePerm); err != nil {
 		return err
 	}
 
@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Audit log outcomes.
+const (
+	AuditSuccess = "success"
+	AuditFailed  = "failed"
+	AuditDenied  = "denied"
+)
+
+// AuditSystem is the actor name of background workers and commands.
+const AuditSystem = "system"
+
+type AuditLogs []AuditLog
+
+// AuditLog represents a user or system action that is relevant for security, e.g. a login or deletion.
+type AuditLog struct {
+	ID           uint      `gorm:"primary_key" json:"ID" yaml:"ID"`
+	AuditTime    time.Time `sql:"index" json:"Time" yaml:"Time"`
+	ActorUID     string    `gorm:"type:VARBINARY(42);index;" json:"ActorUID" yaml:"ActorUID,omitempty"`
+	ActorName    string    `gorm:"type:VARCHAR(255);index;" json:"ActorName" yaml:"ActorName,omitempty"`
+	ClientIP     string    `gorm:"type:VARBINARY(64);" json:"ClientIP" yaml:"ClientIP,omitempty"`
+	AuditAction  string    `gorm:"type:VARBINARY(64);" json:"Action" yaml:"Action"`
+	Resource     string    `gorm:"type:VARBINARY(64);" json:"Resource" yaml:"Resource,omitempty"`
+	ResourceUID  string    `gorm:"type:VARBINARY(255);index;" json:"ResourceUID" yaml:"ResourceUID,omitempty"`
+	AuditOutcome string    `gorm:"type:VARBINARY(16);" json:"Outcome" yaml:"Outcome"`
+	AuditMessage string    `gorm:"type:VARCHAR(1024);" json:"Message" yaml:"Message,omitempty"`
+}
+
+// TableName returns the entity database table name.
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// Create inserts the audit log entry to the database.
+func (m *AuditLog) Create() error {
+	if m.AuditTime.IsZero() {
+		m.AuditTime = TimeStamp()
+	}
+
+	if m.AuditOutcome == "" {
+		m.AuditOutcome = AuditSuccess
+	}
+
+	m.ActorName = txt.Clip(m.ActorName, 255)
+	m.ResourceUID = txt.Clip(m.ResourceUID, 255)
+	m.AuditMessage = txt.Clip(m.AuditMessage, 1024)
+
+	return Db().Create(m).Error
+}
+
+// Audit adds an entry to the audit log and logs errors instead of returning them.
+func Audit(m AuditLog) {
+	if err := m.Create(); err != nil {
+		log.Errorf("audit: %s (%s %s)", err, m.AuditAction, txt.Quote(m.ResourceUID))
+	}
+}
+
+// PruneAuditLogs removes entries older than maxAge and keeps at most maxEntries, zero values disable the limit.
+func PruneAuditLogs(maxAge time.Duration, maxEntries int) (deleted int64, err error) {
+	if maxAge > 0 {
+		res := UnscopedDb().Where("audit_time < ?", TimeStamp().Add(-1*maxAge)).Delete(AuditLog{})
+
+		if res.Error != nil {
+			return deleted, res.Error
+		}
+
+		deleted += res.RowsAffected
+	}
+
+	if maxEntries > 0 {
+		var keep AuditLogs
+
+		// Find the oldest entry that should be kept.
+		if err := UnscopedDb().Order("id DESC").Offset(maxEntries - 1).Limit(1).Find(&keep).Error; err != nil {
+			return deleted, err
+		} else if len(keep) == 0 {
+			return deleted, nil
+		}
+
+		res := UnscopedDb().Where("id < ?", keep[0].ID).Delete(AuditLog{})
+
+		if res.Error != nil {
+			return deleted, res.Error
+		}
+
+		deleted += res.RowsAffected
+	}
+
+	return deleted, nil
+}
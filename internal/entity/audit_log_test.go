@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLog_Create(t *testing.T) {
+	m := AuditLog{ActorName: "admin", AuditAction: "login", Resource: "users"}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, m.ID)
+	assert.False(t, m.AuditTime.IsZero())
+	assert.Equal(t, AuditSuccess, m.AuditOutcome)
+}
+
+func TestPruneAuditLogs(t *testing.T) {
+	Audit(AuditLog{AuditTime: TimeStamp().Add(-48 * time.Hour), ActorName: AuditSystem, AuditAction: "delete"})
+
+	for i := 0; i < 3; i++ {
+		Audit(AuditLog{ActorName: AuditSystem, AuditAction: "delete"})
+	}
+
+	deleted, err := PruneAuditLogs(24*time.Hour, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, deleted, int64(1))
+
+	deleted, err = PruneAuditLogs(0, 2)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, deleted, int64(1))
+
+	var count int
+
+	if err := Db().Model(AuditLog{}).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, count)
+}
@@ -131,6 +131,19 @@ func FirstOrCreateCamera(m *Camera) *Camera {
 	return &UnknownCamera
 }
 
+// FindCamera returns the camera with the given slug, or nil if it was not found.
+func FindCamera(slug string) *Camera {
+	result := Camera{}
+
+	if slug == "" {
+		return nil
+	} else if err := Db().Where("camera_slug = ?", slug).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
 // String returns an identifier that can be used in logs.
 func (m *Camera) String() string {
 	return m.CameraName
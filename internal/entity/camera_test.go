@@ -98,6 +98,22 @@ func TestNewCamera(t *testing.T) {
 	})
 }
 
+func TestFindCamera(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		result := FindCamera("canon-eos-5d")
+
+		if result == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, "canon-eos-5d", result.CameraSlug)
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Nil(t, FindCamera("xxx-not-existing"))
+		assert.Nil(t, FindCamera(""))
+	})
+}
+
 func TestCamera_String(t *testing.T) {
 	t.Run("model XXX make Nikon", func(t *testing.T) {
 		camera := NewCamera("XXX", "Nikon")
@@ -25,6 +25,8 @@ type Details struct {
 	CopyrightSrc string    `gorm:"type:VARBINARY(8);" json:"CopyrightSrc" yaml:"CopyrightSrc,omitempty"`
 	License      string    `gorm:"type:VARCHAR(255);" json:"License" yaml:"License,omitempty"`
 	LicenseSrc   string    `gorm:"type:VARBINARY(8);" json:"LicenseSrc" yaml:"LicenseSrc,omitempty"`
+	Text         string    `gorm:"type:TEXT;" json:"Text" yaml:"Text,omitempty"`
+	TextSrc      string    `gorm:"type:VARBINARY(8);" json:"TextSrc" yaml:"TextSrc,omitempty"`
 	CreatedAt    time.Time `yaml:"-"`
 	UpdatedAt    time.Time `yaml:"-"`
 }
@@ -104,6 +106,11 @@ func (m *Details) NoLicense() bool {
 	return m.License == ""
 }
 
+// NoText tests if the photo has no recognized Text.
+func (m *Details) NoText() bool {
+	return m.Text == ""
+}
+
 // HasKeywords tests if the photo has a Keywords.
 func (m *Details) HasKeywords() bool {
 	return !m.NoKeywords()
@@ -134,6 +141,22 @@ func (m *Details) HasLicense() bool {
 	return !m.NoLicense()
 }
 
+// HasText tests if the photo has a recognized Text.
+func (m *Details) HasText() bool {
+	return !m.NoText()
+}
+
+// SetText updates the recognized text, an empty value is stored so that the source shows it has been processed.
+func (m *Details) SetText(data, src string) {
+	if (SrcPriority[src] < SrcPriority[m.TextSrc]) && m.HasText() {
+		// Ignore if priority is lower and text already exists.
+		return
+	}
+
+	m.Text = txt.Clip(data, txt.ClipDescription)
+	m.TextSrc = src
+}
+
 // SetKeywords updates the photo details field.
 func (m *Details) SetKeywords(data, src string) {
 	val := txt.Clip(data, txt.ClipDescription)
@@ -63,6 +63,7 @@ var DetailsFixtures = DetailsMap{
 		Artist:       "Jens Mander",
 		Copyright:    "Copyright 2020",
 		License:      "n/a",
+		Text:         "Friedrichsbrücke\nBaujahr 1893",
 		CreatedAt:    TimeStamp(),
 		UpdatedAt:    TimeStamp(),
 		KeywordsSrc:  "meta",
@@ -71,5 +72,6 @@ var DetailsFixtures = DetailsMap{
 		ArtistSrc:    "meta",
 		CopyrightSrc: "manual",
 		LicenseSrc:   "manual",
+		TextSrc:      "ocr",
 	},
 }
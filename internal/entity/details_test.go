@@ -213,6 +213,31 @@ func TestDetails_SetKeywords(t *testing.T) {
 	})
 }
 
+func TestDetails_SetText(t *testing.T) {
+	t.Run("no text", func(t *testing.T) {
+		details := &Details{PhotoID: 123}
+		assert.True(t, details.NoText())
+
+		details.SetText("", SrcOcr)
+		assert.False(t, details.HasText())
+		assert.Equal(t, SrcOcr, details.TextSrc)
+	})
+	t.Run("new text", func(t *testing.T) {
+		details := &Details{PhotoID: 123, Text: "Total 12.99", TextSrc: SrcOcr}
+
+		details.SetText("Grand Total 14.99", SrcOcr)
+		assert.Equal(t, "Grand Total 14.99", details.Text)
+		assert.True(t, details.HasText())
+	})
+	t.Run("manual text has priority", func(t *testing.T) {
+		details := &Details{PhotoID: 123, Text: "Meeting Notes", TextSrc: SrcManual}
+
+		details.SetText("Meetlng Notes", SrcOcr)
+		assert.Equal(t, "Meeting Notes", details.Text)
+		assert.Equal(t, SrcManual, details.TextSrc)
+	})
+}
+
 func TestDetails_SetSubject(t *testing.T) {
 	t.Run("no subject", func(t *testing.T) {
 		description := &Details{PhotoID: 123, Subject: ""}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"encoding/json"
+	"math"
 	"strings"
 
 	"github.com/montanaflynn/stats"
@@ -58,28 +59,64 @@ func EmbeddingsMidpoint(embeddings Embeddings) (result Embedding, radius float64
 	return result, radius, count
 }
 
-// UnmarshalEmbeddings parses face embedding JSON.
+// UnmarshalEmbeddings parses embeddings JSON.
 func UnmarshalEmbeddings(s string) (result Embeddings) {
 	if !strings.HasPrefix(s, "[[") {
 		return nil
 	}
 
 	if err := json.Unmarshal([]byte(s), &result); err != nil {
-		log.Errorf("faces: %s", err)
+		log.Errorf("embeddings: %s", err)
 	}
 
 	return result
 }
 
-// UnmarshalEmbedding parses a single face embedding JSON.
+// UnmarshalEmbedding parses a single embedding JSON.
 func UnmarshalEmbedding(s string) (result Embedding) {
 	if !strings.HasPrefix(s, "[") {
 		return nil
 	}
 
 	if err := json.Unmarshal([]byte(s), &result); err != nil {
-		log.Errorf("faces: %s", err)
+		log.Errorf("embeddings: %s", err)
 	}
 
 	return result
 }
+
+// MarshalEmbedding returns the JSON representation of an embedding.
+func MarshalEmbedding(e Embedding) (json.RawMessage, error) {
+	return json.Marshal(e)
+}
+
+// EmbeddingDistance returns the Euclidean distance between two embeddings.
+func EmbeddingDistance(a, b Embedding) float64 {
+	if len(a) != len(b) {
+		return -1
+	}
+
+	return clusters.EuclideanDistance(a, b)
+}
+
+// CosineDistance returns the cosine distance between two embeddings,
+// ranging from 0 for vectors pointing in the same direction to 2 for opposite vectors.
+func CosineDistance(a, b Embedding) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return -1
+	}
+
+	var dot, na, nb float64
+
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+
+	if na == 0 || nb == 0 {
+		return -1
+	}
+
+	return 1 - dot/(math.Sqrt(na)*math.Sqrt(nb))
+}
@@ -93,3 +93,39 @@ func TestUnmarshalEmbeddings(t *testing.T) {
 		assert.Equal(t, [][]float64{{0, 0}}, r)
 	})
 }
+
+func TestMarshalEmbedding(t *testing.T) {
+	r, err := MarshalEmbedding(Embedding{-0.013, -0.031})
+	assert.Nil(t, err)
+	assert.Equal(t, "[-0.013,-0.031]", string(r))
+}
+
+func TestEmbeddingDistance(t *testing.T) {
+	t.Run("same", func(t *testing.T) {
+		assert.Equal(t, 0.0, EmbeddingDistance(Embedding{1, 2}, Embedding{1, 2}))
+	})
+	t.Run("different", func(t *testing.T) {
+		assert.Equal(t, 5.0, EmbeddingDistance(Embedding{0, 0}, Embedding{3, 4}))
+	})
+	t.Run("dimension mismatch", func(t *testing.T) {
+		assert.Equal(t, -1.0, EmbeddingDistance(Embedding{0, 0}, Embedding{3}))
+	})
+}
+
+func TestCosineDistance(t *testing.T) {
+	t.Run("same direction", func(t *testing.T) {
+		assert.InDelta(t, 0.0, CosineDistance(Embedding{1, 1}, Embedding{2, 2}), 0.000001)
+	})
+	t.Run("orthogonal", func(t *testing.T) {
+		assert.InDelta(t, 1.0, CosineDistance(Embedding{1, 0}, Embedding{0, 1}), 0.000001)
+	})
+	t.Run("opposite", func(t *testing.T) {
+		assert.InDelta(t, 2.0, CosineDistance(Embedding{1, 0}, Embedding{-1, 0}), 0.000001)
+	})
+	t.Run("zero vector", func(t *testing.T) {
+		assert.Equal(t, -1.0, CosineDistance(Embedding{0, 0}, Embedding{1, 0}))
+	})
+	t.Run("dimension mismatch", func(t *testing.T) {
+		assert.Equal(t, -1.0, CosineDistance(Embedding{1, 0}, Embedding{1}))
+	})
+}
@@ -48,16 +48,25 @@ var Entities = Types{
 	"countries":           &Country{},
 	"albums":              &Album{},
 	"photos_albums":       &PhotoAlbum{},
+	"albums_followers":    &AlbumFollower{},
 	"labels":              &Label{},
 	"categories":          &Category{},
 	"photos_labels":       &PhotoLabel{},
 	"keywords":            &Keyword{},
 	"photos_keywords":     &PhotoKeyword{},
+	"tags":                &Tag{},
+	"photos_tags":         &PhotoTag{},
+	"photos_edits":        &PhotoEdit{},
+	"audit_logs":          &AuditLog{},
+	"webhooks":            &Webhook{},
+	"webhooks_deliveries": &WebhookDelivery{},
 	"passwords":           &Password{},
 	"links":               &Link{},
+	"files_embeddings":    &FileEmbedding{},
 	Subject{}.TableName(): &Subject{},
 	Face{}.TableName():    &Face{},
 	Marker{}.TableName():  &Marker{},
+	"subjects_negatives":  &SubjectNegative{},
 }
 
 type RowCount struct {
@@ -24,6 +24,7 @@ type Face struct {
 	SubjUID         string          `gorm:"type:VARBINARY(42);index;" json:"SubjUID" yaml:"SubjUID,omitempty"`
 	Samples         int             `json:"Samples" yaml:"Samples,omitempty"`
 	SampleRadius    float64         `json:"SampleRadius" yaml:"SampleRadius,omitempty"`
+	MatchRadius     float64         `json:"MatchRadius" yaml:"MatchRadius,omitempty"`
 	Collisions      int             `json:"Collisions" yaml:"Collisions,omitempty"`
 	CollisionRadius float64         `json:"CollisionRadius" yaml:"CollisionRadius,omitempty"`
 	EmbeddingJSON   json.RawMessage `gorm:"type:MEDIUMBLOB;" json:"-" yaml:"EmbeddingJSON,omitempty"`
@@ -64,7 +65,7 @@ func (m *Face) SetEmbeddings(embeddings Embeddings) (err error) {
 		m.SampleRadius = 0.35
 	}
 
-	m.EmbeddingJSON, err = json.Marshal(m.embedding)
+	m.EmbeddingJSON, err = MarshalEmbedding(m.embedding)
 
 	if err != nil {
 		return err
@@ -131,7 +132,7 @@ func (m *Face) Match(embeddings Embeddings) (match bool, dist float64) {
 	case dist < 0:
 		// Should never happen.
 		return false, dist
-	case dist > (m.SampleRadius + face.ClusterRadius):
+	case dist > m.Radius():
 		// Too far.
 		return false, dist
 	case m.CollisionRadius > 0.1 && dist > m.CollisionRadius:
@@ -143,6 +144,16 @@ func (m *Face) Match(embeddings Embeddings) (match bool, dist float64) {
 	return true, dist
 }
 
+// Radius returns the maximum distance of matching embeddings, either learned from
+// confirmed samples or the sample radius plus the default cluster radius.
+func (m *Face) Radius() float64 {
+	if m.MatchRadius > 0 {
+		return m.MatchRadius
+	}
+
+	return m.SampleRadius + face.ClusterRadius
+}
+
 // ResolveCollision resolves a collision with a different subject's face.
 func (m *Face) ResolveCollision(embeddings Embeddings) (resolved bool, err error) {
 	if m.SubjUID == "" {
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/face"
 )
 
 func TestFace_TableName(t *testing.T) {
@@ -11,6 +13,16 @@ func TestFace_TableName(t *testing.T) {
 	assert.Contains(t, m.TableName(), "faces")
 }
 
+func TestFace_Radius(t *testing.T) {
+	m := NewFace("12345", SrcAuto, Embeddings{})
+
+	assert.Equal(t, face.ClusterRadius, m.Radius())
+
+	m.MatchRadius = 0.42
+
+	assert.Equal(t, 0.42, m.Radius())
+}
+
 func TestFace_Match(t *testing.T) {
 	t.Run("1000003-4", func(t *testing.T) {
 		m := FaceFixtures.Get("joe-biden")
@@ -30,6 +42,16 @@ func TestFace_Match(t *testing.T) {
 		assert.Less(t, dist, 1.28)
 	})
 
+	t.Run("MatchRadius", func(t *testing.T) {
+		m := FaceFixtures.Get("joe-biden")
+		m.MatchRadius = 1.2
+		match, dist := m.Match(MarkerFixtures.Pointer("1000003-6").Embeddings())
+
+		assert.False(t, match)
+		assert.Greater(t, dist, 1.27)
+		assert.Less(t, dist, 1.28)
+	})
+
 	t.Run("len(embeddings) == 0", func(t *testing.T) {
 		m := FaceFixtures.Get("joe-biden")
 		match, dist := m.Match(Embeddings{})
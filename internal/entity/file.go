@@ -200,6 +200,8 @@ func (m File) Missing() bool {
 func (m *File) DeletePermanently() error {
 	Db().Unscoped().Delete(FileShare{}, "file_id = ?", m.ID)
 	Db().Unscoped().Delete(FileSync{}, "file_id = ?", m.ID)
+	Db().Unscoped().Delete(FileEmbedding{}, "file_uid = ?", m.FileUID)
+	Db().Unscoped().Delete(PhotoTag{}, "file_id = ?", m.ID)
 
 	return Db().Unscoped().Delete(m).Error
 }
@@ -211,6 +213,7 @@ func (m *File) Delete(permanently bool) error {
 	}
 
 	Db().Delete(File{}, "id = ?", m.ID)
+	Db().Unscoped().Delete(FileEmbedding{}, "file_uid = ?", m.FileUID)
 
 	return Db().Delete(m).Error
 }
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// FileEmbedding represents an image embedding of a primary file for semantic search.
+type FileEmbedding struct {
+	FileUID        string          `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"FileUID" yaml:"FileUID"`
+	PhotoUID       string          `gorm:"type:VARBINARY(42);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	EmbeddingModel string          `gorm:"type:VARBINARY(64);index;" json:"Model" yaml:"Model"`
+	EmbeddingJSON  json.RawMessage `gorm:"type:MEDIUMBLOB;" json:"-" yaml:"EmbeddingJSON,omitempty"`
+	embedding      Embedding       `gorm:"-"`
+	CreatedAt      time.Time       `json:"CreatedAt" yaml:"-"`
+	UpdatedAt      time.Time       `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (FileEmbedding) TableName() string {
+	return "files_embeddings"
+}
+
+// NewFileEmbedding returns a new file embedding.
+func NewFileEmbedding(file File, model string, embedding Embedding) *FileEmbedding {
+	result := &FileEmbedding{
+		FileUID:        file.FileUID,
+		PhotoUID:       file.PhotoUID,
+		EmbeddingModel: model,
+	}
+
+	if err := result.SetEmbedding(embedding); err != nil {
+		log.Errorf("file: failed setting embedding (%s)", err)
+	}
+
+	return result
+}
+
+// SetEmbedding assigns the embedding vector.
+func (m *FileEmbedding) SetEmbedding(embedding Embedding) (err error) {
+	m.embedding = embedding
+	m.EmbeddingJSON, err = MarshalEmbedding(embedding)
+
+	return err
+}
+
+// Embedding returns the parsed embedding vector.
+func (m *FileEmbedding) Embedding() Embedding {
+	if len(m.EmbeddingJSON) == 0 {
+		return Embedding{}
+	} else if len(m.embedding) > 0 {
+		return m.embedding
+	} else if err := json.Unmarshal(m.EmbeddingJSON, &m.embedding); err != nil {
+		log.Errorf("file: failed parsing embedding json (%s)", err)
+	}
+
+	return m.embedding
+}
+
+// Save updates the existing or inserts a new row.
+func (m *FileEmbedding) Save() error {
+	if m.FileUID == "" {
+		return fmt.Errorf("file uid missing")
+	} else if len(m.EmbeddingJSON) == 0 {
+		return fmt.Errorf("embedding missing")
+	}
+
+	return UnscopedDb().Save(m).Error
+}
+
+// FindFileEmbedding returns the embedding of a file, or nil if it doesn't exist.
+func FindFileEmbedding(fileUID string) *FileEmbedding {
+	result := FileEmbedding{}
+
+	if err := Db().Where("file_uid = ?", fileUID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindPhotoEmbedding returns the embedding of the primary file of a photo, or nil if it doesn't exist.
+func FindPhotoEmbedding(photoUID string) *FileEmbedding {
+	result := FileEmbedding{}
+
+	if err := Db().Joins("JOIN files f ON f.file_uid = files_embeddings.file_uid AND f.file_primary = 1 AND f.deleted_at IS NULL").
+		Where("files_embeddings.photo_uid = ?", photoUID).
+		First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
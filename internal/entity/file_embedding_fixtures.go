@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"time"
+)
+
+// EmbeddingModelTest is the model name used for embedding fixtures.
+const EmbeddingModelTest = "test"
+
+type FileEmbeddingMap map[string]FileEmbedding
+
+func (m FileEmbeddingMap) Get(name string) FileEmbedding {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return FileEmbedding{}
+}
+
+func (m FileEmbeddingMap) Pointer(name string) *FileEmbedding {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &FileEmbedding{}
+}
+
+var FileEmbeddingFixtures = FileEmbeddingMap{
+	"bridge.jpg": {
+		FileUID:        FileFixtures.Get("bridge.jpg").FileUID,
+		PhotoUID:       FileFixtures.Get("bridge.jpg").PhotoUID,
+		EmbeddingModel: EmbeddingModelTest,
+		EmbeddingJSON:  []byte("[1,0,0]"),
+		CreatedAt:      time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:      time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+	},
+	"bridge1.jpg": {
+		FileUID:        FileFixtures.Get("bridge1.jpg").FileUID,
+		PhotoUID:       FileFixtures.Get("bridge1.jpg").PhotoUID,
+		EmbeddingModel: EmbeddingModelTest,
+		EmbeddingJSON:  []byte("[0.9,0.1,0]"),
+		CreatedAt:      time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:      time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+	},
+	"bridge2.jpg": {
+		FileUID:        FileFixtures.Get("bridge2.jpg").FileUID,
+		PhotoUID:       FileFixtures.Get("bridge2.jpg").PhotoUID,
+		EmbeddingModel: EmbeddingModelTest,
+		EmbeddingJSON:  []byte("[0,0,1]"),
+		CreatedAt:      time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		UpdatedAt:      time.Date(2020, 3, 28, 14, 6, 0, 0, time.UTC),
+	},
+}
+
+// CreateFileEmbeddingFixtures inserts known entities into the database for testing.
+func CreateFileEmbeddingFixtures() {
+	for _, entity := range FileEmbeddingFixtures {
+		Db().Create(&entity)
+	}
+}
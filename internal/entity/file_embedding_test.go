@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileEmbedding_TableName(t *testing.T) {
+	assert.Equal(t, "files_embeddings", FileEmbedding{}.TableName())
+}
+
+func TestNewFileEmbedding(t *testing.T) {
+	file := File{FileUID: "ft2es39w45bnlqxx", PhotoUID: "pt9jtdre2lvl0yxx"}
+	m := NewFileEmbedding(file, "clip", Embedding{0.5, 0.25})
+
+	assert.Equal(t, "ft2es39w45bnlqxx", m.FileUID)
+	assert.Equal(t, "pt9jtdre2lvl0yxx", m.PhotoUID)
+	assert.Equal(t, "clip", m.EmbeddingModel)
+	assert.Equal(t, "[0.5,0.25]", string(m.EmbeddingJSON))
+	assert.Equal(t, Embedding{0.5, 0.25}, m.Embedding())
+}
+
+func TestFileEmbedding_Embedding(t *testing.T) {
+	t.Run("Fixture", func(t *testing.T) {
+		m := FileEmbeddingFixtures.Get("bridge1.jpg")
+		assert.Equal(t, Embedding{0.9, 0.1, 0}, m.Embedding())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		m := FileEmbedding{}
+		assert.Equal(t, Embedding{}, m.Embedding())
+	})
+}
+
+func TestFileEmbedding_Save(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		file := File{FileUID: "ft2es39w45bnlqe1", PhotoUID: "pt9jtdre2lvl0ye1"}
+		m := NewFileEmbedding(file, EmbeddingModelTest, Embedding{0, 1, 0})
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.SetEmbedding(Embedding{0, 0.5, 0.5}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		found := FindFileEmbedding("ft2es39w45bnlqe1")
+
+		if found == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, Embedding{0, 0.5, 0.5}, found.Embedding())
+	})
+	t.Run("FileUIDMissing", func(t *testing.T) {
+		m := NewFileEmbedding(File{}, EmbeddingModelTest, Embedding{0, 1, 0})
+		assert.Error(t, m.Save())
+	})
+	t.Run("EmbeddingMissing", func(t *testing.T) {
+		m := FileEmbedding{FileUID: "ft2es39w45bnlqe2"}
+		assert.Error(t, m.Save())
+	})
+}
+
+func TestFindFileEmbedding(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		m := FindFileEmbedding("ft2es39w45bnlqdw")
+
+		if m == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, EmbeddingModelTest, m.EmbeddingModel)
+		assert.Equal(t, Embedding{1, 0, 0}, m.Embedding())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindFileEmbedding("ft2es39w45bnlqzz"))
+	})
+}
+
+func TestFindPhotoEmbedding(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		m := FindPhotoEmbedding(FileFixtures.Get("bridge1.jpg").PhotoUID)
+
+		if m == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, "ft2es39q45bnlqd0", m.FileUID)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindPhotoEmbedding("pt9jtdre2lvl0zzz"))
+	})
+}
@@ -364,6 +364,23 @@ func TestFile_Delete(t *testing.T) {
 
 		assert.Nil(t, err2)
 	})
+	t.Run("embedding", func(t *testing.T) {
+		file := &File{FileType: "jpg", FileSize: 500, FileName: "ToBeDeletedEmbedding", FileRoot: "", PhotoID: 5678, PhotoUID: "pt9jtdre2lvl0ye2"}
+
+		if err := file.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := NewFileEmbedding(*file, EmbeddingModelTest, Embedding{1, 0, 0}).Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := file.Delete(false); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindFileEmbedding(file.FileUID))
+	})
 }
 
 func TestPrimaryFile(t *testing.T) {
@@ -27,6 +27,8 @@ func CreateTestFixtures() {
 	CreateSubjectFixtures()
 	CreateMarkerFixtures()
 	CreateFaceFixtures()
+	CreateSubjectNegativeFixtures()
+	CreateFileEmbeddingFixtures()
 	CreateUserFixtures()
 	CreatePasswordFixtures()
 }
@@ -186,6 +186,32 @@ func FindValidLinks(token, share string) (result Links) {
 	return result
 }
 
+// SharedUIDs returns the shared entity UID, including nested albums if an album is shared.
+func (m *Link) SharedUIDs() []string {
+	if m.ShareUID == "" {
+		return []string{}
+	}
+
+	result := []string{m.ShareUID}
+
+	if rnd.IsPPID(m.ShareUID, 'a') {
+		result = append(result, AlbumDescendantUIDs(m.ShareUID)...)
+	}
+
+	return result
+}
+
+// Shares tests if the link grants access to the entity with the given UID.
+func (m *Link) Shares(uid string) bool {
+	for _, shared := range m.SharedUIDs() {
+		if shared == uid {
+			return true
+		}
+	}
+
+	return false
+}
+
 // String returns an human readable identifier for logging.
 func (m *Link) String() string {
 	return m.LinkUID
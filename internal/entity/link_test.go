@@ -195,3 +195,19 @@ func TestLink_String(t *testing.T) {
 		assert.Equal(t, uid, link.String())
 	})
 }
+
+func TestLink_SharedUIDs(t *testing.T) {
+	t.Run("album", func(t *testing.T) {
+		albums := testAlbumTree(t, "Shared Root", "Shared Child")
+		link := NewLink(albums[0].AlbumUID, false, false)
+
+		assert.Equal(t, []string{albums[0].AlbumUID, albums[1].AlbumUID}, link.SharedUIDs())
+		assert.True(t, link.Shares(albums[1].AlbumUID))
+		assert.False(t, link.Shares("at9lxuqxpogaaba1"))
+	})
+	t.Run("empty", func(t *testing.T) {
+		link := Link{}
+
+		assert.Empty(t, link.SharedUIDs())
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/photoprism/photoprism/pkg/rnd"
 
 	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -127,6 +128,8 @@ func (m *Marker) SaveForm(f form.Marker) (changed bool, err error) {
 	}
 
 	if f.SubjSrc == SrcManual && strings.TrimSpace(f.MarkerName) != "" && f.MarkerName != m.MarkerName {
+		subjUID := m.SubjUID
+
 		m.SubjSrc = SrcManual
 		m.MarkerName = txt.Title(txt.Clip(f.MarkerName, txt.ClipDefault))
 
@@ -134,6 +137,17 @@ func (m *Marker) SaveForm(f form.Marker) (changed bool, err error) {
 			return changed, err
 		}
 
+		// Forget previous rejection of the confirmed subject.
+		if m.SubjUID != "" {
+			if err := Db().Delete(SubjectNegative{}, "subj_uid = ? AND marker_uid = ?", m.SubjUID, m.MarkerUID).Error; err != nil {
+				return changed, err
+			}
+		}
+
+		if m.SubjUID != subjUID {
+			defer m.PublishSubject()
+		}
+
 		changed = true
 	}
 
@@ -171,6 +185,11 @@ func (m *Marker) SetFace(f *Face, dist float64) (updated bool, err error) {
 		return false, fmt.Errorf("not a face marker")
 	}
 
+	// Subject rejected by the user?
+	if m.SubjSrc == SrcManual && m.SubjUID == "" && RejectedSubject(m.MarkerUID, f.SubjUID) {
+		return false, nil
+	}
+
 	// Any reason we don't want to set a new face for this marker?
 	if m.SubjSrc == SrcAuto || f.SubjUID == "" || m.SubjUID == "" || f.SubjUID == m.SubjUID {
 		// Don't skip if subject wasn't set manually, or subjects match.
@@ -249,6 +268,10 @@ func (m *Marker) SetFace(f *Face, dist float64) (updated bool, err error) {
 		return false, nil
 	}
 
+	if m.SubjUID != subjUID {
+		m.PublishSubject()
+	}
+
 	return true, m.RefreshPhotos()
 }
 
@@ -383,6 +406,11 @@ func (m *Marker) ClearSubject(src string) error {
 		m.face = FindFace(m.FaceID)
 	}
 
+	// Remember rejected subject so that it won't be matched again.
+	if src == SrcManual && m.SubjUID != "" {
+		FirstOrCreateSubjectNegative(NewSubjectNegative(m.SubjUID, m.MarkerUID))
+	}
+
 	// Update index & resolve collisions.
 	if err := m.Updates(Values{"MarkerName": "", "FaceID": "", "FaceDist": -1.0, "SubjUID": "", "SubjSrc": src}); err != nil {
 		return err
@@ -473,6 +501,32 @@ func (m *Marker) RefreshPhotos() error {
 		gorm.Expr(Marker{}.TableName()), m.MarkerUID).Error
 }
 
+// PublishSubject notifies subscribers like webhooks that a person was found in a photo.
+func (m *Marker) PublishSubject() {
+	subj := m.Subject()
+
+	if subj == nil || !subj.IsPerson() {
+		return
+	}
+
+	var photoUIDs []string
+
+	if err := Db().Model(&File{}).Where("file_uid = ?", m.FileUID).Pluck("photo_uid", &photoUIDs).Error; err != nil {
+		log.Errorf("marker: %s (find photo)", err)
+		return
+	} else if len(photoUIDs) == 0 {
+		return
+	}
+
+	event.Publish("people.photos.added", event.Data{
+		"uid":    subj.SubjUID,
+		"name":   subj.SubjName,
+		"src":    m.SubjSrc,
+		"marker": m.MarkerUID,
+		"photos": photoUIDs,
+	})
+}
+
 // Matched updates the match timestamp.
 func (m *Marker) Matched() error {
 	m.MatchedAt = TimePointer()
@@ -273,6 +273,28 @@ func TestMarker_ClearSubject(t *testing.T) {
 		assert.Equal(t, "", FindMarker("mt9k3pw1wowu1002").FaceID)
 		assert.Equal(t, int(1), FindFace("PI6A2XGOTUXEFI7CBF4KCI5I2I3JEJHS").Collisions)
 	})
+	t.Run("manual", func(t *testing.T) {
+		m := NewMarker(FileFixtures.Get("exampleFileName.jpg"), testArea, "jqy3y652h8njw0sx", SrcManual, MarkerFace)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, RejectedSubject(m.MarkerUID, "jqy3y652h8njw0sx"))
+
+		if err := m.ClearSubject(SrcManual); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, m.SubjUID)
+		assert.True(t, RejectedSubject(m.MarkerUID, "jqy3y652h8njw0sx"))
+
+		updated, err := m.SetFace(&Face{ID: "TEST", SubjUID: "jqy3y652h8njw0sx"}, 0.1)
+
+		assert.NoError(t, err)
+		assert.False(t, updated)
+		assert.Empty(t, m.FaceID)
+	})
 }
 
 func TestMarker_ClearFace(t *testing.T) {
@@ -43,6 +43,19 @@ func (m Markers) FaceCount() (faces int) {
 	return faces
 }
 
+// Embeddings returns the embeddings of all valid face markers.
+func (m Markers) Embeddings() (result Embeddings) {
+	for _, marker := range m {
+		if marker.MarkerInvalid || marker.MarkerType != MarkerFace {
+			continue
+		}
+
+		result = append(result, marker.Embeddings()...)
+	}
+
+	return result
+}
+
 // SubjectNames returns known subject names.
 func (m Markers) SubjectNames() (names []string) {
 	for _, marker := range m {
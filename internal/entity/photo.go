@@ -61,6 +61,11 @@ type Photo struct {
 	PhotoPrivate     bool         `json:"Private" yaml:"Private,omitempty"`
 	PhotoScan        bool         `json:"Scan" yaml:"Scan,omitempty"`
 	PhotoPanorama    bool         `json:"Panorama" yaml:"Panorama,omitempty"`
+	PhotoRating      int8         `json:"Rating" yaml:"Rating,omitempty"`
+	PhotoPick        bool         `json:"Pick" yaml:"Pick,omitempty"`
+	PhotoReject      bool         `json:"Reject" yaml:"Reject,omitempty"`
+	PhotoColorLabel  string       `gorm:"type:VARBINARY(16);default:'';" json:"ColorLabel" yaml:"ColorLabel,omitempty"`
+	RatingSrc        string       `gorm:"type:VARBINARY(8);" json:"RatingSrc" yaml:"RatingSrc,omitempty"`
 	TimeZone         string       `gorm:"type:VARBINARY(64);" json:"TimeZone" yaml:"TimeZone,omitempty"`
 	PlaceID          string       `gorm:"type:VARBINARY(42);index;default:'zz'" json:"PlaceID" yaml:"-"`
 	PlaceSrc         string       `gorm:"type:VARBINARY(8);" json:"PlaceSrc" yaml:"PlaceSrc,omitempty"`
@@ -99,6 +104,7 @@ type Photo struct {
 	EditedAt         *time.Time   `yaml:"EditedAt,omitempty"`
 	CheckedAt        *time.Time   `sql:"index" yaml:"-"`
 	DeletedAt        *time.Time   `sql:"index" yaml:"DeletedAt,omitempty"`
+	EditedBy         string       `gorm:"-" json:"-" yaml:"-"`
 }
 
 // TableName returns the entity database table name.
@@ -238,11 +244,22 @@ func (m *Photo) Create() error {
 	return nil
 }
 
-// Save updates an existing photo or inserts a new one.
+// Save updates an existing photo or inserts a new one, changes by users and from metadata are recorded.
 func (m *Photo) Save() error {
 	photoMutex.Lock()
 	defer photoMutex.Unlock()
 
+	var old *Photo
+
+	// Changes while indexing are recorded as well, so that they can be reverted.
+	if m.HasID() {
+		old = &Photo{}
+
+		if err := UnscopedDb().Preload("Details").First(old, "id = ?", m.ID).Error; err != nil {
+			old = nil
+		}
+	}
+
 	if err := Save(m, "ID", "PhotoUID"); err != nil {
 		return err
 	}
@@ -251,6 +268,10 @@ func (m *Photo) Save() error {
 		return err
 	}
 
+	if old != nil {
+		m.SaveEdits(*old)
+	}
+
 	return m.ResolvePrimary()
 }
 
@@ -419,6 +440,7 @@ func (m *Photo) IndexKeywords() error {
 	keywords = append(keywords, txt.Words(details.Keywords)...)
 	keywords = append(keywords, txt.Keywords(details.Subject)...)
 	keywords = append(keywords, txt.Keywords(details.Artist)...)
+	keywords = append(keywords, txt.Keywords(details.Text)...)
 
 	keywords = txt.UniqueWords(keywords)
 
@@ -941,9 +963,12 @@ func (m *Photo) Delete(permanently bool) error {
 // Delete permanently deletes the entity from the database.
 func (m *Photo) DeletePermanently() error {
 	Db().Unscoped().Delete(File{}, "photo_id = ?", m.ID)
+	Db().Unscoped().Delete(FileEmbedding{}, "photo_uid = ?", m.PhotoUID)
 	Db().Unscoped().Delete(Details{}, "photo_id = ?", m.ID)
 	Db().Unscoped().Delete(PhotoKeyword{}, "photo_id = ?", m.ID)
 	Db().Unscoped().Delete(PhotoLabel{}, "photo_id = ?", m.ID)
+	Db().Unscoped().Delete(PhotoTag{}, "photo_id = ?", m.ID)
+	Db().Unscoped().Delete(PhotoEdit{}, "photo_uid = ?", m.PhotoUID)
 	Db().Unscoped().Delete(PhotoAlbum{}, "photo_uid = ?", m.PhotoUID)
 
 	return Db().Unscoped().Delete(m).Error
@@ -966,6 +991,7 @@ func (m *Photo) Updates(values interface{}) error {
 
 // SetFavorite updates the favorite status of a photo.
 func (m *Photo) SetFavorite(favorite bool) error {
+	old := *m
 	changed := m.PhotoFavorite != favorite
 	m.PhotoFavorite = favorite
 	m.PhotoQuality = m.QualityScore()
@@ -974,6 +1000,8 @@ func (m *Photo) SetFavorite(favorite bool) error {
 		return err
 	}
 
+	m.SaveEdits(old)
+
 	// Update counters if changed and not deleted.
 	if changed && m.PhotoPrivate == false && m.DeletedAt == nil {
 		if favorite {
@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SaveBatchEdit applies a partial edit form and saves the photo if anything changed.
+// Values from a source with higher priority, e.g. manual edits, remain unchanged.
+func (m *Photo) SaveBatchEdit(f form.BatchEdit, source string) (changed bool, err error) {
+	if !m.HasID() {
+		return false, errors.New("photo: can't save batch edit, id is empty")
+	}
+
+	offset, err := f.Offset()
+
+	if err != nil {
+		return false, err
+	}
+
+	details := m.GetDetails()
+	photoBefore, detailsBefore := *m, *details
+
+	if f.PhotoTitle != nil {
+		m.SetTitle(*f.PhotoTitle, source)
+	}
+
+	if f.PhotoDescription != nil {
+		m.SetDescription(*f.PhotoDescription, source)
+	}
+
+	if f.PhotoLat != nil && f.PhotoLng != nil {
+		altitude := m.PhotoAltitude
+
+		if f.PhotoAltitude != nil {
+			altitude = *f.PhotoAltitude
+		}
+
+		m.SetCoordinates(*f.PhotoLat, *f.PhotoLng, altitude, source)
+	} else if f.PhotoAltitude != nil && SrcPriority[source] >= SrcPriority[m.PlaceSrc] {
+		m.PhotoAltitude = *f.PhotoAltitude
+	}
+
+	if f.PhotoCountry != nil && SrcPriority[source] >= SrcPriority[m.PlaceSrc] {
+		if country := strings.ToLower(txt.Clip(*f.PhotoCountry, 2)); len(country) == 2 {
+			m.PhotoCountry = country
+			m.PlaceSrc = source
+		}
+	}
+
+	if offset != 0 {
+		m.SetTakenAt(m.TakenAt.Add(offset), m.TakenAtLocal.Add(offset), "", source)
+	}
+
+	if f.Keywords != nil {
+		details.SetKeywords(*f.Keywords, source)
+	}
+
+	if f.Subject != nil {
+		details.SetSubject(*f.Subject, source)
+	}
+
+	if f.Artist != nil {
+		details.SetArtist(*f.Artist, source)
+	}
+
+	if f.Copyright != nil {
+		details.SetCopyright(*f.Copyright, source)
+	}
+
+	if f.License != nil {
+		details.SetLicense(*f.License, source)
+	}
+
+	locChanged := m.PhotoLat != photoBefore.PhotoLat || m.PhotoLng != photoBefore.PhotoLng
+	photoChanged := locChanged || m.PhotoTitle != photoBefore.PhotoTitle ||
+		m.PhotoDescription != photoBefore.PhotoDescription || m.PhotoAltitude != photoBefore.PhotoAltitude ||
+		m.PhotoCountry != photoBefore.PhotoCountry || !m.TakenAt.Equal(photoBefore.TakenAt)
+	detailsChanged := details.Keywords != detailsBefore.Keywords || details.Subject != detailsBefore.Subject ||
+		details.Artist != detailsBefore.Artist || details.Copyright != detailsBefore.Copyright ||
+		details.License != detailsBefore.License
+
+	if !photoChanged && !detailsChanged {
+		return false, nil
+	}
+
+	if locChanged {
+		locKeywords, labels := m.UpdateLocation()
+
+		m.AddLabels(labels)
+
+		w := txt.UniqueWords(txt.Words(details.Keywords))
+		w = append(w, locKeywords...)
+
+		details.Keywords = strings.Join(txt.UniqueWords(w), ", ")
+	}
+
+	if err := m.SyncKeywordLabels(); err != nil {
+		log.Errorf("photo: %s", err)
+	}
+
+	if err := m.IndexKeywords(); err != nil {
+		log.Errorf("photo: %s", err)
+	}
+
+	edited := TimeStamp()
+	m.EditedAt = &edited
+	m.PhotoQuality = m.QualityScore()
+
+	if err := m.Save(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
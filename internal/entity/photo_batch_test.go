@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_SaveBatchEdit(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := Photo{PhotoTitle: "Old Title", TitleSrc: SrcMeta, TakenAt: time.Date(2020, 5, 31, 23, 30, 0, 0, time.UTC), TakenAtLocal: time.Date(2020, 5, 31, 23, 30, 0, 0, time.UTC), TakenSrc: SrcMeta}
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		title := "New Title"
+
+		changed, err := m.SaveBatchEdit(form.BatchEdit{PhotoTitle: &title, TakenOffset: "1h"}, SrcBatch)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, changed)
+		assert.Equal(t, "New Title", m.PhotoTitle)
+		assert.Equal(t, SrcBatch, m.TitleSrc)
+		assert.Equal(t, 6, m.PhotoMonth)
+		assert.Equal(t, 1, m.PhotoDay)
+		assert.NotNil(t, m.EditedAt)
+	})
+	t.Run("manual priority", func(t *testing.T) {
+		m := Photo{PhotoTitle: "Manual Title", TitleSrc: SrcManual}
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		title := "New Title"
+
+		changed, err := m.SaveBatchEdit(form.BatchEdit{PhotoTitle: &title}, SrcBatch)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, changed)
+		assert.Equal(t, "Manual Title", m.PhotoTitle)
+	})
+	t.Run("no id", func(t *testing.T) {
+		m := Photo{}
+		title := "New Title"
+
+		_, err := m.SaveBatchEdit(form.BatchEdit{PhotoTitle: &title}, SrcBatch)
+
+		assert.Error(t, err)
+	})
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -82,6 +83,41 @@ func UpdateSubjectFileCounts() (err error) {
 	return nil
 }
 
+// UpdatePhotoFaceCounts updates the number of faces of photos with markers of the given subjects or faces.
+func UpdatePhotoFaceCounts(subjUIDs, faceIDs []string) (err error) {
+	if len(subjUIDs) == 0 && len(faceIDs) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+
+	var cond []string
+	var values []interface{}
+
+	if len(subjUIDs) > 0 {
+		cond = append(cond, "m.subj_uid IN (?)")
+		values = append(values, subjUIDs)
+	}
+
+	if len(faceIDs) > 0 {
+		cond = append(cond, "m.face_id IN (?)")
+		values = append(values, faceIDs)
+	}
+
+	if err = UnscopedDb().Table("photos").
+		Where(fmt.Sprintf("id IN (SELECT f.photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid WHERE %s)",
+			Marker{}.TableName(), strings.Join(cond, " OR ")), values...).
+		UpdateColumn("photo_faces", gorm.Expr(fmt.Sprintf("(SELECT COUNT(*) FROM files f JOIN %s m ON f.file_uid = m.file_uid "+
+			"WHERE f.photo_id = photos.id AND f.file_primary = 1 AND f.deleted_at IS NULL "+
+			"AND m.marker_type = ? AND m.marker_invalid = 0)", Marker{}.TableName()), MarkerFace)).Error; err != nil {
+		return err
+	}
+
+	log.Debugf("counts: updated photo faces [%s]", time.Since(start))
+
+	return nil
+}
+
 // UpdateLabelPhotoCounts updates the label photo counts.
 func UpdateLabelPhotoCounts() (err error) {
 	start := time.Now()
@@ -23,3 +23,21 @@ func TestUpdatePhotoCounts(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestUpdatePhotoFaceCounts(t *testing.T) {
+	t.Run("Subject", func(t *testing.T) {
+		if err := UpdatePhotoFaceCounts([]string{"jqy1y111h1njaaac"}, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Face", func(t *testing.T) {
+		if err := UpdatePhotoFaceCounts(nil, []string{FaceFixtures.Get("actress-1").ID}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		if err := UpdatePhotoFaceCounts(nil, nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
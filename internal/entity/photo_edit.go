@@ -0,0 +1,395 @@
+package entity
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// DetailsPrefix marks edit fields that belong to the photo details.
+const DetailsPrefix = "Details."
+
+// PhotoEditFields maps tracked photo fields to the fields that store their source.
+var PhotoEditFields = map[string]string{
+	"TakenAt":          "TakenSrc",
+	"TakenAtLocal":     "TakenSrc",
+	"TimeZone":         "TakenSrc",
+	"PhotoType":        "TypeSrc",
+	"PhotoTitle":       "TitleSrc",
+	"PhotoDescription": "DescriptionSrc",
+	"PhotoLat":         "PlaceSrc",
+	"PhotoLng":         "PlaceSrc",
+	"PhotoAltitude":    "PlaceSrc",
+	"PhotoCountry":     "PlaceSrc",
+	"PhotoFavorite":    "",
+	"PhotoPrivate":     "",
+	"PhotoRating":      "RatingSrc",
+	"PhotoPick":        "RatingSrc",
+	"PhotoReject":      "RatingSrc",
+	"PhotoColorLabel":  "RatingSrc",
+}
+
+// PhotoEditGroups lists tracked fields that must be reverted together.
+var PhotoEditGroups = [][]string{
+	{"TakenAt", "TakenAtLocal", "TimeZone"},
+	{"PhotoLat", "PhotoLng", "PhotoAltitude", "PhotoCountry"},
+}
+
+// editGroup returns the fields that must be reverted together with the given field.
+func editGroup(field string) []string {
+	for _, group := range PhotoEditGroups {
+		for _, name := range group {
+			if name == field {
+				return group
+			}
+		}
+	}
+
+	return []string{field}
+}
+
+// DetailsEditFields maps tracked photo details fields to the fields that store their source.
+var DetailsEditFields = map[string]string{
+	"Keywords":  "KeywordsSrc",
+	"Notes":     "NotesSrc",
+	"Subject":   "SubjectSrc",
+	"Artist":    "ArtistSrc",
+	"Copyright": "CopyrightSrc",
+	"License":   "LicenseSrc",
+}
+
+type PhotoEdits []PhotoEdit
+
+// PhotoEdit represents a change of a photo metadata field that can be reverted.
+type PhotoEdit struct {
+	ID        uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	PhotoUID  string    `gorm:"type:VARBINARY(42);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	EditField string    `gorm:"type:VARBINARY(64);" json:"Field" yaml:"Field"`
+	OldValue  string    `gorm:"type:TEXT;" json:"OldValue" yaml:"OldValue"`
+	OldSrc    string    `gorm:"type:VARBINARY(8);" json:"OldSrc" yaml:"OldSrc,omitempty"`
+	NewValue  string    `gorm:"type:TEXT;" json:"NewValue" yaml:"NewValue"`
+	EditSrc   string    `gorm:"type:VARBINARY(8);" json:"Src" yaml:"Src,omitempty"`
+	UserUID   string    `gorm:"type:VARBINARY(42);index;" json:"UserUID,omitempty" yaml:"UserUID,omitempty"`
+	CreatedAt time.Time `gorm:"index;" json:"CreatedAt" yaml:"CreatedAt"`
+}
+
+// TableName returns the entity database table name.
+func (PhotoEdit) TableName() string {
+	return "photos_edits"
+}
+
+// Create inserts the edit to the database.
+func (m *PhotoEdit) Create() error {
+	return Db().Create(m).Error
+}
+
+// FindPhotoEdit returns the edit with the given ID, or nil if it was not found.
+func FindPhotoEdit(id uint) *PhotoEdit {
+	result := PhotoEdit{}
+
+	if id == 0 {
+		return nil
+	} else if err := Db().Where("id = ?", id).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// editValue returns a field value as string.
+func editValue(v reflect.Value) string {
+	switch t := v.Interface().(type) {
+	case time.Time:
+		return t.UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// setEditValue sets a field value from its string representation.
+func setEditValue(v reflect.Value, s string) error {
+	switch v.Interface().(type) {
+	case time.Time:
+		t, err := time.Parse(time.RFC3339, s)
+
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.ValueOf(t.UTC()))
+	case string:
+		v.SetString(s)
+	case bool:
+		v.SetBool(txt.Bool(s))
+	case int, int8, int16, int32, int64:
+		i, err := strconv.ParseInt(s, 10, 64)
+
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(i)
+	case float32, float64:
+		f, err := strconv.ParseFloat(s, 64)
+
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+// diffEdits returns the changed fields of two structs.
+func diffEdits(photoUID, prefix string, fields map[string]string, old, cur reflect.Value) (result PhotoEdits) {
+	for name, srcName := range fields {
+		oldValue, newValue := editValue(old.FieldByName(name)), editValue(cur.FieldByName(name))
+
+		if oldValue == newValue {
+			continue
+		}
+
+		edit := PhotoEdit{
+			PhotoUID:  photoUID,
+			EditField: prefix + name,
+			OldValue:  oldValue,
+			NewValue:  newValue,
+		}
+
+		if srcName != "" {
+			edit.OldSrc = old.FieldByName(srcName).String()
+			edit.EditSrc = cur.FieldByName(srcName).String()
+		}
+
+		result = append(result, edit)
+	}
+
+	return result
+}
+
+// Edits returns the tracked fields that differ from a previous version of the photo.
+func (m *Photo) Edits(old Photo) (result PhotoEdits) {
+	result = diffEdits(m.PhotoUID, "", PhotoEditFields, reflect.ValueOf(old), reflect.ValueOf(*m))
+
+	if m.Details != nil && old.Details != m.Details {
+		oldDetails := old.Details
+
+		// Details may not have been saved before.
+		if oldDetails == nil {
+			oldDetails = &Details{}
+		}
+
+		result = append(result, diffEdits(m.PhotoUID, DetailsPrefix, DetailsEditFields, reflect.ValueOf(*oldDetails), reflect.ValueOf(*m.Details))...)
+	}
+
+	edits := make(PhotoEdits, 0, len(result))
+
+	for _, edit := range result {
+		if m.EditedBy != "" {
+			if edit.EditSrc == SrcAuto {
+				edit.EditSrc = SrcManual
+			}
+		} else if edit.EditSrc != SrcMeta && edit.EditSrc != SrcXmp {
+			// Changes without user are only recorded if they were read from metadata, e.g. when indexing sidecar files.
+			continue
+		}
+
+		edit.UserUID = m.EditedBy
+		edits = append(edits, edit)
+	}
+
+	return edits
+}
+
+// SaveEdits records the tracked fields that differ from a previous version of the photo.
+func (m *Photo) SaveEdits(old Photo) {
+	if !m.HasID() || m.PhotoUID == "" {
+		return
+	}
+
+	// Edits saved together share the same time, so that related fields can be reverted together.
+	createdAt := TimeStamp()
+
+	for _, edit := range m.Edits(old) {
+		edit.CreatedAt = createdAt
+
+		if err := edit.Create(); err != nil {
+			log.Errorf("photo: %s (save edit of %s)", err, edit.EditField)
+		}
+	}
+}
+
+// History returns the recorded edits, newest first.
+func (m *Photo) History() (result PhotoEdits) {
+	if m.PhotoUID == "" {
+		return result
+	}
+
+	if err := Db().Where("photo_uid = ?", m.PhotoUID).Order("id DESC").Find(&result).Error; err != nil {
+		log.Errorf("photo: %s (find edits)", err)
+	}
+
+	return result
+}
+
+// SetEditValue changes a tracked field and its source, e.g. to revert an edit.
+func (m *Photo) SetEditValue(field, value, src string) error {
+	var v reflect.Value
+	var srcName string
+	var ok bool
+
+	if name := strings.TrimPrefix(field, DetailsPrefix); name != field {
+		if srcName, ok = DetailsEditFields[name]; !ok {
+			return fmt.Errorf("photo: field %s can't be edited", txt.Quote(field))
+		}
+
+		v = reflect.ValueOf(m.GetDetails()).Elem()
+		field = name
+	} else if srcName, ok = PhotoEditFields[field]; !ok {
+		return fmt.Errorf("photo: field %s can't be edited", txt.Quote(field))
+	} else {
+		v = reflect.ValueOf(m).Elem()
+	}
+
+	if err := setEditValue(v.FieldByName(field), value); err != nil {
+		return fmt.Errorf("photo: %s (set %s)", err, field)
+	}
+
+	if srcName != "" {
+		v.FieldByName(srcName).SetString(src)
+	}
+
+	return nil
+}
+
+// Revert restores the previous value of the edited field, and of related fields that were changed with it.
+func (m *PhotoEdit) Revert(userUID string) error {
+	photo := Photo{PhotoUID: m.PhotoUID}
+
+	if err := photo.Find(); err != nil {
+		return fmt.Errorf("photo: %s not found", txt.Quote(m.PhotoUID))
+	}
+
+	edits := PhotoEdits{*m}
+
+	if group := editGroup(m.EditField); len(group) > 1 {
+		var related PhotoEdits
+
+		if err := Db().Where("photo_uid = ? AND created_at = ? AND id <> ? AND edit_field IN (?)", m.PhotoUID, m.CreatedAt, m.ID, group).Find(&related).Error; err != nil {
+			return err
+		}
+
+		edits = append(edits, related...)
+	}
+
+	reverted := make(map[string]bool)
+
+	for _, edit := range edits {
+		if err := photo.SetEditValue(edit.EditField, edit.OldValue, edit.OldSrc); err != nil {
+			return err
+		}
+
+		reverted[edit.EditField] = true
+	}
+
+	photo.EditedBy = userUID
+
+	return photo.saveReverted(reverted)
+}
+
+// RevertPhotoEdits restores the values of all fields before the given time and returns the number of changed fields.
+func RevertPhotoEdits(photoUID string, after time.Time, userUID string) (int, error) {
+	var edits PhotoEdits
+
+	if err := Db().Where("photo_uid = ? AND created_at > ?", photoUID, after).Order("id ASC").Find(&edits).Error; err != nil {
+		return 0, err
+	} else if len(edits) == 0 {
+		return 0, nil
+	}
+
+	photo := Photo{PhotoUID: photoUID}
+
+	if err := photo.Find(); err != nil {
+		return 0, fmt.Errorf("photo: %s not found", txt.Quote(photoUID))
+	}
+
+	reverted := make(map[string]bool)
+
+	// The oldest edit of each field after the given time contains its original value.
+	for _, edit := range edits {
+		if reverted[edit.EditField] {
+			continue
+		}
+
+		if err := photo.SetEditValue(edit.EditField, edit.OldValue, edit.OldSrc); err != nil {
+			return 0, err
+		}
+
+		reverted[edit.EditField] = true
+	}
+
+	photo.EditedBy = userUID
+
+	if err := photo.saveReverted(reverted); err != nil {
+		return 0, err
+	}
+
+	return len(reverted), nil
+}
+
+// saveReverted updates the fields that depend on the reverted fields and saves the photo,
+// like SavePhotoForm does after editing.
+func (m *Photo) saveReverted(fields map[string]bool) error {
+	if fields["TakenAt"] || fields["TakenAtLocal"] || fields["TimeZone"] {
+		if m.TakenSrc == SrcManual && !m.TakenAtLocal.IsZero() {
+			m.PhotoYear = m.TakenAtLocal.Year()
+			m.PhotoMonth = int(m.TakenAtLocal.Month())
+			m.PhotoDay = m.TakenAtLocal.Day()
+		} else {
+			m.UpdateDateFields()
+		}
+	}
+
+	details := m.GetDetails()
+
+	if fields["PhotoLat"] || fields["PhotoLng"] || fields["PhotoCountry"] {
+		locKeywords, labels := m.UpdateLocation()
+
+		m.AddLabels(labels)
+
+		w := txt.UniqueWords(txt.Words(details.Keywords))
+		w = append(w, locKeywords...)
+
+		details.Keywords = strings.Join(txt.UniqueWords(w), ", ")
+	}
+
+	if err := m.SyncKeywordLabels(); err != nil {
+		log.Errorf("photo: %s", err)
+	}
+
+	if err := m.IndexKeywords(); err != nil {
+		log.Errorf("photo: %s", err)
+	}
+
+	edited := TimeStamp()
+	m.EditedAt = &edited
+	m.PhotoQuality = m.QualityScore()
+
+	if err := m.Save(); err != nil {
+		return err
+	}
+
+	if err := UpdatePhotoCounts(); err != nil {
+		log.Errorf("photo: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_Edits(t *testing.T) {
+	taken := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	old := Photo{PhotoUID: "pt9jtdre2lvl0e01", TakenAt: taken, PhotoTitle: "Old", TitleSrc: SrcMeta, PhotoLat: 1.5}
+	m := old
+	m.TakenAt = taken.Add(time.Hour)
+	m.TakenSrc = SrcManual
+	m.PhotoTitle = "New"
+	m.TitleSrc = SrcXmp
+	m.PhotoFavorite = true
+	m.EditedBy = "uqxetse3cy5eo9z2"
+
+	edits := m.Edits(old)
+
+	assert.Len(t, edits, 3)
+
+	for _, edit := range edits {
+		assert.Equal(t, "uqxetse3cy5eo9z2", edit.UserUID)
+
+		switch edit.EditField {
+		case "TakenAt":
+			assert.Equal(t, "2020-01-02T03:04:05Z", edit.OldValue)
+			assert.Equal(t, "2020-01-02T04:04:05Z", edit.NewValue)
+			assert.Equal(t, SrcManual, edit.EditSrc)
+		case "PhotoTitle":
+			assert.Equal(t, "Old", edit.OldValue)
+			assert.Equal(t, SrcMeta, edit.OldSrc)
+			assert.Equal(t, SrcXmp, edit.EditSrc)
+		case "PhotoFavorite":
+			assert.Equal(t, "true", edit.NewValue)
+			assert.Equal(t, SrcManual, edit.EditSrc)
+		default:
+			t.Errorf("unexpected field %s", edit.EditField)
+		}
+	}
+}
+
+func TestPhoto_EditsWithoutUser(t *testing.T) {
+	old := Photo{PhotoUID: "pt9jtdre2lvl0e02", PhotoTitle: "Old", TitleSrc: SrcName, PhotoDescription: "Old"}
+	m := old
+	m.PhotoTitle = "New"
+	m.TitleSrc = SrcXmp
+	m.PhotoDescription = "Generated"
+	m.PhotoFavorite = true
+
+	edits := m.Edits(old)
+
+	// Only changes read from metadata are recorded.
+	if assert.Len(t, edits, 1) {
+		assert.Equal(t, "PhotoTitle", edits[0].EditField)
+		assert.Equal(t, SrcXmp, edits[0].EditSrc)
+		assert.Equal(t, SrcName, edits[0].OldSrc)
+		assert.Equal(t, "", edits[0].UserUID)
+	}
+}
+
+func TestPhoto_SetEditValue(t *testing.T) {
+	m := Photo{}
+
+	assert.NoError(t, m.SetEditValue("PhotoLat", "48.51", SrcXmp))
+	assert.Equal(t, float32(48.51), m.PhotoLat)
+	assert.Equal(t, SrcXmp, m.PlaceSrc)
+
+	assert.NoError(t, m.SetEditValue("TakenAt", "2020-01-02T03:04:05Z", SrcMeta))
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), m.TakenAt)
+
+	assert.NoError(t, m.SetEditValue("PhotoPrivate", "true", ""))
+	assert.True(t, m.PhotoPrivate)
+
+	assert.NoError(t, m.SetEditValue("Details.Artist", "Old Artist", SrcMeta))
+	assert.Equal(t, "Old Artist", m.GetDetails().Artist)
+	assert.Equal(t, SrcMeta, m.GetDetails().ArtistSrc)
+
+	assert.Error(t, m.SetEditValue("PhotoPath", "foo", ""))
+	assert.Error(t, m.SetEditValue("Details.Text", "foo", ""))
+	assert.Error(t, m.SetEditValue("PhotoAltitude", "high", ""))
+}
+
+func TestPhotoEdit_Revert(t *testing.T) {
+	m := Photo{PhotoTitle: "Original Title", TitleSrc: SrcMeta, TakenAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), TakenAtLocal: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	m.SetTitle("Changed Title", SrcManual)
+	m.GetDetails().SetArtist("Changed Artist", SrcManual)
+	m.EditedBy = "uqxetse3cy5eo9z2"
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	history := m.History()
+
+	assert.Len(t, history, 2)
+
+	var titleEdit *PhotoEdit
+
+	for i := range history {
+		if history[i].EditField == "PhotoTitle" {
+			titleEdit = FindPhotoEdit(history[i].ID)
+		}
+	}
+
+	if titleEdit == nil {
+		t.Fatal("title edit not found")
+	}
+
+	if err := titleEdit.Revert("uqxetse3cy5eo9z2"); err != nil {
+		t.Fatal(err)
+	}
+
+	p := Photo{PhotoUID: m.PhotoUID}
+
+	if err := p.Find(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Original Title", p.PhotoTitle)
+	assert.Equal(t, SrcMeta, p.TitleSrc)
+	assert.Equal(t, "Changed Artist", p.GetDetails().Artist)
+
+	// Reverting creates a new edit.
+	assert.Len(t, p.History(), 3)
+
+	count, err := RevertPhotoEdits(m.PhotoUID, time.Time{}, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, count)
+
+	p = Photo{PhotoUID: m.PhotoUID}
+
+	if err := p.Find(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Original Title", p.PhotoTitle)
+	assert.Equal(t, "", p.GetDetails().Artist)
+}
+
+func TestPhotoEdit_RevertTakenAt(t *testing.T) {
+	taken := time.Date(2018, 6, 2, 10, 0, 0, 0, time.UTC)
+	m := Photo{PhotoTitle: "Time Travel", TakenAt: taken, TakenAtLocal: taken, TimeZone: "UTC", TakenSrc: SrcMeta}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	m.UpdateDateFields()
+	m.EditedBy = "uqxetse3cy5eo9z2"
+
+	if err := m.ShiftTakenAt(400*24*time.Hour, "Europe/Berlin"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2019, m.PhotoYear)
+
+	var takenEdit *PhotoEdit
+
+	for _, edit := range m.History() {
+		if edit.EditField == "TakenAt" {
+			takenEdit = FindPhotoEdit(edit.ID)
+		}
+	}
+
+	if takenEdit == nil {
+		t.Fatal("date taken edit not found")
+	}
+
+	if err := takenEdit.Revert("uqxetse3cy5eo9z2"); err != nil {
+		t.Fatal(err)
+	}
+
+	p := Photo{PhotoUID: m.PhotoUID}
+
+	if err := p.Find(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Local time and time zone are reverted with the date taken.
+	assert.Equal(t, taken, p.TakenAt.UTC())
+	assert.Equal(t, taken, p.TakenAtLocal.UTC())
+	assert.Equal(t, "UTC", p.TimeZone)
+	assert.Equal(t, SrcMeta, p.TakenSrc)
+	assert.Equal(t, 2018, p.PhotoYear)
+	assert.Equal(t, 6, p.PhotoMonth)
+	assert.Equal(t, 2, p.PhotoDay)
+}
@@ -715,6 +715,10 @@ var PhotoFixtures = PhotoMap{
 		PhotoPrivate:     false,
 		PhotoScan:        false,
 		PhotoPanorama:    false,
+		PhotoRating:      5,
+		PhotoPick:        true,
+		PhotoColorLabel:  "red",
+		RatingSrc:        SrcXmp,
 		TimeZone:         "",
 		Place:            PlaceFixtures.Pointer("emptyNameLongCity"),
 		PlaceID:          PlaceFixtures.Pointer("emptyNameLongCity").ID,
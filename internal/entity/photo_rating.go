@@ -0,0 +1,63 @@
+package entity
+
+import "fmt"
+
+// RatingMax is the maximum number of stars.
+const RatingMax = 5
+
+// SetRating changes the star rating, pick and reject flags, and color label if the source has priority,
+// so that values removed e.g. in Lightroom are cleared as well.
+func (m *Photo) SetRating(rating int, pick, reject bool, colorLabel, source string) {
+	if SrcPriority[source] < SrcPriority[m.RatingSrc] && m.HasRating() {
+		return
+	} else if rating <= 0 && !pick && !reject && colorLabel == "" && !m.HasRating() {
+		return
+	}
+
+	if rating < 0 {
+		rating = 0
+	} else if rating > RatingMax {
+		rating = RatingMax
+	}
+
+	m.PhotoRating = int8(rating)
+	m.PhotoPick = pick && !reject
+	m.PhotoReject = reject
+	m.PhotoColorLabel = colorLabel
+	m.RatingSrc = source
+}
+
+// HasRating returns true if the photo has a star rating, pick or reject flag, or color label.
+func (m *Photo) HasRating() bool {
+	return m.PhotoRating > 0 || m.PhotoPick || m.PhotoReject || m.PhotoColorLabel != ""
+}
+
+// UpdateRating sets the star rating, pick and reject flags, and color label as manually edited.
+func (m *Photo) UpdateRating(rating int, pick, reject bool, colorLabel string) error {
+	if rating < 0 || rating > RatingMax {
+		return fmt.Errorf("photo: rating must be between 0 and %d", RatingMax)
+	}
+
+	old := *m
+	pick = pick && !reject
+
+	if err := m.Updates(Values{
+		"PhotoRating":     int8(rating),
+		"PhotoPick":       pick,
+		"PhotoReject":     reject,
+		"PhotoColorLabel": colorLabel,
+		"RatingSrc":       SrcManual,
+	}); err != nil {
+		return err
+	}
+
+	m.PhotoRating = int8(rating)
+	m.PhotoPick = pick
+	m.PhotoReject = reject
+	m.PhotoColorLabel = colorLabel
+	m.RatingSrc = SrcManual
+
+	m.SaveEdits(old)
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_SetRating(t *testing.T) {
+	t.Run("meta", func(t *testing.T) {
+		m := Photo{}
+
+		m.SetRating(4, true, false, "red", SrcMeta)
+
+		assert.Equal(t, int8(4), m.PhotoRating)
+		assert.True(t, m.PhotoPick)
+		assert.False(t, m.PhotoReject)
+		assert.Equal(t, "red", m.PhotoColorLabel)
+		assert.Equal(t, SrcMeta, m.RatingSrc)
+		assert.True(t, m.HasRating())
+	})
+	t.Run("empty", func(t *testing.T) {
+		m := Photo{PhotoRating: 3, PhotoPick: true, PhotoColorLabel: "red", RatingSrc: SrcMeta}
+
+		// Lower priority sources don't clear values.
+		m.SetRating(0, false, false, "", SrcAuto)
+
+		assert.Equal(t, int8(3), m.PhotoRating)
+		assert.Equal(t, SrcMeta, m.RatingSrc)
+
+		// Values removed in the XMP sidecar are cleared.
+		m.SetRating(0, false, false, "", SrcXmp)
+
+		assert.Equal(t, int8(0), m.PhotoRating)
+		assert.False(t, m.PhotoPick)
+		assert.Equal(t, "", m.PhotoColorLabel)
+		assert.Equal(t, SrcXmp, m.RatingSrc)
+		assert.False(t, m.HasRating())
+	})
+	t.Run("none", func(t *testing.T) {
+		m := Photo{}
+
+		m.SetRating(0, false, false, "", SrcMeta)
+
+		assert.Equal(t, "", m.RatingSrc)
+	})
+	t.Run("priority", func(t *testing.T) {
+		m := Photo{PhotoRating: 2, RatingSrc: SrcManual}
+
+		m.SetRating(5, false, false, "", SrcXmp)
+
+		assert.Equal(t, int8(2), m.PhotoRating)
+
+		m.SetRating(5, false, false, "", SrcManual)
+
+		assert.Equal(t, int8(5), m.PhotoRating)
+	})
+	t.Run("reject", func(t *testing.T) {
+		m := Photo{}
+
+		m.SetRating(9, true, true, "", SrcXmp)
+
+		assert.Equal(t, int8(RatingMax), m.PhotoRating)
+		assert.False(t, m.PhotoPick)
+		assert.True(t, m.PhotoReject)
+	})
+}
+
+func TestPhoto_UpdateRating(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := Photo{PhotoRating: 3, RatingSrc: SrcXmp}
+		m.Save()
+
+		if err := m.UpdateRating(1, true, true, "blue"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int8(1), m.PhotoRating)
+		assert.False(t, m.PhotoPick)
+		assert.True(t, m.PhotoReject)
+		assert.Equal(t, "blue", m.PhotoColorLabel)
+		assert.Equal(t, SrcManual, m.RatingSrc)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		m := Photo{}
+
+		assert.Error(t, m.UpdateRating(6, false, false, ""))
+	})
+}
@@ -0,0 +1,111 @@
+package entity
+
+import "github.com/photoprism/photoprism/pkg/txt"
+
+// PhotoTag represents the many-to-many relation between Photo and Tag.
+//
+// Tags found in file metadata are linked with the file ID, so that they
+// can be removed when they no longer appear in the file.
+type PhotoTag struct {
+	PhotoID uint `gorm:"primary_key;auto_increment:false"`
+	TagID   uint `gorm:"primary_key;auto_increment:false;index"`
+	FileID  uint `gorm:"primary_key;auto_increment:false;index"`
+}
+
+// TableName returns the entity database table name.
+func (PhotoTag) TableName() string {
+	return "photos_tags"
+}
+
+// NewPhotoTag registers a new PhotoTag relation, fileID is 0 if the tag was not found in file metadata.
+func NewPhotoTag(photoID, tagID, fileID uint) *PhotoTag {
+	result := &PhotoTag{
+		PhotoID: photoID,
+		TagID:   tagID,
+		FileID:  fileID,
+	}
+
+	return result
+}
+
+// Create inserts a new row to the database.
+func (m *PhotoTag) Create() error {
+	return Db().Create(m).Error
+}
+
+// FirstOrCreatePhotoTag returns the existing row, inserts a new row or nil in case of errors.
+func FirstOrCreatePhotoTag(m *PhotoTag) *PhotoTag {
+	result := PhotoTag{}
+
+	if err := Db().Where("photo_id = ? AND tag_id = ? AND file_id = ?", m.PhotoID, m.TagID, m.FileID).First(&result).Error; err == nil {
+		return &result
+	} else if createErr := m.Create(); createErr == nil {
+		return m
+	} else if err := Db().Where("photo_id = ? AND tag_id = ? AND file_id = ?", m.PhotoID, m.TagID, m.FileID).First(&result).Error; err == nil {
+		return &result
+	} else {
+		log.Errorf("photo-tag: %s (find or create)", createErr)
+	}
+
+	return nil
+}
+
+// AddTags adds hierarchical keywords like "People|Family|Kids" to the photo.
+func (m *Photo) AddTags(paths []string) {
+	m.addTags(paths, 0)
+}
+
+// SyncFileTags adds the hierarchical keywords found in the metadata of a file,
+// and removes those that no longer appear in it.
+func (m *Photo) SyncFileTags(fileID uint, paths []string) {
+	if !m.HasID() || fileID == 0 {
+		return
+	}
+
+	tagIDs := m.addTags(paths, fileID)
+
+	stale := Db().Where("photo_id = ? AND file_id = ?", m.ID, fileID)
+
+	if len(tagIDs) > 0 {
+		stale = stale.Where("tag_id NOT IN (?)", tagIDs)
+	}
+
+	if err := stale.Delete(PhotoTag{}).Error; err != nil {
+		log.Errorf("photo: %s (remove stale tags)", err)
+	}
+}
+
+// addTags adds hierarchical keywords to the photo and returns their tag IDs.
+func (m *Photo) addTags(paths []string, fileID uint) (tagIDs []uint) {
+	if !m.HasID() {
+		return tagIDs
+	}
+
+	for _, path := range paths {
+		t := FirstOrCreateTagPath(path)
+
+		if t == nil {
+			log.Errorf("index: tag %s should not be nil - bug? (%s)", txt.Quote(path), m)
+			continue
+		}
+
+		if FirstOrCreatePhotoTag(NewPhotoTag(m.ID, t.ID, fileID)) != nil {
+			tagIDs = append(tagIDs, t.ID)
+		}
+	}
+
+	return tagIDs
+}
+
+// Tags returns the tags assigned to the photo.
+func (m *Photo) Tags() (result Tags) {
+	if !m.HasID() {
+		return result
+	}
+
+	if err := Db().Where("id IN (SELECT tag_id FROM photos_tags WHERE photo_id = ?)", m.ID).Order("tag_slug").Find(&result).Error; err != nil {
+		log.Errorf("photo: %s (find tags)", err)
+	}
+
+	return result
+}
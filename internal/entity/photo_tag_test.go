@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_AddTags(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+
+		m.AddTags([]string{"People|Family|Kids", "Places|Europe|Norway"})
+		m.AddTags([]string{"People|Family|Kids"})
+
+		tags := m.Tags()
+
+		assert.Len(t, tags, 2)
+		assert.Equal(t, "kids", tags[0].TagSlug)
+		assert.Equal(t, "norway", tags[1].TagSlug)
+	})
+	t.Run("no id", func(t *testing.T) {
+		m := Photo{}
+
+		m.AddTags([]string{"People|Family|Kids"})
+
+		assert.Empty(t, m.Tags())
+	})
+}
+
+func TestPhoto_SyncFileTags(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo02")
+
+		m.SyncFileTags(1000002, []string{"Events|Wedding", "Places|Europe|France"})
+		m.SyncFileTags(1000003, []string{"Places|Europe|France"})
+
+		assert.Len(t, m.Tags(), 2)
+
+		// Tags that no longer appear in the file metadata are removed.
+		m.SyncFileTags(1000002, []string{"Events|Birthday"})
+
+		tags := m.Tags()
+
+		if assert.Len(t, tags, 2) {
+			assert.Equal(t, "birthday", tags[0].TagSlug)
+			assert.Equal(t, "france", tags[1].TagSlug)
+		}
+
+		m.SyncFileTags(1000002, nil)
+		m.SyncFileTags(1000003, nil)
+
+		assert.Empty(t, m.Tags())
+	})
+	t.Run("no file id", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo02")
+
+		m.SyncFileTags(0, []string{"Events|Wedding"})
+
+		assert.Empty(t, m.Tags())
+	})
+}
@@ -1151,3 +1151,30 @@ func TestPhoto_MapKey(t *testing.T) {
 	m := &Photo{TakenAt: time.Date(2016, 11, 11, 9, 7, 18, 0, time.UTC), CellID: "abc236"}
 	assert.Equal(t, "ogh006/abc236", m.MapKey())
 }
+
+func TestPhoto_DeletePermanently(t *testing.T) {
+	t.Run("embedding", func(t *testing.T) {
+		photo := &Photo{PhotoTitle: "ToBePermanentlyDeleted"}
+
+		if err := photo.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := &File{FileType: "jpg", FileName: "ToBePermanentlyDeletedPhoto.jpg", PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FilePrimary: true}
+
+		if err := file.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := NewFileEmbedding(*file, EmbeddingModelTest, Embedding{1, 0, 0}).Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := photo.DeletePermanently(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindFileEmbedding(file.FileUID))
+		assert.Nil(t, FindPhotoEmbedding(photo.PhotoUID))
+	})
+}
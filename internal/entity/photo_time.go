@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ShiftTakenAt adds an offset to the date taken and optionally changes the time zone,
+// e.g. to correct camera clock errors. The result is saved as manually edited.
+func (m *Photo) ShiftTakenAt(offset time.Duration, zone string) error {
+	if !m.HasID() {
+		return fmt.Errorf("photo: can't shift time, id is empty")
+	}
+
+	if zone != "" {
+		if _, err := time.LoadLocation(zone); err != nil {
+			return fmt.Errorf("photo: invalid time zone %s", txt.Quote(zone))
+		}
+	}
+
+	old := *m
+	local := m.TakenAtLocal.Add(offset)
+
+	if local.Year() < 1000 || local.Year() > txt.YearMax {
+		return fmt.Errorf("photo: invalid date %s", local.Format("2006-01-02 15:04:05"))
+	}
+
+	m.TakenAtLocal = local
+
+	if zone != "" {
+		// Interpret the local time in the new time zone.
+		m.TimeZone = zone
+		m.TakenAt = m.GetTakenAt()
+	} else {
+		m.TakenAt = m.TakenAt.Add(offset)
+	}
+
+	m.TakenSrc = SrcManual
+	m.PhotoYear = m.TakenAtLocal.Year()
+	m.PhotoMonth = int(m.TakenAtLocal.Month())
+	m.PhotoDay = m.TakenAtLocal.Day()
+
+	edited := TimeStamp()
+	m.EditedAt = &edited
+
+	if err := m.Updates(Values{
+		"TakenAt":      m.TakenAt,
+		"TakenAtLocal": m.TakenAtLocal,
+		"TimeZone":     m.TimeZone,
+		"TakenSrc":     m.TakenSrc,
+		"PhotoYear":    m.PhotoYear,
+		"PhotoMonth":   m.PhotoMonth,
+		"PhotoDay":     m.PhotoDay,
+		"EditedAt":     m.EditedAt,
+	}); err != nil {
+		return err
+	}
+
+	m.SaveEdits(old)
+
+	return nil
+}
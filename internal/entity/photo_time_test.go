@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_ShiftTakenAt(t *testing.T) {
+	taken := time.Date(2020, 12, 31, 23, 30, 0, 0, time.UTC)
+
+	t.Run("offset", func(t *testing.T) {
+		m := Photo{TakenAt: taken, TakenAtLocal: taken, TakenSrc: SrcMeta}
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.ShiftTakenAt(time.Hour, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, taken.Add(time.Hour), m.TakenAt)
+		assert.Equal(t, taken.Add(time.Hour), m.TakenAtLocal)
+		assert.Equal(t, SrcManual, m.TakenSrc)
+		assert.Equal(t, 2021, m.PhotoYear)
+		assert.Equal(t, 1, m.PhotoMonth)
+		assert.Equal(t, 1, m.PhotoDay)
+	})
+	t.Run("time zone", func(t *testing.T) {
+		m := Photo{TakenAt: taken, TakenAtLocal: taken, TimeZone: "UTC", TakenSrc: SrcMeta}
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.ShiftTakenAt(0, "Europe/Berlin"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Europe/Berlin", m.TimeZone)
+		assert.Equal(t, taken, m.TakenAtLocal)
+		assert.Equal(t, taken.Add(-time.Hour), m.TakenAt)
+		assert.Equal(t, 2020, m.PhotoYear)
+	})
+	t.Run("invalid time zone", func(t *testing.T) {
+		m := Photo{TakenAt: taken, TakenAtLocal: taken}
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, m.ShiftTakenAt(0, "Mars/Olympus"))
+	})
+	t.Run("no id", func(t *testing.T) {
+		m := Photo{TakenAt: taken, TakenAtLocal: taken}
+
+		assert.Error(t, m.ShiftTakenAt(time.Hour, ""))
+	})
+}
@@ -0,0 +1,147 @@
+package entity
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+var photoXmpMutex = sync.Mutex{}
+
+// XmpCreatorTool identifies XMP sidecar files written by PhotoPrism.
+const XmpCreatorTool = "PhotoPrism"
+
+const xmpTemplate = `<?xpacket begin="" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:xmp="http://ns.adobe.com/xap/1.0/"
+    xmlns:xmpDM="http://ns.adobe.com/xmp/1.0/DynamicMedia/"
+    xmp:CreatorTool="%s"
+    xmp:Rating="%d"
+    xmp:Label="%s"
+    xmpDM:pick="%d"/>
+ </rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>
+`
+
+// xmpNamespaces maps the prefixes of the properties written by PhotoPrism to their namespace.
+var xmpNamespaces = map[string]string{
+	"xmp":   "http://ns.adobe.com/xap/1.0/",
+	"xmpDM": "http://ns.adobe.com/xmp/1.0/DynamicMedia/",
+}
+
+// xmpDescription matches the start of the first rdf:Description element.
+var xmpDescription = regexp.MustCompile(`<rdf:Description\b`)
+
+// xmpValues returns the star rating, pick flag and color label as XMP property values.
+func (m *Photo) xmpValues() (rating int, label string, pick int) {
+	rating = int(m.PhotoRating)
+
+	if m.PhotoReject {
+		rating = -1
+		pick = -1
+	} else if m.PhotoPick {
+		pick = 1
+	}
+
+	return rating, txt.UcFirst(m.PhotoColorLabel), pick
+}
+
+// Xmp returns the star rating, pick flag and color label as XMP document.
+func (m *Photo) Xmp() []byte {
+	rating, label, pick := m.xmpValues()
+
+	return []byte(fmt.Sprintf(xmpTemplate, XmpCreatorTool, rating, label, pick))
+}
+
+// UpdateXmp sets the star rating, pick flag and color label in an existing XMP document,
+// all other content is preserved.
+func (m *Photo) UpdateXmp(data []byte) ([]byte, error) {
+	if !xmpDescription.Match(data) {
+		return data, fmt.Errorf("rdf:Description not found")
+	}
+
+	rating, label, pick := m.xmpValues()
+
+	data = setXmpProperty(data, "xmp", "Rating", strconv.Itoa(rating))
+	data = setXmpProperty(data, "xmp", "Label", html.EscapeString(label))
+	data = setXmpProperty(data, "xmpDM", "pick", strconv.Itoa(pick))
+
+	return data, nil
+}
+
+// setXmpProperty replaces the value of an XMP property, or adds it to the first rdf:Description element.
+func setXmpProperty(data []byte, prefix, name, value string) []byte {
+	prop := regexp.QuoteMeta(prefix + ":" + name)
+	repl := strings.ReplaceAll(value, "$", "$$")
+
+	// Property as attribute, e.g. xmp:Rating="3".
+	if attr := regexp.MustCompile(`(\s` + prop + `\s*=\s*)("[^"]*"|'[^']*')`); attr.Match(data) {
+		return attr.ReplaceAll(data, []byte(`${1}"`+repl+`"`))
+	}
+
+	// Property as element, e.g. <xmp:Rating>3</xmp:Rating>.
+	if elem := regexp.MustCompile(`(<` + prop + `>)[^<]*(</` + prop + `>)`); elem.Match(data) {
+		return elem.ReplaceAll(data, []byte("${1}"+repl+"${2}"))
+	}
+
+	attr := fmt.Sprintf(` %s:%s="%s"`, prefix, name, value)
+
+	// Declare the namespace if needed.
+	if !bytes.Contains(data, []byte("xmlns:"+prefix+"=")) {
+		attr = fmt.Sprintf(` xmlns:%s="%s"`, prefix, xmpNamespaces[prefix]) + attr
+	}
+
+	loc := xmpDescription.FindIndex(data)
+
+	result := make([]byte, 0, len(data)+len(attr))
+	result = append(result, data[:loc[1]]...)
+	result = append(result, attr...)
+	result = append(result, data[loc[1]:]...)
+
+	return result
+}
+
+// SaveAsXmp saves the star rating, pick flag and color label as XMP sidecar file.
+// Existing files that were not created by PhotoPrism are updated, so that other metadata is kept.
+func (m *Photo) SaveAsXmp(fileName string) error {
+	photoXmpMutex.Lock()
+	defer photoXmpMutex.Unlock()
+
+	xmpData := m.Xmp()
+
+	if data, err := ioutil.ReadFile(fileName); err == nil && !bytes.Contains(data, []byte(fmt.Sprintf("xmp:CreatorTool=\"%s\"", XmpCreatorTool))) {
+		if xmpData, err = m.UpdateXmp(data); err != nil {
+			return fmt.Errorf("can't update %s, %s", txt.Quote(filepath.Base(fileName)), err)
+		}
+	}
+
+	// Make sure directory exists.
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return err
+	}
+
+	// Write XMP data to file.
+	if err := fs.WriteFile(fileName, xmpData, os.ModePerm); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// XmpFileName returns the XMP sidecar file name.
+func (m *Photo) XmpFileName(originalsPath, sidecarPath string) string {
+	return fs.FileName(filepath.Join(originalsPath, m.PhotoPath, m.PhotoName), sidecarPath, originalsPath, fs.XmpExt)
+}
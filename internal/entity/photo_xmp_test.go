@@ -0,0 +1,131 @@
+package entity
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_SaveAsXmp(t *testing.T) {
+	t.Run("rating", func(t *testing.T) {
+		m := Photo{PhotoRating: 4, PhotoPick: true, PhotoColorLabel: "red"}
+
+		fileName := filepath.Join(os.TempDir(), ".photoprism_test.xmp")
+
+		if err := m.SaveAsXmp(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := meta.XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 4, data.Rating)
+		assert.Equal(t, meta.PickAccepted, data.Pick)
+		assert.Equal(t, "red", data.ColorLabel)
+
+		// Files created by PhotoPrism may be replaced.
+		m.PhotoReject = true
+
+		if err := m.SaveAsXmp(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err = meta.XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, data.Rejected())
+
+		if err := os.Remove(fileName); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("foreign file", func(t *testing.T) {
+		m := Photo{PhotoRating: 2, PhotoColorLabel: "green"}
+
+		fileName := filepath.Join(os.TempDir(), ".photoprism_foreign.xmp")
+
+		foreign := `<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:xmp="http://ns.adobe.com/xap/1.0/"
+    xmlns:dc="http://purl.org/dc/elements/1.1/"
+   xmp:Rating="4">
+   <xmp:CreatorTool>Adobe Photoshop Lightroom Classic 10.0 (Macintosh)</xmp:CreatorTool>
+   <xmp:Label>Red</xmp:Label>
+   <dc:title>
+    <rdf:Alt>
+     <rdf:li xml:lang="x-default">Bryggen</rdf:li>
+    </rdf:Alt>
+   </dc:title>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>`
+
+		if err := ioutil.WriteFile(fileName, []byte(foreign), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.Remove(fileName)
+
+		if err := m.SaveAsXmp(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := meta.XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Rating, label and pick flag are updated.
+		assert.Equal(t, 2, data.Rating)
+		assert.Equal(t, "green", data.ColorLabel)
+		assert.Equal(t, meta.PickNone, data.Pick)
+
+		// Other content is preserved.
+		assert.Equal(t, "Bryggen", data.Title)
+
+		content, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(content), "<xmp:CreatorTool>Adobe Photoshop Lightroom Classic 10.0 (Macintosh)</xmp:CreatorTool>")
+		assert.Contains(t, string(content), `xmlns:xmpDM="http://ns.adobe.com/xmp/1.0/DynamicMedia/" xmpDM:pick="0"`)
+	})
+	t.Run("invalid file", func(t *testing.T) {
+		m := Photo{PhotoRating: 2}
+
+		fileName := filepath.Join(os.TempDir(), ".photoprism_invalid.xmp")
+
+		if err := ioutil.WriteFile(fileName, []byte("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\"></x:xmpmeta>"), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, m.SaveAsXmp(fileName))
+
+		if err := os.Remove(fileName); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestPhoto_XmpFileName(t *testing.T) {
+	m := PhotoFixtures.Get("Photo01")
+	assert.Equal(t, "xxx/2790/02/yyy/Photo01.xmp", m.XmpFileName("xxx", "yyy"))
+
+	if err := os.RemoveAll("xxx"); err != nil {
+		t.Fatal(err)
+	}
+}
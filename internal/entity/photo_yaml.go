@@ -43,7 +43,7 @@ func (m *Photo) SaveAsYaml(fileName string) error {
 	defer photoYamlMutex.Unlock()
 
 	// Write YAML data to file.
-	if err := ioutil.WriteFile(fileName, data, os.ModePerm); err != nil {
+	if err := fs.WriteFile(fileName, data, os.ModePerm); err != nil {
 		return err
 	}
 
@@ -9,12 +9,14 @@ const (
 	SrcAuto     = ""
 	SrcDefault  = "default"
 	SrcManual   = "manual"
+	SrcBatch    = "batch"
 	SrcEstimate = "estimate"
 	SrcName     = "name"
 	SrcMeta     = "meta"
 	SrcXmp      = "xmp"
 	SrcYaml     = "yaml"
 	SrcMarker   = "marker"
+	SrcOcr      = "ocr"
 	SrcImage    = classify.SrcImage
 	SrcKeyword  = classify.SrcKeyword
 	SrcLocation = classify.SrcLocation
@@ -38,9 +40,11 @@ var SrcPriority = Priorities{
 	SrcYaml:     8,
 	SrcLocation: 8,
 	SrcMarker:   8,
+	SrcOcr:      8,
 	SrcImage:    8,
 	SrcKeyword:  16,
 	SrcMeta:     16,
 	SrcXmp:      32,
+	SrcBatch:    48,
 	SrcManual:   64,
 }
@@ -308,6 +308,19 @@ func (m *Subject) MergeWith(other *Subject) error {
 		return fmt.Errorf("other subject's uid is empty")
 	} else if m.SubjUID == "" {
 		return fmt.Errorf("subject uid is empty")
+	} else if m.SubjUID == other.SubjUID {
+		return fmt.Errorf("can't merge subject with itself")
+	}
+
+	// Keep rejected matches, except for markers that were also rejected for the other subject.
+	if err := Db().Where("subj_uid = ?", m.SubjUID).
+		Where(fmt.Sprintf("marker_uid IN (SELECT marker_uid FROM (SELECT marker_uid FROM %s WHERE subj_uid = ?) AS n)",
+			SubjectNegative{}.TableName()), other.SubjUID).
+		Delete(&SubjectNegative{}).Error; err != nil {
+		return err
+	} else if err := Db().Exec(fmt.Sprintf("UPDATE %s SET subj_uid = ? WHERE subj_uid = ?",
+		SubjectNegative{}.TableName()), other.SubjUID, m.SubjUID).Error; err != nil {
+		return err
 	}
 
 	// Update markers and faces with new SubjUID.
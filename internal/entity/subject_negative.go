@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// SubjectNegative represents a face marker that was rejected as a match for a subject.
+type SubjectNegative struct {
+	SubjUID   string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"SubjUID" yaml:"SubjUID"`
+	MarkerUID string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index;" json:"MarkerUID" yaml:"MarkerUID"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// SubjectNegatives represents a list of rejected subject matches.
+type SubjectNegatives []SubjectNegative
+
+// TableName returns the entity database table name.
+func (SubjectNegative) TableName() string {
+	return "subjects_negatives"
+}
+
+// NewSubjectNegative returns a new rejected subject match.
+func NewSubjectNegative(subjUID, markerUID string) *SubjectNegative {
+	return &SubjectNegative{
+		SubjUID:   subjUID,
+		MarkerUID: markerUID,
+	}
+}
+
+// Create inserts the entity to the database.
+func (m *SubjectNegative) Create() error {
+	if m.SubjUID == "" || m.MarkerUID == "" {
+		return fmt.Errorf("subject and marker uid must not be empty")
+	}
+
+	return Db().Create(m).Error
+}
+
+// Delete removes the entity from the database.
+func (m *SubjectNegative) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// FirstOrCreateSubjectNegative returns the existing entity, inserts a new entity or nil in case of errors.
+func FirstOrCreateSubjectNegative(m *SubjectNegative) *SubjectNegative {
+	result := SubjectNegative{}
+
+	if err := Db().Where("subj_uid = ? AND marker_uid = ?", m.SubjUID, m.MarkerUID).First(&result).Error; err == nil {
+		return &result
+	} else if createErr := m.Create(); createErr == nil {
+		return m
+	} else if err := Db().Where("subj_uid = ? AND marker_uid = ?", m.SubjUID, m.MarkerUID).First(&result).Error; err == nil {
+		return &result
+	} else {
+		log.Errorf("subject: %s (add negative %s for %s)", createErr, m.MarkerUID, m.SubjUID)
+	}
+
+	return nil
+}
+
+// RejectedSubject tests if a user rejected the subject as a match for the marker.
+func RejectedSubject(markerUID, subjUID string) bool {
+	if markerUID == "" || subjUID == "" {
+		return false
+	}
+
+	result := SubjectNegative{}
+
+	return Db().Where("subj_uid = ? AND marker_uid = ?", subjUID, markerUID).First(&result).Error == nil
+}
+
+// NegativeMap maps marker uids to the subjects they were rejected for.
+type NegativeMap map[string]map[string]bool
+
+// Add adds a rejected subject match.
+func (m NegativeMap) Add(markerUID, subjUID string) {
+	if m[markerUID] == nil {
+		m[markerUID] = make(map[string]bool)
+	}
+
+	m[markerUID][subjUID] = true
+}
+
+// Rejected tests if the subject was rejected as a match for the marker.
+func (m NegativeMap) Rejected(markerUID, subjUID string) bool {
+	if subjUID == "" {
+		return false
+	} else if subjects, ok := m[markerUID]; ok {
+		return subjects[subjUID]
+	}
+
+	return false
+}
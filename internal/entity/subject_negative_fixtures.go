@@ -0,0 +1,33 @@
+package entity
+
+type SubjectNegativeMap map[string]SubjectNegative
+
+func (m SubjectNegativeMap) Get(name string) SubjectNegative {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return SubjectNegative{}
+}
+
+func (m SubjectNegativeMap) Pointer(name string) *SubjectNegative {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &SubjectNegative{}
+}
+
+var SubjectNegativeFixtures = SubjectNegativeMap{
+	"actor-1": SubjectNegative{
+		SubjUID:   SubjectFixtures.Get("actor-1").SubjUID,
+		MarkerUID: MarkerFixtures.Get("1000003-3").MarkerUID,
+	},
+}
+
+// CreateSubjectNegativeFixtures inserts known entities into the database for testing.
+func CreateSubjectNegativeFixtures() {
+	for _, entity := range SubjectNegativeFixtures {
+		Db().Create(&entity)
+	}
+}
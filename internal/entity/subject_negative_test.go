@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubjectNegative_TableName(t *testing.T) {
+	assert.Equal(t, "subjects_negatives", SubjectNegative{}.TableName())
+}
+
+func TestFirstOrCreateSubjectNegative(t *testing.T) {
+	t.Run("Existing", func(t *testing.T) {
+		fixture := SubjectNegativeFixtures.Get("actor-1")
+		m := FirstOrCreateSubjectNegative(NewSubjectNegative(fixture.SubjUID, fixture.MarkerUID))
+
+		if m == nil {
+			t.Fatal("result must not be nil")
+		}
+
+		assert.Equal(t, fixture.SubjUID, m.SubjUID)
+		assert.Equal(t, fixture.MarkerUID, m.MarkerUID)
+	})
+	t.Run("New", func(t *testing.T) {
+		m := FirstOrCreateSubjectNegative(NewSubjectNegative("jqy3y652h8njw0sx", "mt9k3pw1wowuy444"))
+
+		if m == nil {
+			t.Fatal("result must not be nil")
+		}
+
+		assert.True(t, RejectedSubject("mt9k3pw1wowuy444", "jqy3y652h8njw0sx"))
+
+		if err := m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, RejectedSubject("mt9k3pw1wowuy444", "jqy3y652h8njw0sx"))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Nil(t, FirstOrCreateSubjectNegative(NewSubjectNegative("", "mt9k3pw1wowuy444")))
+	})
+}
+
+func TestRejectedSubject(t *testing.T) {
+	fixture := SubjectNegativeFixtures.Get("actor-1")
+
+	assert.True(t, RejectedSubject(fixture.MarkerUID, fixture.SubjUID))
+	assert.False(t, RejectedSubject(fixture.MarkerUID, "jqu0xs11qekk9jx8"))
+	assert.False(t, RejectedSubject("", fixture.SubjUID))
+}
+
+func TestNegativeMap_Rejected(t *testing.T) {
+	m := make(NegativeMap)
+	m.Add("mt9k3pw1wowuy111", "jqy1y111h1njaaad")
+
+	assert.True(t, m.Rejected("mt9k3pw1wowuy111", "jqy1y111h1njaaad"))
+	assert.False(t, m.Rejected("mt9k3pw1wowuy111", "jqu0xs11qekk9jx8"))
+	assert.False(t, m.Rejected("mt9k3pw1wowuy222", "jqy1y111h1njaaad"))
+	assert.False(t, m.Rejected("mt9k3pw1wowuy111", ""))
+}
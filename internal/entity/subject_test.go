@@ -279,3 +279,42 @@ func TestSubject_RefreshPhotos(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestSubject_MergeWith(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := NewSubject("Merge Source", SubjPerson, SrcManual)
+		other := NewSubject("Merge Target", SubjPerson, SrcManual)
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		} else if err := other.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		marker := NewMarker(FileFixtures.Get("exampleFileName.jpg"), testArea, m.SubjUID, SrcManual, MarkerFace)
+		marker.SubjSrc = SrcManual
+
+		if err := marker.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		FirstOrCreateSubjectNegative(NewSubjectNegative(m.SubjUID, "mt9k3pw1wowuy444"))
+
+		if err := m.MergeWith(other); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindSubject(m.SubjUID))
+		assert.Equal(t, other.SubjUID, FindMarker(marker.MarkerUID).SubjUID)
+		assert.False(t, RejectedSubject("mt9k3pw1wowuy444", m.SubjUID))
+		assert.True(t, RejectedSubject("mt9k3pw1wowuy444", other.SubjUID))
+	})
+	t.Run("Self", func(t *testing.T) {
+		m := SubjectFixtures.Pointer("joe-biden")
+		assert.Error(t, m.MergeWith(m))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		m := SubjectFixtures.Pointer("joe-biden")
+		assert.Error(t, m.MergeWith(nil))
+	})
+}
@@ -0,0 +1,302 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+var tagMutex = sync.Mutex{}
+
+// TagSeparator separates the levels of hierarchical keywords, e.g. "Places|Europe|Norway".
+const TagSeparator = "|"
+
+// TagMaxDepth limits the tag nesting depth.
+const TagMaxDepth = 16
+
+// Tags represents a list of tags.
+type Tags []Tag
+
+// Tag represents a hierarchical keyword, e.g. imported from lr:hierarchicalSubject.
+type Tag struct {
+	ID        uint      `gorm:"primary_key" json:"ID" yaml:"-"`
+	TagUID    string    `gorm:"type:VARBINARY(42);unique_index;" json:"UID" yaml:"UID"`
+	ParentUID string    `gorm:"type:VARBINARY(42);unique_index:idx_tags_parent_slug;default:''" json:"ParentUID,omitempty" yaml:"ParentUID,omitempty"`
+	TagSlug   string    `gorm:"type:VARBINARY(160);unique_index:idx_tags_parent_slug;" json:"Slug" yaml:"-"`
+	TagName   string    `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Tag) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.TagUID, 't') {
+		return nil
+	}
+
+	return scope.SetColumn("TagUID", rnd.PPID('t'))
+}
+
+// NewTag returns a new tag with the given name and parent tag UID.
+func NewTag(name, parentUID string) *Tag {
+	name = strings.TrimSpace(txt.Clip(strings.ReplaceAll(name, TagSeparator, " "), txt.ClipDefault))
+
+	result := &Tag{
+		ParentUID: parentUID,
+		TagSlug:   slug.Make(txt.Clip(name, txt.ClipSlug)),
+		TagName:   name,
+	}
+
+	return result
+}
+
+// Create inserts the tag to the database.
+func (m *Tag) Create() error {
+	tagMutex.Lock()
+	defer tagMutex.Unlock()
+
+	return Db().Create(m).Error
+}
+
+// Save updates the existing or inserts a new tag.
+func (m *Tag) Save() error {
+	tagMutex.Lock()
+	defer tagMutex.Unlock()
+
+	return Db().Save(m).Error
+}
+
+// Updates multiple columns in the database.
+func (m *Tag) Updates(values interface{}) error {
+	return UnscopedDb().Model(m).UpdateColumns(values).Error
+}
+
+// FindTag returns the tag with the given UID, or nil if it was not found.
+func FindTag(uid string) *Tag {
+	result := Tag{}
+
+	if uid == "" {
+		return nil
+	} else if err := Db().Where("tag_uid = ?", uid).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FirstOrCreateTag returns the existing tag, inserts a new tag or nil in case of errors.
+func FirstOrCreateTag(m *Tag) *Tag {
+	result := Tag{}
+
+	if err := Db().Where("parent_uid = ? AND tag_slug = ?", m.ParentUID, m.TagSlug).First(&result).Error; err == nil {
+		return &result
+	} else if createErr := m.Create(); createErr == nil {
+		return m
+	} else if err := Db().Where("parent_uid = ? AND tag_slug = ?", m.ParentUID, m.TagSlug).First(&result).Error; err == nil {
+		return &result
+	} else {
+		log.Errorf("tag: %s (find or create %s)", createErr, m.TagSlug)
+	}
+
+	return nil
+}
+
+// FirstOrCreateTagPath returns the tag for a hierarchical keyword like "Places|Europe|Norway",
+// missing parent tags are created as needed.
+func FirstOrCreateTagPath(path string) *Tag {
+	var result *Tag
+
+	parentUID := ""
+
+	for i, name := range strings.Split(path, TagSeparator) {
+		if i >= TagMaxDepth {
+			break
+		}
+
+		t := NewTag(name, parentUID)
+
+		if t.TagSlug == "" {
+			continue
+		}
+
+		if result = FirstOrCreateTag(t); result == nil {
+			return nil
+		}
+
+		parentUID = result.TagUID
+	}
+
+	return result
+}
+
+// Parent returns the parent tag, or nil if it's a top-level tag.
+func (m *Tag) Parent() *Tag {
+	return FindTag(m.ParentUID)
+}
+
+// Ancestors returns all parent tags, starting with the top-level tag.
+func (m *Tag) Ancestors() (result Tags) {
+	seen := map[string]bool{m.TagUID: true}
+	t := m
+
+	for i := 0; i < TagMaxDepth; i++ {
+		if t = t.Parent(); t == nil || seen[t.TagUID] {
+			break
+		}
+
+		seen[t.TagUID] = true
+		result = append(Tags{*t}, result...)
+	}
+
+	return result
+}
+
+// Path returns the hierarchical keyword, e.g. "Places|Europe|Norway".
+func (m *Tag) Path() string {
+	var names []string
+
+	for _, t := range m.Ancestors() {
+		names = append(names, t.TagName)
+	}
+
+	return strings.Join(append(names, m.TagName), TagSeparator)
+}
+
+// Children returns the direct child tags.
+func (m *Tag) Children() (result Tags) {
+	if m.TagUID == "" {
+		return result
+	}
+
+	if err := Db().Where("parent_uid = ?", m.TagUID).Order("tag_slug").Find(&result).Error; err != nil {
+		log.Errorf("tag: %s (find children)", err)
+	}
+
+	return result
+}
+
+// Rename changes the tag name, the name must be unique among its siblings.
+func (m *Tag) Rename(name string) error {
+	return m.Change(name, m.ParentUID)
+}
+
+// Move changes the parent tag, an empty UID moves the tag to the top level.
+func (m *Tag) Move(parentUID string) error {
+	return m.Change(m.TagName, parentUID)
+}
+
+// Change renames the tag and moves it to a different parent tag, both changes
+// are validated before they are saved together.
+func (m *Tag) Change(name, parentUID string) error {
+	t := NewTag(name, parentUID)
+
+	if t.TagSlug == "" {
+		return fmt.Errorf("tag: name must not be empty")
+	} else if t.TagName == m.TagName && parentUID == m.ParentUID {
+		return nil
+	}
+
+	if parentUID != m.ParentUID {
+		if err := m.validParent(parentUID); err != nil {
+			return err
+		}
+	}
+
+	// The name must be unique among its new siblings.
+	if (t.TagSlug != m.TagSlug || parentUID != m.ParentUID) && t.exists() {
+		return fmt.Errorf("tag: %s already exists", txt.Quote(t.TagName))
+	}
+
+	if err := m.Updates(Values{"TagName": t.TagName, "TagSlug": t.TagSlug, "ParentUID": parentUID}); err != nil {
+		return err
+	}
+
+	m.TagName = t.TagName
+	m.TagSlug = t.TagSlug
+	m.ParentUID = parentUID
+
+	return nil
+}
+
+// validParent returns an error if the tag can't be moved to the parent tag, an empty UID is the top level.
+func (m *Tag) validParent(parentUID string) error {
+	if parentUID == "" {
+		return nil
+	} else if !rnd.IsPPID(parentUID, 't') {
+		return fmt.Errorf("tag: invalid parent uid %s", parentUID)
+	} else if parentUID == m.TagUID {
+		return fmt.Errorf("tag: can't be its own parent")
+	}
+
+	parent := FindTag(parentUID)
+
+	if parent == nil {
+		return fmt.Errorf("tag: parent %s not found", parentUID)
+	}
+
+	ancestors := parent.Ancestors()
+
+	if len(ancestors)+1 >= TagMaxDepth {
+		return fmt.Errorf("tag: nesting depth exceeds %d", TagMaxDepth)
+	}
+
+	// Tags must not become descendants of themselves.
+	for _, a := range ancestors {
+		if a.TagUID == m.TagUID {
+			return fmt.Errorf("tag: %s is a descendant of %s", parentUID, m.TagUID)
+		}
+	}
+
+	return nil
+}
+
+// exists returns true if a tag with the same slug and parent already exists.
+func (m *Tag) exists() bool {
+	result := Tag{}
+
+	return Db().Where("parent_uid = ? AND tag_slug = ?", m.ParentUID, m.TagSlug).First(&result).Error == nil
+}
+
+// TagDescendantUIDs returns the UIDs of all tags nested in the tag with the given UID.
+func TagDescendantUIDs(tagUID string) (result []string) {
+	if tagUID == "" {
+		return result
+	}
+
+	seen := map[string]bool{tagUID: true}
+	parents := []string{tagUID}
+
+	for i := 0; i < TagMaxDepth && len(parents) > 0; i++ {
+		var children []string
+
+		if err := Db().Model(&Tag{}).Where("parent_uid IN (?)", parents).Pluck("tag_uid", &children).Error; err != nil {
+			log.Errorf("tag: %s (find descendants)", err)
+			break
+		}
+
+		parents = parents[:0]
+
+		for _, uid := range children {
+			if seen[uid] {
+				continue
+			}
+
+			seen[uid] = true
+			parents = append(parents, uid)
+			result = append(result, uid)
+		}
+	}
+
+	return result
+}
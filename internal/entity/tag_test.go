@@ -0,0 +1,138 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTag(t *testing.T) {
+	t.Run("name", func(t *testing.T) {
+		m := NewTag(" Bergen ", "")
+
+		assert.Equal(t, "Bergen", m.TagName)
+		assert.Equal(t, "bergen", m.TagSlug)
+		assert.Equal(t, "", m.ParentUID)
+	})
+	t.Run("separator", func(t *testing.T) {
+		m := NewTag("Kids|Family", "t000000000000001")
+
+		assert.Equal(t, "Kids Family", m.TagName)
+		assert.Equal(t, "kids-family", m.TagSlug)
+		assert.Equal(t, "t000000000000001", m.ParentUID)
+	})
+}
+
+func TestFirstOrCreateTagPath(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		bergen := FirstOrCreateTagPath("Places|Europe|Norway|Bergen")
+
+		if bergen == nil {
+			t.Fatal("tag must not be nil")
+		}
+
+		assert.Equal(t, "Bergen", bergen.TagName)
+		assert.Equal(t, "Places|Europe|Norway|Bergen", bergen.Path())
+
+		oslo := FirstOrCreateTagPath("Places|Europe|Norway|Oslo")
+
+		if oslo == nil {
+			t.Fatal("tag must not be nil")
+		}
+
+		assert.Equal(t, bergen.ParentUID, oslo.ParentUID)
+		assert.Equal(t, bergen.TagUID, FirstOrCreateTagPath("places|europe|norway|bergen").TagUID)
+
+		norway := oslo.Parent()
+
+		if norway == nil {
+			t.Fatal("parent must not be nil")
+		}
+
+		assert.Equal(t, "Norway", norway.TagName)
+		assert.Len(t, norway.Children(), 2)
+		assert.Len(t, oslo.Ancestors(), 3)
+		assert.ElementsMatch(t, []string{bergen.TagUID, oslo.TagUID}, TagDescendantUIDs(norway.TagUID))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, FirstOrCreateTagPath("|"))
+	})
+}
+
+func TestTag_Rename(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		m := FirstOrCreateTagPath("Rename|Kids")
+
+		if err := m.Rename("Children"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Children", m.TagName)
+		assert.Equal(t, "children", m.TagSlug)
+		assert.Equal(t, "Children", FindTag(m.TagUID).TagName)
+	})
+	t.Run("exists", func(t *testing.T) {
+		m := FirstOrCreateTagPath("Rename|Cats")
+		FirstOrCreateTagPath("Rename|Dogs")
+
+		assert.Error(t, m.Rename("Dogs"))
+		assert.Error(t, m.Rename(" "))
+	})
+}
+
+func TestTag_Move(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		kids := FirstOrCreateTagPath("Move|Family|Kids")
+		people := FirstOrCreateTagPath("Move|People")
+
+		if err := kids.Move(people.TagUID); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Move|People|Kids", FindTag(kids.TagUID).Path())
+
+		if err := kids.Move(""); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Kids", FindTag(kids.TagUID).Path())
+	})
+	t.Run("cycle", func(t *testing.T) {
+		family := FirstOrCreateTagPath("Cycle|Family")
+		kids := FirstOrCreateTagPath("Cycle|Family|Kids")
+
+		assert.Error(t, family.Move(kids.TagUID))
+		assert.Error(t, family.Move(family.TagUID))
+		assert.Error(t, family.Move("invalid"))
+		assert.Error(t, family.Move("t000000000000001"))
+	})
+}
+
+func TestTag_Change(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		kids := FirstOrCreateTagPath("Change|Family|Kids")
+		people := FirstOrCreateTagPath("Change|People")
+
+		if err := kids.Change("Children", people.TagUID); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Change|People|Children", FindTag(kids.TagUID).Path())
+	})
+	t.Run("exists", func(t *testing.T) {
+		kids := FirstOrCreateTagPath("Change|Friends|Kids")
+		people := FirstOrCreateTagPath("Change|Team")
+		FirstOrCreateTagPath("Change|Team|Members")
+
+		// Nothing is changed if the new name exists in the new parent tag.
+		assert.Error(t, kids.Change("Members", people.TagUID))
+		assert.Equal(t, "Change|Friends|Kids", FindTag(kids.TagUID).Path())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		kids := FirstOrCreateTagPath("Change|Home|Kids")
+
+		assert.Error(t, kids.Change("", ""))
+		assert.Error(t, kids.Change("Children", kids.TagUID))
+		assert.Equal(t, "Change|Home|Kids", FindTag(kids.TagUID).Path())
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/mail"
+	"strings"
 	"time"
 
 	"github.com/photoprism/photoprism/internal/form"
@@ -32,7 +33,10 @@ type User struct {
 	UserName       string     `gorm:"size:64;" json:"UserName" yaml:"UserName,omitempty"`
 	UserStatus     string     `gorm:"size:32;" json:"UserStatus" yaml:"UserStatus,omitempty"`
 	UserDisabled   bool       `json:"UserDisabled" yaml:"UserDisabled,omitempty"`
+	AuthSrc        string     `gorm:"type:VARBINARY(8);" json:"AuthSrc" yaml:"AuthSrc,omitempty"`
+	AuthID         string     `gorm:"type:VARBINARY(255);index;" json:"-" yaml:"AuthID,omitempty"`
 	UserSettings   string     `gorm:"type:LONGTEXT;" json:"-" yaml:"-"`
+	UserLocale     string     `gorm:"type:VARBINARY(42);" json:"Locale" yaml:"Locale,omitempty"`
 	PrimaryEmail   string     `gorm:"size:255;index;" json:"PrimaryEmail" yaml:"PrimaryEmail,omitempty"`
 	EmailConfirmed bool       `json:"EmailConfirmed" yaml:"EmailConfirmed,omitempty"`
 	BackupEmail    string     `gorm:"size:255;" json:"BackupEmail" yaml:"BackupEmail,omitempty"`
@@ -67,6 +71,7 @@ type User struct {
 	InvitedBy      string     `gorm:"type:VARBINARY(32);" json:"-" yaml:"-"`
 	ConfirmToken   string     `gorm:"type:VARBINARY(64);" json:"-" yaml:"-"`
 	ResetToken     string     `gorm:"type:VARBINARY(64);" json:"-" yaml:"-"`
+	ResetAt        *time.Time `json:"-" yaml:"-"`
 	ApiToken       string     `gorm:"column:api_token;type:VARBINARY(128);" json:"-" yaml:"-"`
 	ApiSecret      string     `gorm:"column:api_secret;type:VARBINARY(128);" json:"-" yaml:"-"`
 	LoginAttempts  int        `json:"-" yaml:"-"`
@@ -315,6 +320,24 @@ func (m *User) InvalidPassword(password string) bool {
 	return false
 }
 
+// SetLocale updates the locale used for notifications, e.g. the language of the browser the user signed in with.
+func (m *User) SetLocale(locale string) error {
+	locale = strings.TrimSpace(locale)
+
+	if locale == "" || locale == m.UserLocale || !m.Registered() {
+		return nil
+	}
+
+	m.UserLocale = locale
+
+	return Db().Model(m).UpdateColumn("user_locale", locale).Error
+}
+
+// CanUseWebDAV tests if the user may access files via WebDAV, admins always can.
+func (m *User) CanUseWebDAV() bool {
+	return m.Registered() && !m.UserDisabled && !m.Deleted() && (m.RoleAdmin || m.WebDAV)
+}
+
 // Role returns the user role for ACL permission checks.
 func (m *User) Role() acl.Role {
 	if m.RoleAdmin {
@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Authentication sources.
+const (
+	AuthLocal = ""
+	AuthOIDC  = "oidc"
+	AuthLDAP  = "ldap"
+	AuthProxy = "proxy"
+)
+
+// ExternalUser represents a user authenticated by an external identity provider.
+type ExternalUser struct {
+	AuthSrc  string
+	AuthID   string
+	UserName string
+	FullName string
+	Email    string
+	Role     acl.Role
+}
+
+// FindUserByAuth returns the user with the given external identity or nil if not found.
+func FindUserByAuth(src, id string) *User {
+	if src == AuthLocal || id == "" {
+		return nil
+	}
+
+	result := User{}
+
+	if err := Db().Preload("Address").Where("auth_src = ? AND auth_id = ?", src, id).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// SetRole replaces the role flags with the given role.
+func (m *User) SetRole(role acl.Role) error {
+	switch role {
+	case acl.RoleAdmin, acl.RoleChild, acl.RoleFamily, acl.RoleFriend, acl.RoleGuest:
+	default:
+		return fmt.Errorf("user: unsupported role %s", txt.Quote(string(role)))
+	}
+
+	m.RoleAdmin = role == acl.RoleAdmin
+	m.RoleChild = role == acl.RoleChild
+	m.RoleFamily = role == acl.RoleFamily
+	m.RoleFriend = role == acl.RoleFriend
+	m.RoleGuest = role == acl.RoleGuest
+
+	return nil
+}
+
+// LoginExternalUser returns the user account of an external identity and creates it if register is true.
+func LoginExternalUser(u ExternalUser, register bool) (*User, error) {
+	if u.AuthSrc == AuthLocal || u.AuthID == "" {
+		return nil, fmt.Errorf("user: missing external identity")
+	}
+
+	if u.Role == "" {
+		return nil, fmt.Errorf("user: %s has no authorized role", txt.Quote(u.UserName))
+	}
+
+	m := FindUserByAuth(u.AuthSrc, u.AuthID)
+
+	if m == nil {
+		if !register {
+			return nil, fmt.Errorf("user: %s is not registered", txt.Quote(u.UserName))
+		}
+
+		m = &User{
+			UserName:     strings.TrimSpace(u.UserName),
+			FullName:     txt.Clip(u.FullName, 128),
+			PrimaryEmail: u.Email,
+			AuthSrc:      u.AuthSrc,
+			AuthID:       u.AuthID,
+		}
+
+		if err := m.SetRole(u.Role); err != nil {
+			return nil, err
+		}
+
+		if err := m.Validate(); err != nil {
+			return nil, fmt.Errorf("user: %s (register %s)", err, txt.Quote(m.UserName))
+		}
+
+		if err := m.Create(); err != nil {
+			return nil, err
+		}
+
+		log.Infof("user: registered %s via %s", txt.Quote(m.UserName), u.AuthSrc)
+	} else if m.UserDisabled {
+		return nil, fmt.Errorf("user: %s is disabled", txt.Quote(m.UserName))
+	} else {
+		if err := m.SetRole(u.Role); err != nil {
+			return nil, err
+		}
+
+		if u.FullName != "" {
+			m.FullName = txt.Clip(u.FullName, 128)
+		}
+
+		if u.Email != "" {
+			m.PrimaryEmail = u.Email
+		}
+
+		if err := m.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := Db().Model(m).UpdateColumn("login_at", TimeStamp()).Error; err != nil {
+		log.Errorf("user: %s (update last login)", err)
+	}
+
+	return m, nil
+}
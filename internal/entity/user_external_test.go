@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_SetRole(t *testing.T) {
+	m := User{RoleAdmin: true}
+
+	assert.NoError(t, m.SetRole(acl.RoleFamily))
+	assert.False(t, m.RoleAdmin)
+	assert.True(t, m.RoleFamily)
+	assert.Equal(t, acl.RoleFamily, m.Role())
+
+	assert.Error(t, m.SetRole(acl.RolePartner))
+	assert.True(t, m.RoleFamily)
+}
+
+func TestLoginExternalUser(t *testing.T) {
+	u := ExternalUser{AuthSrc: AuthOIDC, AuthID: "oidc-sub-123", UserName: "anna.oidc", FullName: "Anna", Email: "anna.oidc@example.com", Role: acl.RoleFamily}
+
+	t.Run("not registered", func(t *testing.T) {
+		m, err := LoginExternalUser(u, false)
+
+		assert.Error(t, err)
+		assert.Nil(t, m)
+	})
+	t.Run("register", func(t *testing.T) {
+		m, err := LoginExternalUser(u, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, m.Registered())
+		assert.Equal(t, acl.RoleFamily, m.Role())
+		assert.Equal(t, AuthOIDC, m.AuthSrc)
+	})
+	t.Run("update role", func(t *testing.T) {
+		admin := u
+		admin.Role = acl.RoleAdmin
+		admin.FullName = "Anna Admin"
+
+		m, err := LoginExternalUser(admin, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, acl.RoleAdmin, m.Role())
+		assert.Equal(t, "Anna Admin", FindUserByAuth(AuthOIDC, "oidc-sub-123").FullName)
+	})
+	t.Run("no role", func(t *testing.T) {
+		none := u
+		none.Role = ""
+
+		_, err := LoginExternalUser(none, true)
+
+		assert.Error(t, err)
+	})
+	t.Run("name exists", func(t *testing.T) {
+		other := u
+		other.AuthID = "oidc-sub-456"
+		other.Email = ""
+
+		_, err := LoginExternalUser(other, true)
+
+		assert.Error(t, err)
+	})
+}
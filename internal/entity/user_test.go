@@ -387,6 +387,44 @@ func TestUser_Role(t *testing.T) {
 	})
 }
 
+func TestUser_CanUseWebDAV(t *testing.T) {
+	t.Run("admin", func(t *testing.T) {
+		p := User{UserUID: "uqxetse3cy5eo9z2", UserName: "Hanna", RoleAdmin: true}
+		assert.True(t, p.CanUseWebDAV())
+	})
+	t.Run("family", func(t *testing.T) {
+		p := User{UserUID: "uqxetse3cy5eo9z2", UserName: "Hanna", RoleFamily: true}
+		assert.False(t, p.CanUseWebDAV())
+		p.WebDAV = true
+		assert.True(t, p.CanUseWebDAV())
+	})
+	t.Run("disabled", func(t *testing.T) {
+		p := User{UserUID: "uqxetse3cy5eo9z2", UserName: "Hanna", RoleAdmin: true, UserDisabled: true}
+		assert.False(t, p.CanUseWebDAV())
+	})
+	t.Run("unknown", func(t *testing.T) {
+		assert.False(t, UnknownUser.CanUseWebDAV())
+	})
+}
+
+func TestUser_SetLocale(t *testing.T) {
+	m := &User{UserName: "set-locale", PrimaryEmail: "set-locale@example.com"}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, m.SetLocale(" de "))
+	assert.Equal(t, "de", FindUserByUID(m.UserUID).UserLocale)
+
+	// Empty values are ignored.
+	assert.NoError(t, m.SetLocale(""))
+	assert.Equal(t, "de", FindUserByUID(m.UserUID).UserLocale)
+
+	assert.NoError(t, UnknownUser.SetLocale("fr"))
+	assert.Equal(t, "", UnknownUser.UserLocale)
+}
+
 func TestUser_Validate(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
 		u := &User{
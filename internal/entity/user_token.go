@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// ResetTokenExpires is the max age of password reset tokens.
+const ResetTokenExpires = 24 * time.Hour
+
+// newToken returns a random token for email links and the hash that is stored in the database.
+func newToken() (token, hash string) {
+	token = strings.ReplaceAll(rnd.UUID(), "-", "")
+
+	return token, tokenHash(token)
+}
+
+// tokenHash returns the hex encoded SHA256 hash of a token.
+func tokenHash(token string) string {
+	h := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(h[:])
+}
+
+// validToken tests if the token has the expected format.
+func validToken(token string) bool {
+	return len(token) == 32 && rnd.IsHex(token)
+}
+
+// FindUserByEmail returns the registered user with the primary email address or nil if not found.
+func FindUserByEmail(email string) *User {
+	email = strings.TrimSpace(email)
+
+	if email == "" {
+		return nil
+	}
+
+	result := User{}
+
+	if err := Db().Where("primary_email = ? AND user_disabled = 0", email).First(&result).Error; err != nil {
+		return nil
+	} else if !result.Registered() {
+		return nil
+	}
+
+	return &result
+}
+
+// FindUserByResetToken returns the user with a valid password reset token or nil if not found.
+func FindUserByResetToken(token string) *User {
+	if !validToken(token) {
+		return nil
+	}
+
+	result := User{}
+
+	if err := Db().Where("reset_token = ? AND user_disabled = 0", tokenHash(token)).First(&result).Error; err != nil {
+		return nil
+	} else if result.ResetAt == nil || result.ResetAt.Before(TimeStamp().Add(-1*ResetTokenExpires)) {
+		return nil
+	}
+
+	return &result
+}
+
+// FindUserByConfirmToken returns the user with the email confirmation token or nil if not found.
+func FindUserByConfirmToken(token string) *User {
+	if !validToken(token) {
+		return nil
+	}
+
+	result := User{}
+
+	if err := Db().Where("confirm_token = ?", tokenHash(token)).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// NewResetToken creates a password reset token, only its hash is stored.
+func (m *User) NewResetToken() (token string, err error) {
+	if !m.Registered() {
+		return "", fmt.Errorf("only registered users can reset their password")
+	}
+
+	token, m.ResetToken = newToken()
+	m.ResetAt = TimePointer()
+
+	return token, Db().Model(m).Updates(Values{"ResetToken": m.ResetToken, "ResetAt": m.ResetAt}).Error
+}
+
+// ResetPassword sets a new password and invalidates the reset token.
+func (m *User) ResetPassword(password string) error {
+	if err := m.SetPassword(password); err != nil {
+		return err
+	}
+
+	m.ResetToken = ""
+	m.ResetAt = nil
+	m.LoginAttempts = 0
+
+	return Db().Model(m).Updates(Values{"ResetToken": "", "ResetAt": nil, "LoginAttempts": 0}).Error
+}
+
+// NewConfirmToken creates an email confirmation token, only its hash is stored.
+func (m *User) NewConfirmToken() (token string, err error) {
+	if m.PrimaryEmail == "" {
+		return "", fmt.Errorf("user %s has no email address", m.String())
+	}
+
+	token, m.ConfirmToken = newToken()
+
+	return token, Db().Model(m).Update("ConfirmToken", m.ConfirmToken).Error
+}
+
+// ConfirmEmail marks the primary email address as confirmed.
+func (m *User) ConfirmEmail() error {
+	m.EmailConfirmed = true
+	m.ConfirmToken = ""
+
+	return Db().Model(m).Updates(Values{"EmailConfirmed": true, "ConfirmToken": ""}).Error
+}
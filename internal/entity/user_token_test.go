@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindUserByEmail(t *testing.T) {
+	t.Run("bob", func(t *testing.T) {
+		m := FindUserByEmail(" bob@example.com ")
+
+		if m == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, "bob", m.UserName)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, FindUserByEmail("friend@example.com"))
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, FindUserByEmail(""))
+	})
+}
+
+func TestUser_NewResetToken(t *testing.T) {
+	m := &User{UserName: "reset-token", PrimaryEmail: "reset-token@example.com"}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := m.NewResetToken()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, token, 32)
+	assert.NotEqual(t, token, m.ResetToken)
+
+	t.Run("found", func(t *testing.T) {
+		found := FindUserByResetToken(token)
+
+		if found == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, m.UserUID, found.UserUID)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Nil(t, FindUserByResetToken("foo"))
+		assert.Nil(t, FindUserByResetToken(m.ResetToken))
+	})
+	t.Run("expired", func(t *testing.T) {
+		expired := TimeStamp().Add(-1 * (ResetTokenExpires + time.Minute))
+
+		if err := Db().Model(m).UpdateColumn("ResetAt", expired).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindUserByResetToken(token))
+	})
+	t.Run("reset", func(t *testing.T) {
+		if err := m.ResetPassword("newpassword"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, m.ResetToken)
+		assert.False(t, m.InvalidPassword("newpassword"))
+		assert.Nil(t, FindUserByResetToken(token))
+	})
+	t.Run("unregistered", func(t *testing.T) {
+		_, err := (&User{}).NewResetToken()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestUser_NewConfirmToken(t *testing.T) {
+	m := &User{UserName: "confirm-token", PrimaryEmail: "confirm-token@example.com"}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := m.NewConfirmToken()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found := FindUserByConfirmToken(token)
+
+	if found == nil {
+		t.Fatal("result should not be nil")
+	}
+
+	assert.False(t, found.EmailConfirmed)
+
+	if err := found.ConfirmEmail(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, found.EmailConfirmed)
+	assert.Nil(t, FindUserByConfirmToken(token))
+
+	t.Run("no email", func(t *testing.T) {
+		_, err := (&User{UserName: "no-email"}).NewConfirmToken()
+
+		assert.Error(t, err)
+	})
+}
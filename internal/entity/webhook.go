@@ -0,0 +1,139 @@
+package entity
+
+import (
+	"bytes"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/ulule/deepcopier"
+)
+
+// WebhookRetryLimit is the default number of retries for failed deliveries.
+const WebhookRetryLimit = 3
+
+type Webhooks []Webhook
+
+// Webhook represents an outgoing webhook that receives library events, e.g. new photos.
+type Webhook struct {
+	ID             uint       `gorm:"primary_key" json:"-" yaml:"-"`
+	WebhookUID     string     `gorm:"type:VARBINARY(42);unique_index;" json:"UID" yaml:"UID"`
+	WebhookName    string     `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name,omitempty"`
+	WebhookURL     string     `gorm:"type:VARBINARY(1024);" json:"URL" yaml:"URL"`
+	WebhookTopics  string     `gorm:"type:VARBINARY(1024);" json:"Topics" yaml:"Topics"`
+	WebhookFilter  string     `gorm:"type:VARBINARY(1024);" json:"Filter" yaml:"Filter,omitempty"`
+	WebhookSecret  string     `gorm:"type:VARBINARY(255);" json:"Secret" yaml:"-"`
+	WebhookEnabled bool       `json:"Enabled" yaml:"Enabled"`
+	RetryLimit     int        `json:"RetryLimit" yaml:"RetryLimit"`
+	CreatedAt      time.Time  `deepcopier:"skip" json:"CreatedAt" yaml:"CreatedAt"`
+	UpdatedAt      time.Time  `deepcopier:"skip" json:"UpdatedAt" yaml:"UpdatedAt"`
+	DeletedAt      *time.Time `deepcopier:"skip" sql:"index" json:"-" yaml:"-"`
+}
+
+// TableName returns the entity database table name.
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Webhook) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.WebhookUID, 'w') {
+		return nil
+	}
+
+	return scope.SetColumn("WebhookUID", rnd.PPID('w'))
+}
+
+// CreateWebhook creates a new webhook entity in the database, a random secret is generated if none was provided.
+func CreateWebhook(f form.Webhook) (m *Webhook, err error) {
+	m = &Webhook{
+		WebhookUID: rnd.PPID('w'),
+	}
+
+	if f.RetryLimit == 0 {
+		f.RetryLimit = WebhookRetryLimit
+	}
+
+	if f.WebhookSecret == "" {
+		f.WebhookSecret = strings.ReplaceAll(rnd.UUID(), "-", "")
+	}
+
+	err = m.SaveForm(f)
+
+	return m, err
+}
+
+// SaveForm updates the entity using form data and stores it in the database, an empty secret is ignored.
+func (m *Webhook) SaveForm(f form.Webhook) error {
+	secret := m.WebhookSecret
+
+	if err := deepcopier.Copy(m).From(f); err != nil {
+		return err
+	}
+
+	if m.WebhookSecret == "" {
+		m.WebhookSecret = secret
+	}
+
+	m.WebhookName = txt.Clip(strings.TrimSpace(m.WebhookName), 160)
+
+	return Db().Save(m).Error
+}
+
+// Delete deletes the entity from the database.
+func (m *Webhook) Delete() error {
+	return Db().Delete(m).Error
+}
+
+// Topics returns the event topic patterns, e.g. "photos.created" or "albums.*".
+func (m *Webhook) Topics() (result []string) {
+	for _, s := range strings.Split(m.WebhookTopics, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
+// Subscribed tests if the webhook is enabled and subscribed to the topic.
+func (m *Webhook) Subscribed(topic string) bool {
+	if !m.WebhookEnabled {
+		return false
+	}
+
+	for _, pattern := range m.Topics() {
+		if pattern == topic {
+			return true
+		} else if ok, _ := path.Match(pattern, topic); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Filtered tests if the JSON event data should be skipped because it doesn't contain any of the UIDs
+// in the webhook filter, e.g. of a person or an album. An empty filter matches all events.
+func (m *Webhook) Filtered(data []byte) bool {
+	filter := strings.TrimSpace(m.WebhookFilter)
+
+	if filter == "" {
+		return false
+	}
+
+	for _, uid := range strings.Split(filter, ",") {
+		if uid = strings.TrimSpace(uid); uid == "" {
+			continue
+		} else if bytes.Contains(data, []byte(strconv.Quote(uid))) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// WebhookDeliveryRetention is the max age of webhook delivery log entries.
+const WebhookDeliveryRetention = 30 * 24 * time.Hour
+
+type WebhookDeliveries []WebhookDelivery
+
+// WebhookDelivery represents a logged webhook request including the number of attempts and the result.
+type WebhookDelivery struct {
+	ID               uint      `gorm:"primary_key" json:"ID" yaml:"ID"`
+	WebhookUID       string    `gorm:"type:VARBINARY(42);index;" json:"WebhookUID" yaml:"WebhookUID"`
+	DeliveryUUID     string    `gorm:"type:VARBINARY(42);" json:"UUID" yaml:"UUID"`
+	EventName        string    `gorm:"type:VARBINARY(128);" json:"Event" yaml:"Event"`
+	StatusCode       int       `json:"StatusCode" yaml:"StatusCode,omitempty"`
+	DeliveryAttempts int       `json:"Attempts" yaml:"Attempts"`
+	DeliveryError    string    `gorm:"type:VARCHAR(1024);" json:"Error" yaml:"Error,omitempty"`
+	DeliveryDuration int64     `json:"Duration" yaml:"Duration"`
+	DeliveredAt      time.Time `sql:"index" json:"DeliveredAt" yaml:"DeliveredAt"`
+}
+
+// TableName returns the entity database table name.
+func (WebhookDelivery) TableName() string {
+	return "webhooks_deliveries"
+}
+
+// Success tests if the webhook was delivered successfully.
+func (m *WebhookDelivery) Success() bool {
+	return m.DeliveryError == "" && m.StatusCode >= 200 && m.StatusCode < 300
+}
+
+// Create inserts the delivery log entry to the database.
+func (m *WebhookDelivery) Create() error {
+	if m.DeliveredAt.IsZero() {
+		m.DeliveredAt = TimeStamp()
+	}
+
+	m.DeliveryError = txt.Clip(m.DeliveryError, 1024)
+
+	return Db().Create(m).Error
+}
+
+// PruneWebhookDeliveries removes delivery log entries older than maxAge.
+func PruneWebhookDeliveries(maxAge time.Duration) (deleted int64, err error) {
+	res := UnscopedDb().Where("delivered_at < ?", TimeStamp().Add(-1*maxAge)).Delete(WebhookDelivery{})
+
+	return res.RowsAffected, res.Error
+}
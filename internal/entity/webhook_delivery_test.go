@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookDelivery_Success(t *testing.T) {
+	assert.True(t, (&WebhookDelivery{StatusCode: 204}).Success())
+	assert.False(t, (&WebhookDelivery{StatusCode: 500, DeliveryError: "Internal Server Error"}).Success())
+	assert.False(t, (&WebhookDelivery{DeliveryError: "connection refused"}).Success())
+}
+
+func TestWebhookDelivery_Create(t *testing.T) {
+	m := WebhookDelivery{WebhookUID: "wqzfqh4x8opr2c3x", EventName: "photos.created", StatusCode: 502, DeliveryAttempts: 4, DeliveryError: strings.Repeat("x", 2000)}
+
+	assert.NoError(t, m.Create())
+	assert.False(t, m.DeliveredAt.IsZero())
+	assert.LessOrEqual(t, len(m.DeliveryError), 1024)
+}
+
+func TestPruneWebhookDeliveries(t *testing.T) {
+	old := WebhookDelivery{WebhookUID: "wqzfqh4x8opr2c3y", EventName: "albums.updated", DeliveredAt: TimeStamp().Add(-48 * time.Hour)}
+	recent := WebhookDelivery{WebhookUID: "wqzfqh4x8opr2c3y", EventName: "albums.updated"}
+
+	assert.NoError(t, old.Create())
+	assert.NoError(t, recent.Create())
+
+	deleted, err := PruneWebhookDeliveries(24 * time.Hour)
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+
+	var count int
+
+	UnscopedDb().Model(WebhookDelivery{}).Where("webhook_uid = ?", "wqzfqh4x8opr2c3y").Count(&count)
+
+	assert.Equal(t, 1, count)
+}
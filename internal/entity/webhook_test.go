@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	t.Run("RandomSecret", func(t *testing.T) {
+		m, err := CreateWebhook(form.Webhook{WebhookName: " Home Assistant ", WebhookURL: "http://homeassistant.local:8123/api/webhook/photos", WebhookTopics: "photos.created"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, rnd.IsPPID(m.WebhookUID, 'w'))
+		assert.Equal(t, "Home Assistant", m.WebhookName)
+		assert.Len(t, m.WebhookSecret, 32)
+		assert.Equal(t, WebhookRetryLimit, m.RetryLimit)
+		assert.False(t, m.WebhookEnabled)
+		assert.NoError(t, m.Delete())
+	})
+	t.Run("KeepSecret", func(t *testing.T) {
+		m, err := CreateWebhook(form.Webhook{WebhookURL: "https://example.com/", WebhookTopics: "albums.*", WebhookSecret: "foo", WebhookEnabled: true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "foo", m.WebhookSecret)
+
+		f, err := form.NewWebhook(m)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f.WebhookSecret = ""
+		f.WebhookTopics = "photos.*"
+
+		assert.NoError(t, m.SaveForm(f))
+		assert.Equal(t, "foo", m.WebhookSecret)
+		assert.Equal(t, "photos.*", m.WebhookTopics)
+		assert.NoError(t, m.Delete())
+	})
+}
+
+func TestWebhook_Subscribed(t *testing.T) {
+	m := Webhook{WebhookTopics: "photos.created, albums.*,,", WebhookEnabled: true}
+
+	assert.Equal(t, []string{"photos.created", "albums.*"}, m.Topics())
+	assert.True(t, m.Subscribed("photos.created"))
+	assert.False(t, m.Subscribed("photos.updated"))
+	assert.True(t, m.Subscribed("albums.updated"))
+	assert.True(t, m.Subscribed("albums.photos.added"))
+	assert.False(t, m.Subscribed("labels.created"))
+
+	m.WebhookEnabled = false
+	assert.False(t, m.Subscribed("photos.created"))
+}
+
+func TestWebhook_Filtered(t *testing.T) {
+	data := []byte(`{"uid":"jqu0xs11qekk9jx8","photos":["pt9jtdre2lvl0yh7"]}`)
+
+	assert.False(t, (&Webhook{}).Filtered(data))
+	assert.False(t, (&Webhook{WebhookFilter: "jqu0xs11qekk9jx8"}).Filtered(data))
+	assert.False(t, (&Webhook{WebhookFilter: "at9lxuqxpogaaba7, pt9jtdre2lvl0yh7"}).Filtered(data))
+	assert.True(t, (&Webhook{WebhookFilter: "at9lxuqxpogaaba7"}).Filtered(data))
+	assert.True(t, (&Webhook{WebhookFilter: "jqu0xs11qekk9jx"}).Filtered(data))
+}
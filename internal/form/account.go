@@ -1,6 +1,8 @@
 package form
 
 import (
+	"errors"
+
 	"github.com/photoprism/photoprism/internal/remote"
 	"github.com/ulule/deepcopier"
 )
@@ -14,6 +16,11 @@ type Account struct {
 	AccKey        string `json:"AccKey"`
 	AccUser       string `json:"AccUser"`
 	AccPass       string `json:"AccPass"`
+	AccBucket     string `json:"AccBucket"`
+	AccPrefix     string `json:"AccPrefix"`
+	AccRegion     string `json:"AccRegion"`
+	AccSSHKey     string `json:"AccSSHKey"`
+	AccHostKey    string `json:"AccHostKey"`
 	AccError      string `json:"AccError"`
 	AccShare      bool   `json:"AccShare"`
 	AccSync       bool   `json:"AccSync"`
@@ -36,7 +43,20 @@ func NewAccount(m interface{}) (f Account, err error) {
 }
 
 func (f *Account) ServiceDiscovery() error {
-	acc, err := remote.Discover(f.AccURL, f.AccUser, f.AccPass)
+	// S3-compatible services can't be discovered, the bucket name is required instead.
+	if f.AccType == remote.ServiceS3 {
+		if f.AccURL == "" {
+			return errors.New("service URL is empty")
+		} else if f.AccBucket == "" {
+			return errors.New("bucket name is empty")
+		} else if f.AccName == "" {
+			f.AccName = f.AccBucket
+		}
+
+		return nil
+	}
+
+	acc, err := remote.Discover(f.AccURL, f.AccUser, f.AccPass, f.AccHostKey)
 
 	if err != nil {
 		return err
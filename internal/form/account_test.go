@@ -54,4 +54,17 @@ func TestAccount_ServiceDiscovery(t *testing.T) {
 		err := account.ServiceDiscovery()
 		assert.Equal(t, "service URL is empty", err.Error())
 	})
+	t.Run("s3", func(t *testing.T) {
+		account := Account{AccOwner: "bar", AccURL: "https://s3.example.com", AccType: "s3", AccBucket: "photos"}
+
+		err := account.ServiceDiscovery()
+		assert.Nil(t, err)
+		assert.Equal(t, "photos", account.AccName)
+	})
+	t.Run("s3 bucket empty", func(t *testing.T) {
+		account := Account{AccOwner: "bar", AccURL: "https://s3.example.com", AccType: "s3"}
+
+		err := account.ServiceDiscovery()
+		assert.Equal(t, "bucket name is empty", err.Error())
+	})
 }
@@ -6,6 +6,7 @@ import "github.com/ulule/deepcopier"
 type Album struct {
 	Thumb            string `json:"Thumb"`
 	ThumbSrc         string `json:"ThumbSrc"`
+	ParentUID        string `json:"ParentUID"`
 	AlbumType        string `json:"Type"`
 	AlbumTitle       string `json:"Title"`
 	AlbumLocation    string `json:"Location"`
@@ -4,6 +4,8 @@ package form
 type AlbumSearch struct {
 	Query    string `form:"q"`
 	ID       string `form:"id"`
+	Parent   string `form:"parent"`
+	Root     bool   `form:"root"`
 	Type     string `form:"type"`
 	Location string `form:"location"`
 	Category string `form:"category"`
@@ -0,0 +1,33 @@
+package form
+
+import "time"
+
+// AuditSearch represents search form fields for "/api/v1/audit".
+type AuditSearch struct {
+	Query    string    `form:"q"`
+	Actor    string    `form:"actor"`
+	Resource string    `form:"resource"`
+	UID      string    `form:"uid"`
+	Action   string    `form:"action"`
+	Outcome  string    `form:"outcome"`
+	Before   time.Time `form:"before" time_format:"2006-01-02"`
+	After    time.Time `form:"after" time_format:"2006-01-02"`
+	Count    int       `form:"count" serialize:"-"`
+	Offset   int       `form:"offset" serialize:"-"`
+}
+
+func (f *AuditSearch) GetQuery() string {
+	return f.Query
+}
+
+func (f *AuditSearch) SetQuery(q string) {
+	f.Query = q
+}
+
+func (f *AuditSearch) ParseQueryString() error {
+	return ParseQueryString(f)
+}
+
+func NewAuditSearch(query string) AuditSearch {
+	return AuditSearch{Query: query}
+}
@@ -0,0 +1,29 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditSearch_ParseQueryString(t *testing.T) {
+	t.Run("valid query", func(t *testing.T) {
+		form := &AuditSearch{Query: "actor:admin resource:files outcome:failed after:2021-06-01"}
+
+		if err := form.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "admin", form.Actor)
+		assert.Equal(t, "files", form.Resource)
+		assert.Equal(t, "failed", form.Outcome)
+		assert.Equal(t, time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), form.After)
+	})
+}
+
+func TestNewAuditSearch(t *testing.T) {
+	r := NewAuditSearch("actor:admin")
+	assert.IsType(t, AuditSearch{}, r)
+	assert.Equal(t, "actor:admin", r.GetQuery())
+}
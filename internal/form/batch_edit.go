@@ -0,0 +1,62 @@
+package form
+
+import (
+	"fmt"
+	"time"
+)
+
+// BatchEdit represents a partial photo edit form that is applied to all photos in a selection.
+// Fields that are nil remain unchanged.
+type BatchEdit struct {
+	Selection
+	PhotoTitle       *string  `json:"Title"`
+	PhotoDescription *string  `json:"Description"`
+	PhotoLat         *float32 `json:"Lat"`
+	PhotoLng         *float32 `json:"Lng"`
+	PhotoAltitude    *int     `json:"Altitude"`
+	PhotoCountry     *string  `json:"Country"`
+	Keywords         *string  `json:"Keywords"`
+	Subject          *string  `json:"Subject"`
+	Artist           *string  `json:"Artist"`
+	Copyright        *string  `json:"Copyright"`
+	License          *string  `json:"License"`
+	TakenOffset      string   `json:"TakenOffset"`
+}
+
+// NoValues returns true if no value should be changed.
+func (f BatchEdit) NoValues() bool {
+	return f.PhotoTitle == nil && f.PhotoDescription == nil &&
+		f.PhotoLat == nil && f.PhotoLng == nil && f.PhotoAltitude == nil && f.PhotoCountry == nil &&
+		f.Keywords == nil && f.Subject == nil && f.Artist == nil && f.Copyright == nil && f.License == nil &&
+		f.TakenOffset == ""
+}
+
+// Offset returns the time offset that should be added to the date taken, e.g. "-1h30m".
+func (f BatchEdit) Offset() (time.Duration, error) {
+	if f.TakenOffset == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(f.TakenOffset)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid time offset %s", f.TakenOffset)
+	}
+
+	return d, nil
+}
+
+// Validate returns an error if the form contains invalid values.
+func (f BatchEdit) Validate() error {
+	if (f.PhotoLat == nil) != (f.PhotoLng == nil) {
+		return fmt.Errorf("latitude and longitude must be set together")
+	} else if f.PhotoLat != nil && (*f.PhotoLat < -90 || *f.PhotoLat > 90) {
+		return fmt.Errorf("invalid latitude %f", *f.PhotoLat)
+	} else if f.PhotoLng != nil && (*f.PhotoLng < -180 || *f.PhotoLng > 180) {
+		return fmt.Errorf("invalid longitude %f", *f.PhotoLng)
+	}
+
+	_, err := f.Offset()
+
+	return err
+}
@@ -0,0 +1,51 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchEdit_NoValues(t *testing.T) {
+	t.Run("no values", func(t *testing.T) {
+		f := BatchEdit{Selection: Selection{Photos: []string{"foo"}}}
+		assert.True(t, f.NoValues())
+	})
+	t.Run("offset", func(t *testing.T) {
+		f := BatchEdit{TakenOffset: "1h"}
+		assert.False(t, f.NoValues())
+	})
+}
+
+func TestBatchEdit_Offset(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		f := BatchEdit{TakenOffset: "-1h30m"}
+		d, err := f.Offset()
+		assert.NoError(t, err)
+		assert.Equal(t, -90*time.Minute, d)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		f := BatchEdit{TakenOffset: "1 hour"}
+		_, err := f.Offset()
+		assert.Error(t, err)
+	})
+}
+
+func TestBatchEdit_Validate(t *testing.T) {
+	lat, lng := float32(48.51), float32(9.05)
+
+	t.Run("valid", func(t *testing.T) {
+		f := BatchEdit{PhotoLat: &lat, PhotoLng: &lng}
+		assert.NoError(t, f.Validate())
+	})
+	t.Run("lat only", func(t *testing.T) {
+		f := BatchEdit{PhotoLat: &lat}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("invalid lng", func(t *testing.T) {
+		invalid := float32(190)
+		f := BatchEdit{PhotoLat: &lat, PhotoLng: &invalid}
+		assert.Error(t, f.Validate())
+	})
+}
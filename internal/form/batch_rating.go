@@ -0,0 +1,16 @@
+package form
+
+// BatchRating represents a star rating, pick flag and color label update for multiple photos.
+// Fields that are nil remain unchanged.
+type BatchRating struct {
+	Selection
+	Rating     *int    `json:"Rating"`
+	Pick       *bool   `json:"Pick"`
+	Reject     *bool   `json:"Reject"`
+	ColorLabel *string `json:"ColorLabel"`
+}
+
+// NoValues returns true if no value should be changed.
+func (f BatchRating) NoValues() bool {
+	return f.Rating == nil && f.Pick == nil && f.Reject == nil && f.ColorLabel == nil
+}
@@ -0,0 +1,19 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchRating_NoValues(t *testing.T) {
+	t.Run("no values", func(t *testing.T) {
+		f := BatchRating{Selection: Selection{Photos: []string{"foo"}}}
+		assert.True(t, f.NoValues())
+	})
+	t.Run("rating", func(t *testing.T) {
+		rating := 3
+		f := BatchRating{Selection: Selection{Photos: []string{"foo"}}, Rating: &rating}
+		assert.False(t, f.NoValues())
+	})
+}
@@ -0,0 +1,7 @@
+package form
+
+// FaceSplit represents face markers to be moved to a new face cluster.
+type FaceSplit struct {
+	Markers []string `json:"Markers"`
+	SubjUID string   `json:"SubjUID"`
+}
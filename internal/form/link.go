@@ -9,4 +9,5 @@ type Link struct {
 	MaxViews    uint   `json:"MaxViews"`
 	CanComment  bool   `json:"CanComment"`
 	CanEdit     bool   `json:"CanEdit"`
+	Email       string `json:"Email"`
 }
@@ -0,0 +1,6 @@
+package form
+
+// PasswordReset represents a password reset request form.
+type PasswordReset struct {
+	Email string `json:"email"`
+}
@@ -44,6 +44,11 @@ type Photo struct {
 	PhotoPrivate     bool      `json:"Private"`
 	PhotoScan        bool      `json:"Scan"`
 	PhotoPanorama    bool      `json:"Panorama"`
+	PhotoRating      int8      `json:"Rating"`
+	PhotoPick        bool      `json:"Pick"`
+	PhotoReject      bool      `json:"Reject"`
+	PhotoColorLabel  string    `json:"ColorLabel"`
+	RatingSrc        string    `json:"RatingSrc"`
 	PhotoAltitude    int       `json:"Altitude"`
 	PhotoLat         float32   `json:"Lat"`
 	PhotoLng         float32   `json:"Lng"`
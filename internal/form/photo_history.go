@@ -0,0 +1,8 @@
+package form
+
+import "time"
+
+// RevertEdits represents a request to revert all metadata changes after a point in time.
+type RevertEdits struct {
+	After time.Time `json:"After"`
+}
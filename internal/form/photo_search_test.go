@@ -95,6 +95,28 @@ func TestParseQueryString(t *testing.T) {
 		assert.Equal(t, uint(0x61a8), form.Dist)
 		assert.Equal(t, float32(33.45343), form.Lat)
 	})
+	t.Run("tag path", func(t *testing.T) {
+		form := &PhotoSearch{Query: "tag:places/europe/norway"}
+
+		if err := form.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "places/europe/norway", form.Tag)
+		assert.Equal(t, "", form.Query)
+	})
+	t.Run("rating", func(t *testing.T) {
+		form := &PhotoSearch{Query: "rating:>=4 reject:true color-label:red"}
+
+		if err := form.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ">=4", form.Rating)
+		assert.True(t, form.Reject)
+		assert.Equal(t, "red", form.ColorLabel)
+		assert.Equal(t, "", form.Query)
+	})
 	t.Run("valid query 2", func(t *testing.T) {
 		form := &PhotoSearch{Query: "chroma:200 title:\"te:st\" after:2018-01-15 favorite:true lng:33.45343166666667"}
 
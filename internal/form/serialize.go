@@ -91,7 +91,7 @@ func Unserialize(f SearchForm, q string) (result error) {
 	for _, char := range q {
 		if unicode.IsSpace(char) && !escaped {
 			if isKeyValue {
-				fieldName := strings.Title(string(key))
+				fieldName := strings.ReplaceAll(strings.Title(string(key)), "-", "")
 				field := formValues.FieldByName(fieldName)
 				stringValue := string(value)
 
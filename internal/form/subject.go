@@ -18,3 +18,8 @@ func NewSubject(m interface{}) (f Subject, err error) {
 
 	return f, err
 }
+
+// SubjectMerge represents a list of subjects to be merged into another subject.
+type SubjectMerge struct {
+	Subjects []string `json:"Subjects"`
+}
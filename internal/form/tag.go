@@ -0,0 +1,7 @@
+package form
+
+// Tag represents a tag edit form.
+type Tag struct {
+	TagName   string `json:"Name"`
+	ParentUID string `json:"ParentUID"`
+}
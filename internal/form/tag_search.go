@@ -0,0 +1,27 @@
+package form
+
+// TagSearch represents search form fields for "/api/v1/tags".
+type TagSearch struct {
+	Query  string `form:"q"`
+	ID     string `form:"id"`
+	Parent string `form:"parent"`
+	All    bool   `form:"all"`
+	Count  int    `form:"count" binding:"required" serialize:"-"`
+	Offset int    `form:"offset" serialize:"-"`
+}
+
+func (f *TagSearch) GetQuery() string {
+	return f.Query
+}
+
+func (f *TagSearch) SetQuery(q string) {
+	f.Query = q
+}
+
+func (f *TagSearch) ParseQueryString() error {
+	return ParseQueryString(f)
+}
+
+func NewTagSearch(query string) TagSearch {
+	return TagSearch{Query: query}
+}
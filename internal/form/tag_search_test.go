@@ -0,0 +1,28 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQueryStringTag(t *testing.T) {
+	t.Run("valid query", func(t *testing.T) {
+		form := &TagSearch{Query: "parent:t000000000000001 all:true count:10"}
+
+		if err := form.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "t000000000000001", form.Parent)
+		assert.Equal(t, true, form.All)
+		assert.Equal(t, 10, form.Count)
+		assert.Equal(t, "", form.Query)
+	})
+}
+
+func TestNewTagSearch(t *testing.T) {
+	r := NewTagSearch("kids")
+	assert.IsType(t, TagSearch{}, r)
+	assert.Equal(t, "kids", r.Query)
+}
@@ -0,0 +1,77 @@
+package form
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+)
+
+// TimeShift represents a date taken correction for photos in a selection or matching a search filter.
+type TimeShift struct {
+	Selection
+	Filter        string `json:"Filter"`        // Photo search query, e.g. "camera:2 after:2021-06-01 path:2021/trip".
+	Offset        string `json:"Offset"`        // Time offset, e.g. "-1h30m".
+	TimeZone      string `json:"TimeZone"`      // New time zone, e.g. "Europe/Berlin".
+	Reference     string `json:"Reference"`     // Photo UID of a picture that shows a clock.
+	ReferenceTime string `json:"ReferenceTime"` // Time shown on the clock, e.g. "2021-06-01 14:03:00".
+	Sidecar       bool   `json:"Sidecar"`       // Write the result to YAML sidecar files.
+	UserUID       string `json:"-"`             // User who requested the change.
+}
+
+// Duration returns the parsed time offset.
+func (f TimeShift) Duration() (time.Duration, error) {
+	if f.Offset == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(f.Offset)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid time offset %s", f.Offset)
+	}
+
+	return d, nil
+}
+
+// ClockTime returns the time shown on the reference photo as local time without time zone.
+func (f TimeShift) ClockTime() (time.Time, error) {
+	t, err := dateparse.ParseIn(f.ReferenceTime, time.UTC)
+
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid reference time %s", f.ReferenceTime)
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC), nil
+}
+
+// Validate returns an error if the form contains invalid values.
+func (f TimeShift) Validate() error {
+	if f.Empty() && strings.TrimSpace(f.Filter) == "" {
+		return fmt.Errorf("no photos selected")
+	}
+
+	if (f.Reference == "") != (f.ReferenceTime == "") {
+		return fmt.Errorf("reference photo and time must be set together")
+	} else if f.Reference != "" && f.Offset != "" {
+		return fmt.Errorf("offset can't be combined with a reference photo")
+	} else if f.Reference == "" && f.Offset == "" && f.TimeZone == "" {
+		return fmt.Errorf("offset, time zone or reference photo required")
+	}
+
+	if f.TimeZone != "" {
+		if _, err := time.LoadLocation(f.TimeZone); err != nil {
+			return fmt.Errorf("invalid time zone %s", f.TimeZone)
+		}
+	}
+
+	if f.Reference != "" {
+		_, err := f.ClockTime()
+		return err
+	}
+
+	_, err := f.Duration()
+
+	return err
+}
@@ -0,0 +1,57 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeShift_Duration(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		d, err := TimeShift{Offset: "-1h"}.Duration()
+		assert.NoError(t, err)
+		assert.Equal(t, -time.Hour, d)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := TimeShift{Offset: "1 day"}.Duration()
+		assert.Error(t, err)
+	})
+}
+
+func TestTimeShift_ClockTime(t *testing.T) {
+	result, err := TimeShift{ReferenceTime: "2021-06-01 14:03:00"}.ClockTime()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 6, 1, 14, 3, 0, 0, time.UTC), result)
+}
+
+func TestTimeShift_Validate(t *testing.T) {
+	t.Run("offset", func(t *testing.T) {
+		f := TimeShift{Selection: Selection{Photos: []string{"foo"}}, Offset: "1h"}
+		assert.NoError(t, f.Validate())
+	})
+	t.Run("filter and zone", func(t *testing.T) {
+		f := TimeShift{Filter: "camera:2", TimeZone: "Europe/Berlin"}
+		assert.NoError(t, f.Validate())
+	})
+	t.Run("reference", func(t *testing.T) {
+		f := TimeShift{Filter: "camera:2", Reference: "pt9jtdre2lvl0yh7", ReferenceTime: "2021-06-01 14:03:00"}
+		assert.NoError(t, f.Validate())
+	})
+	t.Run("no photos", func(t *testing.T) {
+		f := TimeShift{Offset: "1h"}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("no values", func(t *testing.T) {
+		f := TimeShift{Filter: "camera:2"}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("reference and offset", func(t *testing.T) {
+		f := TimeShift{Filter: "camera:2", Offset: "1h", Reference: "pt9jtdre2lvl0yh7", ReferenceTime: "2021-06-01 14:03:00"}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("invalid zone", func(t *testing.T) {
+		f := TimeShift{Filter: "camera:2", TimeZone: "Mars/Olympus"}
+		assert.Error(t, f.Validate())
+	})
+}
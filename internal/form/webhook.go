@@ -0,0 +1,56 @@
+package form
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ulule/deepcopier"
+)
+
+// Webhook represents an outgoing webhook form.
+type Webhook struct {
+	WebhookName    string `json:"Name"`
+	WebhookURL     string `json:"URL"`
+	WebhookTopics  string `json:"Topics"`
+	WebhookFilter  string `json:"Filter"`
+	WebhookSecret  string `json:"Secret"`
+	WebhookEnabled bool   `json:"Enabled"`
+	RetryLimit     int    `json:"RetryLimit"`
+}
+
+func NewWebhook(m interface{}) (f Webhook, err error) {
+	err = deepcopier.Copy(m).To(&f)
+
+	return f, err
+}
+
+// Validate returns an error if the webhook url or topic patterns are invalid.
+func (f *Webhook) Validate() error {
+	f.WebhookURL = strings.TrimSpace(f.WebhookURL)
+
+	if f.WebhookURL == "" {
+		return errors.New("url is empty")
+	} else if u, err := url.Parse(f.WebhookURL); err != nil {
+		return err
+	} else if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return errors.New("url must start with http:// or https://")
+	}
+
+	if strings.TrimSpace(f.WebhookTopics) == "" {
+		return errors.New("no topics")
+	}
+
+	for _, t := range strings.Split(f.WebhookTopics, ",") {
+		if _, err := path.Match(strings.TrimSpace(t), ""); err != nil {
+			return errors.New("invalid topic pattern")
+		}
+	}
+
+	if f.RetryLimit < 0 {
+		return errors.New("retry limit must not be negative")
+	}
+
+	return nil
+}
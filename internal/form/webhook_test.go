@@ -0,0 +1,53 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWebhook(t *testing.T) {
+	m := struct {
+		WebhookName   string
+		WebhookURL    string
+		WebhookTopics string
+		RetryLimit    int
+	}{
+		WebhookName:   "Home Assistant",
+		WebhookURL:    "http://homeassistant.local:8123/api/webhook/photos",
+		WebhookTopics: "photos.created",
+		RetryLimit:    5,
+	}
+
+	f, err := NewWebhook(m)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Home Assistant", f.WebhookName)
+	assert.Equal(t, "http://homeassistant.local:8123/api/webhook/photos", f.WebhookURL)
+	assert.Equal(t, "photos.created", f.WebhookTopics)
+	assert.Equal(t, 5, f.RetryLimit)
+}
+
+func TestWebhook_Validate(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		f := Webhook{WebhookURL: " https://matrix.example.com/hook ", WebhookTopics: "photos.created, albums.*"}
+		assert.NoError(t, f.Validate())
+		assert.Equal(t, "https://matrix.example.com/hook", f.WebhookURL)
+	})
+	t.Run("EmptyURL", func(t *testing.T) {
+		f := Webhook{WebhookTopics: "photos.*"}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("Scheme", func(t *testing.T) {
+		f := Webhook{WebhookURL: "ftp://example.com/", WebhookTopics: "photos.*"}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("NoTopics", func(t *testing.T) {
+		f := Webhook{WebhookURL: "https://example.com/"}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("InvalidTopic", func(t *testing.T) {
+		f := Webhook{WebhookURL: "https://example.com/", WebhookTopics: "photos.["}
+		assert.Error(t, f.Validate())
+	})
+}
@@ -31,6 +31,7 @@ const (
 	ErrZipFailed
 	ErrInvalidCredentials
 	ErrInvalidLink
+	ErrTooManyRequests
 
 	MsgChangesSaved
 	MsgAlbumCreated
@@ -70,6 +71,9 @@ const (
 	MsgAlbumsDeleted
 	MsgZipCreatedIn
 	MsgPermanentlyDeleted
+	MsgPasswordResetSent
+	MsgConfirmationSent
+	MsgEmailConfirmed
 )
 
 var Messages = MessageMap{
@@ -104,6 +108,7 @@ var Messages = MessageMap{
 	ErrZipFailed:          gettext("Failed to create zip file"),
 	ErrInvalidCredentials: gettext("Invalid credentials"),
 	ErrInvalidLink:        gettext("Invalid link"),
+	ErrTooManyRequests:    gettext("Too many requests, please try again later"),
 
 	// Info and confirmation messages:
 	MsgChangesSaved:          gettext("Changes successfully saved"),
@@ -144,4 +149,7 @@ var Messages = MessageMap{
 	MsgAlbumsDeleted:         gettext("Albums deleted"),
 	MsgZipCreatedIn:          gettext("Zip created in %d s"),
 	MsgPermanentlyDeleted:    gettext("Permanently deleted"),
+	MsgPasswordResetSent:     gettext("If the email address is registered, you will receive a link to reset your password"),
+	MsgConfirmationSent:      gettext("Confirmation email sent"),
+	MsgEmailConfirmed:        gettext("Email address confirmed"),
 }
@@ -0,0 +1,308 @@
+/*
+
+Package ldap implements user authentication with LDAP directories such as OpenLDAP or Active Directory.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	ldapv3 "github.com/go-ldap/ldap/v3"
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// DefaultUserFilter finds users by their login name, %s is replaced with the escaped name.
+const DefaultUserFilter = "(&(objectClass=person)(uid=%s))"
+
+// Timeout is the max time to wait for the directory server.
+var Timeout = 15 * time.Second
+
+var (
+	ErrUserNotFound       = errors.New("ldap: user not found")
+	ErrInvalidCredentials = errors.New("ldap: invalid credentials")
+)
+
+// userAttributes are the attributes requested for users.
+var userAttributes = []string{"dn", "entryUUID", "objectGUID", "uid", "sAMAccountName", "cn", "displayName", "mail", "memberOf"}
+
+// Config represents the directory server settings.
+type Config struct {
+	URI          string // e.g. ldaps://ldap.example.com
+	StartTLS     bool   // upgrade unencrypted connections with StartTLS
+	Insecure     bool   // don't verify the server certificate
+	BindDN       string // service account, anonymous search if empty
+	BindPassword string
+	BaseDN       string
+	UserFilter   string // e.g. (&(objectClass=person)(uid=%s))
+	GroupFilter  string // optional, e.g. (&(objectClass=groupOfNames)(member=%s))
+}
+
+// User represents an authenticated directory user.
+type User struct {
+	DN       string
+	ID       string
+	UserName string
+	FullName string
+	Email    string
+	Groups   []string
+}
+
+// Client represents an LDAP authenticator.
+type Client struct {
+	Config
+}
+
+// NewClient returns a new LDAP authenticator.
+func NewClient(conf Config) *Client {
+	if conf.UserFilter == "" {
+		conf.UserFilter = DefaultUserFilter
+	}
+
+	return &Client{Config: conf}
+}
+
+// Host returns the server host name.
+func (c *Client) Host() string {
+	u, err := url.Parse(c.URI)
+
+	if err != nil {
+		return ""
+	}
+
+	return u.Hostname()
+}
+
+// tlsConfig returns the TLS settings for encrypted connections.
+func (c *Client) tlsConfig() *tls.Config {
+	return &tls.Config{ServerName: c.Host(), InsecureSkipVerify: c.Insecure}
+}
+
+// Connect opens a new connection and binds with the service account.
+func (c *Client) Connect() (*ldapv3.Conn, error) {
+	conn, err := ldapv3.DialURL(c.URI, ldapv3.DialWithTLSConfig(c.tlsConfig()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetTimeout(Timeout)
+
+	if c.StartTLS && !strings.HasPrefix(strings.ToLower(c.URI), "ldaps:") {
+		if err := conn.StartTLS(c.tlsConfig()); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if err := c.bindService(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// bindService binds with the service account, if any.
+func (c *Client) bindService(conn *ldapv3.Conn) error {
+	if c.BindDN == "" {
+		return nil
+	}
+
+	return conn.Bind(c.BindDN, c.BindPassword)
+}
+
+// Authenticate verifies the password of a user and returns the directory entry.
+func (c *Client) Authenticate(userName, password string) (*User, error) {
+	userName = strings.TrimSpace(userName)
+
+	// Empty passwords would result in an unauthenticated bind that always succeeds.
+	if userName == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := c.Connect()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	entry, err := c.findUser(conn, userName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.Bind(entry.DN, password); ldapv3.IsErrorWithCode(err, ldapv3.LDAPResultInvalidCredentials) {
+		return nil, ErrInvalidCredentials
+	} else if err != nil {
+		return nil, err
+	}
+
+	user := &User{
+		DN:       entry.DN,
+		ID:       entryID(entry),
+		UserName: firstValue(entry, "uid", "sAMAccountName"),
+		FullName: firstValue(entry, "displayName", "cn"),
+		Email:    entry.GetAttributeValue("mail"),
+	}
+
+	if user.UserName == "" {
+		user.UserName = userName
+	}
+
+	for _, dn := range entry.GetAttributeValues("memberOf") {
+		user.Groups = appendGroup(user.Groups, GroupName(dn))
+	}
+
+	if c.GroupFilter != "" {
+		// Searching groups may not be permitted for the user.
+		if err := c.bindService(conn); err != nil {
+			return nil, err
+		}
+
+		groups, err := c.findGroups(conn, entry.DN)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, g := range groups {
+			user.Groups = appendGroup(user.Groups, g)
+		}
+	}
+
+	log.Debugf("ldap: authenticated %s", user.DN)
+
+	return user, nil
+}
+
+// findUser searches the user with the given login name.
+func (c *Client) findUser(conn *ldapv3.Conn, userName string) (*ldapv3.Entry, error) {
+	req := ldapv3.NewSearchRequest(c.BaseDN, ldapv3.ScopeWholeSubtree, ldapv3.NeverDerefAliases, 2, int(Timeout.Seconds()), false,
+		fmt.Sprintf(c.UserFilter, ldapv3.EscapeFilter(userName)), userAttributes, nil)
+
+	result, err := conn.Search(req)
+
+	if ldapv3.IsErrorWithCode(err, ldapv3.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("ldap: user name %s is ambiguous", userName)
+	} else if err != nil {
+		return nil, err
+	}
+
+	switch len(result.Entries) {
+	case 0:
+		return nil, ErrUserNotFound
+	case 1:
+		return result.Entries[0], nil
+	default:
+		return nil, fmt.Errorf("ldap: user name %s is ambiguous", userName)
+	}
+}
+
+// findGroups returns the names of the groups a user is a member of.
+func (c *Client) findGroups(conn *ldapv3.Conn, userDN string) (groups []string, err error) {
+	req := ldapv3.NewSearchRequest(c.BaseDN, ldapv3.ScopeWholeSubtree, ldapv3.NeverDerefAliases, 0, int(Timeout.Seconds()), false,
+		fmt.Sprintf(c.GroupFilter, ldapv3.EscapeFilter(userDN)), []string{"cn"}, nil)
+
+	result, err := conn.Search(req)
+
+	if err != nil {
+		return groups, err
+	}
+
+	for _, entry := range result.Entries {
+		if name := entry.GetAttributeValue("cn"); name != "" {
+			groups = append(groups, name)
+		} else {
+			groups = append(groups, GroupName(entry.DN))
+		}
+	}
+
+	return groups, nil
+}
+
+// GroupName returns the common name of a group, e.g. "admins" for "cn=admins,ou=groups,dc=example,dc=com".
+func GroupName(dn string) string {
+	parsed, err := ldapv3.ParseDN(dn)
+
+	if err != nil || len(parsed.RDNs) == 0 || len(parsed.RDNs[0].Attributes) == 0 {
+		return dn
+	}
+
+	return parsed.RDNs[0].Attributes[0].Value
+}
+
+// entryID returns a stable identifier that does not change if the user is renamed or moved.
+func entryID(entry *ldapv3.Entry) string {
+	if id := entry.GetAttributeValue("entryUUID"); id != "" {
+		return id
+	}
+
+	if guid := entry.GetRawAttributeValue("objectGUID"); len(guid) > 0 {
+		return fmt.Sprintf("%x", guid)
+	}
+
+	return strings.ToLower(entry.DN)
+}
+
+// firstValue returns the value of the first attribute that is not empty.
+func firstValue(entry *ldapv3.Entry, attributes ...string) string {
+	for _, attr := range attributes {
+		if val := entry.GetAttributeValue(attr); val != "" {
+			return val
+		}
+	}
+
+	return ""
+}
+
+// appendGroup adds a group name if it's not in the list yet.
+func appendGroup(groups []string, name string) []string {
+	if name == "" {
+		return groups
+	}
+
+	for _, g := range groups {
+		if strings.EqualFold(g, name) {
+			return groups
+		}
+	}
+
+	return append(groups, name)
+}
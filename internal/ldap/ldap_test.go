@@ -0,0 +1,144 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ldap/ldaptest"
+)
+
+func testServer() *ldaptest.TestServer {
+	return ldaptest.NewTestServer(
+		ldaptest.TestEntry{DN: "cn=service,dc=example,dc=com", Password: "service"},
+		ldaptest.TestEntry{DN: "uid=anna,ou=people,dc=example,dc=com", Password: "Anna123!", Attributes: map[string][]string{
+			"objectClass": {"person", "inetOrgPerson"},
+			"uid":         {"anna"},
+			"cn":          {"Anna Example"},
+			"mail":        {"anna@example.com"},
+			"entryUUID":   {"5b0c8d5e-1f4a-4d7b-9a3e-2c1f0e6d7a8b"},
+			"memberOf":    {"cn=family,ou=groups,dc=example,dc=com"},
+		}},
+		ldaptest.TestEntry{DN: "uid=bob,ou=people,dc=example,dc=com", Password: "Bob123!", Attributes: map[string][]string{
+			"objectClass": {"person"},
+			"uid":         {"bob"},
+		}},
+		ldaptest.TestEntry{DN: "cn=admins,ou=groups,dc=example,dc=com", Attributes: map[string][]string{
+			"objectClass": {"groupOfNames"},
+			"cn":          {"admins"},
+			"member":      {"uid=bob,ou=people,dc=example,dc=com"},
+		}},
+	)
+}
+
+func testConfig(s *ldaptest.TestServer) Config {
+	return Config{
+		URI:          s.URI(),
+		BindDN:       "cn=service,dc=example,dc=com",
+		BindPassword: "service",
+		BaseDN:       "dc=example,dc=com",
+		GroupFilter:  "(&(objectClass=groupOfNames)(member=%s))",
+	}
+}
+
+func TestClient_Authenticate(t *testing.T) {
+	s := testServer()
+	defer s.Close()
+
+	t.Run("success", func(t *testing.T) {
+		c := NewClient(testConfig(s))
+
+		user, err := c.Authenticate("anna", "Anna123!")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "uid=anna,ou=people,dc=example,dc=com", user.DN)
+		assert.Equal(t, "5b0c8d5e-1f4a-4d7b-9a3e-2c1f0e6d7a8b", user.ID)
+		assert.Equal(t, "anna", user.UserName)
+		assert.Equal(t, "Anna Example", user.FullName)
+		assert.Equal(t, "anna@example.com", user.Email)
+		assert.Equal(t, []string{"family"}, user.Groups)
+	})
+	t.Run("group search", func(t *testing.T) {
+		c := NewClient(testConfig(s))
+
+		user, err := c.Authenticate("BOB", "Bob123!")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "bob", user.UserName)
+		assert.Equal(t, "uid=bob,ou=people,dc=example,dc=com", user.ID)
+		assert.Equal(t, []string{"admins"}, user.Groups)
+	})
+	t.Run("invalid password", func(t *testing.T) {
+		c := NewClient(testConfig(s))
+
+		_, err := c.Authenticate("anna", "wrong")
+
+		assert.Equal(t, ErrInvalidCredentials, err)
+	})
+	t.Run("empty password", func(t *testing.T) {
+		c := NewClient(testConfig(s))
+
+		_, err := c.Authenticate("anna", "")
+
+		assert.Equal(t, ErrInvalidCredentials, err)
+	})
+	t.Run("unknown user", func(t *testing.T) {
+		c := NewClient(testConfig(s))
+
+		_, err := c.Authenticate("eve", "Eve123!")
+
+		assert.Equal(t, ErrUserNotFound, err)
+	})
+	t.Run("filter injection", func(t *testing.T) {
+		c := NewClient(testConfig(s))
+
+		_, err := c.Authenticate("*", "Anna123!")
+
+		assert.Equal(t, ErrUserNotFound, err)
+	})
+	t.Run("invalid service account", func(t *testing.T) {
+		conf := testConfig(s)
+		conf.BindPassword = "wrong"
+
+		_, err := NewClient(conf).Authenticate("anna", "Anna123!")
+
+		assert.Error(t, err)
+		assert.NotEqual(t, ErrInvalidCredentials, err)
+	})
+	t.Run("starttls", func(t *testing.T) {
+		conf := testConfig(s)
+		conf.StartTLS = true
+		conf.Insecure = true
+
+		user, err := NewClient(conf).Authenticate("anna", "Anna123!")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "anna", user.UserName)
+	})
+	t.Run("starttls untrusted certificate", func(t *testing.T) {
+		conf := testConfig(s)
+		conf.StartTLS = true
+
+		_, err := NewClient(conf).Authenticate("anna", "Anna123!")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGroupName(t *testing.T) {
+	assert.Equal(t, "admins", GroupName("cn=admins,ou=groups,dc=example,dc=com"))
+	assert.Equal(t, "family", GroupName("family"))
+}
+
+func TestClient_Host(t *testing.T) {
+	assert.Equal(t, "ldap.example.com", NewClient(Config{URI: "ldaps://ldap.example.com:636"}).Host())
+}
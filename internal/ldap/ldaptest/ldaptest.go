@@ -0,0 +1,329 @@
+// Package ldaptest provides an in-process LDAP directory server for tests.
+package ldaptest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	ldapv3 "github.com/go-ldap/ldap/v3"
+)
+
+// startTLSOID is the object identifier of the StartTLS extended operation.
+const startTLSOID = "1.3.6.1.4.1.1466.20037"
+
+// TestEntry represents a directory entry of the test server.
+type TestEntry struct {
+	DN         string
+	Password   string
+	Attributes map[string][]string
+}
+
+// TestServer is an in-memory LDAP server stand-in for tests, it supports simple binds, searches and StartTLS.
+type TestServer struct {
+	Entries  []TestEntry
+	listener net.Listener
+	tls      *tls.Config
+	mutex    sync.Mutex
+}
+
+// NewTestServer starts a new test server with the given entries.
+func NewTestServer(entries ...TestEntry) *TestServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		panic(err)
+	}
+
+	s := &TestServer{Entries: entries, listener: listener, tls: testTLSConfig()}
+
+	go s.serve()
+
+	return s
+}
+
+// URI returns the server URI.
+func (s *TestServer) URI() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+// Close stops the server.
+func (s *TestServer) Close() {
+	_ = s.listener.Close()
+}
+
+// serve accepts new connections.
+func (s *TestServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// handle processes the requests of a single connection.
+func (s *TestServer) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	var bound *TestEntry
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+
+		id := packet.Children[0].Value
+		op := packet.Children[1]
+
+		switch op.Tag {
+		case ldapv3.ApplicationBindRequest:
+			var code int64
+
+			bound, code = s.bind(op)
+
+			s.write(conn, id, result(ldapv3.ApplicationBindResponse, code))
+		case ldapv3.ApplicationUnbindRequest:
+			return
+		case ldapv3.ApplicationSearchRequest:
+			if bound == nil {
+				s.write(conn, id, result(ldapv3.ApplicationSearchResultDone, ldapv3.LDAPResultInsufficientAccessRights))
+				continue
+			}
+
+			code := int64(ldapv3.LDAPResultSuccess)
+			entries := s.search(op)
+
+			if limit, ok := op.Children[3].Value.(int64); ok && limit > 0 && int64(len(entries)) > limit {
+				entries = entries[:limit]
+				code = ldapv3.LDAPResultSizeLimitExceeded
+			}
+
+			for _, e := range entries {
+				s.write(conn, id, e)
+			}
+
+			s.write(conn, id, result(ldapv3.ApplicationSearchResultDone, code))
+		case ldapv3.ApplicationExtendedRequest:
+			if len(op.Children) == 0 || op.Children[0].Data.String() != startTLSOID {
+				s.write(conn, id, result(ldapv3.ApplicationExtendedResponse, ldapv3.LDAPResultProtocolError))
+				continue
+			}
+
+			s.write(conn, id, result(ldapv3.ApplicationExtendedResponse, ldapv3.LDAPResultSuccess))
+
+			tlsConn := tls.Server(conn, s.tls)
+
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+
+			conn = tlsConn
+		default:
+			s.write(conn, id, result(ldapv3.ApplicationExtendedResponse, ldapv3.LDAPResultUnwillingToPerform))
+		}
+	}
+}
+
+// bind verifies the credentials of a simple bind request.
+func (s *TestServer) bind(op *ber.Packet) (*TestEntry, int64) {
+	if len(op.Children) < 3 {
+		return nil, ldapv3.LDAPResultProtocolError
+	}
+
+	dn := op.Children[1].Data.String()
+	password := op.Children[2].Data.String()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i := range s.Entries {
+		if strings.EqualFold(s.Entries[i].DN, dn) && s.Entries[i].Password != "" && s.Entries[i].Password == password {
+			return &s.Entries[i], ldapv3.LDAPResultSuccess
+		}
+	}
+
+	return nil, ldapv3.LDAPResultInvalidCredentials
+}
+
+// search returns the entries below the base DN that match the filter.
+func (s *TestServer) search(op *ber.Packet) (results []*ber.Packet) {
+	if len(op.Children) < 8 {
+		return results
+	}
+
+	base := strings.ToLower(op.Children[0].Data.String())
+	filter := op.Children[6]
+
+	var attributes []string
+
+	for _, a := range op.Children[7].Children {
+		attributes = append(attributes, a.Data.String())
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, e := range s.Entries {
+		if !strings.HasSuffix(strings.ToLower(e.DN), base) || !match(e, filter) {
+			continue
+		}
+
+		entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldapv3.ApplicationSearchResultEntry, nil, "Search Result Entry")
+		entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, "DN"))
+
+		attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+
+		for name, values := range e.Attributes {
+			if !requested(name, attributes) {
+				continue
+			}
+
+			attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+			attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Name"))
+
+			vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+
+			for _, v := range values {
+				vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+			}
+
+			attr.AppendChild(vals)
+			attrs.AppendChild(attr)
+		}
+
+		entry.AppendChild(attrs)
+		results = append(results, entry)
+	}
+
+	return results
+}
+
+// write sends a response message.
+func (s *TestServer) write(conn net.Conn, id interface{}, op *ber.Packet) {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "Message ID"))
+	packet.AppendChild(op)
+
+	_, _ = conn.Write(packet.Bytes())
+}
+
+// result returns an LDAP result with the given code.
+func result(tag ber.Tag, code int64) *ber.Packet {
+	op := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	op.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	op.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+
+	return op
+}
+
+// requested tests if an attribute was requested, all attributes are returned if the list is empty.
+func requested(name string, attributes []string) bool {
+	if len(attributes) == 0 {
+		return true
+	}
+
+	for _, a := range attributes {
+		if strings.EqualFold(a, name) || a == "*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// match tests if an entry matches a search filter, only and, or, not, equality and presence filters are supported.
+func match(e TestEntry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case ldapv3.FilterAnd:
+		for _, f := range filter.Children {
+			if !match(e, f) {
+				return false
+			}
+		}
+
+		return true
+	case ldapv3.FilterOr:
+		for _, f := range filter.Children {
+			if match(e, f) {
+				return true
+			}
+		}
+
+		return false
+	case ldapv3.FilterNot:
+		return len(filter.Children) == 1 && !match(e, filter.Children[0])
+	case ldapv3.FilterEqualityMatch:
+		if len(filter.Children) != 2 {
+			return false
+		}
+
+		name := filter.Children[0].Data.String()
+		value := filter.Children[1].Data.String()
+
+		for _, v := range values(e, name) {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+
+		return false
+	case ldapv3.FilterPresent:
+		return len(values(e, filter.Data.String())) > 0
+	default:
+		return false
+	}
+}
+
+// values returns the values of an entry attribute, the name is case-insensitive.
+func values(e TestEntry, name string) []string {
+	for n, v := range e.Attributes {
+		if strings.EqualFold(n, name) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// testTLSConfig returns a TLS config with a self-signed certificate.
+func testTLSConfig() *tls.Config {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		panic(err)
+	}
+
+	tpl := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &tpl, &tpl, &key.PublicKey, key)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+}
@@ -0,0 +1,101 @@
+package mailer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// AlertInterval is the min time between two alerts for the same task.
+var AlertInterval = time.Hour
+
+var alerts = struct {
+	mutex   sync.Mutex
+	sent    map[string]time.Time
+	pending sync.WaitGroup
+}{sent: make(map[string]time.Time)}
+
+// AlertRecipients returns the email addresses of admins and additional alert recipients.
+func AlertRecipients(conf *config.Config) (result []string) {
+	done := make(map[string]bool)
+
+	for _, addr := range append(query.AdminEmails(), conf.AlertEmail()...) {
+		if addr == "" || done[addr] {
+			continue
+		}
+
+		done[addr] = true
+		result = append(result, addr)
+	}
+
+	return result
+}
+
+// Alert notifies admins that a background task like indexing, sync or backup failed,
+// repeated failures of the same task are reported once per AlertInterval. Alerts are
+// sent in the background, so that the task is not delayed by the mail server.
+func Alert(conf *config.Config, task string, taskErr error) {
+	if taskErr == nil || !conf.MailEnabled() {
+		return
+	}
+
+	now := time.Now()
+
+	alerts.mutex.Lock()
+
+	if last, ok := alerts.sent[task]; ok && now.Sub(last) < AlertInterval {
+		alerts.mutex.Unlock()
+		log.Debugf("mailer: %s alert suppressed", task)
+		return
+	}
+
+	alerts.sent[task] = now
+	alerts.mutex.Unlock()
+
+	alerts.pending.Add(1)
+
+	go func() {
+		defer alerts.pending.Done()
+
+		if err := sendAlert(conf, task, taskErr, now); err != nil {
+			log.Errorf("mailer: %s (send %s alert)", err, task)
+		}
+	}()
+}
+
+// WaitAlerts waits until pending alerts have been sent, e.g. before a command exits.
+func WaitAlerts() {
+	alerts.pending.Wait()
+}
+
+// sendAlert sends an alert to all recipients in their locale.
+func sendAlert(conf *config.Config, task string, taskErr error, now time.Time) error {
+	to := AlertRecipients(conf)
+
+	if len(to) == 0 {
+		log.Debugf("mailer: no recipients for %s alert", task)
+		return nil
+	}
+
+	messages, err := RenderAll(conf, "alert", Data{
+		"Task":    task,
+		"Time":    now.UTC().Format(time.RFC1123),
+		"Error":   txt.Clip(taskErr.Error(), 4096),
+		"Minutes": int(AlertInterval.Minutes()),
+	}, to)
+
+	if err != nil {
+		return err
+	}
+
+	for _, m := range messages {
+		if err := Send(conf, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
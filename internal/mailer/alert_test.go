@@ -0,0 +1,61 @@
+package mailer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlertRecipients(t *testing.T) {
+	conf, _, stop := testServer(t)
+	defer stop()
+
+	conf.Options().AlertEmail = "ops@example.com, alice@example.com"
+	defer func() { conf.Options().AlertEmail = "" }()
+
+	result := AlertRecipients(conf)
+
+	assert.Contains(t, result, "alice@example.com")
+	assert.Contains(t, result, "ops@example.com")
+	assert.NotContains(t, result, "bob@example.com")
+
+	count := 0
+
+	for _, addr := range result {
+		if addr == "alice@example.com" {
+			count++
+		}
+	}
+
+	assert.Equal(t, 1, count)
+}
+
+func TestAlert(t *testing.T) {
+	conf, s, stop := testServer(t)
+	defer stop()
+
+	Alert(conf, "test-sync", errors.New("connection refused"))
+	Alert(conf, "test-sync", errors.New("connection refused again"))
+
+	// Alerts are sent in the background.
+	WaitAlerts()
+
+	messages := s.Wait(1, time.Second)
+
+	if len(messages) != 1 {
+		t.Fatalf("expected one message, got %d", len(messages))
+	}
+
+	assert.Contains(t, messages[0].To, "alice@example.com")
+	assert.Equal(t, "Alert: test-sync failed", messages[0].Header("Subject"))
+	assert.Contains(t, messages[0].Text(), "connection refused")
+	assert.Contains(t, messages[0].Text(), "60 minutes")
+
+	t.Run("no error", func(t *testing.T) {
+		Alert(conf, "test-backup", nil)
+
+		assert.Len(t, s.Messages(), 1)
+	})
+}
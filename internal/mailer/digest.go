@@ -0,0 +1,125 @@
+package mailer
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// DigestInterval is the min time between two digest emails to the same user.
+var DigestInterval = 7 * 24 * time.Hour
+
+// DigestAlbum represents an album in a digest email.
+type DigestAlbum struct {
+	Title string
+	Count int
+	Link  string
+}
+
+// SendDigests emails users who follow albums a summary of new photos, at most once per DigestInterval.
+func SendDigests(conf *config.Config) (sent int, err error) {
+	if !conf.MailEnabled() {
+		return 0, nil
+	}
+
+	now := entity.TimeStamp()
+
+	followers, err := query.DueAlbumFollowers(now.Add(-1 * DigestInterval))
+
+	if err != nil {
+		return 0, err
+	}
+
+	byUser := make(map[string]entity.AlbumFollowers)
+	var users []string
+
+	for _, f := range followers {
+		if _, ok := byUser[f.UserUID]; !ok {
+			users = append(users, f.UserUID)
+		}
+
+		byUser[f.UserUID] = append(byUser[f.UserUID], f)
+	}
+
+	for _, userUID := range users {
+		if ok, err := sendDigest(conf, userUID, byUser[userUID], now); err != nil {
+			log.Errorf("mailer: %s (send digest)", err)
+		} else if ok {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+// sendDigest sends a digest to a single user and updates the digest time of the followed albums.
+func sendDigest(conf *config.Config, userUID string, followers entity.AlbumFollowers, now time.Time) (bool, error) {
+	user := entity.FindUserByUID(userUID)
+
+	if user == nil || user.UserDisabled || !user.EmailConfirmed || user.PrimaryEmail == "" {
+		return false, nil
+	}
+
+	since := now.Add(-1 * DigestInterval)
+	var albums []DigestAlbum
+
+	for _, f := range followers {
+		if f.DigestAt != nil && f.DigestAt.Before(since) {
+			since = *f.DigestAt
+		}
+
+		after := f.CreatedAt
+
+		if f.DigestAt != nil {
+			after = *f.DigestAt
+		}
+
+		count, err := query.AlbumPhotosAddedSince(f.AlbumUID, after)
+
+		if err != nil {
+			return false, err
+		} else if count == 0 {
+			continue
+		}
+
+		album, err := query.AlbumByUID(f.AlbumUID)
+
+		if err != nil {
+			continue
+		}
+
+		albums = append(albums, DigestAlbum{
+			Title: album.AlbumTitle,
+			Count: count,
+			Link:  conf.SiteUrl() + "albums/" + album.AlbumUID + "/" + album.AlbumSlug,
+		})
+	}
+
+	if len(albums) > 0 {
+		m, err := Render(conf, "digest", user.UserLocale, Data{
+			"Name":   userName(user),
+			"Since":  since.Format("2006-01-02"),
+			"Albums": albums,
+		})
+
+		if err != nil {
+			return false, err
+		}
+
+		m.To = []string{user.PrimaryEmail}
+
+		if err = Send(conf, m); err != nil {
+			return false, err
+		}
+	}
+
+	for i := range followers {
+		if err := followers[i].Digested(now); err != nil {
+			log.Errorf("mailer: %s (update digest time)", err)
+		}
+	}
+
+	return len(albums) > 0, nil
+}
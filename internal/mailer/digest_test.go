@@ -0,0 +1,91 @@
+package mailer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendDigests(t *testing.T) {
+	conf, s, stop := testServer(t)
+	defer stop()
+
+	album := entity.AlbumFixtures.Get("holiday-2030")
+	user := entity.UserFixtures.Get("alice")
+
+	follower, err := entity.FollowAlbum(album.AlbumUID, user.UserUID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.UnfollowAlbum(album.AlbumUID, user.UserUID)
+
+	if err := follower.Digested(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("unconfirmed", func(t *testing.T) {
+		sent, err := SendDigests(conf)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, sent)
+		assert.Empty(t, s.Messages())
+	})
+
+	if err := follower.Digested(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entity.Db().Model(&user).UpdateColumn("EmailConfirmed", true).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.Db().Model(&user).UpdateColumn("EmailConfirmed", false)
+
+	t.Run("confirmed", func(t *testing.T) {
+		sent, err := SendDigests(conf)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, sent)
+
+		messages := s.Messages()
+
+		if len(messages) != 1 {
+			t.Fatalf("expected one message, got %d", len(messages))
+		}
+
+		assert.Equal(t, []string{"alice@example.com"}, messages[0].To)
+		assert.Equal(t, "New photos in albums you follow", messages[0].Header("Subject"))
+		assert.Contains(t, messages[0].Text(), album.AlbumTitle+":")
+		assert.Contains(t, messages[0].Text(), conf.SiteUrl()+"albums/"+album.AlbumUID+"/"+album.AlbumSlug)
+	})
+	t.Run("not due", func(t *testing.T) {
+		sent, err := SendDigests(conf)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, sent)
+
+		due, err := query.DueAlbumFollowers(time.Now().Add(-1 * DigestInterval))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, f := range due {
+			assert.NotEqual(t, album.AlbumUID, f.AlbumUID)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+/*
+
+Package mailer sends templated email notifications via SMTP.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package mailer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+var log = event.Log
+
+// ErrDisabled is returned when no outgoing mail server is configured.
+var ErrDisabled = errors.New("email notifications are disabled")
+
+// Message represents a plain text email.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+	Date    time.Time
+}
+
+// Bytes returns the message in Internet Message Format with quoted-printable UTF-8 text.
+func (m Message) Bytes() []byte {
+	var buf bytes.Buffer
+
+	from, err := mail.ParseAddress(m.From)
+
+	if err != nil {
+		from = &mail.Address{Address: m.From}
+	}
+
+	date := m.Date
+
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	domain := "localhost"
+
+	if i := strings.LastIndex(from.Address, "@"); i >= 0 {
+		domain = from.Address[i+1:]
+	}
+
+	header := func(key, value string) {
+		buf.WriteString(key + ": " + value + "\r\n")
+	}
+
+	header("From", from.String())
+	header("To", strings.Join(m.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	header("Date", date.Format(time.RFC1123Z))
+	header("Message-ID", fmt.Sprintf("<%s@%s>", rnd.UUID(), domain))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=UTF-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	header("Auto-Submitted", "auto-generated")
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	_, _ = w.Write([]byte(m.Body))
+	_ = w.Close()
+
+	return buf.Bytes()
+}
+
+// Send delivers a message to the configured outgoing mail server.
+func Send(conf *config.Config, m Message) error {
+	if !conf.MailEnabled() {
+		return ErrDisabled
+	} else if len(m.To) == 0 {
+		return errors.New("no recipients")
+	}
+
+	if m.From == "" {
+		m.From = conf.SMTPFrom()
+	}
+
+	from, err := mail.ParseAddress(m.From)
+
+	if err != nil {
+		return fmt.Errorf("invalid sender address %s", m.From)
+	}
+
+	to := make([]string, 0, len(m.To))
+
+	for _, s := range m.To {
+		addr, err := mail.ParseAddress(s)
+
+		if err != nil {
+			return fmt.Errorf("invalid recipient address %s", s)
+		}
+
+		to = append(to, addr.Address)
+	}
+
+	if err := deliver(conf, from.Address, to, m.Bytes()); err != nil {
+		return err
+	}
+
+	log.Debugf("mailer: sent %s to %d recipients", m.Subject, len(to))
+
+	return nil
+}
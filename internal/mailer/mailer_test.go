@@ -0,0 +1,146 @@
+package mailer
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mailer/mailertest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	log = logrus.StandardLogger()
+	log.SetLevel(logrus.DebugLevel)
+
+	if err := os.Remove(".test.db"); err == nil {
+		log.Debugln("removed .test.db")
+	}
+
+	c := config.TestConfig()
+
+	code := m.Run()
+
+	_ = c.CloseDb()
+
+	os.Exit(code)
+}
+
+// testServer starts a test server and configures it as outgoing mail server until stopped.
+func testServer(t *testing.T) (*config.Config, *mailertest.TestServer, func()) {
+	t.Helper()
+
+	conf := config.TestConfig()
+	s := mailertest.NewTestServer()
+	o := conf.Options()
+	o.SMTPHost, o.SMTPPort, o.SMTPTLS = s.Host(), s.Port(), config.SMTPNone
+
+	return conf, s, func() {
+		o.SMTPHost, o.SMTPPort, o.SMTPTLS = "", 0, ""
+		s.Close()
+	}
+}
+
+func TestMessage_Bytes(t *testing.T) {
+	m := Message{
+		From:    `"Ferienfotos" <noreply@example.com>`,
+		To:      []string{"bob@example.com"},
+		Subject: "Grüße aus Berlin",
+		Body:    "Hallo Bob,\nschöne Grüße!\n",
+		Date:    time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	result := string(m.Bytes())
+
+	assert.Contains(t, result, "From: \"Ferienfotos\" <noreply@example.com>\r\n")
+	assert.Contains(t, result, "To: bob@example.com\r\n")
+	assert.Contains(t, result, "Subject: =?utf-8?q?Gr=C3=BC=C3=9Fe_aus_Berlin?=\r\n")
+	assert.Contains(t, result, "Date: Wed, 01 Sep 2021 12:00:00 +0000\r\n")
+	assert.Contains(t, result, "@example.com>\r\n")
+	assert.Contains(t, result, "Content-Transfer-Encoding: quoted-printable\r\n")
+	assert.Contains(t, result, "\r\n\r\nHallo Bob,\r\nsch=C3=B6ne Gr=C3=BC=C3=9Fe!\r\n")
+}
+
+func TestSend(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		conf, s, stop := testServer(t)
+		defer stop()
+
+		err := Send(conf, Message{
+			To:      []string{"Bob <bob@example.com>"},
+			Subject: "Test",
+			Body:    "Hello Bob",
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		messages := s.Messages()
+
+		if len(messages) != 1 {
+			t.Fatalf("expected one message, got %d", len(messages))
+		}
+
+		assert.Equal(t, "noreply@localhost", messages[0].From)
+		assert.Equal(t, []string{"bob@example.com"}, messages[0].To)
+		assert.Equal(t, "Test", messages[0].Header("Subject"))
+		assert.Equal(t, "Hello Bob\n", messages[0].Text())
+	})
+	t.Run("auth", func(t *testing.T) {
+		conf, s, stop := testServer(t)
+		defer stop()
+
+		conf.Options().SMTPUser = "smtp-user"
+		conf.Options().SMTPPassword = "smtp-pass"
+		defer func() { conf.Options().SMTPUser, conf.Options().SMTPPassword = "", "" }()
+
+		if err := Send(conf, Message{To: []string{"bob@example.com"}, Subject: "Auth"}); err != nil {
+			t.Fatal(err)
+		}
+
+		messages := s.Messages()
+
+		if len(messages) != 1 {
+			t.Fatalf("expected one message, got %d", len(messages))
+		}
+
+		assert.True(t, strings.HasPrefix(messages[0].Auth, "PLAIN "))
+	})
+	t.Run("starttls not supported", func(t *testing.T) {
+		conf, s, stop := testServer(t)
+		defer stop()
+
+		conf.Options().SMTPTLS = config.SMTPStartTLS
+		defer func() { conf.Options().SMTPTLS = config.SMTPNone }()
+
+		err := Send(conf, Message{To: []string{"bob@example.com"}, Subject: "Reset"})
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "does not support STARTTLS")
+		}
+
+		assert.Empty(t, s.Messages())
+	})
+	t.Run("disabled", func(t *testing.T) {
+		err := Send(config.TestConfig(), Message{To: []string{"bob@example.com"}})
+
+		assert.Equal(t, ErrDisabled, err)
+	})
+	t.Run("no recipients", func(t *testing.T) {
+		conf, _, stop := testServer(t)
+		defer stop()
+
+		assert.Error(t, Send(conf, Message{Subject: "Test"}))
+	})
+	t.Run("invalid recipient", func(t *testing.T) {
+		conf, s, stop := testServer(t)
+		defer stop()
+
+		assert.Error(t, Send(conf, Message{To: []string{"foo"}}))
+		assert.Empty(t, s.Messages())
+	})
+}
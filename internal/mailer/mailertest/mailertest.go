@@ -0,0 +1,213 @@
+// Package mailertest provides a local SMTP server that records messages for tests.
+package mailertest
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TestServer is a local SMTP server stand-in for tests, messages are recorded instead of delivered.
+type TestServer struct {
+	listener net.Listener
+	messages []TestMessage
+	mutex    sync.Mutex
+}
+
+// TestMessage represents a message received by the test server.
+type TestMessage struct {
+	From string
+	To   []string
+	Auth string
+	Data string
+}
+
+// NewTestServer starts a new test server on a random local port.
+func NewTestServer() *TestServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		panic(err)
+	}
+
+	s := &TestServer{listener: l}
+
+	go s.serve()
+
+	return s
+}
+
+// Host returns the server hostname.
+func (s *TestServer) Host() string {
+	return s.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+// Port returns the server port.
+func (s *TestServer) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Messages returns the received messages.
+func (s *TestServer) Messages() []TestMessage {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]TestMessage(nil), s.messages...)
+}
+
+// Wait waits until at least count messages were received and returns them.
+func (s *TestServer) Wait(count int, timeout time.Duration) []TestMessage {
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		if m := s.Messages(); len(m) >= count {
+			return m
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return s.Messages()
+}
+
+// Close shuts down the test server.
+func (s *TestServer) Close() {
+	_ = s.listener.Close()
+}
+
+func (s *TestServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// handle implements the subset of SMTP used by net/smtp without STARTTLS.
+func (s *TestServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	msg := TestMessage{}
+
+	reply := func(code int, text string) {
+		_ = tp.PrintfLine("%d %s", code, text)
+	}
+
+	reply(220, "localhost test server")
+
+	for {
+		line, err := tp.ReadLine()
+
+		if err != nil {
+			return
+		}
+
+		cmd, arg := line, ""
+
+		if i := strings.Index(line, " "); i > 0 {
+			cmd, arg = line[:i], line[i+1:]
+		}
+
+		switch strings.ToUpper(cmd) {
+		case "EHLO", "HELO":
+			_ = tp.PrintfLine("250-localhost")
+			_ = tp.PrintfLine("250-8BITMIME")
+			_ = tp.PrintfLine("250 AUTH PLAIN")
+		case "AUTH":
+			msg.Auth = arg
+			reply(235, "authenticated")
+		case "MAIL":
+			msg.From = pathArg(arg)
+			reply(250, "ok")
+		case "RCPT":
+			msg.To = append(msg.To, pathArg(arg))
+			reply(250, "ok")
+		case "DATA":
+			reply(354, "end data with <CR><LF>.<CR><LF>")
+
+			data, err := ioutil.ReadAll(tp.DotReader())
+
+			if err != nil {
+				return
+			}
+
+			msg.Data = string(data)
+
+			s.mutex.Lock()
+			s.messages = append(s.messages, msg)
+			s.mutex.Unlock()
+
+			msg = TestMessage{Auth: msg.Auth}
+
+			reply(250, "queued")
+		case "RSET":
+			msg = TestMessage{Auth: msg.Auth}
+			reply(250, "ok")
+		case "NOOP":
+			reply(250, "ok")
+		case "QUIT":
+			reply(221, "bye")
+			return
+		default:
+			reply(502, "command not implemented")
+		}
+	}
+}
+
+// pathArg returns the address in angle brackets of a MAIL or RCPT command.
+func pathArg(arg string) string {
+	start, end := strings.Index(arg, "<"), strings.Index(arg, ">")
+
+	if start < 0 || end < start {
+		return ""
+	}
+
+	return arg[start+1 : end]
+}
+
+// Header returns the decoded message header value.
+func (m TestMessage) Header(key string) string {
+	msg, err := mail.ReadMessage(strings.NewReader(m.Data))
+
+	if err != nil {
+		return ""
+	}
+
+	value, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get(key))
+
+	if err != nil {
+		return ""
+	}
+
+	return value
+}
+
+// Text returns the decoded message body.
+func (m TestMessage) Text() string {
+	msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(m.Data)))
+
+	if err != nil {
+		return ""
+	}
+
+	body, err := ioutil.ReadAll(quotedprintable.NewReader(msg.Body))
+
+	if err != nil {
+		return fmt.Sprintf("invalid body: %s", err)
+	}
+
+	return string(body)
+}
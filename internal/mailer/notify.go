@@ -0,0 +1,99 @@
+package mailer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Share contains the details of a new share link notification.
+type Share struct {
+	Sender   string
+	Title    string
+	Link     string
+	Password bool
+	Expires  time.Time
+}
+
+// userName returns the name used to greet a user.
+func userName(user *entity.User) string {
+	if user.FullName != "" {
+		return user.FullName
+	}
+
+	return user.UserName
+}
+
+// SendReset sends a password reset link to the primary email address of a user.
+func SendReset(conf *config.Config, user *entity.User, token string) error {
+	if user.PrimaryEmail == "" {
+		return fmt.Errorf("user %s has no email address", user.String())
+	}
+
+	m, err := Render(conf, "reset", user.UserLocale, Data{
+		"Name":  userName(user),
+		"Link":  conf.SiteUrl() + "reset/" + token,
+		"Hours": int(entity.ResetTokenExpires.Hours()),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	m.To = []string{user.PrimaryEmail}
+
+	return Send(conf, m)
+}
+
+// SendConfirm sends an email address confirmation link to a user.
+func SendConfirm(conf *config.Config, user *entity.User, token string) error {
+	if user.PrimaryEmail == "" {
+		return fmt.Errorf("user %s has no email address", user.String())
+	}
+
+	m, err := Render(conf, "confirm", user.UserLocale, Data{
+		"Name":  userName(user),
+		"Email": user.PrimaryEmail,
+		"Link":  conf.SiteUrl() + strings.TrimPrefix(config.ApiUri, "/") + "/users/confirm/" + token,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	m.To = []string{user.PrimaryEmail}
+
+	return Send(conf, m)
+}
+
+// SendShare notifies recipients of a new share link.
+func SendShare(conf *config.Config, share Share, to []string) error {
+	data := Data{
+		"Sender":   share.Sender,
+		"Title":    share.Title,
+		"Link":     share.Link,
+		"Password": share.Password,
+		"Expires":  "",
+	}
+
+	if !share.Expires.IsZero() {
+		data["Expires"] = share.Expires.Format("2006-01-02 15:04 MST")
+	}
+
+	messages, err := RenderAll(conf, "share", data, to)
+
+	if err != nil {
+		return err
+	}
+
+	for _, m := range messages {
+		if err := Send(conf, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package mailer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendReset(t *testing.T) {
+	conf, s, stop := testServer(t)
+	defer stop()
+
+	user := entity.UserFixtures.Get("bob")
+
+	if err := SendReset(conf, &user, "0123456789abcdef0123456789abcdef"); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := s.Messages()
+
+	if len(messages) != 1 {
+		t.Fatalf("expected one message, got %d", len(messages))
+	}
+
+	assert.Equal(t, []string{"bob@example.com"}, messages[0].To)
+	assert.Equal(t, "Reset your password", messages[0].Header("Subject"))
+	assert.Contains(t, messages[0].Text(), "Hello Bob,")
+	assert.Contains(t, messages[0].Text(), conf.SiteUrl()+"reset/0123456789abcdef0123456789abcdef")
+
+	t.Run("no email", func(t *testing.T) {
+		assert.Error(t, SendReset(conf, &entity.User{UserName: "foo"}, "token"))
+	})
+}
+
+func TestSendConfirm(t *testing.T) {
+	conf, s, stop := testServer(t)
+	defer stop()
+
+	user := entity.UserFixtures.Get("alice")
+
+	if err := SendConfirm(conf, &user, "0123456789abcdef0123456789abcdef"); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := s.Messages()
+
+	if len(messages) != 1 {
+		t.Fatalf("expected one message, got %d", len(messages))
+	}
+
+	assert.Equal(t, "Confirm your email address", messages[0].Header("Subject"))
+	assert.Contains(t, messages[0].Text(), "alice@example.com")
+	assert.Contains(t, messages[0].Text(), conf.SiteUrl()+"api/v1/users/confirm/0123456789abcdef0123456789abcdef")
+}
+
+func TestSendShare(t *testing.T) {
+	conf, s, stop := testServer(t)
+	defer stop()
+
+	share := Share{
+		Sender:  "Alice",
+		Title:   "Holiday 2030",
+		Link:    conf.SiteUrl() + "s/abc/holiday-2030",
+		Expires: time.Date(2030, 1, 2, 3, 4, 0, 0, time.UTC),
+	}
+
+	if err := SendShare(conf, share, []string{"bob@example.com", "carol@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	messages := s.Messages()
+
+	if len(messages) != 1 {
+		t.Fatalf("expected one message, got %d", len(messages))
+	}
+
+	assert.Equal(t, []string{"bob@example.com", "carol@example.com"}, messages[0].To)
+	assert.Equal(t, "Alice shared \"Holiday 2030\" with you", messages[0].Header("Subject"))
+	assert.Contains(t, messages[0].Text(), share.Link)
+	assert.Contains(t, messages[0].Text(), "2030-01-02 03:04 UTC")
+	assert.NotContains(t, messages[0].Text(), "password")
+}
@@ -0,0 +1,87 @@
+package mailer
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+// Timeout is the max duration of an SMTP session.
+var Timeout = 30 * time.Second
+
+// deliver sends raw message data to the configured SMTP server.
+func deliver(conf *config.Config, from string, to []string, data []byte) error {
+	host := conf.SMTPHost()
+	mode := conf.SMTPTLS()
+	tlsConfig := &tls.Config{ServerName: host}
+	dialer := &net.Dialer{Timeout: Timeout}
+
+	var conn net.Conn
+	var err error
+
+	if mode == config.SMTPTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", conf.SMTPAddr(), tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", conf.SMTPAddr())
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(Timeout))
+
+	c, err := smtp.NewClient(conn, host)
+
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	defer c.Close()
+
+	if mode == config.SMTPStartTLS {
+		// Never fall back to plain text, messages may contain password reset links.
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("%s does not support STARTTLS", host)
+		} else if err = c.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if user := conf.SMTPUser(); user != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return fmt.Errorf("%s does not support authentication", host)
+		} else if err = c.Auth(smtp.PlainAuth("", user, conf.SMTPPassword(), host)); err != nil {
+			return err
+		}
+	}
+
+	if err = c.Mail(from); err != nil {
+		return err
+	}
+
+	for _, rcpt := range to {
+		if err = c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(data); err != nil {
+		return err
+	} else if err = w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
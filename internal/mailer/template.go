@@ -0,0 +1,127 @@
+package mailer
+
+import (
+	"bytes"
+	"net/mail"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/leonelquinteros/gotext"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/i18n"
+)
+
+// Data contains template variables.
+type Data map[string]interface{}
+
+var locales = struct {
+	mutex sync.Mutex
+	cache map[i18n.Locale]*gotext.Locale
+}{cache: make(map[i18n.Locale]*gotext.Locale)}
+
+// translator returns the translations for the recipient locale, or the configured user interface language if it is empty.
+func translator(conf *config.Config, locale string) *gotext.Locale {
+	if locale == "" {
+		locale = conf.Settings().UI.Language
+	}
+
+	loc := i18n.ParseLocale(locale)
+
+	locales.mutex.Lock()
+	defer locales.mutex.Unlock()
+
+	if l, ok := locales.cache[loc]; ok {
+		return l
+	}
+
+	l := gotext.NewLocale(conf.LocalesPath(), loc.Locale())
+	l.AddDomain("default")
+
+	locales.cache[loc] = l
+
+	return l
+}
+
+// Render creates a message from the named template in the mail templates path, translated for the recipient locale.
+func Render(conf *config.Config, name, locale string, data Data) (m Message, err error) {
+	l := translator(conf, locale)
+
+	if data == nil {
+		data = Data{}
+	}
+
+	data["SiteTitle"] = conf.SiteTitle()
+	data["SiteUrl"] = conf.SiteUrl()
+
+	tmpl, err := template.New(name).Funcs(template.FuncMap{
+		"T": func(s string, vars ...interface{}) string {
+			return l.Get(s, vars...)
+		},
+	}).ParseFiles(
+		filepath.Join(conf.MailTemplatesPath(), "footer.txt"),
+		filepath.Join(conf.MailTemplatesPath(), name+".txt"),
+	)
+
+	if err != nil {
+		return m, err
+	}
+
+	var subject, body bytes.Buffer
+
+	if err = tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return m, err
+	} else if err = tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return m, err
+	}
+
+	m.Subject = strings.TrimSpace(subject.String())
+	m.Body = strings.TrimLeft(body.String(), "\n")
+
+	return m, nil
+}
+
+// RecipientLocale returns the locale of the user with the email address, or an empty string if it is unknown.
+func RecipientLocale(addr string) string {
+	if a, err := mail.ParseAddress(addr); err == nil {
+		addr = a.Address
+	}
+
+	if m := entity.FindUserByEmail(addr); m != nil {
+		return m.UserLocale
+	}
+
+	return ""
+}
+
+// RenderAll creates a message from the named template for each recipient locale.
+func RenderAll(conf *config.Config, name string, data Data, to []string) (result []Message, err error) {
+	var locales []string
+	recipients := make(map[string][]string)
+
+	for _, addr := range to {
+		locale := RecipientLocale(addr)
+
+		if _, ok := recipients[locale]; !ok {
+			locales = append(locales, locale)
+		}
+
+		recipients[locale] = append(recipients[locale], addr)
+	}
+
+	for _, locale := range locales {
+		m, err := Render(conf, name, locale, data)
+
+		if err != nil {
+			return result, err
+		}
+
+		m.To = recipients[locale]
+		result = append(result, m)
+	}
+
+	return result, nil
+}
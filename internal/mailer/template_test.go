@@ -0,0 +1,92 @@
+package mailer
+
+import (
+	"testing"
+
+	"github.com/leonelquinteros/gotext"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// testLocale adds a test translation for the reset subject to the "xx" locale.
+func testLocale(conf *config.Config) {
+	po := gotext.NewPo()
+	po.Parse([]byte("msgid \"Reset your password\"\nmsgstr \"Reset your xx password\"\n"))
+
+	translator(conf, "xx").AddTranslator("default", po)
+}
+
+func TestRender(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("reset", func(t *testing.T) {
+		m, err := Render(conf, "reset", "", Data{"Name": "Bob", "Link": "http://localhost:2342/reset/abc", "Hours": 24})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Reset your password", m.Subject)
+		assert.Contains(t, m.Body, "Hello Bob,")
+		assert.Contains(t, m.Body, "http://localhost:2342/reset/abc")
+		assert.Contains(t, m.Body, "The link expires in 24 hours.")
+		assert.Contains(t, m.Body, conf.SiteTitle())
+	})
+	t.Run("share", func(t *testing.T) {
+		m, err := Render(conf, "share", "", Data{"Sender": "Alice", "Title": "Holiday", "Link": "http://localhost:2342/s/abc/holiday", "Password": true})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Alice shared \"Holiday\" with you", m.Subject)
+		assert.Contains(t, m.Body, "password protected")
+		assert.NotContains(t, m.Body, "expires")
+	})
+	t.Run("locale", func(t *testing.T) {
+		testLocale(conf)
+
+		m, err := Render(conf, "reset", "xx", Data{"Name": "Bob", "Link": "http://localhost:2342/reset/abc", "Hours": 24})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Reset your xx password", m.Subject)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := Render(conf, "foo", "", nil)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestRenderAll(t *testing.T) {
+	conf := config.TestConfig()
+
+	testLocale(conf)
+
+	m := &entity.User{UserName: "render-locale", PrimaryEmail: "render-locale@example.com", UserLocale: "xx"}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "xx", RecipientLocale("Render Locale <render-locale@example.com>"))
+	assert.Equal(t, "", RecipientLocale("nobody@example.com"))
+
+	messages, err := RenderAll(conf, "reset", Data{"Name": "Bob", "Link": "http://localhost:2342/reset/abc", "Hours": 24},
+		[]string{"nobody@example.com", "render-locale@example.com", "other@example.com"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, messages, 2) {
+		assert.Equal(t, "Reset your password", messages[0].Subject)
+		assert.Equal(t, []string{"nobody@example.com", "other@example.com"}, messages[0].To)
+		assert.Equal(t, "Reset your xx password", messages[1].Subject)
+		assert.Equal(t, []string{"render-locale@example.com"}, messages[1].To)
+	}
+}
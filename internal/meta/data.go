@@ -21,6 +21,10 @@ type Data struct {
 	Title        string        `meta:"Title"`
 	Subject      string        `meta:"Subject,PersonInImage,ObjectName,HierarchicalSubject,CatalogSets"`
 	Keywords     Keywords      `meta:"Keywords"`
+	Tags         Tags          `meta:"-"`
+	Rating       int           `meta:"Rating"`
+	Pick         int           `meta:"-"`
+	ColorLabel   string        `meta:"Label,ColorLabel"`
 	Notes        string        `meta:"-"`
 	Artist       string        `meta:"Artist,Creator,OwnerName"`
 	Description  string        `meta:"Description"`
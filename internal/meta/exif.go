@@ -191,6 +191,12 @@ func (data *Data) Exif(fileName string, fileType fs.FileFormat) (err error) {
 		}
 	}
 
+	if value, ok := tags["Rating"]; ok {
+		if i, err := strconv.Atoi(value); err == nil {
+			data.Rating = i
+		}
+	}
+
 	if value, ok := tags["ImageUniqueID"]; ok {
 		if id := rnd.SanitizeUUID(value); id != "" {
 			data.DocumentID = id
@@ -134,6 +134,28 @@ func (data *Data) Exiftool(jsonData []byte, originalName string) (err error) {
 		}
 	}
 
+	// Add hierarchical keywords, e.g. from Lightroom, digiKam or Microsoft Photo.
+	for _, key := range []string{"HierarchicalSubject", "CatalogSets"} {
+		if tags, ok := jsonValues[key]; !ok {
+			continue
+		} else if tags.IsArray() {
+			for _, tag := range tags.Array() {
+				data.AddTags(tag.String())
+			}
+		} else {
+			data.AddTags(tags.String())
+		}
+	}
+
+	// Add pick flags, e.g. from Lightroom or digiKam.
+	if pick, ok := jsonValues["Pick"]; ok {
+		data.SetPick(int(pick.Int()))
+	} else if label, ok := jsonValues["PickLabel"]; ok {
+		data.SetPickLabel(int(label.Int()))
+	}
+
+	data.ColorLabel = ColorLabel(data.ColorLabel)
+
 	// Set latitude and longitude if known and not already set.
 	if data.Lat == 0 && data.Lng == 0 {
 		if data.GPSPosition != "" {
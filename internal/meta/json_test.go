@@ -719,6 +719,7 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, "iPhone 6s back camera 4.15mm f/2.2", data.LensModel)
 		assert.Equal(t, "holiday, greetings", data.Subject)
 		assert.Equal(t, "greetings, holiday", data.Keywords.String())
+		assert.Equal(t, Tags{"holiday", "greetings"}, data.Tags)
 	})
 
 	t.Run("newline.json", func(t *testing.T) {
@@ -889,4 +890,16 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, 0, data.Altitude)
 		assert.Equal(t, 1, data.Orientation)
 	})
+	t.Run("rating.json", func(t *testing.T) {
+		data, err := JSON("testdata/rating.json", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 5, data.Stars())
+		assert.Equal(t, "blue", data.ColorLabel)
+		assert.True(t, data.Picked())
+		assert.False(t, data.Rejected())
+	})
 }
@@ -0,0 +1,89 @@
+package meta
+
+import (
+	"strings"
+)
+
+// Pick flags as found in Lightroom (xmpDM:pick) or digiKam (PickLabel) metadata.
+const (
+	PickRejected = -1
+	PickNone     = 0
+	PickAccepted = 1
+)
+
+// RatingMax is the maximum number of stars.
+const RatingMax = 5
+
+// ColorLabels maps color label names and digiKam color label numbers to normalized names.
+var ColorLabels = map[string]string{
+	"red":     "red",
+	"1":       "red",
+	"orange":  "orange",
+	"2":       "orange",
+	"yellow":  "yellow",
+	"3":       "yellow",
+	"green":   "green",
+	"4":       "green",
+	"blue":    "blue",
+	"5":       "blue",
+	"purple":  "purple",
+	"magenta": "purple",
+	"6":       "purple",
+	"gray":    "gray",
+	"grey":    "gray",
+	"7":       "gray",
+	"black":   "black",
+	"8":       "black",
+	"white":   "white",
+	"9":       "white",
+}
+
+// ColorLabel returns the normalized color label name, or an empty string if unknown.
+func ColorLabel(s string) string {
+	return ColorLabels[strings.ToLower(SanitizeString(s))]
+}
+
+// Stars returns the star rating from 0 to 5.
+func (data Data) Stars() int {
+	if data.Rating < 0 {
+		return 0
+	} else if data.Rating > RatingMax {
+		return RatingMax
+	}
+
+	return data.Rating
+}
+
+// Picked returns true if the picture was flagged as pick.
+func (data Data) Picked() bool {
+	return data.Pick == PickAccepted
+}
+
+// Rejected returns true if the picture was rejected, e.g. with a rating of -1 in Lightroom.
+func (data Data) Rejected() bool {
+	return data.Rating < 0 || data.Pick == PickRejected
+}
+
+// SetPick sets the pick flag based on a Lightroom pick value, e.g. 1 or -1.
+func (data *Data) SetPick(pick int) {
+	switch {
+	case pick > 0:
+		data.Pick = PickAccepted
+	case pick < 0:
+		data.Pick = PickRejected
+	default:
+		data.Pick = PickNone
+	}
+}
+
+// SetPickLabel sets the pick flag based on a digiKam pick label, 1 = rejected, 2 = pending, 3 = accepted.
+func (data *Data) SetPickLabel(label int) {
+	switch label {
+	case 1:
+		data.Pick = PickRejected
+	case 3:
+		data.Pick = PickAccepted
+	default:
+		data.Pick = PickNone
+	}
+}
@@ -0,0 +1,43 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorLabel(t *testing.T) {
+	assert.Equal(t, "red", ColorLabel("Red"))
+	assert.Equal(t, "purple", ColorLabel("magenta"))
+	assert.Equal(t, "green", ColorLabel("4"))
+	assert.Equal(t, "", ColorLabel("To Do"))
+	assert.Equal(t, "", ColorLabel(""))
+}
+
+func TestData_Stars(t *testing.T) {
+	assert.Equal(t, 0, Data{Rating: -1}.Stars())
+	assert.Equal(t, 3, Data{Rating: 3}.Stars())
+	assert.Equal(t, 5, Data{Rating: 7}.Stars())
+}
+
+func TestData_Rejected(t *testing.T) {
+	assert.True(t, Data{Rating: -1}.Rejected())
+	assert.True(t, Data{Pick: PickRejected}.Rejected())
+	assert.False(t, Data{Rating: 2, Pick: PickAccepted}.Rejected())
+}
+
+func TestData_SetPick(t *testing.T) {
+	data := Data{}
+
+	data.SetPick(1)
+	assert.True(t, data.Picked())
+
+	data.SetPick(-1)
+	assert.True(t, data.Rejected())
+
+	data.SetPickLabel(3)
+	assert.True(t, data.Picked())
+
+	data.SetPickLabel(2)
+	assert.Equal(t, PickNone, data.Pick)
+}
@@ -0,0 +1,65 @@
+package meta
+
+import (
+	"strings"
+)
+
+// TagSeparator separates the levels of hierarchical keywords, e.g. "Places|Europe|Norway|Bergen".
+const TagSeparator = "|"
+
+// Tags represents a list of hierarchical keywords.
+type Tags []string
+
+// String returns a string containing all tags.
+func (t Tags) String() string {
+	return strings.Join(t, ", ")
+}
+
+// TagPath returns a clean hierarchical keyword with empty levels removed.
+func TagPath(s string) string {
+	var levels []string
+
+	for _, name := range strings.Split(SanitizeString(s), TagSeparator) {
+		if name = strings.TrimSpace(name); name != "" {
+			levels = append(levels, name)
+		}
+	}
+
+	return strings.Join(levels, TagSeparator)
+}
+
+// TagName returns the last level of a hierarchical keyword, e.g. "Kids" for "People|Family|Kids".
+func TagName(s string) string {
+	s = TagPath(s)
+
+	if i := strings.LastIndex(s, TagSeparator); i >= 0 {
+		return s[i+1:]
+	}
+
+	return s
+}
+
+// AddTags appends hierarchical keywords like "People|Family|Kids" if not already present,
+// their last level is added to the keywords as well.
+func (data *Data) AddTags(tags ...string) {
+	for _, s := range tags {
+		if s = TagPath(s); s == "" {
+			continue
+		}
+
+		data.AddKeywords(TagName(s))
+
+		found := false
+
+		for _, t := range data.Tags {
+			if strings.EqualFold(t, s) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			data.Tags = append(data.Tags, s)
+		}
+	}
+}
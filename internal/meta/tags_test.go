@@ -0,0 +1,31 @@
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagPath(t *testing.T) {
+	assert.Equal(t, "Places|Europe|Norway", TagPath("Places|Europe|Norway"))
+	assert.Equal(t, "People|Family", TagPath(" People | |Family|"))
+	assert.Equal(t, "holiday", TagPath("holiday"))
+	assert.Equal(t, "", TagPath("|"))
+}
+
+func TestTagName(t *testing.T) {
+	assert.Equal(t, "Norway", TagName("Places|Europe|Norway"))
+	assert.Equal(t, "Family", TagName(" People | |Family|"))
+	assert.Equal(t, "holiday", TagName("holiday"))
+	assert.Equal(t, "", TagName("|"))
+}
+
+func TestData_AddTags(t *testing.T) {
+	data := NewData()
+
+	data.AddTags("People|Family|Kids", "", "people|family|kids", "Places|Norway")
+
+	assert.Equal(t, Tags{"People|Family|Kids", "Places|Norway"}, data.Tags)
+	assert.Equal(t, "People|Family|Kids, Places|Norway", data.Tags.String())
+	assert.Equal(t, "kids, norway", data.Keywords.String())
+}
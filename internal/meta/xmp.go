@@ -65,5 +65,19 @@ func (data *Data) XMP(fileName string) (err error) {
 		data.AddKeywords(doc.Keywords())
 	}
 
+	data.AddTags(doc.HierarchicalKeywords()...)
+
+	if rating, ok := doc.Rating(); ok {
+		data.Rating = rating
+	}
+
+	if pick, ok := doc.Pick(); ok {
+		data.Pick = pick
+	}
+
+	if label := doc.ColorLabel(); label != "" {
+		data.ColorLabel = label
+	}
+
 	return nil
 }
@@ -3,8 +3,11 @@ package meta
 import (
 	"encoding/xml"
 	"io/ioutil"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // XmpDocument represents an XMP sidecar file.
@@ -35,6 +38,15 @@ type XmpDocument struct {
 			CreateDate      string `xml:"CreateDate"`      // 2020-01-01T17:28:23
 			MetadataDate    string `xml:"MetadataDate"`    // 2020-01-01T17:28:23.89961...
 			Rating          string `xml:"Rating"`          // 4
+			RatingAttr      string `xml:"Rating,attr"`     // 4
+			Label           string `xml:"Label"`           // Red
+			LabelAttr       string `xml:"Label,attr"`      // Red
+			Pick            string `xml:"pick"`            // 1
+			PickAttr        string `xml:"pick,attr"`       // 1
+			PickLabel       string `xml:"PickLabel"`       // 3
+			PickLabelAttr   string `xml:"PickLabel,attr"`  // 3
+			ColorLabel      string `xml:"ColorLabel"`      // 1
+			ColorLabelAttr  string `xml:"ColorLabel,attr"` // 1
 			Lens            string `xml:"Lens"`            // HUAWEI P30 Rear Main Came...
 			LensModel       string `xml:"LensModel"`       // HUAWEI P30 Rear Main Came...
 			DateCreated     string `xml:"DateCreated"`     // 2020-01-01T17:28:25.72962...
@@ -82,6 +94,20 @@ type XmpDocument struct {
 					Li   []string `xml:"li"` // desk, coffee, computer
 				} `xml:"Seq" json:"seq,omitempty"`
 			} `xml:"subject" json:"subject,omitempty"`
+			HierarchicalSubject struct {
+				Text string `xml:",chardata" json:"text,omitempty"`
+				Bag  struct {
+					Text string   `xml:",chardata" json:"text,omitempty"`
+					Li   []string `xml:"li"` // Places|Europe|Norway|Bergen
+				} `xml:"Bag" json:"bag,omitempty"`
+			} `xml:"hierarchicalSubject" json:"hierarchicalsubject,omitempty"`
+			CatalogSets struct {
+				Text string `xml:",chardata" json:"text,omitempty"`
+				Bag  struct {
+					Text string   `xml:",chardata" json:"text,omitempty"`
+					Li   []string `xml:"li"` // People|Family|Kids
+				} `xml:"Bag" json:"bag,omitempty"`
+			} `xml:"CatalogSets" json:"catalogsets,omitempty"`
 			Rights struct {
 				Text string `xml:",chardata" json:"text,omitempty"`
 				Alt  struct {
@@ -287,3 +313,50 @@ func (doc *XmpDocument) Keywords() string {
 
 	return strings.Join(s, ", ")
 }
+
+// HierarchicalKeywords returns the Lightroom hierarchical keywords and catalog sets, e.g. "People|Family|Kids".
+func (doc *XmpDocument) HierarchicalKeywords() []string {
+	return append(doc.RDF.Description.HierarchicalSubject.Bag.Li, doc.RDF.Description.CatalogSets.Bag.Li...)
+}
+
+// firstValue returns the first non-empty value.
+func firstValue(values ...string) string {
+	for _, v := range values {
+		if v = SanitizeString(v); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// Rating returns the XMP document star rating, -1 means rejected.
+func (doc *XmpDocument) Rating() (rating int, ok bool) {
+	if s := firstValue(doc.RDF.Description.Rating, doc.RDF.Description.RatingAttr); s == "" {
+		return 0, false
+	} else if f, err := strconv.ParseFloat(s, 32); err != nil {
+		return 0, false
+	} else {
+		return int(f), true
+	}
+}
+
+// Pick returns the XMP document pick flag, see PickAccepted and PickRejected.
+func (doc *XmpDocument) Pick() (pick int, ok bool) {
+	data := Data{}
+
+	if s := firstValue(doc.RDF.Description.Pick, doc.RDF.Description.PickAttr); s != "" {
+		data.SetPick(txt.Int(s))
+	} else if s := firstValue(doc.RDF.Description.PickLabel, doc.RDF.Description.PickLabelAttr); s != "" {
+		data.SetPickLabel(txt.Int(s))
+	} else {
+		return PickNone, false
+	}
+
+	return data.Pick, true
+}
+
+// ColorLabel returns the normalized XMP document color label.
+func (doc *XmpDocument) ColorLabel() string {
+	return ColorLabel(firstValue(doc.RDF.Description.Label, doc.RDF.Description.LabelAttr, doc.RDF.Description.ColorLabel, doc.RDF.Description.ColorLabelAttr))
+}
@@ -37,6 +37,37 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, "HUAWEI", data.CameraMake)
 		assert.Equal(t, "ELE-L29", data.CameraModel)
 		assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
+		assert.Equal(t, 4, data.Rating)
+	})
+
+	t.Run("lightroom", func(t *testing.T) {
+		data, err := XMP("testdata/lightroom.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Bryggen", data.Title)
+		assert.Equal(t, Tags{"Places|Europe|Norway|Bergen", "People|Family|Kids", "Events|Vacation"}, data.Tags)
+		assert.Equal(t, "bergen, kids, vacation", data.Keywords.String())
+		assert.Equal(t, 4, data.Stars())
+		assert.Equal(t, "red", data.ColorLabel)
+		assert.True(t, data.Picked())
+		assert.False(t, data.Rejected())
+	})
+
+	t.Run("digikam", func(t *testing.T) {
+		data, err := XMP("testdata/digikam.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, -1, data.Rating)
+		assert.Equal(t, 0, data.Stars())
+		assert.Equal(t, "purple", data.ColorLabel)
+		assert.False(t, data.Picked())
+		assert.True(t, data.Rejected())
 	})
 
 	t.Run("canon_eos_6d", func(t *testing.T) {
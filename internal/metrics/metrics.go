@@ -0,0 +1,199 @@
+/*
+
+Package metrics provides Prometheus metrics for monitoring and capacity planning.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Namespace is the common metric name prefix.
+const Namespace = "photoprism"
+
+// Registry contains all metrics exposed by the API.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HttpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace, Subsystem: "http", Name: "requests_total",
+		Help: "Number of HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	HttpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace, Subsystem: "http", Name: "request_duration_seconds",
+		Help:    "HTTP request latencies by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	IndexFiles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace, Subsystem: "index", Name: "files_total",
+		Help: "Number of indexed files by result, including imported files.",
+	}, []string{"status"})
+
+	IndexDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Namespace, Subsystem: "index", Name: "file_duration_seconds",
+		Help:    "Time spent indexing a single file.",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 14),
+	})
+
+	IndexLastActivity = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace, Subsystem: "index", Name: "last_activity_timestamp_seconds",
+		Help: "Unix time when the indexer last finished a file, e.g. for detecting stuck indexing.",
+	})
+
+	ImportFiles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace, Subsystem: "import", Name: "files_total",
+		Help: "Number of imported main files by result.",
+	}, []string{"status"})
+
+	ConvertFiles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace, Subsystem: "convert", Name: "files_total",
+		Help: "Number of converted files by target format and result.",
+	}, []string{"format", "result"})
+
+	ConvertDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace, Subsystem: "convert", Name: "duration_seconds",
+		Help:    "Time spent converting a file by target format.",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 12),
+	}, []string{"format"})
+
+	ThumbCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace, Subsystem: "thumb", Name: "cache_hits_total",
+		Help: "Number of thumbnails found in cache.",
+	})
+
+	ThumbCacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace, Subsystem: "thumb", Name: "cache_misses_total",
+		Help: "Number of thumbnails not found in cache.",
+	})
+
+	ThumbDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Namespace, Subsystem: "thumb", Name: "generation_duration_seconds",
+		Help:    "Time spent creating a thumbnail.",
+		Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+	})
+
+	ClassifyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace, Subsystem: "classify", Name: "duration_seconds",
+		Help:    "Time spent on image classification by model (labels, faces or nsfw).",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"model"})
+)
+
+// Workers maps worker names to their busy state.
+var Workers = map[string]*mutex.Busy{
+	"main":  &mutex.MainWorker,
+	"sync":  &mutex.SyncWorker,
+	"share": &mutex.ShareWorker,
+	"meta":  &mutex.MetaWorker,
+	"faces": &mutex.FacesWorker,
+	"edit":  &mutex.EditWorker,
+}
+
+func init() {
+	Registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		HttpRequests,
+		HttpDuration,
+		IndexFiles,
+		IndexDuration,
+		IndexLastActivity,
+		ImportFiles,
+		ConvertFiles,
+		ConvertDuration,
+		ThumbCacheHits,
+		ThumbCacheMisses,
+		ThumbDuration,
+		ClassifyDuration,
+	)
+
+	for name, worker := range Workers {
+		w := worker
+
+		Registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   Namespace,
+			Subsystem:   "worker",
+			Name:        "busy",
+			Help:        "Indicates if a background worker is running (1) or idle (0).",
+			ConstLabels: prometheus.Labels{"worker": name},
+		}, func() float64 {
+			if w.Busy() {
+				return 1
+			}
+
+			return 0
+		}))
+	}
+}
+
+// Request records an HTTP request, route is empty for unknown paths.
+func Request(method, route string, status int, start time.Time) {
+	if route == "" {
+		route = "unmatched"
+	}
+
+	HttpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	HttpDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+}
+
+// Indexed records an indexed file.
+func Indexed(status string, start time.Time) {
+	IndexFiles.WithLabelValues(status).Inc()
+	IndexDuration.Observe(time.Since(start).Seconds())
+	IndexLastActivity.SetToCurrentTime()
+}
+
+// Imported records an imported main file.
+func Imported(status string) {
+	ImportFiles.WithLabelValues(status).Inc()
+}
+
+// Converted records a file conversion.
+func Converted(format string, err error, start time.Time) {
+	result := "success"
+
+	if err != nil {
+		result = "error"
+	}
+
+	ConvertFiles.WithLabelValues(format, result).Inc()
+	ConvertDuration.WithLabelValues(format).Observe(time.Since(start).Seconds())
+}
+
+// Classified records the time spent running a classification model.
+func Classified(model string, start time.Time) {
+	ClassifyDuration.WithLabelValues(model).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest(t *testing.T) {
+	Request("GET", "/api/v1/photos/:uid", 200, time.Now())
+	Request("GET", "", 404, time.Now())
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(HttpRequests.WithLabelValues("GET", "/api/v1/photos/:uid", "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(HttpRequests.WithLabelValues("GET", "unmatched", "404")))
+}
+
+func TestIndexed(t *testing.T) {
+	Indexed("added", time.Now().Add(-time.Second))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(IndexFiles.WithLabelValues("added")))
+	assert.InDelta(t, float64(time.Now().Unix()), testutil.ToFloat64(IndexLastActivity), 5)
+}
+
+func TestConverted(t *testing.T) {
+	Converted("jpg", nil, time.Now())
+	Converted("jpg", errors.New("failed"), time.Now())
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(ConvertFiles.WithLabelValues("jpg", "success")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(ConvertFiles.WithLabelValues("jpg", "error")))
+}
+
+func TestWorkers(t *testing.T) {
+	assert.Nil(t, mutex.EditWorker.Start())
+	defer mutex.EditWorker.Stop()
+
+	n, err := testutil.GatherAndCount(Registry, "photoprism_worker_busy")
+
+	assert.NoError(t, err)
+	assert.Equal(t, len(Workers), n)
+}
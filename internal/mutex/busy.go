@@ -22,6 +22,10 @@ func (b *Busy) Start() error {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
+	if ShuttingDown() {
+		return errors.New("shutting down")
+	}
+
 	if b.canceled {
 		return errors.New("still running")
 	}
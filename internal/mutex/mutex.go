@@ -2,6 +2,8 @@ package mutex
 
 import (
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 var (
@@ -11,9 +13,48 @@ var (
 	ShareWorker = Busy{}
 	MetaWorker  = Busy{}
 	FacesWorker = Busy{}
+	EditWorker  = Busy{}
 )
 
+var shuttingDown int32
+
 // WorkersBusy returns true if any worker is busy.
 func WorkersBusy() bool {
-	return MainWorker.Busy() || SyncWorker.Busy() || ShareWorker.Busy() || MetaWorker.Busy() || FacesWorker.Busy()
+	return MainWorker.Busy() || SyncWorker.Busy() || ShareWorker.Busy() || MetaWorker.Busy() || FacesWorker.Busy() || EditWorker.Busy()
+}
+
+// CancelWorkers asks all running workers to stop at their next checkpoint.
+func CancelWorkers() {
+	MainWorker.Cancel()
+	SyncWorker.Cancel()
+	ShareWorker.Cancel()
+	MetaWorker.Cancel()
+	FacesWorker.Cancel()
+	EditWorker.Cancel()
+}
+
+// WaitWorkers waits until no worker is busy, returns false if the timeout was reached.
+func WaitWorkers(timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+
+	for WorkersBusy() {
+		if time.Now().After(deadline) {
+			return false
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	return true
+}
+
+// Shutdown cancels running workers and prevents new ones from being started.
+func Shutdown() {
+	atomic.StoreInt32(&shuttingDown, 1)
+	CancelWorkers()
+}
+
+// ShuttingDown tests if Shutdown was called.
+func ShuttingDown() bool {
+	return atomic.LoadInt32(&shuttingDown) == 1
 }
@@ -1,7 +1,9 @@
 package mutex
 
 import (
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -9,3 +11,33 @@ import (
 func TestWorkersBusy(t *testing.T) {
 	assert.False(t, WorkersBusy())
 }
+
+func TestWaitWorkers(t *testing.T) {
+	assert.True(t, WaitWorkers(time.Second))
+
+	assert.Nil(t, MetaWorker.Start())
+
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		MetaWorker.Stop()
+	}()
+
+	assert.False(t, WaitWorkers(50*time.Millisecond))
+	assert.True(t, WaitWorkers(time.Second))
+}
+
+func TestShutdown(t *testing.T) {
+	defer atomic.StoreInt32(&shuttingDown, 0)
+
+	assert.Nil(t, SyncWorker.Start())
+	assert.False(t, ShuttingDown())
+
+	Shutdown()
+
+	assert.True(t, ShuttingDown())
+	assert.True(t, SyncWorker.Canceled())
+	assert.EqualError(t, ShareWorker.Start(), "shutting down")
+
+	SyncWorker.Stop()
+	assert.False(t, WorkersBusy())
+}
@@ -0,0 +1,84 @@
+/*
+
+Package ocr extracts text from images using optical character recognition.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package ocr
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+var log = event.Log
+
+// MinWordLength is the minimum number of letters or digits a line must contain to be kept.
+var MinWordLength = 3
+
+var spaceRegexp = regexp.MustCompile(`[ \t\f\v]+`)
+
+// Clean removes recognition noise like empty lines and lines without words from the text.
+func Clean(s string) string {
+	var lines []string
+
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(spaceRegexp.ReplaceAllString(line, " "))
+
+		if line == "" || !hasWord(line) {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return txt.Clip(strings.Join(lines, "\n"), txt.ClipDescription)
+}
+
+// hasWord tests if the line contains a sequence of at least MinWordLength letters or digits.
+func hasWord(line string) bool {
+	n := 0
+
+	for _, r := range line {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			n++
+
+			if n >= MinWordLength {
+				return true
+			}
+		} else {
+			n = 0
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,18 @@
+package ocr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClean(t *testing.T) {
+	t.Run("Receipt", func(t *testing.T) {
+		result := Clean("  CORNER   SHOP \n\n ~~ . \n Milk\t\t1.29\n|\nTOTAL   1.29\n\f")
+		assert.Equal(t, "CORNER SHOP\nMilk 1.29\nTOTAL 1.29", result)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", Clean(""))
+		assert.Equal(t, "", Clean(" \n . ,\n"))
+	})
+}
@@ -0,0 +1,62 @@
+package ocr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// DefaultLanguages is the default Tesseract language setting.
+const DefaultLanguages = "eng"
+
+// Tesseract runs the Tesseract command-line tool to recognize text in images.
+type Tesseract struct {
+	bin       string
+	languages string
+}
+
+// NewTesseract returns a new Tesseract OCR engine, languages can be combined like "eng+deu".
+func NewTesseract(bin, languages string) *Tesseract {
+	if languages = strings.TrimSpace(languages); languages == "" {
+		languages = DefaultLanguages
+	}
+
+	return &Tesseract{bin: bin, languages: languages}
+}
+
+// File returns the text recognized in an image file.
+func (t *Tesseract) File(filename string) (string, error) {
+	if t.bin == "" {
+		return "", errors.New("ocr: tesseract command not found")
+	} else if !fs.FileExists(filename) {
+		return "", fmt.Errorf("ocr: %s not found", txt.Quote(filename))
+	}
+
+	cmd := exec.Command(t.bin, filename, "stdout", "-l", t.languages)
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	// Run tesseract command.
+	if err := cmd.Run(); err != nil {
+		if s := strings.TrimSpace(stderr.String()); s != "" {
+			return "", fmt.Errorf("ocr: %s", s)
+		} else {
+			return "", fmt.Errorf("ocr: %s", err)
+		}
+	}
+
+	result := Clean(out.String())
+
+	log.Tracef("ocr: found %d characters in %s", len(result), txt.Quote(filename))
+
+	return result, nil
+}
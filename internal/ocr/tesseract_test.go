@@ -0,0 +1,66 @@
+package ocr
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testBin creates a shell script that prints the text like tesseract would.
+func testBin(t *testing.T, script string) string {
+	bin := filepath.Join(t.TempDir(), "tesseract")
+
+	if err := ioutil.WriteFile(bin, []byte("#!/bin/sh\n"+script+"\n"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	return bin
+}
+
+// testImage creates an empty image file, the test command does not read it.
+func testImage(t *testing.T) string {
+	filename := filepath.Join(t.TempDir(), "sign.jpg")
+
+	if err := ioutil.WriteFile(filename, []byte{}, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	return filename
+}
+
+func TestNewTesseract(t *testing.T) {
+	assert.Equal(t, DefaultLanguages, NewTesseract("tesseract", " ").languages)
+	assert.Equal(t, "eng+deu", NewTesseract("tesseract", "eng+deu").languages)
+}
+
+func TestTesseract_File(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		bin := testBin(t, `echo "OPEN\n$4 $2 ?\n.."`)
+
+		result, err := NewTesseract(bin, "eng+deu").File(testImage(t))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "OPEN\neng+deu stdout ?", result)
+	})
+	t.Run("CommandFailed", func(t *testing.T) {
+		bin := testBin(t, `echo "Error opening data file" >&2; exit 1`)
+
+		_, err := NewTesseract(bin, "").File(testImage(t))
+
+		assert.EqualError(t, err, "ocr: Error opening data file")
+	})
+	t.Run("NoCommand", func(t *testing.T) {
+		_, err := NewTesseract("", "").File(testImage(t))
+		assert.Error(t, err)
+	})
+	t.Run("FileNotFound", func(t *testing.T) {
+		_, err := NewTesseract("tesseract", "").File(filepath.Join(t.TempDir(), "missing.jpg"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,132 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ClockSkew is the tolerated time difference between PhotoPrism and the provider.
+const ClockSkew = 2 * time.Minute
+
+// Audience represents the "aud" claim, which may be a string or a list of strings.
+type Audience []string
+
+// UnmarshalJSON accepts both a single audience and a list.
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var s string
+
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = Audience{s}
+		return nil
+	}
+
+	var list []string
+
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+
+	*a = list
+
+	return nil
+}
+
+// Contains tests if the audience contains the client ID.
+func (a Audience) Contains(clientID string) bool {
+	for _, s := range a {
+		if s == clientID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Claims represents the claims of an ID token.
+type Claims struct {
+	Issuer            string   `json:"iss"`
+	Subject           string   `json:"sub"`
+	Audience          Audience `json:"aud"`
+	AuthorizedParty   string   `json:"azp"`
+	Expiry            int64    `json:"exp"`
+	IssuedAt          int64    `json:"iat"`
+	NotBefore         int64    `json:"nbf"`
+	Nonce             string   `json:"nonce"`
+	Email             string   `json:"email"`
+	EmailVerified     bool     `json:"email_verified"`
+	Name              string   `json:"name"`
+	PreferredUsername string   `json:"preferred_username"`
+	Groups            []string `json:"groups"`
+}
+
+// ParseClaims decodes the token payload.
+func ParseClaims(payload []byte) (*Claims, error) {
+	c := &Claims{}
+
+	if err := json.Unmarshal(payload, c); err != nil {
+		return nil, fmt.Errorf("oidc: %s (parse claims)", err)
+	}
+
+	return c, nil
+}
+
+// Validate checks the issuer, audience, nonce and validity period.
+func (c *Claims) Validate(issuer, clientID, nonce string, now time.Time) error {
+	if strings.TrimRight(c.Issuer, "/") != strings.TrimRight(issuer, "/") {
+		return fmt.Errorf("oidc: unexpected issuer %s", txt.Quote(c.Issuer))
+	}
+
+	if c.Subject == "" {
+		return fmt.Errorf("oidc: missing subject")
+	}
+
+	if !c.Audience.Contains(clientID) {
+		return fmt.Errorf("oidc: token was issued for a different client")
+	}
+
+	if len(c.Audience) > 1 && c.AuthorizedParty != "" && c.AuthorizedParty != clientID {
+		return fmt.Errorf("oidc: token was issued for a different party")
+	}
+
+	if c.Nonce != nonce {
+		return fmt.Errorf("oidc: invalid nonce")
+	}
+
+	if c.Expiry == 0 || now.After(time.Unix(c.Expiry, 0).Add(ClockSkew)) {
+		return fmt.Errorf("oidc: token expired")
+	}
+
+	if c.NotBefore > 0 && now.Add(ClockSkew).Before(time.Unix(c.NotBefore, 0)) {
+		return fmt.Errorf("oidc: token not valid yet")
+	}
+
+	return nil
+}
+
+// UserName returns the preferred user name, the email address or the subject.
+func (c *Claims) UserName() string {
+	if c.PreferredUsername != "" {
+		return c.PreferredUsername
+	} else if c.Email != "" && c.EmailVerified {
+		return c.Email
+	}
+
+	return c.Subject
+}
+
+// GroupNames returns the group names without path prefix, e.g. "family" instead of "/family".
+func (c *Claims) GroupNames() []string {
+	result := make([]string, 0, len(c.Groups))
+
+	for _, g := range c.Groups {
+		if g = strings.Trim(g, "/ "); g != "" {
+			result = append(result, g)
+		}
+	}
+
+	return result
+}
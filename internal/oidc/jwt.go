@@ -0,0 +1,107 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+var ErrInvalidToken = errors.New("oidc: invalid token")
+
+// jwtHeader represents the JOSE header of a signed token.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify checks the signature of a compact serialized JWT and returns its payload.
+func Verify(raw string, keys *KeySet) ([]byte, error) {
+	parts := strings.Split(raw, ".")
+
+	if len(parts) != 3 || keys == nil {
+		return nil, ErrInvalidToken
+	}
+
+	headerJson, err := base64.RawURLEncoding.DecodeString(parts[0])
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var header jwtHeader
+
+	if err := json.Unmarshal(headerJson, &header); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var hash crypto.Hash
+
+	switch header.Alg {
+	case "RS256", "PS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "PS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "PS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		// Unsigned and HMAC signed tokens are rejected.
+		return nil, fmt.Errorf("oidc: unsupported signing algorithm %s", txt.Quote(header.Alg))
+	}
+
+	key, err := keys.Key(header.Kid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	h := hash.New()
+	h.Write([]byte(parts[0] + "." + parts[1]))
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if strings.HasPrefix(header.Alg, "RS") {
+			err = rsa.VerifyPKCS1v15(k, hash, digest, signature)
+		} else if strings.HasPrefix(header.Alg, "PS") {
+			err = rsa.VerifyPSS(k, hash, digest, signature, nil)
+		} else {
+			err = ErrInvalidToken
+		}
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+
+		if !strings.HasPrefix(header.Alg, "ES") || len(signature) != 2*size {
+			err = ErrInvalidToken
+		} else if !ecdsa.Verify(k, digest, new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:])) {
+			err = ErrInvalidToken
+		}
+	default:
+		err = ErrInvalidToken
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: invalid token signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return payload, nil
+}
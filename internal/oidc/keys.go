@@ -0,0 +1,180 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// KeyRefreshInterval limits how often the key set is fetched when an unknown key ID is found.
+const KeyRefreshInterval = time.Minute
+
+// JSONWebKey represents a public key in a JWK set.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// PublicKey returns the decoded RSA or EC public key.
+func (k JSONWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", txt.Quote(k.Crv))
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+
+		if err != nil {
+			return nil, err
+		}
+
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("invalid ec key")
+		}
+
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", txt.Quote(k.Kty))
+	}
+}
+
+// KeySet represents the cached signing keys of a provider.
+type KeySet struct {
+	uri     string
+	http    *http.Client
+	keys    map[string]crypto.PublicKey
+	updated time.Time
+	mutex   sync.Mutex
+}
+
+// NewKeySet returns a new key set that is fetched from the given JWKS URI.
+func NewKeySet(client *http.Client, uri string) *KeySet {
+	return &KeySet{uri: uri, http: client, keys: make(map[string]crypto.PublicKey)}
+}
+
+// Key returns the public key with the given ID and refreshes the set if it is not known yet.
+func (s *KeySet) Key(kid string) (crypto.PublicKey, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+
+	if time.Since(s.updated) < KeyRefreshInterval {
+		return nil, fmt.Errorf("oidc: unknown key %s", txt.Quote(kid))
+	}
+
+	if err := s.refresh(); err != nil {
+		return nil, err
+	}
+
+	// Tokens without key ID are accepted if the set contains a single key.
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, nil
+		}
+	}
+
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("oidc: unknown key %s", txt.Quote(kid))
+}
+
+// refresh fetches the key set from the provider.
+func (s *KeySet) refresh() error {
+	s.updated = time.Now()
+
+	resp, err := s.http.Get(s.uri)
+
+	if err != nil {
+		return fmt.Errorf("oidc: %s (fetch keys)", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc: fetching keys failed with status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []JSONWebKey `json:"keys"`
+	}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&set); err != nil {
+		return fmt.Errorf("oidc: %s (fetch keys)", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.PublicKey()
+
+		if err != nil {
+			log.Debugf("oidc: %s (key %s)", err, txt.Quote(k.Kid))
+			continue
+		}
+
+		keys[k.Kid] = key
+	}
+
+	s.keys = keys
+
+	return nil
+}
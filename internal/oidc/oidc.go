@@ -0,0 +1,165 @@
+/*
+
+Package oidc implements OpenID Connect single sign-on with the authorization code flow and PKCE.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package oidc
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// StateExpiration is the max time between redirecting to the provider and the callback.
+const StateExpiration = 10 * time.Minute
+
+var ErrInvalidState = errors.New("oidc: invalid or expired state")
+
+// authRequest represents a pending login.
+type authRequest struct {
+	Verifier string
+	Nonce    string
+}
+
+// Client represents an OpenID Connect relying party.
+type Client struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	provider     *Provider
+	keys         *KeySet
+	pending      *gc.Cache
+	http         *http.Client
+	mutex        sync.Mutex
+}
+
+// NewClient returns a new client, the provider metadata is discovered on first use.
+func NewClient(issuer, clientID, clientSecret, redirectURL string, scopes []string) *Client {
+	return &Client{
+		Issuer:       issuer,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       scopes,
+		pending:      gc.New(StateExpiration, time.Minute),
+		http:         &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Provider returns the provider metadata and runs discovery if needed.
+func (c *Client) Provider() (*Provider, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.provider != nil {
+		return c.provider, nil
+	}
+
+	p, err := Discover(c.http, c.Issuer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.provider = p
+	c.keys = NewKeySet(c.http, p.JwksURI)
+
+	return p, nil
+}
+
+// AuthURL returns the provider authorization URL and the state of a new login.
+func (c *Client) AuthURL() (authURL, state string, err error) {
+	p, err := c.Provider()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	state = RandomString()
+	req := authRequest{Verifier: RandomString(), Nonce: RandomString()}
+
+	c.pending.SetDefault(state, req)
+
+	return p.AuthCodeURL(c.ClientID, c.RedirectURL, c.Scopes, state, req.Nonce, Challenge(req.Verifier)), state, nil
+}
+
+// Callback exchanges the authorization code and returns the verified ID token claims.
+func (c *Client) Callback(state, code string) (*Claims, error) {
+	cached, ok := c.pending.Get(state)
+
+	if !ok || state == "" {
+		return nil, ErrInvalidState
+	}
+
+	// States can only be used once.
+	c.pending.Delete(state)
+
+	req := cached.(authRequest)
+
+	p, err := c.Provider()
+
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := p.Exchange(c.http, c.ClientID, c.ClientSecret, c.RedirectURL, code, req.Verifier)
+
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := Verify(token.IDToken, c.keys)
+
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := ParseClaims(payload)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := claims.Validate(p.Issuer, c.ClientID, req.Nonce, time.Now()); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("oidc: verified id token of %s", claims.Subject)
+
+	return claims, nil
+}
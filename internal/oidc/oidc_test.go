@@ -0,0 +1,177 @@
+package oidc_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/oidc"
+	"github.com/photoprism/photoprism/internal/oidc/oidctest"
+)
+
+// login follows the redirect to the test provider and returns the callback parameters.
+func login(t *testing.T, authURL string) (state, code string) {
+	client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	resp, err := client.Get(authURL)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp.Body.Close()
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return location.Query().Get("state"), location.Query().Get("code")
+}
+
+func TestClient_Callback(t *testing.T) {
+	p := oidctest.NewTestProvider("photoprism", oidc.Claims{Subject: "123", PreferredUsername: "anna", Email: "anna@example.com", Groups: []string{"/family"}})
+	defer p.Close()
+
+	t.Run("success", func(t *testing.T) {
+		c := oidc.NewClient(p.Issuer(), "photoprism", "secret", "http://localhost:2342/api/v1/oidc/redirect", []string{"openid", "email"})
+
+		authURL, state, err := c.AuthURL()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, authURL, "code_challenge_method=S256")
+
+		returnedState, code := login(t, authURL)
+
+		assert.Equal(t, state, returnedState)
+
+		claims, err := c.Callback(returnedState, code)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "123", claims.Subject)
+		assert.Equal(t, "anna", claims.UserName())
+		assert.Equal(t, []string{"family"}, claims.GroupNames())
+
+		// States can't be used twice.
+		_, err = c.Callback(returnedState, code)
+		assert.Equal(t, oidc.ErrInvalidState, err)
+	})
+	t.Run("invalid state", func(t *testing.T) {
+		c := oidc.NewClient(p.Issuer(), "photoprism", "", "http://localhost:2342/api/v1/oidc/redirect", []string{"openid"})
+
+		_, err := c.Callback("xxx", "yyy")
+
+		assert.Equal(t, oidc.ErrInvalidState, err)
+	})
+	t.Run("wrong client", func(t *testing.T) {
+		c := oidc.NewClient(p.Issuer(), "other", "", "http://localhost:2342/api/v1/oidc/redirect", []string{"openid"})
+
+		authURL, _, err := c.AuthURL()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.Callback(login(t, authURL))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDiscover(t *testing.T) {
+	p := oidctest.NewTestProvider("photoprism", oidc.Claims{})
+	defer p.Close()
+
+	t.Run("success", func(t *testing.T) {
+		result, err := oidc.Discover(http.DefaultClient, p.Issuer()+"/")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, p.Issuer()+"/token", result.TokenEndpoint)
+		assert.True(t, result.SupportsPKCE())
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := oidc.Discover(http.DefaultClient, p.Issuer()+"/xxx")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestVerify(t *testing.T) {
+	p := oidctest.NewTestProvider("photoprism", oidc.Claims{})
+	defer p.Close()
+
+	keys := oidc.NewKeySet(http.DefaultClient, p.Issuer()+"/jwks")
+
+	t.Run("valid", func(t *testing.T) {
+		payload, err := oidc.Verify(p.Sign(oidc.Claims{Subject: "123"}), keys)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		claims, err := oidc.ParseClaims(payload)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "123", claims.Subject)
+	})
+	t.Run("modified", func(t *testing.T) {
+		token := p.Sign(oidc.Claims{Subject: "123"})
+		other := p.Sign(oidc.Claims{Subject: "456"})
+
+		_, err := oidc.Verify(token[:len(token)-10]+other[len(other)-10:], keys)
+
+		assert.Error(t, err)
+	})
+	t.Run("unsigned", func(t *testing.T) {
+		_, err := oidc.Verify("eyJhbGciOiJub25lIn0.eyJzdWIiOiIxMjMifQ.", keys)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestClaims_Validate(t *testing.T) {
+	now := time.Now()
+	c := oidc.Claims{Issuer: "https://id.example.com/", Subject: "123", Audience: oidc.Audience{"photoprism"}, Nonce: "n", Expiry: now.Add(time.Hour).Unix()}
+
+	assert.NoError(t, c.Validate("https://id.example.com", "photoprism", "n", now))
+	assert.Error(t, c.Validate("https://evil.example.com", "photoprism", "n", now))
+	assert.Error(t, c.Validate("https://id.example.com", "other", "n", now))
+	assert.Error(t, c.Validate("https://id.example.com", "photoprism", "x", now))
+	assert.Error(t, c.Validate("https://id.example.com", "photoprism", "n", now.Add(2*time.Hour)))
+}
+
+func TestAudience_UnmarshalJSON(t *testing.T) {
+	claims, err := oidc.ParseClaims([]byte(`{"aud": "photoprism"}`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, oidc.Audience{"photoprism"}, claims.Audience)
+
+	claims, err = oidc.ParseClaims([]byte(`{"aud": ["a", "b"]}`))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, oidc.Audience{"a", "b"}, claims.Audience)
+}
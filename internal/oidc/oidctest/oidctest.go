@@ -0,0 +1,135 @@
+// Package oidctest provides an in-process OpenID Connect provider for tests.
+package oidctest
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/oidc"
+)
+
+// TestProvider is an in-process OpenID Connect provider stand-in for tests.
+type TestProvider struct {
+	Server   *httptest.Server
+	ClientID string
+	Claims   oidc.Claims
+	key      *rsa.PrivateKey
+	codes    map[string]url.Values
+	mutex    sync.Mutex
+}
+
+// NewTestProvider starts a new test provider that issues tokens with the given claims.
+func NewTestProvider(clientID string, claims oidc.Claims) *TestProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		panic(err)
+	}
+
+	p := &TestProvider{ClientID: clientID, Claims: claims, key: key, codes: make(map[string]url.Values)}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(oidc.DiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, oidc.Provider{
+			Issuer:                p.Issuer(),
+			AuthorizationEndpoint: p.Issuer() + "/auth",
+			TokenEndpoint:         p.Issuer() + "/token",
+			JwksURI:               p.Issuer() + "/jwks",
+			ChallengeMethods:      []string{"S256"},
+		})
+	})
+
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, map[string][]oidc.JSONWebKey{"keys": {{
+			Kty: "RSA",
+			Kid: "test",
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+
+	// Logs the user in right away and redirects back with an authorization code.
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		code := oidc.RandomString()
+
+		p.mutex.Lock()
+		p.codes[code] = q
+		p.mutex.Unlock()
+
+		http.Redirect(w, r, q.Get("redirect_uri")+"?code="+code+"&state="+url.QueryEscape(q.Get("state")), http.StatusFound)
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+
+		p.mutex.Lock()
+		q, ok := p.codes[r.PostForm.Get("code")]
+		delete(p.codes, r.PostForm.Get("code"))
+		p.mutex.Unlock()
+
+		if !ok || oidc.Challenge(r.PostForm.Get("code_verifier")) != q.Get("code_challenge") || r.PostForm.Get("redirect_uri") != q.Get("redirect_uri") {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJson(w, oidc.Token{Error: "invalid_grant"})
+			return
+		}
+
+		claims := p.Claims
+		claims.Issuer = p.Issuer()
+		claims.Audience = oidc.Audience{p.ClientID}
+		claims.Nonce = q.Get("nonce")
+		claims.IssuedAt = time.Now().Unix()
+		claims.Expiry = time.Now().Add(time.Hour).Unix()
+
+		writeJson(w, oidc.Token{AccessToken: oidc.RandomString(), TokenType: "Bearer", IDToken: p.Sign(claims)})
+	})
+
+	p.Server = httptest.NewServer(mux)
+
+	return p
+}
+
+// Issuer returns the issuer URL.
+func (p *TestProvider) Issuer() string {
+	return p.Server.URL
+}
+
+// Sign returns a RS256 signed token with the given claims.
+func (p *TestProvider) Sign(claims oidc.Claims) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "test"})
+	payload, _ := json.Marshal(claims)
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+
+	if err != nil {
+		panic(err)
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// Close shuts down the test server.
+func (p *TestProvider) Close() {
+	p.Server.Close()
+}
+
+func writeJson(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
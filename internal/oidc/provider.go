@@ -0,0 +1,169 @@
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// DiscoveryPath is appended to the issuer URL to get the provider metadata.
+const DiscoveryPath = "/.well-known/openid-configuration"
+
+// Provider represents OpenID Connect provider metadata.
+type Provider struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	UserinfoEndpoint      string   `json:"userinfo_endpoint"`
+	JwksURI               string   `json:"jwks_uri"`
+	ChallengeMethods      []string `json:"code_challenge_methods_supported"`
+}
+
+// Token represents a token endpoint response.
+type Token struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	IDToken          string `json:"id_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Discover fetches the metadata of the provider with the given issuer URL.
+func Discover(client *http.Client, issuer string) (*Provider, error) {
+	issuer = strings.TrimRight(issuer, "/")
+
+	if issuer == "" {
+		return nil, fmt.Errorf("oidc: issuer url is empty")
+	}
+
+	resp, err := client.Get(issuer + DiscoveryPath)
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s (discovery)", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: discovery failed with status %d", resp.StatusCode)
+	}
+
+	p := &Provider{}
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(p); err != nil {
+		return nil, fmt.Errorf("oidc: %s (discovery)", err)
+	}
+
+	if strings.TrimRight(p.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("oidc: issuer %s does not match %s", txt.Quote(p.Issuer), txt.Quote(issuer))
+	}
+
+	if p.AuthorizationEndpoint == "" || p.TokenEndpoint == "" || p.JwksURI == "" {
+		return nil, fmt.Errorf("oidc: incomplete provider metadata")
+	}
+
+	if !p.SupportsPKCE() {
+		log.Warnf("oidc: provider does not announce PKCE support")
+	}
+
+	return p, nil
+}
+
+// SupportsPKCE tests if the provider announces S256 code challenges, it is assumed if the metadata is missing.
+func (p *Provider) SupportsPKCE() bool {
+	if len(p.ChallengeMethods) == 0 {
+		return true
+	}
+
+	for _, m := range p.ChallengeMethods {
+		if m == "S256" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AuthCodeURL returns the URL of the authorization endpoint for a new login.
+func (p *Provider) AuthCodeURL(clientID, redirectURL string, scopes []string, state, nonce, challenge string) string {
+	v := url.Values{}
+
+	v.Set("response_type", "code")
+	v.Set("client_id", clientID)
+	v.Set("redirect_uri", redirectURL)
+	v.Set("scope", strings.Join(scopes, " "))
+	v.Set("state", state)
+	v.Set("nonce", nonce)
+	v.Set("code_challenge", challenge)
+	v.Set("code_challenge_method", "S256")
+
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		return p.AuthorizationEndpoint + "&" + v.Encode()
+	}
+
+	return p.AuthorizationEndpoint + "?" + v.Encode()
+}
+
+// Exchange redeems an authorization code at the token endpoint.
+func (p *Provider) Exchange(client *http.Client, clientID, clientSecret, redirectURL, code, verifier string) (*Token, error) {
+	v := url.Values{}
+
+	v.Set("grant_type", "authorization_code")
+	v.Set("code", code)
+	v.Set("redirect_uri", redirectURL)
+	v.Set("code_verifier", verifier)
+
+	if clientSecret == "" {
+		v.Set("client_id", clientID)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.TokenEndpoint, strings.NewReader(v.Encode()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	if clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s (token)", err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %s (token)", err)
+	}
+
+	token := &Token{}
+
+	if err := json.Unmarshal(body, token); err != nil {
+		return nil, fmt.Errorf("oidc: invalid token response with status %d", resp.StatusCode)
+	}
+
+	if token.Error != "" {
+		return nil, fmt.Errorf("oidc: %s %s", token.Error, token.ErrorDescription)
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: token request failed with status %d", resp.StatusCode)
+	} else if token.IDToken == "" {
+		return nil, fmt.Errorf("oidc: token response contains no id token")
+	}
+
+	return token, nil
+}
@@ -84,13 +84,23 @@ func RestoreAlbums(backupPath string, force bool) (count int, result error) {
 		return count, nil
 	}
 
-	for _, fileName := range albums {
-		a := entity.Album{}
+	backups := make([]entity.Album, len(albums))
+	parents := make(map[string]bool)
 
-		if err := a.LoadFromYaml(fileName); err != nil {
+	// Load all backups first, so that parent albums are restored even if they are empty.
+	for i, fileName := range albums {
+		if err := backups[i].LoadFromYaml(fileName); err != nil {
 			log.Errorf("restore: %s in %s", err, txt.Quote(filepath.Base(fileName)))
 			result = err
-		} else if a.AlbumType == "" || len(a.Photos) == 0 && a.AlbumFilter == "" {
+		} else if backups[i].ParentUID != "" {
+			parents[backups[i].ParentUID] = true
+		}
+	}
+
+	for i, fileName := range albums {
+		a := backups[i]
+
+		if a.AlbumType == "" || len(a.Photos) == 0 && a.AlbumFilter == "" && !parents[a.AlbumUID] {
 			log.Debugf("restore: skipping %s", txt.Quote(filepath.Base(fileName)))
 		} else if err := a.Find(); err == nil {
 			log.Infof("%s: %s already exists", a.AlbumType, txt.Quote(a.AlbumTitle))
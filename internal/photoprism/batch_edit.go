@@ -0,0 +1,161 @@
+package photoprism
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// BatchEditFailure represents a photo that could not be updated.
+type BatchEditFailure struct {
+	PhotoUID string `json:"UID"`
+	Error    string `json:"Error"`
+}
+
+// BatchEditStatus represents the progress of a batch edit.
+type BatchEditStatus struct {
+	Running    bool               `json:"Running"`
+	Canceled   bool               `json:"Canceled"`
+	Total      int                `json:"Total"`
+	Processed  int                `json:"Processed"`
+	Updated    int                `json:"Updated"`
+	Failures   []BatchEditFailure `json:"Failures"`
+	StartedAt  time.Time          `json:"StartedAt"`
+	FinishedAt time.Time          `json:"FinishedAt"`
+}
+
+// BatchEdit represents a worker that applies partial edits to multiple photos.
+type BatchEdit struct {
+	conf   *config.Config
+	mutex  sync.Mutex
+	status BatchEditStatus
+}
+
+// NewBatchEdit returns a new BatchEdit worker.
+func NewBatchEdit(conf *config.Config) *BatchEdit {
+	instance := &BatchEdit{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Status returns the progress of the current or last batch edit.
+func (w *BatchEdit) Status() BatchEditStatus {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	result := w.status
+	result.Failures = append([]BatchEditFailure{}, w.status.Failures...)
+
+	return result
+}
+
+// Cancel stops the current batch edit.
+func (w *BatchEdit) Cancel() {
+	mutex.EditWorker.Cancel()
+}
+
+// Start applies the form values to all photos in the background.
+func (w *BatchEdit) Start(photos entity.Photos, f form.BatchEdit) error {
+	if err := f.Validate(); err != nil {
+		return err
+	} else if f.NoValues() {
+		return fmt.Errorf("batch edit: no values")
+	}
+
+	if err := mutex.EditWorker.Start(); err != nil {
+		return err
+	}
+
+	w.mutex.Lock()
+	w.status = BatchEditStatus{Running: true, Total: len(photos), StartedAt: time.Now().UTC()}
+	w.mutex.Unlock()
+
+	go w.run(photos, f)
+
+	return nil
+}
+
+// run applies the form values to all photos and reports failures.
+func (w *BatchEdit) run(photos entity.Photos, f form.BatchEdit) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("batch edit: %s (panic)\nstack: %s", r, debug.Stack())
+		}
+
+		w.mutex.Lock()
+		w.status.Running = false
+		w.status.FinishedAt = time.Now().UTC()
+		w.mutex.Unlock()
+
+		mutex.EditWorker.Stop()
+
+		event.Publish("batch.edit.completed", event.Data{"status": w.Status()})
+	}()
+
+	var updated entity.Photos
+
+	for _, p := range photos {
+		if mutex.EditWorker.Canceled() {
+			log.Infof("batch edit: canceled after %d photos", len(updated))
+
+			w.mutex.Lock()
+			w.status.Canceled = true
+			w.mutex.Unlock()
+
+			break
+		}
+
+		changed, err := p.SaveBatchEdit(f, entity.SrcBatch)
+
+		w.mutex.Lock()
+		w.status.Processed++
+
+		if err != nil {
+			log.Errorf("batch edit: %s (%s)", err, p.PhotoUID)
+			w.status.Failures = append(w.status.Failures, BatchEditFailure{PhotoUID: p.PhotoUID, Error: err.Error()})
+		} else if changed {
+			w.status.Updated++
+		}
+
+		w.mutex.Unlock()
+
+		if err == nil && changed {
+			w.saveYaml(p)
+			updated = append(updated, p)
+		}
+	}
+
+	if len(updated) > 0 {
+		if err := entity.UpdatePhotoCounts(); err != nil {
+			log.Errorf("batch edit: %s", err)
+		}
+
+		event.EntitiesUpdated("photos", updated)
+	}
+}
+
+// saveYaml updates the YAML backup file if enabled.
+func (w *BatchEdit) saveYaml(p entity.Photo) {
+	if !w.conf.BackupYaml() {
+		return
+	}
+
+	fileName := p.YamlFileName(w.conf.OriginalsPath(), w.conf.SidecarPath())
+
+	if err := p.SaveAsYaml(fileName); err != nil {
+		log.Errorf("batch edit: %s (update yaml)", err)
+	} else {
+		log.Debugf("batch edit: updated yaml file %s", txt.Quote(filepath.Base(fileName)))
+	}
+}
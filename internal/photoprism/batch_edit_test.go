@@ -0,0 +1,102 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func waitBatchEdit(t *testing.T, w *BatchEdit) BatchEditStatus {
+	for i := 0; i < 100; i++ {
+		if status := w.Status(); !status.Running {
+			return status
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	t.Fatal("batch edit still running")
+
+	return BatchEditStatus{}
+}
+
+func TestBatchEdit_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	t.Run("success", func(t *testing.T) {
+		w := NewBatchEdit(conf)
+
+		manual := entity.PhotoFixtures.Get("19800101_000002_D640C559")
+		manual.SetTitle("Manual Title", entity.SrcManual)
+
+		if err := manual.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos, err := query.PhotoSelection(form.Selection{Photos: []string{"pt9jtdre2lvl0y12", manual.PhotoUID}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		title := "Batch Title"
+		artist := "Batch Artist"
+
+		if err := w.Start(photos, form.BatchEdit{PhotoTitle: &title, Artist: &artist}); err != nil {
+			t.Fatal(err)
+		}
+
+		status := waitBatchEdit(t, w)
+
+		assert.Equal(t, 2, status.Total)
+		assert.Equal(t, 2, status.Processed)
+		assert.Empty(t, status.Failures)
+
+		batch, err := query.PhotoByUID("pt9jtdre2lvl0y12")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Batch Title", batch.PhotoTitle)
+		assert.Equal(t, entity.SrcBatch, batch.TitleSrc)
+		assert.Equal(t, "Batch Artist", batch.GetDetails().Artist)
+
+		// Manual values have priority.
+		m, err := query.PhotoByUID(manual.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Manual Title", m.PhotoTitle)
+	})
+	t.Run("failure", func(t *testing.T) {
+		w := NewBatchEdit(conf)
+		title := "Batch Title"
+
+		if err := w.Start(entity.Photos{{PhotoTitle: "no id"}}, form.BatchEdit{PhotoTitle: &title}); err != nil {
+			t.Fatal(err)
+		}
+
+		status := waitBatchEdit(t, w)
+
+		assert.Equal(t, 1, status.Processed)
+		assert.Len(t, status.Failures, 1)
+	})
+	t.Run("invalid offset", func(t *testing.T) {
+		w := NewBatchEdit(conf)
+
+		assert.Error(t, w.Start(entity.Photos{}, form.BatchEdit{TakenOffset: "xxx"}))
+	})
+	t.Run("no values", func(t *testing.T) {
+		w := NewBatchEdit(conf)
+
+		assert.Error(t, w.Start(entity.Photos{}, form.BatchEdit{}))
+	})
+}
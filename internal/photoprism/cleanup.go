@@ -10,6 +10,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/event"
 
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/mutex"
@@ -116,7 +117,11 @@ func (w *CleanUp) Start(opt CleanUpOptions) (thumbs int, orphans int, err error)
 			continue
 		}
 
-		if err := Delete(p); err != nil {
+		err := Delete(p)
+
+		AuditDelete("cleanup", acl.ResourcePhotos, p.PhotoUID, err)
+
+		if err != nil {
 			log.Errorf("cleanup: %s (remove orphan photo)", err.Error())
 		} else {
 			orphans++
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"math"
 	"os"
 	"os/exec"
@@ -13,10 +12,12 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/karrick/godirwalk"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -162,7 +163,7 @@ func (c *Convert) ToJson(f *MediaFile) (jsonName string, err error) {
 	}
 
 	// Write output to file.
-	if err := ioutil.WriteFile(jsonName, []byte(out.String()), os.ModePerm); err != nil {
+	if err := fs.WriteFile(jsonName, []byte(out.String()), os.ModePerm); err != nil {
 		return "", err
 	}
 
@@ -227,7 +228,7 @@ func (c *Convert) JpegConvertCommand(f *MediaFile, jpegName string, xmpName stri
 }
 
 // ToJpeg converts a single image file to JPEG if possible.
-func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
+func (c *Convert) ToJpeg(f *MediaFile) (file *MediaFile, err error) {
 	if f == nil {
 		return nil, fmt.Errorf("convert: file is nil - you might have found a bug")
 	}
@@ -257,6 +258,10 @@ func (c *Convert) ToJpeg(f *MediaFile) (*MediaFile, error) {
 
 	log.Infof("converting %s to %s", fileName, fs.FormatJpeg)
 
+	defer func(start time.Time) {
+		metrics.Converted(string(fs.FormatJpeg), err, start)
+	}(time.Now())
+
 	xmpName := fs.FormatXMP.Find(f.FileName(), false)
 
 	event.Publish("index.converting", event.Data{
@@ -399,6 +404,10 @@ func (c *Convert) ToAvc(f *MediaFile, encoderName string) (file *MediaFile, err
 
 	log.Infof("converting %s to %s (%s)", fileName, fs.FormatAvc, encoderName)
 
+	defer func(start time.Time) {
+		metrics.Converted(string(fs.FormatAvc), err, start)
+	}(time.Now())
+
 	event.Publish("index.converting", event.Data{
 		"fileType": f.FileType(),
 		"fileName": fileName,
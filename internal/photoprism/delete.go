@@ -1,48 +1,140 @@
 package photoprism
 
 import (
+	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strings"
 
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/storage"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// AuditMissing is the audit log action for files that were flagged as missing, but not deleted.
+const AuditMissing = "missing"
+
+// AuditDelete adds a deletion by a background worker to the audit log.
+func AuditDelete(worker string, resource acl.Resource, uid string, err error) {
+	auditWorker(string(acl.ActionDelete), worker, resource, uid, err)
+}
+
+// AuditFlagMissing adds a file that was flagged as missing by a background worker to the audit log.
+func AuditFlagMissing(worker string, resource acl.Resource, uid string, err error) {
+	auditWorker(AuditMissing, worker, resource, uid, err)
+}
+
+// auditWorker adds an action by a background worker to the audit log.
+func auditWorker(action, worker string, resource acl.Resource, uid string, err error) {
+	m := entity.AuditLog{
+		ActorName:    entity.AuditSystem,
+		AuditAction:  action,
+		Resource:     string(resource),
+		ResourceUID:  uid,
+		AuditOutcome: entity.AuditSuccess,
+		AuditMessage: worker,
+	}
+
+	if err != nil {
+		m.AuditOutcome = entity.AuditFailed
+		m.AuditMessage = fmt.Sprintf("%s: %s", worker, err)
+	}
+
+	entity.Audit(m)
+}
+
 // Delete permanently removes a photo and all its files.
 func Delete(p entity.Photo) error {
-	yamlFileName := p.YamlFileName(Config().OriginalsPath(), Config().SidecarPath())
+	conf := Config()
+	yamlFileName := p.YamlFileName(conf.OriginalsPath(), conf.SidecarPath())
 
 	files := p.AllFiles()
 
 	for _, file := range files {
-		fileName := FileName(file.FileRoot, file.FileName)
-
 		log.Debugf("delete: removing file %s", txt.Quote(file.FileName))
 
-		if f, err := NewMediaFile(fileName); err == nil {
-			if sidecarJson := f.SidecarJsonName(); fs.FileExists(sidecarJson) {
-				log.Debugf("delete: removing json sidecar %s", txt.Quote(filepath.Base(sidecarJson)))
-				logWarn("delete", os.Remove(sidecarJson))
-			}
+		s := RootStorage(file.FileRoot)
 
-			if exifJson, err := f.ExifToolJsonName(); err == nil && fs.FileExists(exifJson) {
-				log.Debugf("delete: removing exiftool sidecar %s", txt.Quote(filepath.Base(exifJson)))
-				logWarn("delete", os.Remove(exifJson))
-			}
+		if sidecarJson := file.FileName + ".json"; s.Exists(sidecarJson) {
+			log.Debugf("delete: removing json sidecar %s", txt.Quote(path.Base(sidecarJson)))
+			logWarn("delete", s.Remove(sidecarJson))
+		}
 
-			logWarn("delete", f.RemoveSidecars())
+		if exifJson, err := CacheName(file.FileHash, "json", "exiftool.json"); err == nil && file.FileHash != "" {
+			logWarn("delete", removeStorageFile(conf.CacheStorage(), exifJson))
 		}
 
-		if fs.FileExists(fileName) {
-			logWarn("delete", os.Remove(fileName))
+		logWarn("delete", removeSidecars(file))
+		logWarn("delete", RemoveFile(file.FileRoot, file.FileName))
+	}
+
+	log.Debugf("delete: removing yaml sidecar %s", txt.Quote(filepath.Base(yamlFileName)))
+	logWarn("delete", removeStorageFile(conf.SidecarStorage(), yamlFileName))
+
+	return p.DeletePermanently()
+}
+
+// RemoveFile removes an original file from storage, including the local copy if any.
+func RemoveFile(fileRoot, fileName string) error {
+	if err := RootStorage(fileRoot).Remove(fileName); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// removeStorageFile removes a file with an absolute local name from storage, if it exists.
+func removeStorageFile(s storage.Storage, fileName string) error {
+	name, err := storage.Rel(s, fileName)
+
+	if err != nil {
+		return err
+	} else if !s.Exists(name) {
+		return nil
+	}
+
+	return s.Remove(name)
+}
+
+// removeSidecars removes the sidecar files of an original file from storage.
+func removeSidecars(file entity.File) error {
+	conf := Config()
+	s := conf.SidecarStorage()
+
+	prefix := fs.RelPrefix(FileName(file.FileRoot, file.FileName), conf.OriginalsPath(), false)
+	globPrefix := filepath.Join(conf.SidecarPath(), prefix) + "."
+
+	var matches []string
+
+	if s.Backend() == storage.BackendLocal {
+		if fileNames, err := filepath.Glob(regexp.QuoteMeta(globPrefix) + "*"); err != nil {
+			return err
+		} else {
+			matches = fileNames
 		}
+	} else if name, err := storage.Rel(s, filepath.Join(conf.SidecarPath(), prefix)); err != nil {
+		return err
+	} else if err := s.Walk(path.Dir(name), func(name string, info os.FileInfo) error {
+		if fileName := filepath.Join(s.Path(), name); strings.HasPrefix(fileName, globPrefix) && filepath.Dir(fileName) == filepath.Dir(globPrefix) {
+			matches = append(matches, fileName)
+		}
+
+		return nil
+	}); err != nil {
+		return err
 	}
 
-	if fs.FileExists(yamlFileName) {
-		log.Debugf("delete: removing yaml sidecar %s", txt.Quote(filepath.Base(yamlFileName)))
-		logWarn("delete", os.Remove(yamlFileName))
+	for _, sidecarName := range matches {
+		if err := removeStorageFile(s, sidecarName); err != nil {
+			log.Errorf("delete: failed removing sidecar %s", txt.Quote(fs.RelName(sidecarName, s.Path())))
+		} else {
+			log.Infof("delete: removed sidecar %s", txt.Quote(fs.RelName(sidecarName, s.Path())))
+		}
 	}
 
-	return p.DeletePermanently()
+	return nil
 }
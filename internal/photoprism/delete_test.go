@@ -0,0 +1,81 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/remote/s3/s3test"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestDelete(t *testing.T) {
+	t.Run("S3", func(t *testing.T) {
+		srv := s3test.NewTestServer("photos")
+		defer srv.Close()
+
+		srv.Put("originals/2021/delete.jpg", []byte("jpeg"))
+		srv.Put("originals/2021/delete.jpg.json", []byte("{}"))
+		srv.Put("originals/2021/keep.jpg", []byte("jpeg"))
+		srv.Put("sidecar/2021/delete.yml", []byte("Favorite: true\n"))
+		srv.Put("sidecar/2021/delete.jpg.xmp", []byte("<x:xmpmeta/>"))
+
+		opt := Config().Options()
+		backup := *opt
+		defer func() { *opt = backup }()
+
+		opt.StorageBackend = "s3"
+		opt.S3Endpoint = srv.URL
+		opt.S3Bucket = "photos"
+		opt.S3AccessKey = "admin"
+		opt.S3SecretKey = "photoprism"
+
+		photo := entity.Photo{PhotoUID: rnd.PPID('p'), PhotoPath: "2021", PhotoName: "delete", PhotoTitle: "Delete"}
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileUID: rnd.PPID('f'), FileName: "2021/delete.jpg", FileRoot: entity.RootOriginals, FilePrimary: true}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, StorageFileExists(entity.RootOriginals, "2021/delete.jpg"))
+
+		if err := Delete(photo); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, key := range []string{"originals/2021/delete.jpg", "originals/2021/delete.jpg.json", "sidecar/2021/delete.yml", "sidecar/2021/delete.jpg.xmp"} {
+			_, ok := srv.Get(key)
+			assert.False(t, ok, key)
+		}
+
+		_, ok := srv.Get("originals/2021/keep.jpg")
+
+		assert.True(t, ok)
+		assert.False(t, StorageFileExists(entity.RootOriginals, "2021/delete.jpg"))
+	})
+}
+
+func TestAuditFlagMissing(t *testing.T) {
+	uid := rnd.PPID('f')
+
+	AuditFlagMissing("purge", acl.ResourceFiles, uid, nil)
+
+	var logs entity.AuditLogs
+
+	if err := entity.Db().Where("resource_uid = ?", uid).Find(&logs).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, logs, 1) {
+		assert.Equal(t, AuditMissing, logs[0].AuditAction)
+		assert.Equal(t, entity.AuditSystem, logs[0].ActorName)
+		assert.Equal(t, "purge", logs[0].AuditMessage)
+	}
+}
@@ -98,6 +98,11 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 		log.Debugf("faces: found no new faces")
 	}
 
+	// Update matching radius based on confirmed samples.
+	if _, err := w.UpdateRadius(); err != nil {
+		log.Errorf("faces: %s (update radius)", err)
+	}
+
 	// Match markers with faces and subjects.
 	matches, err := w.Match(opt)
 
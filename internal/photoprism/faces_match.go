@@ -88,6 +88,13 @@ func (w *Faces) MatchFaces(faces entity.Faces, force bool, matchedBefore *time.T
 	limit := 500
 	max := query.CountMarkers(entity.MarkerFace)
 
+	// Subjects rejected as a match by the user.
+	negatives, err := query.SubjectNegatives()
+
+	if err != nil {
+		return result, err
+	}
+
 	for {
 		var markers entity.Markers
 
@@ -125,7 +132,9 @@ func (w *Faces) MatchFaces(faces entity.Faces, force bool, matchedBefore *time.T
 
 			// Find the closest face match for marker.
 			for i, m := range faces {
-				if ok, dist := m.Match(marker.Embeddings()); ok && (f == nil || dist < d) {
+				if negatives.Rejected(marker.MarkerUID, m.SubjUID) {
+					continue
+				} else if ok, dist := m.Match(marker.Embeddings()); ok && (f == nil || dist < d) {
 					f = &faces[i]
 					d = dist
 				}
@@ -0,0 +1,287 @@
+package photoprism
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clusters"
+)
+
+// RetrainMargin is the minimum distance between the matching radius of a face and
+// samples of other subjects, or samples rejected by the user.
+var RetrainMargin = 0.05
+
+// FacesRetrainResult represents the outcome of Faces.Retrain().
+type FacesRetrainResult struct {
+	Subjects   int
+	Samples    int
+	Removed    int64
+	Added      int
+	Recognized int64
+}
+
+// Retrain rebuilds face clusters from manually confirmed markers only and learns
+// a matching radius for each subject.
+func (w *Faces) Retrain() (result FacesRetrainResult, err error) {
+	if w.Disabled() {
+		return result, fmt.Errorf("facial recognition is disabled")
+	}
+
+	if err := mutex.FacesWorker.Start(); err != nil {
+		return result, err
+	}
+
+	defer mutex.FacesWorker.Stop()
+
+	samples, err := findFaceSamples()
+
+	if err != nil {
+		return result, err
+	}
+
+	result.Subjects = len(samples.subjects)
+	result.Samples = samples.count
+
+	if result.Subjects == 0 {
+		log.Infof("faces: no confirmed samples found, nothing to retrain")
+		return result, nil
+	}
+
+	// Remove existing face clusters and matches.
+	if result.Removed, err = query.RemoveFaceClusters(); err != nil {
+		return result, fmt.Errorf("faces: %s (remove clusters)", err)
+	}
+
+	log.Infof("faces: removed %d clusters, retraining %d subjects", result.Removed, result.Subjects)
+
+	for _, subjUID := range samples.subjects {
+		if w.Canceled() {
+			return result, fmt.Errorf("worker canceled")
+		}
+
+		// Samples of other subjects must not match.
+		others := samples.others(subjUID)
+
+		groups, err := w.clusterSamples(samples.confirmed[subjUID])
+
+		if err != nil {
+			return result, err
+		}
+
+		for _, group := range groups {
+			f := entity.NewFace(subjUID, entity.SrcManual, group)
+			f.MatchRadius = retrainRadius(f, group, others)
+
+			if err := f.Create(); err != nil {
+				log.Errorf("faces: %s (add cluster for subject %s)", err, subjUID)
+				continue
+			}
+
+			result.Added++
+
+			log.Debugf("faces: added cluster %s for subject %s based on %d samples, radius %f", f.ID, subjUID, f.Samples, f.MatchRadius)
+		}
+	}
+
+	// Match markers with the new face clusters.
+	if r, err := w.Match(FacesOptions{Force: true}); err != nil {
+		return result, err
+	} else {
+		result.Recognized = r.Recognized
+	}
+
+	return result, nil
+}
+
+// UpdateRadius updates the matching radius of faces assigned to a subject, so that it keeps
+// a distance to confirmed samples of other subjects and samples rejected by the user.
+func (w *Faces) UpdateRadius() (updated int, err error) {
+	if w.Disabled() {
+		return updated, fmt.Errorf("facial recognition is disabled")
+	}
+
+	samples, err := findFaceSamples()
+
+	if err != nil {
+		return updated, err
+	}
+
+	faces, err := query.Faces(true, false)
+
+	if err != nil {
+		return updated, err
+	}
+
+	for i := range faces {
+		f := &faces[i]
+		others := samples.others(f.SubjUID)
+
+		// Clusters of other subjects must not match either.
+		for j := range faces {
+			if faces[j].SubjUID != f.SubjUID {
+				others = append(others, faces[j].Embedding())
+			}
+		}
+
+		if radius := retrainRadius(f, samples.nearest(f, faces), others); math.Abs(radius-f.MatchRadius) < 0.0001 {
+			continue
+		} else if err := f.Update("MatchRadius", radius); err != nil {
+			log.Errorf("faces: %s (update radius of %s)", err, f.ID)
+		} else {
+			updated++
+		}
+	}
+
+	if updated > 0 {
+		log.Debugf("faces: updated matching radius of %d clusters", updated)
+	}
+
+	return updated, nil
+}
+
+// faceSamples contains the embeddings of confirmed and rejected faces grouped by subject.
+type faceSamples struct {
+	subjects  []string
+	confirmed map[string]entity.Embeddings
+	rejected  map[string]entity.Embeddings
+	count     int
+}
+
+// others returns the samples that must not match a face of the subject.
+func (s faceSamples) others(subjUID string) (result entity.Embeddings) {
+	for uid, emb := range s.confirmed {
+		if uid != subjUID {
+			result = append(result, emb...)
+		}
+	}
+
+	return append(result, s.rejected[subjUID]...)
+}
+
+// nearest returns the confirmed samples of the face subject that are closer to the face than to other faces of the subject.
+func (s faceSamples) nearest(f *entity.Face, faces entity.Faces) (result entity.Embeddings) {
+	faceEmbedding := f.Embedding()
+
+	for _, e := range s.confirmed[f.SubjUID] {
+		dist := clusters.EuclideanDistance(e, faceEmbedding)
+		nearest := true
+
+		for i := range faces {
+			if faces[i].ID != f.ID && faces[i].SubjUID == f.SubjUID && clusters.EuclideanDistance(e, faces[i].Embedding()) < dist {
+				nearest = false
+				break
+			}
+		}
+
+		if nearest {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
+
+// findFaceSamples returns the embeddings of manually confirmed markers and of markers
+// whose subject has been rejected by the user.
+func findFaceSamples() (result faceSamples, err error) {
+	result.confirmed = make(map[string]entity.Embeddings)
+	result.rejected = make(map[string]entity.Embeddings)
+
+	markers, err := query.ConfirmedFaceMarkers(face.ClusterMinSize, face.ClusterMinScore)
+
+	if err != nil {
+		return result, err
+	}
+
+	// Group confirmed samples by subject.
+	for _, m := range markers {
+		emb := m.Embeddings()
+
+		if len(emb) == 0 {
+			continue
+		} else if _, ok := result.confirmed[m.SubjUID]; !ok {
+			result.subjects = append(result.subjects, m.SubjUID)
+		}
+
+		result.confirmed[m.SubjUID] = append(result.confirmed[m.SubjUID], emb...)
+		result.count++
+	}
+
+	// Find samples rejected by the user.
+	negatives, err := query.SubjectNegatives()
+
+	if err != nil {
+		return result, err
+	}
+
+	for markerUID, subjUIDs := range negatives {
+		if m := entity.FindMarker(markerUID); m == nil {
+			continue
+		} else if emb := m.Embeddings(); len(emb) > 0 {
+			for subjUID := range subjUIDs {
+				result.rejected[subjUID] = append(result.rejected[subjUID], emb...)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// clusterSamples groups the confirmed samples of a single subject.
+func (w *Faces) clusterSamples(samples entity.Embeddings) (result []entity.Embeddings, err error) {
+	if len(samples) < 2 {
+		return []entity.Embeddings{samples}, nil
+	}
+
+	c, err := clusters.DBSCAN(1, face.ClusterRadius, w.conf.Workers(), clusters.EuclideanDistance)
+
+	if err != nil {
+		return result, err
+	} else if err = c.Learn(samples); err != nil {
+		return result, err
+	}
+
+	result = make([]entity.Embeddings, len(c.Sizes()))
+
+	for i, n := range c.Guesses() {
+		if n < 1 {
+			// Samples without neighbors become a cluster of their own.
+			result = append(result, entity.Embeddings{samples[i]})
+		} else {
+			result[n-1] = append(result[n-1], samples[i])
+		}
+	}
+
+	return result, nil
+}
+
+// retrainRadius returns the matching radius of a face. It covers the confirmed samples, as faces
+// of some people vary more than the default radius allows, and keeps a distance to samples and
+// clusters of other subjects as well as samples rejected by the user.
+func retrainRadius(f *entity.Face, samples, others entity.Embeddings) float64 {
+	radius := f.SampleRadius + face.ClusterRadius
+	min := f.SampleRadius + RetrainMargin
+	faceEmbedding := f.Embedding()
+
+	for _, e := range samples {
+		if d := clusters.EuclideanDistance(e, faceEmbedding) + RetrainMargin; d > radius {
+			radius = d
+		}
+	}
+
+	for _, e := range others {
+		if d := clusters.EuclideanDistance(e, faceEmbedding) - RetrainMargin; d < radius {
+			radius = d
+		}
+	}
+
+	if radius < min {
+		return min
+	}
+
+	return radius
+}
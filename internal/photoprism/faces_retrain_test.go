@@ -0,0 +1,107 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+func TestFaces_Retrain(t *testing.T) {
+	c := config.TestConfig()
+
+	m := NewFaces(c)
+
+	r, err := m.Retrain()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, r.Subjects, 1)
+	assert.GreaterOrEqual(t, r.Added, r.Subjects)
+
+	var faces entity.Faces
+
+	if err := entity.Db().Where("face_src = ?", entity.SrcManual).Find(&faces).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range faces {
+		assert.GreaterOrEqual(t, f.MatchRadius, f.SampleRadius+RetrainMargin-0.0001)
+	}
+}
+
+func TestRetrainRadius(t *testing.T) {
+	f := entity.NewFace("jqy1y111h1njaaad", entity.SrcManual, entity.Embeddings{{0, 0, 0}})
+
+	t.Run("NoOthers", func(t *testing.T) {
+		assert.Equal(t, face.ClusterRadius, retrainRadius(f, entity.Embeddings{{0, 0, 0}}, entity.Embeddings{}))
+	})
+	t.Run("Nearby", func(t *testing.T) {
+		assert.InDelta(t, 0.45, retrainRadius(f, entity.Embeddings{{0, 0, 0}}, entity.Embeddings{{0.5, 0, 0}, {1, 0, 0}}), 0.0001)
+	})
+	t.Run("Minimum", func(t *testing.T) {
+		assert.Equal(t, RetrainMargin, retrainRadius(f, entity.Embeddings{{0, 0, 0}}, entity.Embeddings{{0.01, 0, 0}}))
+	})
+	t.Run("Spread", func(t *testing.T) {
+		// Confirmed samples that are further apart than the default radius extend it.
+		samples := entity.Embeddings{{0, 0, 0}, {0, 0.9, 0}}
+
+		assert.InDelta(t, 0.95, retrainRadius(f, samples, entity.Embeddings{{2, 0, 0}}), 0.0001)
+	})
+	t.Run("SpreadRejected", func(t *testing.T) {
+		samples := entity.Embeddings{{0, 0, 0}, {0, 0.9, 0}}
+
+		assert.InDelta(t, 0.75, retrainRadius(f, samples, entity.Embeddings{{0.8, 0, 0}}), 0.0001)
+	})
+}
+
+func TestFaceSamples_Nearest(t *testing.T) {
+	a := entity.NewFace("jqy1y111h1njaaad", entity.SrcManual, entity.Embeddings{{0, 0, 0}})
+	b := entity.NewFace("jqy1y111h1njaaad", entity.SrcManual, entity.Embeddings{{1, 0, 0}})
+	other := entity.NewFace("jqy1y111h1njaaae", entity.SrcManual, entity.Embeddings{{0.1, 0, 0}})
+
+	s := faceSamples{confirmed: map[string]entity.Embeddings{
+		"jqy1y111h1njaaad": {{0.2, 0, 0}, {0.9, 0, 0}},
+		"jqy1y111h1njaaae": {{0.1, 0, 0}},
+	}}
+
+	faces := entity.Faces{*a, *b, *other}
+
+	assert.Equal(t, entity.Embeddings{{0.2, 0, 0}}, s.nearest(a, faces))
+	assert.Equal(t, entity.Embeddings{{0.9, 0, 0}}, s.nearest(b, faces))
+}
+
+func TestFaces_UpdateRadius(t *testing.T) {
+	c := config.TestConfig()
+
+	m := NewFaces(c)
+
+	if _, err := m.UpdateRadius(); err != nil {
+		t.Fatal(err)
+	}
+
+	faces, err := query.Faces(true, false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range faces {
+		assert.GreaterOrEqual(t, f.MatchRadius, f.SampleRadius+RetrainMargin-0.0001)
+	}
+
+	// Unchanged radius values are not updated again.
+	updated, err := m.UpdateRadius()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 0, updated)
+}
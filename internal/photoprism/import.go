@@ -25,6 +25,8 @@ type Import struct {
 	conf    *config.Config
 	index   *Index
 	convert *Convert
+	mutex   sync.Mutex
+	folders map[string]bool
 }
 
 // NewImport returns a new importer and expects its dependencies as arguments.
@@ -48,6 +50,34 @@ func (imp *Import) thumbPath() string {
 	return imp.conf.ThumbPath()
 }
 
+// addFolder remembers an originals folder with imported files.
+func (imp *Import) addFolder(destDir string) {
+	imp.mutex.Lock()
+	defer imp.mutex.Unlock()
+
+	if imp.folders == nil {
+		imp.folders = make(map[string]bool)
+	}
+
+	imp.folders[fs.RelName(destDir, imp.originalsPath())] = true
+}
+
+// importedFolders returns the originals folders with imported files since the last call, ordered by name.
+func (imp *Import) importedFolders() (result []string) {
+	imp.mutex.Lock()
+	defer imp.mutex.Unlock()
+
+	for dir := range imp.folders {
+		result = append(result, dir)
+	}
+
+	imp.folders = nil
+
+	sort.Strings(result)
+
+	return result
+}
+
 // Start imports media files from a directory and converts/indexes them as needed.
 func (imp *Import) Start(opt ImportOptions) fs.Done {
 	defer func() {
@@ -73,7 +103,7 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 
 	defer mutex.MainWorker.Stop()
 
-	if err := ind.tensorFlow.Init(); err != nil {
+	if err := ind.classifier.Init(); err != nil {
 		log.Errorf("import: %s", err.Error())
 		return done
 	}
@@ -238,6 +268,11 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 	}
 
 	if filesImported > 0 {
+		// Store imported files if originals are not stored on local disk.
+		for _, dir := range imp.importedFolders() {
+			pushStorage(imp.conf, dir)
+		}
+
 		// Run facial recognition if enabled.
 		if w := NewFaces(imp.conf); w.Disabled() {
 			log.Debugf("import: skipping facial recognition")
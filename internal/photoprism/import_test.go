@@ -1,6 +1,7 @@
 package photoprism
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/classify"
@@ -53,6 +54,19 @@ func TestImport_DestinationFilename(t *testing.T) {
 	assert.Equal(t, conf.OriginalsPath()+"/2019/07/20190705_153230_C167C6FD.cr2", fileName)
 }
 
+func TestImport_importedFolders(t *testing.T) {
+	conf := config.TestConfig()
+
+	imp := NewImport(conf, nil, nil)
+
+	imp.addFolder(filepath.Join(conf.OriginalsPath(), "2021", "07"))
+	imp.addFolder(filepath.Join(conf.OriginalsPath(), "2020", "01"))
+	imp.addFolder(filepath.Join(conf.OriginalsPath(), "2021", "07"))
+
+	assert.Equal(t, []string{"2020/01", "2021/07"}, imp.importedFolders())
+	assert.Empty(t, imp.importedFolders())
+}
+
 func TestImport_Start(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode.")
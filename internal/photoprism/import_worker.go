@@ -8,6 +8,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -57,6 +58,8 @@ func ImportWorker(jobs <-chan ImportJob) {
 			if destFileName, err := imp.DestinationFilename(related.Main, f); err == nil {
 				destDir := filepath.Dir(destFileName)
 
+				imp.addFolder(destDir)
+
 				if fs.PathExists(destDir) {
 					// Do nothing.
 				} else if err := os.MkdirAll(destDir, os.ModePerm); err != nil {
@@ -167,6 +170,8 @@ func ImportWorker(jobs <-chan ImportJob) {
 
 				res := ind.MediaFile(f, indexOpt, originalName)
 
+				metrics.Imported(string(res.Status))
+
 				log.Infof("import: %s main %s file %s", res, f.FileType(), txt.Quote(f.RelName(ind.originalsPath())))
 				done[f.FileName()] = true
 
@@ -3,11 +3,14 @@ package photoprism
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/photoprism/photoprism/internal/face"
 
@@ -16,8 +19,10 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mailer"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/storage"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -25,7 +30,7 @@ import (
 // Index represents an indexer that indexes files in the originals directory.
 type Index struct {
 	conf         *config.Config
-	tensorFlow   *classify.TensorFlow
+	classifier   classify.Classifier
 	nsfwDetector *nsfw.Detector
 	faceNet      *face.Net
 	convert      *Convert
@@ -34,10 +39,10 @@ type Index struct {
 }
 
 // NewIndex returns a new indexer and expects its dependencies as arguments.
-func NewIndex(conf *config.Config, tensorFlow *classify.TensorFlow, nsfwDetector *nsfw.Detector, faceNet *face.Net, convert *Convert, files *Files, photos *Photos) *Index {
+func NewIndex(conf *config.Config, classifier classify.Classifier, nsfwDetector *nsfw.Detector, faceNet *face.Net, convert *Convert, files *Files, photos *Photos) *Index {
 	i := &Index{
 		conf:         conf,
-		tensorFlow:   tensorFlow,
+		classifier:   classifier,
 		nsfwDetector: nsfwDetector,
 		faceNet:      faceNet,
 		convert:      convert,
@@ -56,6 +61,121 @@ func (ind *Index) thumbPath() string {
 	return ind.conf.ThumbPath()
 }
 
+// walkStorage fetches new and modified media files with their related files from storage
+// one by one, and passes them to indexFile. Files are not fetched if they are already indexed.
+func (ind *Index) walkStorage(s storage.Storage, opt IndexOptions, done fs.Done, ignore *fs.IgnoreList, indexFile func(fileName, relName string) error) error {
+	stackSequences := ind.conf.Settings().StackSequences()
+
+	// List stored files by directory, so that related files can be fetched with them.
+	dirs := make(map[string][]string)
+	sidecars := make(map[string][]string)
+	infos := make(map[string]os.FileInfo)
+
+	var names []string
+
+	if err := s.Walk(opt.Path, func(name string, info os.FileInfo) error {
+		dirs[path.Dir(name)] = append(dirs[path.Dir(name)], name)
+		infos[name] = info
+		names = append(names, name)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Sidecar files may be stored separately.
+	sidecarStorage := ind.conf.SidecarStorage()
+
+	if sidecarStorage != s {
+		if err := sidecarStorage.Walk(opt.Path, func(name string, info os.FileInfo) error {
+			sidecars[path.Dir(name)] = append(sidecars[path.Dir(name)], name)
+			return nil
+		}); err != nil {
+			log.Warnf("index: %s", err)
+		}
+	}
+
+	skipDirs := make(map[string]bool)
+
+	for _, name := range names {
+		if mutex.MainWorker.Canceled() {
+			return errors.New("indexing canceled")
+		}
+
+		dir := path.Dir(name)
+		fileName := filepath.Join(s.Path(), name)
+
+		// Skip ignored folders and add new folders to the index.
+		if _, ok := skipDirs[dir]; !ok {
+			skipDirs[dir] = ind.skipStorageDir(s, dir, skipDirs, ignore)
+		}
+
+		if skipDirs[dir] || done[fileName].Exists() || ignore.Ignore(fileName) || !fs.IsMedia(name) {
+			continue
+		}
+
+		// Don't fetch files that are already indexed.
+		if ind.files.Indexed(name, entity.RootOriginals, infos[name].ModTime(), opt.Rescan) {
+			done[fileName] = fs.Found
+			continue
+		}
+
+		prefix := fs.BasePrefix(name, stackSequences)
+
+		for _, related := range dirs[dir] {
+			if fs.BasePrefix(related, stackSequences) != prefix {
+				continue
+			} else if _, err := s.LocalName(related); err != nil {
+				log.Warnf("index: %s", err)
+			}
+		}
+
+		for _, related := range sidecars[dir] {
+			if fs.BasePrefix(related, stackSequences) != prefix {
+				continue
+			} else if _, err := sidecarStorage.LocalName(related); err != nil {
+				log.Warnf("index: %s", err)
+			}
+		}
+
+		if !fs.FileExists(fileName) {
+			continue
+		} else if err := indexFile(fileName, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// skipStorageDir tests if a stored folder should be skipped, and adds it to the index otherwise.
+func (ind *Index) skipStorageDir(s storage.Storage, dir string, skipDirs map[string]bool, ignore *fs.IgnoreList) bool {
+	if dir == "." || dir == "" {
+		return false
+	}
+
+	parent := path.Dir(dir)
+
+	if _, ok := skipDirs[parent]; !ok {
+		skipDirs[parent] = ind.skipStorageDir(s, parent, skipDirs, ignore)
+	}
+
+	if skipDirs[parent] || ignore.Ignore(filepath.Join(s.Path(), dir)) {
+		return true
+	}
+
+	folder := entity.NewFolder(entity.RootOriginals, dir, time.Now())
+
+	if err := folder.Create(); err == nil {
+		log.Infof("index: added folder /%s", folder.Path)
+	}
+
+	event.Publish("index.folder", event.Data{
+		"filePath": dir,
+	})
+
+	return false
+}
+
 // Cancel stops the current indexing operation.
 func (ind *Index) Cancel() {
 	mutex.MainWorker.Cancel()
@@ -72,6 +192,19 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 	done := make(fs.Done)
 	originalsPath := ind.originalsPath()
 	optionsPath := filepath.Join(originalsPath, opt.Path)
+	originals := ind.conf.OriginalsStorage()
+	localStorage := originals.Backend() == storage.BackendLocal
+
+	// Files are fetched on demand if originals are not stored on local disk.
+	if !localStorage {
+		if err := os.MkdirAll(optionsPath, os.ModePerm); err != nil {
+			log.Errorf("index: %s", err)
+		}
+
+		if _, err := originals.LocalName(fs.IgnoreFile); err != nil && !os.IsNotExist(err) {
+			log.Warnf("index: %s", err)
+		}
+	}
 
 	if !fs.PathExists(optionsPath) {
 		event.Error(fmt.Sprintf("index: %s does not exist", txt.Quote(optionsPath)))
@@ -85,7 +218,7 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 
 	defer mutex.MainWorker.Stop()
 
-	if err := ind.tensorFlow.Init(); err != nil {
+	if err := ind.classifier.Init(); err != nil {
 		log.Errorf("index: %s", err.Error())
 
 		return done
@@ -96,10 +229,23 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 	// Start a fixed number of goroutines to index files.
 	var wg sync.WaitGroup
 	var numWorkers = ind.conf.Workers()
+	var failures struct {
+		sync.Mutex
+		count int
+		err   error
+	}
 	wg.Add(numWorkers)
 	for i := 0; i < numWorkers; i++ {
 		go func() {
-			IndexWorker(jobs) // HLc
+			failed, err := IndexWorker(jobs) // HLc
+
+			if failed > 0 {
+				failures.Lock()
+				failures.count += failed
+				failures.err = err
+				failures.Unlock()
+			}
+
 			wg.Done()
 		}()
 	}
@@ -121,106 +267,117 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 		log.Infof(`index: ignored "%s"`, fs.RelName(fileName, originalsPath))
 	}
 
-	err := godirwalk.Walk(optionsPath, &godirwalk.Options{
-		ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
-			log.Errorf("index: %s", strings.Replace(err.Error(), originalsPath, "", 1))
-			return godirwalk.SkipNode
-		},
-		Callback: func(fileName string, info *godirwalk.Dirent) error {
-			if mutex.MainWorker.Canceled() {
-				return errors.New("indexing canceled")
-			}
+	// indexFile adds a media file and its related files to the job queue if they are new or have been modified.
+	indexFile := func(fileName, relName string) error {
+		done[fileName] = fs.Found
 
-			isDir := info.IsDir()
-			isSymlink := info.IsSymlink()
-			relName := fs.RelName(fileName, originalsPath)
+		if !fs.IsMedia(fileName) {
+			return nil
+		}
 
-			if skip, result := fs.SkipWalk(fileName, isDir, isSymlink, done, ignore); skip {
-				if (isSymlink || isDir) && result != filepath.SkipDir {
-					folder := entity.NewFolder(entity.RootOriginals, relName, fs.BirthTime(fileName))
+		mf, err := NewMediaFile(fileName)
 
-					if err := folder.Create(); err == nil {
-						log.Infof("index: added folder /%s", folder.Path)
-					}
-				}
+		if err != nil {
+			log.Error(err)
+			return nil
+		}
 
-				if isDir {
-					event.Publish("index.folder", event.Data{
-						"filePath": relName,
-					})
-				}
+		if mf.FileSize() == 0 {
+			log.Infof("index: skipped empty file %s", txt.Quote(mf.BaseName()))
+			return nil
+		}
 
-				return result
-			}
+		if ind.files.Indexed(relName, entity.RootOriginals, mf.modTime, opt.Rescan) {
+			return nil
+		}
 
-			done[fileName] = fs.Found
+		related, err := mf.RelatedFiles(ind.conf.Settings().StackSequences())
 
-			if !fs.IsMedia(fileName) {
-				return nil
-			}
+		if err != nil {
+			log.Warnf("index: %s", err.Error())
 
-			mf, err := NewMediaFile(fileName)
+			return nil
+		}
 
-			if err != nil {
-				log.Error(err)
-				return nil
-			}
+		var files MediaFiles
 
-			if mf.FileSize() == 0 {
-				log.Infof("index: skipped empty file %s", txt.Quote(mf.BaseName()))
-				return nil
+		for _, f := range related.Files {
+			if done[f.FileName()].Processed() {
+				continue
 			}
 
-			if ind.files.Indexed(relName, entity.RootOriginals, mf.modTime, opt.Rescan) {
-				return nil
+			if f.FileSize() == 0 || ind.files.Indexed(f.RootRelName(), f.Root(), f.ModTime(), opt.Rescan) {
+				done[f.FileName()] = fs.Found
+				continue
 			}
 
-			related, err := mf.RelatedFiles(ind.conf.Settings().StackSequences())
+			files = append(files, f)
+			filesIndexed++
+			done[f.FileName()] = fs.Processed
+		}
 
-			if err != nil {
-				log.Warnf("index: %s", err.Error())
+		done[fileName] = fs.Processed
 
-				return nil
-			}
+		if len(files) == 0 || related.Main == nil {
+			// Nothing to do.
+			return nil
+		}
 
-			var files MediaFiles
+		related.Files = files
 
-			for _, f := range related.Files {
-				if done[f.FileName()].Processed() {
-					continue
-				}
+		jobs <- IndexJob{
+			FileName: mf.FileName(),
+			Related:  related,
+			IndexOpt: opt,
+			Ind:      ind,
+		}
+
+		return nil
+	}
 
-				if f.FileSize() == 0 || ind.files.Indexed(f.RootRelName(), f.Root(), f.ModTime(), opt.Rescan) {
-					done[f.FileName()] = fs.Found
-					continue
+	var err error
+
+	if localStorage {
+		err = godirwalk.Walk(optionsPath, &godirwalk.Options{
+			ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
+				log.Errorf("index: %s", strings.Replace(err.Error(), originalsPath, "", 1))
+				return godirwalk.SkipNode
+			},
+			Callback: func(fileName string, info *godirwalk.Dirent) error {
+				if mutex.MainWorker.Canceled() {
+					return errors.New("indexing canceled")
 				}
 
-				files = append(files, f)
-				filesIndexed++
-				done[f.FileName()] = fs.Processed
-			}
+				isDir := info.IsDir()
+				isSymlink := info.IsSymlink()
+				relName := fs.RelName(fileName, originalsPath)
 
-			done[fileName] = fs.Processed
+				if skip, result := fs.SkipWalk(fileName, isDir, isSymlink, done, ignore); skip {
+					if (isSymlink || isDir) && result != filepath.SkipDir {
+						folder := entity.NewFolder(entity.RootOriginals, relName, fs.BirthTime(fileName))
 
-			if len(files) == 0 || related.Main == nil {
-				// Nothing to do.
-				return nil
-			}
+						if err := folder.Create(); err == nil {
+							log.Infof("index: added folder /%s", folder.Path)
+						}
+					}
 
-			related.Files = files
+					if isDir {
+						event.Publish("index.folder", event.Data{
+							"filePath": relName,
+						})
+					}
 
-			jobs <- IndexJob{
-				FileName: mf.FileName(),
-				Related:  related,
-				IndexOpt: opt,
-				Ind:      ind,
-			}
+					return result
+				}
 
-			return nil
-		},
-		Unsorted:            false,
-		FollowSymbolicLinks: true,
-	})
+				return indexFile(fileName, relName)
+			},
+			Unsorted:            false,
+			FollowSymbolicLinks: true,
+		})
+	} else {
+		err = ind.walkStorage(originals, opt, done, ignore, indexFile)
+	}
 
 	close(jobs)
 	wg.Wait()
@@ -229,7 +386,14 @@ func (ind *Index) Start(opt IndexOptions) fs.Done {
 		log.Error(err.Error())
 	}
 
+	if failures.count > 0 {
+		mailer.Alert(ind.conf, "index", fmt.Errorf("%d files could not be indexed, last error: %s", failures.count, failures.err))
+	}
+
 	if filesIndexed > 0 {
+		// Store sidecar and converted files if originals are not stored on local disk.
+		pushStorage(ind.conf, opt.Path)
+
 		event.Publish("index.updating", event.Data{
 			"step": "faces",
 		})
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/internal/thumb"
 
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -32,7 +33,7 @@ func (ind *Index) classifyImage(jpeg *MediaFile) (results classify.Labels) {
 			continue
 		}
 
-		imageLabels, err := ind.tensorFlow.File(filename)
+		imageLabels, err := ind.classifier.File(filename)
 
 		if err != nil {
 			log.Debugf("%s in %s", err, txt.Quote(jpeg.BaseName()))
@@ -42,6 +43,8 @@ func (ind *Index) classifyImage(jpeg *MediaFile) (results classify.Labels) {
 		labels = append(labels, imageLabels...)
 	}
 
+	metrics.Classified("labels", start)
+
 	// Sort by priority and uncertainty
 	sort.Sort(labels)
 
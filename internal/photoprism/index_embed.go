@@ -0,0 +1,49 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/embed"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/thumb"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// embedImage creates and saves an image embedding of a primary JPEG file for semantic search.
+func (ind *Index) embedImage(jpeg *MediaFile, file entity.File) error {
+	start := time.Now()
+
+	encoder := embed.Active()
+
+	if encoder == nil {
+		return embed.ErrDisabled
+	}
+
+	filename, err := jpeg.Thumbnail(Config().ThumbPath(), thumb.Tile224)
+
+	if err != nil {
+		return err
+	}
+
+	img, err := ioutil.ReadFile(filename)
+
+	if err != nil {
+		return err
+	}
+
+	vector, err := encoder.Image(img)
+
+	if err != nil {
+		return err
+	}
+
+	if err := entity.NewFileEmbedding(file, encoder.Model(), vector).Save(); err != nil {
+		return err
+	}
+
+	log.Debugf("index: created %s embedding for %s [%s]", encoder.Model(), txt.Quote(jpeg.BaseName()), time.Since(start))
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/embed"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEncoder struct{}
+
+func (testEncoder) Model() string {
+	return entity.EmbeddingModelTest
+}
+
+func (testEncoder) Image(img []byte) (embed.Vector, error) {
+	return embed.Vector{0.25, 0.5, 0.25}, nil
+}
+
+func (testEncoder) Text(s string) (embed.Vector, error) {
+	return embed.Vector{0, 1, 0}, nil
+}
+
+func TestIndex_EmbedImage(t *testing.T) {
+	conf := config.TestConfig()
+
+	tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+
+	mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := entity.File{FileUID: "ft2es39w45bnlqe5", PhotoUID: "pt9jtdre2lvl0ye5"}
+
+	t.Run("Disabled", func(t *testing.T) {
+		assert.Equal(t, embed.ErrDisabled, ind.embedImage(mediaFile, file))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		embed.SetEncoder(testEncoder{})
+		defer embed.SetEncoder(nil)
+
+		if err := ind.embedImage(mediaFile, file); err != nil {
+			t.Fatal(err)
+		}
+
+		result := entity.FindFileEmbedding(file.FileUID)
+
+		if result == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, entity.EmbeddingModelTest, result.EmbeddingModel)
+		assert.Equal(t, entity.Embedding{0.25, 0.5, 0.25}, result.Embedding())
+	})
+}
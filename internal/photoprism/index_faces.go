@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/internal/thumb"
 
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -43,6 +44,8 @@ func (ind *Index) detectFaces(jpeg *MediaFile) face.Faces {
 
 	faces, err := ind.faceNet.Detect(thumbName, minSize, true)
 
+	metrics.Classified("faces", start)
+
 	if err != nil {
 		log.Debugf("%s in %s", err, txt.Quote(jpeg.BaseName()))
 	}
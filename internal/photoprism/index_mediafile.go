@@ -10,9 +10,11 @@ import (
 	"github.com/jinzhu/gorm"
 
 	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/embed"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/internal/nsfw"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/thumb"
@@ -71,6 +73,10 @@ func (r IndexResult) Archived() bool {
 }
 
 func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (result IndexResult) {
+	defer func(start time.Time) {
+		metrics.Indexed(string(result.Status), start)
+	}(time.Now())
+
 	if m == nil {
 		err := errors.New("index: media file is nil - you might have found a bug")
 		log.Error(err)
@@ -88,6 +94,7 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 
 	var photoQuery, fileQuery *gorm.DB
 	var locKeywords []string
+	var tags meta.Tags
 
 	file, primaryFile := entity.File{}, entity.File{}
 
@@ -345,6 +352,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			details.SetSubject(metaData.Subject, entity.SrcXmp)
 			details.SetArtist(metaData.Artist, entity.SrcXmp)
 			details.SetCopyright(metaData.Copyright, entity.SrcXmp)
+
+			tags = append(tags, metaData.Tags...)
+			photo.SetRating(metaData.Stars(), metaData.Picked(), metaData.Rejected(), metaData.ColorLabel, entity.SrcXmp)
 		} else {
 			file.FileError = err.Error()
 		}
@@ -364,6 +374,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			details.SetArtist(metaData.Artist, entity.SrcMeta)
 			details.SetCopyright(metaData.Copyright, entity.SrcMeta)
 
+			tags = append(tags, metaData.Tags...)
+			photo.SetRating(metaData.Stars(), metaData.Picked(), metaData.Rejected(), metaData.ColorLabel, entity.SrcMeta)
+
 			if metaData.HasDocumentID() && photo.UUID == "" {
 				log.Infof("index: %s has document_id %s", logName, txt.Quote(metaData.DocumentID))
 
@@ -413,6 +426,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			details.SetArtist(metaData.Artist, entity.SrcMeta)
 			details.SetCopyright(metaData.Copyright, entity.SrcMeta)
 
+			tags = append(tags, metaData.Tags...)
+			photo.SetRating(metaData.Stars(), metaData.Picked(), metaData.Rejected(), metaData.ColorLabel, entity.SrcMeta)
+
 			if metaData.HasDocumentID() && photo.UUID == "" {
 				log.Infof("index: %s has document_id %s", logName, txt.Quote(metaData.DocumentID))
 
@@ -487,15 +503,25 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	if file.FilePrimary {
 		primaryFile = file
 
-		if !Config().DisableTensorFlow() {
-			// Image classification via TensorFlow.
+		if !Config().DisableClassify() {
+			// Image classification via TensorFlow or an external service.
 			labels = ind.classifyImage(m)
+		}
 
-			if !photoExists && Config().Settings().Features.Private && Config().DetectNSFW() {
-				photo.PhotoPrivate = ind.NSFW(m)
+		// Text recognition with Tesseract, disabled by default.
+		if !Config().DisableOCR() && (fileChanged || details.TextSrc == entity.SrcAuto) {
+			if text, err := recognizeText(m); err != nil {
+				log.Warnf("index: %s in %s (recognize text)", err, logName)
+			} else {
+				// Images without text are marked as processed as well, so they are not recognized again.
+				details.SetText(text, entity.SrcOcr)
 			}
 		}
 
+		if !Config().DisableTensorFlow() && !photoExists && Config().Settings().Features.Private && Config().DetectNSFW() {
+			photo.PhotoPrivate = ind.NSFW(m)
+		}
+
 		// Read metadata from embedded Exif and JSON sidecar file, if exists.
 		if metaData := m.MetaData(); metaData.Error == nil {
 			// Update basic metadata.
@@ -512,6 +538,9 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 			details.SetArtist(metaData.Artist, entity.SrcMeta)
 			details.SetCopyright(metaData.Copyright, entity.SrcMeta)
 
+			tags = append(tags, metaData.Tags...)
+			photo.SetRating(metaData.Stars(), metaData.Picked(), metaData.Rejected(), metaData.ColorLabel, entity.SrcMeta)
+
 			if metaData.HasDocumentID() && photo.UUID == "" {
 				log.Debugf("index: %s has document_id %s", logName, txt.Quote(metaData.DocumentID))
 
@@ -713,6 +742,16 @@ func (ind *Index) MediaFile(m *MediaFile, o IndexOptions, originalName string) (
 	result.FileID = file.ID
 	result.FileUID = file.FileUID
 
+	// Update hierarchical keywords found in the file metadata.
+	photo.SyncFileTags(file.ID, tags)
+
+	// Create image embedding for semantic and similarity search, if enabled.
+	if file.FilePrimary && embed.Enabled() && (fileChanged || entity.FindFileEmbedding(file.FileUID) == nil) {
+		if err := ind.embedImage(m, file); err != nil {
+			log.Warnf("index: %s in %s (create embedding)", err, logName)
+		}
+	}
+
 	downloadedAs := fileName
 
 	if originalName != "" {
@@ -760,6 +799,8 @@ func (ind *Index) NSFW(jpeg *MediaFile) bool {
 		return false
 	}
 
+	defer metrics.Classified("nsfw", time.Now())
+
 	if nsfwLabels, err := ind.nsfwDetector.File(filename); err != nil {
 		log.Error(err)
 		return false
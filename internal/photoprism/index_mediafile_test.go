@@ -1,13 +1,17 @@
 package photoprism
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/photoprism/photoprism/internal/face"
 
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -88,6 +92,79 @@ func TestIndex_MediaFile(t *testing.T) {
 	})
 }
 
+func TestIndex_MediaFile_History(t *testing.T) {
+	conf := config.TestConfig()
+
+	testToken := rnd.Token(8)
+	testPath := filepath.Join(conf.OriginalsPath(), testToken)
+
+	defer os.RemoveAll(testPath)
+
+	photo := entity.Photo{PhotoPath: testToken, PhotoName: "sidecar", PhotoTitle: "Original Title", TitleSrc: entity.SrcName}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	testFile, err := NewMediaFile("testdata/apple-test-2.xmp")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := testFile.Copy(filepath.Join(testPath, "sidecar.xmp")); err != nil {
+		t.Fatal(err)
+	}
+
+	mediaFile, err := NewMediaFile(filepath.Join(testPath, "sidecar.xmp"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+
+	result := ind.MediaFile(mediaFile, IndexOptionsAll(), filepath.Join(testToken, "sidecar.xmp"))
+
+	assert.True(t, result.Success())
+
+	var titleEdit *entity.PhotoEdit
+
+	for _, edit := range photo.History() {
+		if edit.EditField == "PhotoTitle" {
+			titleEdit = entity.FindPhotoEdit(edit.ID)
+		}
+	}
+
+	if titleEdit == nil {
+		t.Fatal("title edit not found")
+	}
+
+	// Changes from sidecar files are recorded without user.
+	assert.Equal(t, "Original Title", titleEdit.OldValue)
+	assert.Equal(t, "Botanischer Garten", titleEdit.NewValue)
+	assert.Equal(t, entity.SrcXmp, titleEdit.EditSrc)
+	assert.Equal(t, "", titleEdit.UserUID)
+
+	if err := titleEdit.Revert("uqxetse3cy5eo9z2"); err != nil {
+		t.Fatal(err)
+	}
+
+	p := entity.Photo{PhotoUID: photo.PhotoUID}
+
+	if err := p.Find(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Original Title", p.PhotoTitle)
+	assert.Equal(t, entity.SrcName, p.TitleSrc)
+}
+
 func TestIndexResult_Archived(t *testing.T) {
 	t.Run("true", func(t *testing.T) {
 		r := &IndexResult{IndexArchived, nil, 5, "", 5, ""}
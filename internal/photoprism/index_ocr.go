@@ -0,0 +1,50 @@
+package photoprism
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/ocr"
+	"github.com/photoprism/photoprism/internal/thumb"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// OcrSizeLimit is the maximum thumbnail size used for text recognition.
+var OcrSizeLimit = 1920
+
+// recognizeText returns the text found in a JPEG image using optical character recognition.
+func recognizeText(jpeg *MediaFile) (string, error) {
+	start := time.Now()
+
+	// Large thumbnails are good enough for most documents and avoid processing huge originals.
+	limit := OcrSizeLimit
+
+	if max := thumb.MaxSize(); max < limit {
+		limit = max
+	}
+
+	size, _ := thumb.Find(limit)
+
+	if size == "" {
+		return "", fmt.Errorf("ocr: no thumbnail size within limit %d", limit)
+	}
+
+	filename, err := jpeg.Thumbnail(Config().ThumbPath(), size)
+
+	if err != nil {
+		return "", err
+	}
+
+	text, err := ocr.NewTesseract(Config().TesseractBin(), Config().OCRLanguages()).File(filename)
+
+	if err != nil {
+		return "", err
+	}
+
+	if text != "" {
+		log.Infof("ocr: found %d characters in %s [%s]", len(text), txt.Quote(jpeg.BaseName()), time.Since(start))
+	}
+
+	return text, nil
+}
@@ -7,8 +7,14 @@ type IndexJob struct {
 	Ind      *Index
 }
 
-func IndexWorker(jobs <-chan IndexJob) {
+// IndexWorker indexes jobs until the channel is closed and returns the number of failures with the last error.
+func IndexWorker(jobs <-chan IndexJob) (failed int, err error) {
 	for job := range jobs {
-		IndexRelated(job.Related, job.Ind, job.IndexOpt)
+		if result := IndexRelated(job.Related, job.Ind, job.IndexOpt); result.Failed() {
+			failed++
+			err = result.Err
+		}
 	}
+
+	return failed, err
 }
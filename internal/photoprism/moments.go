@@ -9,6 +9,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mailer"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -233,6 +234,7 @@ func (w *Moments) Start() (err error) {
 
 	if count, err := BackupAlbums(w.conf.AlbumsPath(), false); err != nil {
 		log.Errorf("moments: %s (backup albums)", err.Error())
+		mailer.Alert(w.conf, "backup", err)
 	} else if count > 0 {
 		log.Debugf("moments: %d albums saved as yaml files", count)
 	}
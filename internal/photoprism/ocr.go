@@ -0,0 +1,114 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// OCR represents a worker that recognizes text in existing pictures.
+type OCR struct {
+	conf *config.Config
+}
+
+// NewOCR returns a new OCR worker.
+func NewOCR(conf *config.Config) *OCR {
+	return &OCR{conf: conf}
+}
+
+// Start recognizes text in primary JPEG files that have not been processed yet, or all if force is true.
+// It returns the number of pictures in which text was found.
+func (w *OCR) Start(force bool) (updated int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("ocr: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if w.conf.DisableOCR() {
+		return updated, errors.New("ocr: disabled, requires enable-ocr option and tesseract")
+	}
+
+	if err := mutex.MainWorker.Start(); err != nil {
+		return updated, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	limit := 500
+	var afterID uint
+
+	for {
+		files, err := query.OcrFiles(limit, afterID, force)
+
+		if err != nil {
+			return updated, err
+		}
+
+		if len(files) == 0 {
+			break
+		}
+
+		for _, file := range files {
+			afterID = file.ID
+
+			if mutex.MainWorker.Canceled() {
+				return updated, errors.New("ocr: worker canceled")
+			}
+
+			if ok, err := w.File(file); err != nil {
+				log.Errorf("ocr: %s in %s", err, txt.Quote(file.FileName))
+			} else if ok {
+				updated++
+			}
+		}
+	}
+
+	log.Infof("ocr: found text in %d pictures", updated)
+
+	return updated, nil
+}
+
+// File recognizes text in a primary JPEG file and updates the photo details and keywords.
+func (w *OCR) File(file entity.File) (bool, error) {
+	fileName := FileName(file.FileRoot, file.FileName)
+
+	jpeg, err := NewMediaFile(fileName)
+
+	if err != nil {
+		return false, err
+	}
+
+	text, err := recognizeText(jpeg)
+
+	if err != nil {
+		return false, err
+	}
+
+	photo, err := query.PhotoByID(uint64(file.PhotoID))
+
+	if err != nil {
+		return false, err
+	}
+
+	details := photo.GetDetails()
+	details.SetText(text, entity.SrcOcr)
+
+	if err := details.Save(); err != nil {
+		return false, err
+	}
+
+	// Recognized text can be found with the full-text search as well.
+	if err := photo.IndexKeywords(); err != nil {
+		return false, err
+	}
+
+	return text != "", nil
+}
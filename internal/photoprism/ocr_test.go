@@ -0,0 +1,63 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// testTesseract enables OCR with a shell script that prints a receipt like tesseract would.
+func testTesseract(t *testing.T, conf *config.Config) func() {
+	bin := filepath.Join(t.TempDir(), "tesseract")
+
+	if err := ioutil.WriteFile(bin, []byte("#!/bin/sh\necho \"CORNER SHOP\\n\\nTOTAL 12.99\"\n"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	enabled, tesseractBin := conf.Options().EnableOCR, conf.Options().TesseractBin
+
+	conf.Options().EnableOCR = true
+	conf.Options().TesseractBin = bin
+
+	return func() {
+		conf.Options().EnableOCR = enabled
+		conf.Options().TesseractBin = tesseractBin
+	}
+}
+
+func TestOCR_Start(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		updated, err := NewOCR(conf).Start(false)
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, updated)
+	})
+}
+
+func TestRecognizeText(t *testing.T) {
+	conf := config.TestConfig()
+
+	defer testTesseract(t, conf)()
+
+	assert.False(t, conf.DisableOCR())
+
+	mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/cat_brown.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text, err := recognizeText(mediaFile)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "CORNER SHOP\nTOTAL 12.99", text)
+}
@@ -6,6 +6,7 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/photoprism/photoprism/internal/acl"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/mutex"
@@ -83,7 +84,7 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 			}
 
 			if file.FileMissing {
-				if fs.FileExists(fileName) {
+				if StorageFileExists(file.FileRoot, file.FileName) {
 					if opt.Dry {
 						log.Infof("purge: found %s", txt.Quote(file.FileName))
 						continue
@@ -95,7 +96,7 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 						log.Infof("purge: found %s", txt.Quote(file.FileName))
 					}
 				}
-			} else if !fs.FileExists(fileName) {
+			} else if !StorageFileExists(file.FileRoot, file.FileName) {
 				if opt.Dry {
 					purgedFiles[fileName] = true
 					log.Infof("purge: file %s would be flagged as missing", txt.Quote(file.FileName))
@@ -106,9 +107,13 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 
 				if err := file.Purge(); err != nil {
 					log.Errorf("purge: %s", err)
+					AuditFlagMissing("purge", acl.ResourceFiles, file.FileUID, err)
 					continue
 				}
 
+				// The file is only flagged as missing, its index entry is kept.
+				AuditFlagMissing("purge", acl.ResourceFiles, file.FileUID, nil)
+
 				w.files.Remove(file.FileName, file.FileRoot)
 				purgedFiles[fileName] = true
 				log.Infof("purge: flagged file %s as missing", txt.Quote(file.FileName))
@@ -157,14 +162,25 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 				continue
 			}
 
-			if !fs.FileExists(fileName) {
+			if !StorageFileExists(file.FileRoot, file.FileName) {
 				if opt.Dry {
 					purgedFiles[fileName] = true
 					log.Infof("purge: duplicate %s would be removed", txt.Quote(file.FileName))
 					continue
 				}
 
-				if err := file.Purge(); err != nil {
+				err := file.Purge()
+
+				// Duplicates have no UID, so the indexed file with the same hash is referenced instead.
+				var fileUID string
+
+				if indexed, findErr := entity.FirstFileByHash(file.FileHash); findErr == nil {
+					fileUID = indexed.FileUID
+				}
+
+				AuditDelete(fmt.Sprintf("purge duplicate %s", txt.Quote(file.FileName)), acl.ResourceFiles, fileUID, err)
+
+				if err != nil {
 					log.Errorf("purge: %s", err)
 				} else {
 					w.files.Remove(file.FileName, file.FileRoot)
@@ -212,7 +228,11 @@ func (w *Purge) Start(opt PurgeOptions) (purgedFiles map[string]bool, purgedPhot
 				continue
 			}
 
-			if err := photo.Delete(opt.Hard); err != nil {
+			err := photo.Delete(opt.Hard)
+
+			AuditDelete("purge", acl.ResourcePhotos, photo.PhotoUID, err)
+
+			if err != nil {
 				log.Errorf("purge: %s", err)
 			} else {
 				purgedPhotos[photo.PhotoUID] = true
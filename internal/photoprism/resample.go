@@ -12,6 +12,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/storage"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
 
@@ -121,5 +122,16 @@ func (w *Resample) Start(force bool) (err error) {
 	close(jobs)
 	wg.Wait()
 
+	// Store new thumbnails if cache files are not stored on local disk.
+	if s := w.conf.CacheStorage(); s.Backend() != storage.BackendLocal {
+		if name, err := storage.Rel(s, thumbnailsPath); err != nil {
+			log.Errorf("resample: %s", err)
+		} else if count, err := s.Push(name); err != nil {
+			log.Errorf("resample: %s", err)
+		} else if count > 0 {
+			log.Infof("resample: stored %d thumbnails in %s storage", count, s.Backend())
+		}
+	}
+
 	return err
 }
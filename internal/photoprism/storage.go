@@ -0,0 +1,118 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/storage"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// pushStorage stores originals and sidecar files that have been created or changed locally.
+func pushStorage(conf *config.Config, dir string) {
+	for _, s := range libraryStorage(conf) {
+		if count, err := s.Push(dir); err != nil {
+			log.Error(err)
+		} else if count > 0 {
+			log.Infof("storage: stored %d files in %s storage", count, s.Backend())
+		}
+	}
+}
+
+// libraryStorage returns the originals storage, and the sidecar storage if it is separate.
+func libraryStorage(conf *config.Config) []storage.Storage {
+	if !conf.SidecarPathIsAbs() {
+		return []storage.Storage{conf.OriginalsStorage()}
+	}
+
+	return []storage.Storage{conf.OriginalsStorage(), conf.SidecarStorage()}
+}
+
+// RootStorage returns the storage for a file root.
+func RootStorage(fileRoot string) storage.Storage {
+	c := Config()
+
+	switch fileRoot {
+	case entity.RootSidecar:
+		return c.SidecarStorage()
+	case entity.RootImport:
+		return storage.NewLocal(c.ImportPath())
+	case entity.RootExamples:
+		return storage.NewLocal(c.ExamplesPath())
+	default:
+		return c.OriginalsStorage()
+	}
+}
+
+// StorageFileExists tests if a file exists in storage, even if it has not been fetched to local disk.
+func StorageFileExists(fileRoot, fileName string) bool {
+	return RootStorage(fileRoot).Exists(fileName)
+}
+
+// LocalFileName returns the name of a file on local disk, it is fetched from storage if needed.
+func LocalFileName(fileRoot, fileName string) (string, error) {
+	return RootStorage(fileRoot).LocalName(fileName)
+}
+
+// CachedFile tests if a cache file like a thumbnail exists, it is fetched from cache storage if needed.
+func CachedFile(fileName string) bool {
+	if fs.FileExists(fileName) {
+		return true
+	}
+
+	s := Config().CacheStorage()
+
+	if s.Backend() == storage.BackendLocal {
+		return false
+	} else if name, err := storage.Rel(s, fileName); err != nil {
+		return false
+	} else if _, err := s.LocalName(name); err != nil {
+		return false
+	}
+
+	return true
+}
+
+// StoreCachedFile stores a new cache file like a thumbnail if cache files are not stored on local disk.
+func StoreCachedFile(fileName string) {
+	s := Config().CacheStorage()
+
+	if s.Backend() == storage.BackendLocal || !fs.FileExists(fileName) {
+		return
+	} else if name, err := storage.Rel(s, fileName); err != nil {
+		log.Warnf("storage: %s is not a cache file", txt.Quote(fileName))
+	} else if err := s.Store(name); err != nil {
+		log.Error(err)
+	}
+}
+
+// FetchSidecarFile fetches an existing sidecar file like an XMP file from sidecar storage, so that it can be updated.
+func FetchSidecarFile(fileName string) error {
+	s := Config().SidecarStorage()
+
+	if s.Backend() == storage.BackendLocal {
+		return nil
+	} else if name, err := storage.Rel(s, fileName); err != nil {
+		return fmt.Errorf("storage: %s is not a sidecar file", txt.Quote(fileName))
+	} else if _, err := s.LocalName(name); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// StoreSidecarFile stores a sidecar file that has been created or changed locally if sidecar files are not stored on local disk.
+func StoreSidecarFile(fileName string) error {
+	s := Config().SidecarStorage()
+
+	if s.Backend() == storage.BackendLocal {
+		return nil
+	} else if name, err := storage.Rel(s, fileName); err != nil {
+		return fmt.Errorf("storage: %s is not a sidecar file", txt.Quote(fileName))
+	} else {
+		return s.Store(name)
+	}
+}
@@ -0,0 +1,75 @@
+package photoprism
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/storage"
+)
+
+func TestRootStorage(t *testing.T) {
+	conf := config.TestConfig()
+
+	assert.Equal(t, conf.OriginalsPath(), RootStorage(entity.RootOriginals).Path())
+	assert.Equal(t, conf.SidecarPath(), RootStorage(entity.RootSidecar).Path())
+	assert.Equal(t, conf.ImportPath(), RootStorage(entity.RootImport).Path())
+	assert.Equal(t, storage.BackendLocal, RootStorage(entity.RootExamples).Backend())
+}
+
+func TestLocalFileName(t *testing.T) {
+	conf := config.TestConfig()
+
+	fileName := filepath.Join(conf.OriginalsPath(), "storage-test.jpg")
+
+	if err := ioutil.WriteFile(fileName, []byte("jpeg"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(fileName)
+
+	t.Run("exists", func(t *testing.T) {
+		result, err := LocalFileName(entity.RootOriginals, "storage-test.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, fileName, result)
+	})
+	t.Run("missing", func(t *testing.T) {
+		_, err := LocalFileName(entity.RootOriginals, "storage-missing.jpg")
+
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestCachedFile(t *testing.T) {
+	conf := config.TestConfig()
+
+	fileName := filepath.Join(conf.CachePath(), "storage-test.jpg")
+
+	if err := ioutil.WriteFile(fileName, []byte("jpeg"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Remove(fileName)
+
+	assert.False(t, CachedFile(filepath.Join(conf.ThumbPath(), "storage-missing.jpg")))
+	assert.True(t, CachedFile(fileName))
+
+	// Does nothing for local storage.
+	StoreCachedFile(fileName)
+}
+
+func TestStoreSidecarFile(t *testing.T) {
+	conf := config.TestConfig()
+
+	fileName := filepath.Join(conf.SidecarPath(), "storage-test.xmp")
+
+	// Does nothing for local storage.
+	assert.NoError(t, FetchSidecarFile(fileName))
+	assert.NoError(t, StoreSidecarFile(fileName))
+}
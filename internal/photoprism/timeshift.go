@@ -0,0 +1,172 @@
+package photoprism
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// TimeShiftResult represents the outcome of a time shift.
+type TimeShiftResult struct {
+	Offset   string             `json:"Offset"`
+	TimeZone string             `json:"TimeZone"`
+	Total    int                `json:"Total"`
+	Shifted  int                `json:"Shifted"`
+	Failures []BatchEditFailure `json:"Failures"`
+}
+
+// TimeShift represents a worker that corrects the date taken, e.g. after camera clock errors.
+type TimeShift struct {
+	conf *config.Config
+}
+
+// NewTimeShift returns a new TimeShift worker.
+func NewTimeShift(conf *config.Config) *TimeShift {
+	instance := &TimeShift{
+		conf: conf,
+	}
+
+	return instance
+}
+
+// Offset returns the time offset, in reference mode it's derived from a photo of a known clock.
+func (w *TimeShift) Offset(f form.TimeShift) (time.Duration, error) {
+	if f.Reference == "" {
+		return f.Duration()
+	}
+
+	clock, err := f.ClockTime()
+
+	if err != nil {
+		return 0, err
+	}
+
+	ref, err := query.PhotoByUID(f.Reference)
+
+	if err != nil {
+		return 0, fmt.Errorf("reference photo %s not found", txt.Quote(f.Reference))
+	}
+
+	return clock.Sub(ref.TakenAtLocal), nil
+}
+
+// Photos returns the selected photos and the photos matching the search filter.
+func (w *TimeShift) Photos(f form.TimeShift) (photos entity.Photos, err error) {
+	var uids []string
+
+	seen := make(map[string]bool)
+
+	if f.Filter != "" {
+		for offset := 0; ; offset += query.MaxResults {
+			results, _, err := query.PhotoSearch(form.PhotoSearch{Query: f.Filter, Count: query.MaxResults, Offset: offset})
+
+			if err != nil {
+				return photos, err
+			}
+
+			for _, uid := range results.UIDs() {
+				if !seen[uid] {
+					seen[uid] = true
+					uids = append(uids, uid)
+				}
+			}
+
+			if len(results) < query.MaxResults {
+				break
+			}
+		}
+	}
+
+	sel := f.Selection
+	sel.Photos = append(sel.Photos, uids...)
+
+	if sel.Empty() {
+		return photos, nil
+	}
+
+	return query.PhotoSelection(sel)
+}
+
+// Start shifts the date taken of all matching photos.
+func (w *TimeShift) Start(f form.TimeShift) (result TimeShiftResult, err error) {
+	if err := f.Validate(); err != nil {
+		return result, err
+	}
+
+	offset, err := w.Offset(f)
+
+	if err != nil {
+		return result, err
+	}
+
+	photos, err := w.Photos(f)
+
+	if err != nil {
+		return result, err
+	}
+
+	if err := mutex.EditWorker.Start(); err != nil {
+		return result, err
+	}
+
+	defer mutex.EditWorker.Stop()
+
+	result.Offset = offset.String()
+	result.TimeZone = f.TimeZone
+	result.Total = len(photos)
+
+	log.Infof("timeshift: shifting %d photos by %s", len(photos), offset.String())
+
+	var shifted entity.Photos
+
+	for _, p := range photos {
+		if mutex.EditWorker.Canceled() {
+			return result, fmt.Errorf("timeshift: canceled")
+		}
+
+		p.EditedBy = f.UserUID
+
+		if err := p.ShiftTakenAt(offset, f.TimeZone); err != nil {
+			log.Errorf("timeshift: %s (%s)", err, p.PhotoUID)
+			result.Failures = append(result.Failures, BatchEditFailure{PhotoUID: p.PhotoUID, Error: err.Error()})
+			continue
+		}
+
+		shifted = append(shifted, p)
+
+		if f.Sidecar || w.conf.BackupYaml() {
+			w.saveYaml(p)
+		}
+	}
+
+	result.Shifted = len(shifted)
+
+	if len(shifted) > 0 {
+		event.EntitiesUpdated("photos", shifted)
+	}
+
+	return result, nil
+}
+
+// saveYaml writes the photo metadata to a YAML sidecar file.
+func (w *TimeShift) saveYaml(p entity.Photo) {
+	if !w.conf.SidecarWritable() {
+		return
+	}
+
+	fileName := p.YamlFileName(w.conf.OriginalsPath(), w.conf.SidecarPath())
+
+	if err := p.SaveAsYaml(fileName); err != nil {
+		log.Errorf("timeshift: %s (update yaml)", err)
+	} else {
+		log.Debugf("timeshift: updated yaml file %s", txt.Quote(filepath.Base(fileName)))
+	}
+}
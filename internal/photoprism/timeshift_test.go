@@ -0,0 +1,104 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTimeShiftPhoto(t *testing.T, name string, taken time.Time) entity.Photo {
+	m := entity.Photo{PhotoName: name, PhotoPath: "timeshift", TakenAt: taken, TakenAtLocal: taken, TakenSrc: entity.SrcMeta}
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+func TestTimeShift_Start(t *testing.T) {
+	conf := config.TestConfig()
+	taken := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("offset", func(t *testing.T) {
+		w := NewTimeShift(conf)
+		m := createTimeShiftPhoto(t, "offset", taken)
+
+		result, err := w.Start(form.TimeShift{Selection: form.Selection{Photos: []string{m.PhotoUID}}, Offset: "-1h"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, result.Shifted)
+		assert.Empty(t, result.Failures)
+
+		p, err := query.PhotoByUID(m.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, taken.Add(-time.Hour), p.TakenAtLocal)
+		assert.Equal(t, entity.SrcManual, p.TakenSrc)
+	})
+	t.Run("reference", func(t *testing.T) {
+		w := NewTimeShift(conf)
+		ref := createTimeShiftPhoto(t, "reference", taken)
+		other := createTimeShiftPhoto(t, "other", taken.Add(time.Hour))
+
+		f := form.TimeShift{
+			Selection:     form.Selection{Photos: []string{ref.PhotoUID, other.PhotoUID}},
+			Reference:     ref.PhotoUID,
+			ReferenceTime: "2021-06-01 12:30:00",
+		}
+
+		result, err := w.Start(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "30m0s", result.Offset)
+		assert.Equal(t, 2, result.Shifted)
+
+		p, err := query.PhotoByUID(other.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, taken.Add(90*time.Minute), p.TakenAtLocal)
+	})
+	t.Run("filter", func(t *testing.T) {
+		w := NewTimeShift(conf)
+
+		photos, err := w.Photos(form.TimeShift{Filter: "path:2016/12"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+		assert.Equal(t, "pt9jtdre2lvl0y18", photos[0].PhotoUID)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		w := NewTimeShift(conf)
+
+		_, err := w.Start(form.TimeShift{Filter: "camera:2"})
+
+		assert.Error(t, err)
+	})
+	t.Run("reference not found", func(t *testing.T) {
+		w := NewTimeShift(conf)
+
+		_, err := w.Start(form.TimeShift{Filter: "camera:2", Reference: "pt9jtdre2lvl0xxx", ReferenceTime: "2021-06-01 12:30:00"})
+
+		assert.Error(t, err)
+	})
+}
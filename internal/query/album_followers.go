@@ -0,0 +1,36 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// DueAlbumFollowers returns album followers whose last digest is older than the given time.
+func DueAlbumFollowers(before time.Time) (results entity.AlbumFollowers, err error) {
+	err = Db().
+		Where("digest_at IS NULL OR digest_at < ?", before).
+		Order("user_uid, album_uid").
+		Find(&results).Error
+
+	return results, err
+}
+
+// AlbumFollowers returns the UIDs of users following an album.
+func AlbumFollowers(albumUID string) (results []string, err error) {
+	err = Db().Model(&entity.AlbumFollower{}).
+		Where("album_uid = ?", albumUID).
+		Pluck("user_uid", &results).Error
+
+	return results, err
+}
+
+// AlbumPhotosAddedSince counts the visible photos added to an album after the given time.
+func AlbumPhotosAddedSince(albumUID string, since time.Time) (count int, err error) {
+	err = Db().Model(&entity.PhotoAlbum{}).
+		Joins("JOIN photos ON photos.photo_uid = photos_albums.photo_uid AND photos.deleted_at IS NULL AND photos.photo_private = 0").
+		Where("photos_albums.album_uid = ? AND photos_albums.hidden = 0 AND photos_albums.created_at > ?", albumUID, since).
+		Count(&count).Error
+
+	return count, err
+}
@@ -0,0 +1,73 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDueAlbumFollowers(t *testing.T) {
+	albumUID := entity.AlbumFixtures.Get("holiday-2030").AlbumUID
+	userUID := entity.UserFixtures.Get("alice").UserUID
+
+	if _, err := entity.FollowAlbum(albumUID, userUID); err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.UnfollowAlbum(albumUID, userUID)
+
+	t.Run("due", func(t *testing.T) {
+		results, err := DueAlbumFollowers(time.Now().Add(time.Hour))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 1)
+	})
+	t.Run("not due", func(t *testing.T) {
+		results, err := DueAlbumFollowers(time.Now().Add(-time.Hour))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range results {
+			assert.NotEqual(t, albumUID, r.AlbumUID)
+		}
+	})
+	t.Run("followers", func(t *testing.T) {
+		results, err := AlbumFollowers(albumUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{userUID}, results)
+	})
+}
+
+func TestAlbumPhotosAddedSince(t *testing.T) {
+	albumUID := entity.AlbumFixtures.Get("holiday-2030").AlbumUID
+
+	t.Run("all", func(t *testing.T) {
+		count, err := AlbumPhotosAddedSince(albumUID, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, count, 1)
+	})
+	t.Run("none", func(t *testing.T) {
+		count, err := AlbumPhotosAddedSince(albumUID, time.Now().Add(time.Hour))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, count)
+	})
+}
@@ -38,6 +38,7 @@ type AlbumResult struct {
 	AlbumPrivate     bool      `json:"Private"`
 	PhotoCount       int       `json:"PhotoCount"`
 	LinkCount        int       `json:"LinkCount"`
+	ChildCount       int       `json:"ChildCount"`
 	CreatedAt        time.Time `json:"CreatedAt"`
 	UpdatedAt        time.Time `json:"UpdatedAt"`
 	DeletedAt        time.Time `json:"DeletedAt,omitempty"`
@@ -121,9 +122,10 @@ func AlbumSearch(f form.AlbumSearch) (results AlbumResults, err error) {
 
 	// Base query.
 	s := UnscopedDb().Table("albums").
-		Select("albums.*, cp.photo_count,	cl.link_count").
+		Select("albums.*, cp.photo_count,	cl.link_count, cc.child_count").
 		Joins("LEFT JOIN (SELECT album_uid, count(photo_uid) AS photo_count FROM photos_albums WHERE hidden = 0 AND missing = 0 GROUP BY album_uid) AS cp ON cp.album_uid = albums.album_uid").
 		Joins("LEFT JOIN (SELECT share_uid, count(share_uid) AS link_count FROM links GROUP BY share_uid) AS cl ON cl.share_uid = albums.album_uid").
+		Joins("LEFT JOIN (SELECT parent_uid, count(album_uid) AS child_count FROM albums WHERE deleted_at IS NULL AND parent_uid <> '' GROUP BY parent_uid) AS cc ON cc.parent_uid = albums.album_uid").
 		Where("albums.album_type <> 'folder' OR albums.album_path IN (SELECT photo_path FROM photos WHERE photo_private = 0 AND photo_quality > -1 AND deleted_at IS NULL)").
 		Where("albums.deleted_at IS NULL")
 
@@ -142,6 +144,13 @@ func AlbumSearch(f form.AlbumSearch) (results AlbumResults, err error) {
 		s = s.Order("albums.album_favorite DESC, albums.album_year DESC, albums.album_month DESC, albums.album_day DESC, albums.album_title, albums.created_at DESC")
 	}
 
+	// Filter by parent album, e.g. to navigate nested albums.
+	if f.Parent != "" {
+		s = s.Where("albums.parent_uid = ?", f.Parent)
+	} else if f.Root {
+		s = s.Where("albums.parent_uid = '' OR albums.parent_uid IS NULL")
+	}
+
 	if f.ID != "" {
 		s = s.Where("albums.album_uid IN (?)", strings.Split(f.ID, Or))
 
@@ -205,6 +205,49 @@ func TestAlbumSearch(t *testing.T) {
 
 		assert.Equal(t, 0, len(result))
 	})
+	t.Run("search by parent", func(t *testing.T) {
+		child := entity.NewAlbum("Query Parent Child", entity.AlbumDefault)
+
+		if err := child.SetParent("at9lxuqxpogaaba9"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := child.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := AlbumSearch(form.AlbumSearch{Parent: "at9lxuqxpogaaba9"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, len(result))
+		assert.Equal(t, child.AlbumUID, result[0].AlbumUID)
+
+		result, err = AlbumSearch(form.AlbumSearch{ID: "at9lxuqxpogaaba9"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, len(result))
+		assert.Equal(t, 1, result[0].ChildCount)
+
+		result, err = AlbumSearch(form.AlbumSearch{Root: true, Type: entity.AlbumDefault})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range result {
+			assert.NotEqual(t, child.AlbumUID, r.AlbumUID)
+		}
+
+		if err := child.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	})
 }
 
 func TestUpdateAlbumDates(t *testing.T) {
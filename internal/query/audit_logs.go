@@ -0,0 +1,56 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+// AuditLogs returns audit log entries matching the search form, newest first.
+func AuditLogs(f form.AuditSearch) (results entity.AuditLogs, err error) {
+	if err := f.ParseQueryString(); err != nil {
+		return results, err
+	}
+
+	s := UnscopedDb().Model(entity.AuditLog{})
+
+	// Limit result count.
+	if f.Count > 0 && f.Count <= MaxResults {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	} else {
+		s = s.Limit(MaxResults).Offset(f.Offset)
+	}
+
+	if actor := strings.TrimSpace(f.Actor); actor != "" {
+		s = s.Where("actor_uid = ? OR actor_name = ?", actor, actor)
+	}
+
+	if f.Resource != "" {
+		s = s.Where("resource = ?", f.Resource)
+	}
+
+	if f.UID != "" {
+		s = s.Where("resource_uid = ?", f.UID)
+	}
+
+	if f.Action != "" {
+		s = s.Where("audit_action = ?", f.Action)
+	}
+
+	if f.Outcome != "" {
+		s = s.Where("audit_outcome = ?", f.Outcome)
+	}
+
+	if !f.After.IsZero() {
+		s = s.Where("audit_time >= ?", f.After)
+	}
+
+	if !f.Before.IsZero() {
+		s = s.Where("audit_time < ?", f.Before)
+	}
+
+	err = s.Order("audit_time DESC, id DESC").Find(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,57 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogs(t *testing.T) {
+	entity.Audit(entity.AuditLog{ActorUID: "uqxetse3cy5eo9z2", ActorName: "admin", ClientIP: "127.0.0.1", AuditAction: "delete", Resource: "files", ResourceUID: "ft8es39w45bnlqd1"})
+	entity.Audit(entity.AuditLog{ActorName: "bob", AuditAction: "login", Resource: "users", ResourceUID: "bob", AuditOutcome: entity.AuditFailed})
+
+	t.Run("actor", func(t *testing.T) {
+		results, err := AuditLogs(form.AuditSearch{Actor: "uqxetse3cy5eo9z2"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+
+		for _, r := range results {
+			assert.Equal(t, "uqxetse3cy5eo9z2", r.ActorUID)
+		}
+	})
+	t.Run("query", func(t *testing.T) {
+		results, err := AuditLogs(form.AuditSearch{Query: "actor:bob outcome:failed"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+		assert.Equal(t, "login", results[0].AuditAction)
+	})
+	t.Run("resource uid", func(t *testing.T) {
+		results, err := AuditLogs(form.AuditSearch{UID: "ft8es39w45bnlqd1", Resource: "files"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+	})
+	t.Run("time", func(t *testing.T) {
+		results, err := AuditLogs(form.AuditSearch{Before: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
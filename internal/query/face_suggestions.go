@@ -0,0 +1,116 @@
+package query
+
+import (
+	"math"
+	"sort"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clusters"
+)
+
+// FaceSuggestion represents a subject that may match a face marker.
+type FaceSuggestion struct {
+	SubjUID    string  `json:"SubjUID"`
+	SubjName   string  `json:"Name"`
+	FaceID     string  `json:"FaceID"`
+	Dist       float64 `json:"Dist"`
+	Confidence int     `json:"Confidence"`
+}
+
+// FaceSuggestions represents a list of subject candidates sorted by distance.
+type FaceSuggestions []FaceSuggestion
+
+// MarkerSuggestions returns the subjects closest to a face marker, excluding subjects rejected by the user.
+//
+// Confidence is 100 for a perfect match, 50 at the matching radius of a face, and decreases below.
+func MarkerSuggestions(marker *entity.Marker, limit int) (result FaceSuggestions, err error) {
+	result = FaceSuggestions{}
+
+	if marker == nil || marker.MarkerType != entity.MarkerFace {
+		return result, nil
+	}
+
+	embeddings := marker.Embeddings()
+
+	if len(embeddings) == 0 {
+		return result, nil
+	}
+
+	faces, err := Faces(true, false)
+
+	if err != nil {
+		return result, err
+	}
+
+	negatives, err := SubjectNegatives()
+
+	if err != nil {
+		return result, err
+	}
+
+	subjects, err := SubjectMap()
+
+	if err != nil {
+		return result, err
+	}
+
+	best := make(map[string]FaceSuggestion)
+
+	for _, f := range faces {
+		if negatives.Rejected(marker.MarkerUID, f.SubjUID) {
+			continue
+		}
+
+		subj, ok := subjects[f.SubjUID]
+
+		if !ok {
+			continue
+		}
+
+		faceEmbedding := f.Embedding()
+
+		if len(faceEmbedding) == 0 {
+			continue
+		}
+
+		dist := -1.0
+
+		for _, e := range embeddings {
+			if d := clusters.EuclideanDistance(e, faceEmbedding); d < dist || dist < 0 {
+				dist = d
+			}
+		}
+
+		if s, ok := best[f.SubjUID]; ok && s.Dist <= dist {
+			continue
+		}
+
+		radius := f.Radius()
+
+		best[f.SubjUID] = FaceSuggestion{
+			SubjUID:    f.SubjUID,
+			SubjName:   subj.SubjName,
+			FaceID:     f.ID,
+			Dist:       dist,
+			Confidence: int(math.Round(100 * radius / (radius + dist))),
+		}
+	}
+
+	for _, s := range best {
+		result = append(result, s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Dist == result[j].Dist {
+			return result[i].SubjUID < result[j].SubjUID
+		}
+
+		return result[i].Dist < result[j].Dist
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
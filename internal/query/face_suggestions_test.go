@@ -0,0 +1,56 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestMarkerSuggestions(t *testing.T) {
+	t.Run("Unassigned", func(t *testing.T) {
+		m := entity.MarkerFixtures.Pointer("1000003-4")
+
+		results, err := MarkerSuggestions(m, 3)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 1)
+		assert.LessOrEqual(t, len(results), 3)
+
+		for i, s := range results {
+			assert.NotEmpty(t, s.SubjUID)
+			assert.NotEmpty(t, s.SubjName)
+			assert.NotEmpty(t, s.FaceID)
+			assert.GreaterOrEqual(t, s.Confidence, 0)
+			assert.LessOrEqual(t, s.Confidence, 100)
+
+			if i > 0 {
+				assert.GreaterOrEqual(t, s.Dist, results[i-1].Dist)
+			}
+		}
+	})
+	t.Run("Rejected", func(t *testing.T) {
+		fixture := entity.SubjectNegativeFixtures.Get("actor-1")
+		m := entity.FindMarker(fixture.MarkerUID)
+
+		results, err := MarkerSuggestions(m, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, s := range results {
+			assert.NotEqual(t, fixture.SubjUID, s.SubjUID)
+		}
+	})
+	t.Run("Nil", func(t *testing.T) {
+		results, err := MarkerSuggestions(nil, 5)
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
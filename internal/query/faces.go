@@ -3,8 +3,6 @@ package query
 import (
 	"fmt"
 
-	"github.com/photoprism/photoprism/internal/face"
-
 	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/photoprism/photoprism/internal/entity"
@@ -50,6 +48,7 @@ func MatchFaceMarkers() (affected int64, err error) {
 			Where("face_id = ?", f.ID).
 			Where("subj_src = ?", entity.SrcAuto).
 			Where("subj_uid <> ?", f.SubjUID).
+			Where(fmt.Sprintf("marker_uid NOT IN (SELECT marker_uid FROM %s WHERE subj_uid = ?)", entity.SubjectNegative{}.TableName()), f.SubjUID).
 			Updates(entity.Values{"SubjUID": f.SubjUID, "MarkerReview": false}); res.Error != nil {
 			return affected, err
 		} else if res.RowsAffected > 0 {
@@ -77,6 +76,23 @@ func RemoveAutoFaceClusters() (removed int64, err error) {
 	return res.RowsAffected, res.Error
 }
 
+// RemoveFaceClusters removes all face clusters and face references from the index.
+func RemoveFaceClusters() (removed int64, err error) {
+	if _, err = ResetFaceMarkerMatches(); err != nil {
+		return removed, err
+	}
+
+	if err = Db().Model(&entity.Marker{}).
+		Where("marker_type = ? AND face_id <> ''", entity.MarkerFace).
+		UpdateColumns(entity.Values{"face_id": "", "face_dist": -1.0, "matched_at": nil}).Error; err != nil {
+		return removed, err
+	}
+
+	res := UnscopedDb().Delete(entity.Face{}, "id <> ''")
+
+	return res.RowsAffected, res.Error
+}
+
 // CountNewFaceMarkers counts the number of new face markers in the index.
 func CountNewFaceMarkers(size, score int) (n int) {
 	var f entity.Face
@@ -178,7 +194,7 @@ func ResolveFaceCollisions() (conflicts, resolved int, err error) {
 
 				conflicts++
 
-				r := f1.SampleRadius + face.ClusterRadius
+				r := f1.Radius()
 
 				log.Infof("face %s: conflict at dist %f, Ø %f from %d samples, collision Ø %f", f1.ID, dist, r, f1.Samples, f1.CollisionRadius)
 
@@ -208,3 +224,88 @@ func ResolveFaceCollisions() (conflicts, resolved int, err error) {
 
 	return conflicts, resolved, nil
 }
+
+// SplitFace moves the specified markers to a new face cluster, and returns it along with
+// a new cluster for the remaining markers.
+func SplitFace(f *entity.Face, markerUIDs []string, subjUID string) (split, remaining *entity.Face, err error) {
+	if f == nil {
+		return split, remaining, fmt.Errorf("faces: cluster is nil")
+	} else if len(markerUIDs) == 0 {
+		return split, remaining, fmt.Errorf("faces: no markers selected for splitting %s", f.ID)
+	}
+
+	var markers, moved, kept entity.Markers
+
+	if err = Db().Where("face_id = ?", f.ID).Find(&markers).Error; err != nil {
+		return split, remaining, err
+	}
+
+	selected := make(map[string]bool, len(markerUIDs))
+
+	for _, uid := range markerUIDs {
+		selected[uid] = true
+	}
+
+	for _, m := range markers {
+		if selected[m.MarkerUID] {
+			moved = append(moved, m)
+		} else {
+			kept = append(kept, m)
+		}
+	}
+
+	if len(moved) != len(selected) {
+		return split, remaining, fmt.Errorf("faces: selected markers don't belong to cluster %s", f.ID)
+	} else if len(kept) == 0 {
+		return split, remaining, fmt.Errorf("faces: can't move all markers out of cluster %s", f.ID)
+	} else if len(moved.Embeddings()) == 0 || len(kept.Embeddings()) == 0 {
+		return split, remaining, fmt.Errorf("faces: markers of cluster %s have no embeddings", f.ID)
+	}
+
+	// Create new face clusters.
+	if split = entity.FirstOrCreateFace(entity.NewFace(subjUID, entity.SrcManual, moved.Embeddings())); split == nil {
+		return split, remaining, fmt.Errorf("faces: failed creating new cluster for split markers")
+	} else if remaining = entity.FirstOrCreateFace(entity.NewFace(f.SubjUID, f.FaceSrc, kept.Embeddings())); remaining == nil {
+		return split, remaining, fmt.Errorf("faces: failed creating new cluster for remaining markers")
+	}
+
+	// Update markers.
+	for _, m := range moved {
+		values := entity.Values{"FaceID": split.ID, "FaceDist": -1.0}
+
+		if subjUID != "" {
+			values["SubjUID"] = subjUID
+			values["SubjSrc"] = entity.SrcManual
+			m.SubjUID = subjUID
+		} else if m.SubjSrc == entity.SrcAuto {
+			values["SubjUID"] = ""
+			values["SubjSrc"] = ""
+			values["MarkerName"] = ""
+			m.SubjUID = ""
+		}
+
+		if err = m.Updates(values); err != nil {
+			return split, remaining, err
+		}
+
+		// Remember rejected subject so that the marker won't be matched again.
+		if f.SubjUID != "" && f.SubjUID != m.SubjUID {
+			entity.FirstOrCreateSubjectNegative(entity.NewSubjectNegative(f.SubjUID, m.MarkerUID))
+		}
+	}
+
+	if err = Db().Model(&entity.Marker{}).
+		Where("face_id = ?", f.ID).
+		UpdateColumns(entity.Values{"face_id": remaining.ID, "face_dist": -1.0}).Error; err != nil {
+		return split, remaining, err
+	}
+
+	// Remove the original cluster.
+	if f.ID != split.ID && f.ID != remaining.ID {
+		if err = UnscopedDb().Delete(entity.Face{}, "id = ?", f.ID).Error; err != nil {
+			return split, remaining, err
+		}
+	}
+
+	return split, remaining, nil
+}
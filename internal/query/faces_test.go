@@ -3,6 +3,7 @@ package query
 import (
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/crop"
 	"github.com/photoprism/photoprism/internal/entity"
 
 	"github.com/stretchr/testify/assert"
@@ -203,3 +204,64 @@ func TestResolveFaceCollisions(t *testing.T) {
 	assert.LessOrEqual(t, 3, c)
 	assert.LessOrEqual(t, 3, r)
 }
+
+func TestSplitFace(t *testing.T) {
+	newMarker := func(fixture string) entity.Marker {
+		m := entity.NewMarker(entity.FileFixtures.Get("exampleFileName.jpg"), crop.Area{X: 0.1, Y: 0.1, W: 0.2, H: 0.2}, "jqy1y111h1njaaac", entity.SrcImage, entity.MarkerFace)
+		m.SubjSrc = entity.SrcAuto
+		m.EmbeddingsJSON = entity.MarkerFixtures.Get(fixture).EmbeddingsJSON
+
+		return *m
+	}
+
+	markers := entity.Markers{newMarker("actor-a-1"), newMarker("actor-a-2"), newMarker("1000003-4")}
+
+	f := entity.NewFace("jqy1y111h1njaaac", entity.SrcAuto, markers.Embeddings())
+
+	if err := f.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range markers {
+		markers[i].FaceID = f.ID
+
+		if err := markers[i].Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("NoMarkers", func(t *testing.T) {
+		_, _, err := SplitFace(f, nil, "")
+		assert.Error(t, err)
+	})
+	t.Run("AllMarkers", func(t *testing.T) {
+		_, _, err := SplitFace(f, []string{markers[0].MarkerUID, markers[1].MarkerUID, markers[2].MarkerUID}, "")
+		assert.Error(t, err)
+	})
+	t.Run("UnknownMarker", func(t *testing.T) {
+		_, _, err := SplitFace(f, []string{"mt9k3pw1wowuy111"}, "")
+		assert.Error(t, err)
+	})
+	t.Run("Success", func(t *testing.T) {
+		split, remaining, err := SplitFace(f, []string{markers[2].MarkerUID}, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", split.SubjUID)
+		assert.Equal(t, 1, split.Samples)
+		assert.Equal(t, "jqy1y111h1njaaac", remaining.SubjUID)
+		assert.Equal(t, 2, remaining.Samples)
+		assert.Nil(t, entity.FindFace(f.ID))
+
+		moved := entity.FindMarker(markers[2].MarkerUID)
+		kept := entity.FindMarker(markers[0].MarkerUID)
+
+		assert.Equal(t, split.ID, moved.FaceID)
+		assert.Equal(t, "", moved.SubjUID)
+		assert.Equal(t, remaining.ID, kept.FaceID)
+		assert.Equal(t, "jqy1y111h1njaaac", kept.SubjUID)
+		assert.True(t, entity.RejectedSubject(moved.MarkerUID, "jqy1y111h1njaaac"))
+	})
+}
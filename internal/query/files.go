@@ -47,6 +47,23 @@ func Files(limit, offset int, pathName string, includeMissing bool) (files entit
 	return files, err
 }
 
+// OcrFiles returns primary JPEG files for text recognition sorted by id, starting after the specified file id.
+func OcrFiles(limit int, afterID uint, all bool) (files entity.Files, err error) {
+	stmt := Db().
+		Table("files").Select("files.*").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("files.id > ? AND files.file_primary = 1 AND files.file_missing = 0 AND files.file_type = 'jpg' AND files.deleted_at IS NULL", afterID)
+
+	// Skip files that have already been processed?
+	if !all {
+		stmt = stmt.Where("files.photo_id NOT IN (SELECT photo_id FROM details WHERE text_src <> '')")
+	}
+
+	err = stmt.Order("files.id").Limit(limit).Find(&files).Error
+
+	return files, err
+}
+
 // FilesByUID finds files for the given UIDs.
 func FilesByUID(u []string, limit int, offset int) (files entity.Files, err error) {
 	if err := Db().Where("(photo_uid IN (?) AND file_primary = 1) OR file_uid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
@@ -67,6 +67,61 @@ func TestExistingFiles(t *testing.T) {
 	})
 }
 
+func TestOcrFiles(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		files, err := OcrFiles(1000, 0, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(files), 5)
+
+		for i, f := range files {
+			assert.True(t, f.FilePrimary)
+			assert.Equal(t, "jpg", f.FileType)
+
+			if i > 0 {
+				assert.Greater(t, f.ID, files[i-1].ID)
+			}
+		}
+	})
+	t.Run("AfterID", func(t *testing.T) {
+		files, err := OcrFiles(2, 0, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(files) != 2 {
+			t.Fatal("two files expected")
+		}
+
+		next, err := OcrFiles(2, files[0].ID, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, files[1].ID, next[0].ID)
+	})
+	t.Run("Unprocessed", func(t *testing.T) {
+		if err := entity.DetailsFixtures.Pointer("bridge", 1000003).Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		files, err := OcrFiles(1000, 0, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, f := range files {
+			assert.NotEqual(t, "pt9jtdre2lvl0yh0", f.PhotoUID)
+		}
+	})
+}
+
 func TestFilesByUID(t *testing.T) {
 	t.Run("files found", func(t *testing.T) {
 		files, err := FilesByUID([]string{"ft8es39w45bnlqdw"}, 100, 0)
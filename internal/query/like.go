@@ -23,6 +23,16 @@ func NormalizeSearchQuery(s string) string {
 	return strings.Trim(s, "+&|_-=!@$%^(){}\\<>,.;: ")
 }
 
+// LikeEscape is the escape character for wildcards in LIKE patterns.
+const LikeEscape = "!"
+
+var likeReplacer = strings.NewReplacer(LikeEscape, LikeEscape+LikeEscape, "%", LikeEscape+"%", "_", LikeEscape+"_")
+
+// EscapeLike escapes wildcards so that they are matched literally when used with ESCAPE LikeEscape.
+func EscapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
+
 // IsTooShort tests if a search query is too short.
 func IsTooShort(q string) bool {
 	q = strings.Trim(q, "- '")
@@ -28,6 +28,15 @@ func TestIsTooShort(t *testing.T) {
 	})
 }
 
+func TestEscapeLike(t *testing.T) {
+	t.Run("Plain", func(t *testing.T) {
+		assert.Equal(t, "total", EscapeLike("total"))
+	})
+	t.Run("Wildcards", func(t *testing.T) {
+		assert.Equal(t, "50!% off!_now!!", EscapeLike("50% off_now!"))
+	})
+}
+
 func TestLikeAny(t *testing.T) {
 	t.Run("and_or_search", func(t *testing.T) {
 		if w := LikeAny("k.keyword", "table spoon & usa | img json", true, false); len(w) != 2 {
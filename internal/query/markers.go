@@ -73,6 +73,27 @@ func FaceMarkers(limit, offset int) (result entity.Markers, err error) {
 	return result, err
 }
 
+// ConfirmedFaceMarkers returns valid face markers with a manually assigned subject.
+func ConfirmedFaceMarkers(size, score int) (result entity.Markers, err error) {
+	stmt := Db().
+		Where("marker_type = ?", entity.MarkerFace).
+		Where("marker_invalid = 0").
+		Where("embeddings_json <> ''").
+		Where("subj_src = ? AND subj_uid <> ''", entity.SrcManual)
+
+	if size > 0 {
+		stmt = stmt.Where("size >= ?", size)
+	}
+
+	if score > 0 {
+		stmt = stmt.Where("score >= ?", score)
+	}
+
+	err = stmt.Order("subj_uid, marker_uid").Find(&result).Error
+
+	return result, err
+}
+
 // Embeddings returns existing face embeddings.
 func Embeddings(single, unclustered bool, size, score int) (result entity.Embeddings, err error) {
 	var col []string
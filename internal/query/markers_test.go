@@ -205,3 +205,19 @@ func TestCountMarkers(t *testing.T) {
 
 	assert.GreaterOrEqual(t, n, 1)
 }
+
+func TestConfirmedFaceMarkers(t *testing.T) {
+	results, err := ConfirmedFaceMarkers(0, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.GreaterOrEqual(t, len(results), 1)
+
+	for _, m := range results {
+		assert.Equal(t, entity.SrcManual, m.SubjSrc)
+		assert.NotEmpty(t, m.SubjUID)
+		assert.Equal(t, entity.MarkerFace, m.MarkerType)
+	}
+}
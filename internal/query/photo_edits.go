@@ -0,0 +1,22 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// PhotoEdits returns the recorded metadata changes of a photo, newest first, and their total count.
+func PhotoEdits(photoUID string, limit, offset int) (result entity.PhotoEdits, count int, err error) {
+	if limit <= 0 || limit > MaxResults {
+		limit = MaxResults
+	}
+
+	if err = Db().Model(&entity.PhotoEdit{}).Where("photo_uid = ?", photoUID).Count(&count).Error; err != nil {
+		return result, 0, err
+	}
+
+	err = Db().Where("photo_uid = ?", photoUID).
+		Order("id DESC").Limit(limit).Offset(offset).
+		Find(&result).Error
+
+	return result, count, err
+}
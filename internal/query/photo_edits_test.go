@@ -0,0 +1,151 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhotoEdits(t *testing.T) {
+	m := entity.PhotoFixtures.Get("Photo16")
+
+	// Only changes made by users are recorded.
+	m.EditedBy = "uqxetse3cy5eo9z2"
+
+	if err := m.SetFavorite(!m.PhotoFavorite); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("found", func(t *testing.T) {
+		result, count, err := PhotoEdits(m.PhotoUID, 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		require.Len(t, result, 1)
+		assert.Equal(t, len(result), count)
+		assert.Equal(t, "PhotoFavorite", result[0].EditField)
+	})
+	t.Run("total count", func(t *testing.T) {
+		if err := m.SetFavorite(!m.PhotoFavorite); err != nil {
+			t.Fatal(err)
+		}
+
+		result, count, err := PhotoEdits(m.PhotoUID, 1, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.GreaterOrEqual(t, count, 2)
+	})
+	t.Run("not found", func(t *testing.T) {
+		result, count, err := PhotoEdits("pt9jtdre2lvl0xxx", 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+		assert.Equal(t, 0, count)
+	})
+}
+
+func TestRevertPhotoEdits_Search(t *testing.T) {
+	t.Run("keywords", func(t *testing.T) {
+		m := entity.PhotoFixtures.Get("Photo17")
+		f := form.PhotoSearch{Query: "revertedkeyword", Count: 10}
+
+		before := time.Now().Add(-1 * time.Second)
+
+		m.EditedBy = "uqxetse3cy5eo9z2"
+		m.GetDetails().Keywords = "revertedkeyword"
+		m.GetDetails().KeywordsSrc = entity.SrcManual
+
+		if err := m.IndexKeywords(); err != nil {
+			t.Fatal(err)
+		} else if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+
+		if _, err := entity.RevertPhotoEdits(m.PhotoUID, before, "uqxetse3cy5eo9z2"); err != nil {
+			t.Fatal(err)
+		}
+
+		// Search results reflect the reverted keywords.
+		photos, _, err = PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
+	t.Run("date taken", func(t *testing.T) {
+		m := entity.PhotoFixtures.Get("Photo17")
+		year := m.PhotoYear
+		f := form.PhotoSearch{Year: 1985, Count: 100}
+
+		m.EditedBy = "uqxetse3cy5eo9z2"
+
+		local := time.Date(1985, 3, 4, 5, 6, 7, 0, time.UTC)
+
+		if err := m.ShiftTakenAt(local.Sub(m.TakenAtLocal), ""); err != nil {
+			t.Fatal(err)
+		}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+
+		history, _, err := PhotoEdits(m.PhotoUID, 100, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, edit := range history {
+			if edit.EditField == "TakenAtLocal" {
+				if err := edit.Revert("uqxetse3cy5eo9z2"); err != nil {
+					t.Fatal(err)
+				}
+
+				break
+			}
+		}
+
+		photos, _, err = PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+
+		p := entity.Photo{PhotoUID: m.PhotoUID}
+
+		if err := p.Find(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, year, p.PhotoYear)
+	})
+}
@@ -44,6 +44,10 @@ type PhotoResult struct {
 	PhotoColor       uint8         `json:"Color"`
 	PhotoScan        bool          `json:"Scan"`
 	PhotoPanorama    bool          `json:"Panorama"`
+	PhotoRating      int8          `json:"Rating"`
+	PhotoPick        bool          `json:"Pick"`
+	PhotoReject      bool          `json:"Reject"`
+	PhotoColorLabel  string        `json:"ColorLabel,omitempty"`
 	CameraID         uint          `json:"CameraID"` // Camera
 	CameraSerial     string        `json:"CameraSerial,omitempty"`
 	CameraSrc        string        `json:"CameraSrc,omitempty"`
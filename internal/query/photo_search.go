@@ -47,8 +47,42 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Limit(MaxResults).Offset(f.Offset)
 	}
 
+	// Find visually similar photos or photos matching a natural language description.
+	var ranked []string
+
+	if f.Similar != "" {
+		if ranked, err = SimilarPhotoUIDs(f.Similar); err != nil {
+			log.Debugf("search: %s", err)
+			return results, 0, nil
+		}
+	} else if f.Semantic != "" {
+		if ranked, err = SemanticPhotoUIDs(f.Semantic); err != nil {
+			log.Errorf("search: %s (semantic)", err)
+			return results, 0, err
+		}
+	}
+
+	if f.Similar != "" || f.Semantic != "" {
+		if len(ranked) == 0 {
+			return results, 0, nil
+		}
+
+		s = s.Where("photos.photo_uid IN (?)", ranked)
+
+		// Show closest matches first, unless a different sort order was requested.
+		if f.Order == "" || f.Order == entity.SortOrderRelevance {
+			f.Order = entity.SortOrderDistance
+		}
+	}
+
 	// Set sort order.
 	switch f.Order {
+	case entity.SortOrderDistance:
+		if len(ranked) > 0 {
+			s = s.Order(RankedPhotoOrder(ranked) + ", files.file_primary DESC")
+		} else {
+			s = s.Order("taken_at DESC, photos.photo_uid, files.file_primary DESC")
+		}
 	case entity.SortOrderEdited:
 		s = s.Where("edited_at IS NOT NULL").Order("edited_at DESC, photos.photo_uid, files.file_primary DESC")
 	case entity.SortOrderRelevance:
@@ -132,6 +166,16 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		}
 	}
 
+	// Filter by hierarchical tags, including nested tags.
+	if f.Tag != "" {
+		if tagUIDs := TagUIDs(f.Tag); len(tagUIDs) == 0 {
+			log.Errorf("search: tags %s not found", txt.Quote(f.Tag))
+			return results, 0, fmt.Errorf("%s not found", txt.Quote(f.Tag))
+		} else {
+			s = s.Where("photos.id IN (SELECT pt.photo_id FROM photos_tags pt JOIN tags t ON t.id = pt.tag_id WHERE t.tag_uid IN (?))", tagUIDs)
+		}
+	}
+
 	// Clip to reasonable size and normalize operators.
 	f.Query = NormalizeSearchQuery(f.Query)
 
@@ -222,6 +266,14 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		}
 	}
 
+	// Filter by text recognized in images (OCR).
+	if f.Text != "" {
+		// Split by whitespace only, so that numbers like amounts and dates can be found as well.
+		for _, w := range strings.Fields(f.Text) {
+			s = s.Where("photos.id IN (SELECT d.photo_id FROM details d WHERE d.text LIKE ? ESCAPE '"+LikeEscape+"')", "%"+EscapeLike(w)+"%")
+		}
+	}
+
 	// Filter for one or more subjects?
 	if f.Subject != "" {
 		for _, subj := range strings.Split(strings.ToLower(f.Subject), And) {
@@ -300,6 +352,27 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 		s = s.Where("photos.photo_favorite = 1")
 	}
 
+	// Filter by star rating, e.g. "4", ">=4" or "<3".
+	if f.Rating != "" {
+		if op, stars, err := ParseRating(f.Rating); err != nil {
+			return results, 0, err
+		} else {
+			s = s.Where(fmt.Sprintf("photos.photo_rating %s ?", op), stars)
+		}
+	}
+
+	if f.Pick {
+		s = s.Where("photos.photo_pick = 1")
+	}
+
+	if f.Reject {
+		s = s.Where("photos.photo_reject = 1")
+	}
+
+	if f.ColorLabel != "" {
+		s = s.Where("photos.photo_color_label IN (?)", strings.Split(strings.ToLower(f.ColorLabel), Or))
+	}
+
 	if f.Scan {
 		s = s.Where("photos.photo_scan = 1")
 	}
@@ -440,7 +513,10 @@ func PhotoSearch(f form.PhotoSearch) (results PhotoResults, count int, err error
 	}
 
 	// Filter by album?
-	if f.Album != "" {
+	if f.Album != "" && f.Nested && f.Filter == "" {
+		albums := append([]string{f.Album}, entity.AlbumDescendantUIDs(f.Album)...)
+		s = s.Where("photos.photo_uid IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 0 AND pa.album_uid IN (?))", albums)
+	} else if f.Album != "" {
 		if f.Filter != "" {
 			s = s.Where("photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa WHERE pa.hidden = 1 AND pa.album_uid = ?)", f.Album)
 		} else {
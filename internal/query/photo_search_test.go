@@ -6,6 +6,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/photoprism/photoprism/internal/form"
 )
@@ -885,4 +886,183 @@ func TestPhotoSearch(t *testing.T) {
 
 		assert.GreaterOrEqual(t, len(photos), 2)
 	})
+	t.Run("tag", func(t *testing.T) {
+		m := entity.PhotoFixtures.Get("Photo04")
+		m.AddTags([]string{"Search|Animals|Dogs"})
+
+		f := form.PhotoSearch{Tag: "search/animals", Count: 10}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+		assert.Equal(t, m.PhotoUID, photos[0].PhotoUID)
+
+		f.Tag = "search/xxx"
+
+		if _, _, err := PhotoSearch(f); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+	t.Run("rating", func(t *testing.T) {
+		f := form.PhotoSearch{Rating: ">=4", Count: 10}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+		assert.Equal(t, "pt9jtdre2lvl0y18", photos[0].PhotoUID)
+
+		f = form.PhotoSearch{Pick: true, ColorLabel: "red|blue", Count: 10}
+
+		if photos, _, err = PhotoSearch(f); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+
+		f = form.PhotoSearch{Rating: ">>4", Count: 10}
+
+		if _, _, err := PhotoSearch(f); err == nil {
+			t.Fatal("error expected")
+		}
+	})
+	t.Run("nested album", func(t *testing.T) {
+		child := entity.NewAlbum("Query Nested Child", entity.AlbumDefault)
+
+		if err := child.SetParent("at9lxuqxpogaaba8"); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := child.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		child.AddPhotos([]string{"pt9jtdre2lvl0y11"})
+
+		f := form.PhotoSearch{Album: "at9lxuqxpogaaba8", Count: 10}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.NotEqual(t, "pt9jtdre2lvl0y11", p.PhotoUID)
+		}
+
+		f.Nested = true
+
+		photos, _, err = PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+
+		for _, p := range photos {
+			if p.PhotoUID == "pt9jtdre2lvl0y11" {
+				found = true
+			}
+		}
+
+		assert.True(t, found)
+
+		if err := child.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestPhotoSearch_Text(t *testing.T) {
+	// Recognized text is only stored in the details of the bridge fixture.
+	if err := entity.DetailsFixtures.Pointer("bridge", 1000003).Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Found", func(t *testing.T) {
+		f := form.PhotoSearch{Text: "baujahr 1893", Count: 10, Primary: true}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.NotEqual(t, "pt9jtdre2lvl0yh8", p.PhotoUID)
+		}
+	})
+	t.Run("QueryString", func(t *testing.T) {
+		f := form.PhotoSearch{Query: "text:friedrichs", Count: 10, Primary: true}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+	t.Run("Keywords", func(t *testing.T) {
+		m, err := PhotoByID(1000003)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Recognized text is indexed as keywords, so that it can be found with the full-text search.
+		if err := m.IndexKeywords(); err != nil {
+			t.Fatal(err)
+		}
+
+		photos, _, err := PhotoSearch(form.PhotoSearch{Query: "friedrichsbrücke", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		require.NotEmpty(t, photos)
+		assert.Equal(t, m.PhotoUID, photos[0].PhotoUID)
+	})
+	t.Run("Wildcards", func(t *testing.T) {
+		f := form.PhotoSearch{Text: "baujahr 18%", Count: 10}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+
+		f.Text = "baujahr 18_3"
+
+		if photos, _, err = PhotoSearch(f); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		f := form.PhotoSearch{Text: "baujahr 1999", Count: 10}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
 }
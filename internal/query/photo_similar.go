@@ -0,0 +1,191 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/embed"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// SimilarLimit is the maximum number of nearest neighbors returned by similarity and semantic search.
+var SimilarLimit = 500
+
+// SimilarMaxDist is the maximum cosine distance between visually similar images.
+var SimilarMaxDist = 0.25
+
+// SemanticMaxDist is the maximum cosine distance between a text and a matching image.
+var SemanticMaxDist = 0.85
+
+// embeddingRow represents a single embedding of a primary file.
+type embeddingRow struct {
+	PhotoUID      string
+	EmbeddingJSON []byte
+}
+
+// embeddingItem represents a parsed embedding of a primary file.
+type embeddingItem struct {
+	PhotoUID  string
+	Embedding entity.Embedding
+}
+
+// embeddingCache keeps parsed embeddings in memory until they change.
+type embeddingCache struct {
+	mutex   sync.Mutex
+	model   string
+	count   int
+	updated time.Time
+	items   []embeddingItem
+}
+
+var embeddings = &embeddingCache{}
+
+// Items returns all embeddings of the specified model, reloading them if the index has changed.
+func (c *embeddingCache) Items(model string) ([]embeddingItem, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var count int
+	var latest entity.FileEmbedding
+
+	q := Db().Model(&entity.FileEmbedding{}).Where("embedding_model = ?", model)
+
+	if err := q.Count(&count).Error; err != nil {
+		return nil, err
+	} else if count > 0 {
+		if err := q.Select("updated_at").Order("updated_at DESC").Limit(1).Scan(&latest).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if c.model == model && c.count == count && c.updated.Equal(latest.UpdatedAt) {
+		return c.items, nil
+	}
+
+	var rows []embeddingRow
+
+	if err := Db().Table(entity.FileEmbedding{}.TableName()).
+		Select("files_embeddings.photo_uid, files_embeddings.embedding_json").
+		Joins("JOIN files f ON f.file_uid = files_embeddings.file_uid AND f.file_primary = 1 AND f.deleted_at IS NULL").
+		Where("files_embeddings.embedding_model = ?", model).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]embeddingItem, 0, len(rows))
+
+	for _, row := range rows {
+		var e entity.Embedding
+
+		if err := json.Unmarshal(row.EmbeddingJSON, &e); err != nil {
+			log.Warnf("search: %s (parse embedding of %s)", err, row.PhotoUID)
+			continue
+		}
+
+		items = append(items, embeddingItem{PhotoUID: row.PhotoUID, Embedding: e})
+	}
+
+	c.model = model
+	c.count = count
+	c.updated = latest.UpdatedAt
+	c.items = items
+
+	return items, nil
+}
+
+// NearestPhotoUIDs returns the UIDs of photos with embeddings closest to the vector, ordered by distance.
+func NearestPhotoUIDs(model string, v entity.Embedding, maxDist float64, exclude string) (result []string, err error) {
+	items, err := embeddings.Items(model)
+
+	if err != nil {
+		return result, err
+	}
+
+	type match struct {
+		uid  string
+		dist float64
+	}
+
+	var matches []match
+
+	for _, item := range items {
+		if item.PhotoUID == exclude {
+			continue
+		}
+
+		if dist := entity.CosineDistance(v, item.Embedding); dist < 0 || dist > maxDist {
+			continue
+		} else {
+			matches = append(matches, match{uid: item.PhotoUID, dist: dist})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].dist < matches[j].dist
+	})
+
+	if len(matches) > SimilarLimit {
+		matches = matches[:SimilarLimit]
+	}
+
+	result = make([]string, len(matches))
+
+	for i := range matches {
+		result[i] = matches[i].uid
+	}
+
+	return result, nil
+}
+
+// SimilarPhotoUIDs returns the UIDs of photos that look similar to the specified photo, ordered by distance.
+func SimilarPhotoUIDs(photoUID string) ([]string, error) {
+	m := entity.FindPhotoEmbedding(photoUID)
+
+	if m == nil {
+		return nil, fmt.Errorf("no embedding found for %s", photoUID)
+	}
+
+	return NearestPhotoUIDs(m.EmbeddingModel, m.Embedding(), SimilarMaxDist, photoUID)
+}
+
+// SemanticPhotoUIDs returns the UIDs of photos matching a natural language description, ordered by distance.
+func SemanticPhotoUIDs(text string) ([]string, error) {
+	encoder := embed.Active()
+
+	if encoder == nil {
+		return nil, embed.ErrDisabled
+	}
+
+	v, err := encoder.Text(text)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NearestPhotoUIDs(encoder.Model(), v, SemanticMaxDist, "")
+}
+
+// RankedPhotoOrder returns an order expression that sorts photos by their position in the list of UIDs.
+// Values are inlined since the number of query parameters is limited, so UIDs are validated first.
+func RankedPhotoOrder(uids []string) string {
+	var b strings.Builder
+
+	b.WriteString("CASE photos.photo_uid")
+
+	for i, uid := range uids {
+		if !rnd.IsPPID(uid, 'p') {
+			continue
+		}
+
+		b.WriteString(fmt.Sprintf(" WHEN '%s' THEN %d", uid, i))
+	}
+
+	b.WriteString(fmt.Sprintf(" ELSE %d END", len(uids)))
+
+	return b.String()
+}
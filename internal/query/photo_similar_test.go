@@ -0,0 +1,215 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/embed"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEncoder struct{}
+
+func (testEncoder) Model() string {
+	return entity.EmbeddingModelTest
+}
+
+func (testEncoder) Image(img []byte) (embed.Vector, error) {
+	return embed.Vector{1, 0, 0}, nil
+}
+
+func (testEncoder) Text(s string) (embed.Vector, error) {
+	if s == "bridge" {
+		return embed.Vector{1, 0.1, 0}, nil
+	}
+
+	return embed.Vector{0, 1, 0}, nil
+}
+
+func TestNearestPhotoUIDs(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		result, err := NearestPhotoUIDs(entity.EmbeddingModelTest, entity.Embedding{1, 0, 0}, 1, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0y11", "pt9jtdre2lvl0yh9", "pt9jtdre2lvl0yh0"}, result)
+	})
+	t.Run("Exclude", func(t *testing.T) {
+		result, err := NearestPhotoUIDs(entity.EmbeddingModelTest, entity.Embedding{1, 0, 0}, 0.5, "pt9jtdre2lvl0y11")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh9"}, result)
+	})
+	t.Run("OtherModel", func(t *testing.T) {
+		result, err := NearestPhotoUIDs("clip", entity.Embedding{1, 0, 0}, 1, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
+
+func TestSimilarPhotoUIDs(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		result, err := SimilarPhotoUIDs("pt9jtdre2lvl0y11")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh9"}, result)
+	})
+	t.Run("NoEmbedding", func(t *testing.T) {
+		result, err := SimilarPhotoUIDs("pt9jtdre2lvl0yzz")
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestSemanticPhotoUIDs(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		_, err := SemanticPhotoUIDs("bridge")
+		assert.Equal(t, embed.ErrDisabled, err)
+	})
+	t.Run("Found", func(t *testing.T) {
+		embed.SetEncoder(testEncoder{})
+		defer embed.SetEncoder(nil)
+
+		result, err := SemanticPhotoUIDs("bridge")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"pt9jtdre2lvl0yh9", "pt9jtdre2lvl0y11"}, result)
+	})
+}
+
+func TestRankedPhotoOrder(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		result := RankedPhotoOrder([]string{"pt9jtdre2lvl0yh9", "pt9jtdre2lvl0y11"})
+		assert.Equal(t, "CASE photos.photo_uid WHEN 'pt9jtdre2lvl0yh9' THEN 0 WHEN 'pt9jtdre2lvl0y11' THEN 1 ELSE 2 END", result)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		result := RankedPhotoOrder([]string{"pt9jtdre2lvl0yh9", "' OR 1=1 --"})
+		assert.Equal(t, "CASE photos.photo_uid WHEN 'pt9jtdre2lvl0yh9' THEN 0 ELSE 2 END", result)
+	})
+}
+
+func TestPhotoSearch_Similar(t *testing.T) {
+	t.Run("Similar", func(t *testing.T) {
+		f := form.PhotoSearch{Similar: "pt9jtdre2lvl0y11", Count: 10, Primary: true}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 1) {
+			assert.Equal(t, "pt9jtdre2lvl0yh9", photos[0].PhotoUID)
+		}
+	})
+	t.Run("SimilarQuery", func(t *testing.T) {
+		f := form.PhotoSearch{Query: "similar:pt9jtdre2lvl0y11", Count: 10, Primary: true}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+	})
+	t.Run("NoEmbedding", func(t *testing.T) {
+		f := form.PhotoSearch{Similar: "pt9jtdre2lvl0yzz", Count: 10}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, photos)
+	})
+	t.Run("Semantic", func(t *testing.T) {
+		embed.SetEncoder(testEncoder{})
+		defer embed.SetEncoder(nil)
+
+		f := form.PhotoSearch{Semantic: "bridge", Count: 10, Primary: true}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, photos, 2) {
+			assert.Equal(t, "pt9jtdre2lvl0yh9", photos[0].PhotoUID)
+			assert.Equal(t, "pt9jtdre2lvl0y11", photos[1].PhotoUID)
+		}
+	})
+	t.Run("SemanticDisabled", func(t *testing.T) {
+		f := form.PhotoSearch{Semantic: "bridge", Count: 10}
+
+		_, _, err := PhotoSearch(f)
+
+		assert.Equal(t, embed.ErrDisabled, err)
+	})
+	t.Run("SemanticOrderNewest", func(t *testing.T) {
+		embed.SetEncoder(testEncoder{})
+		defer embed.SetEncoder(nil)
+
+		f := form.PhotoSearch{Semantic: "bridge", Count: 10, Primary: true, Order: entity.SortOrderNewest}
+
+		photos, _, err := PhotoSearch(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 2)
+	})
+}
+
+func TestEmbeddingCache_Items(t *testing.T) {
+	c := &embeddingCache{}
+
+	items, err := c.Items(entity.EmbeddingModelTest)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, items)
+	assert.Equal(t, entity.FileEmbeddingFixtures.Get("bridge.jpg").UpdatedAt.Unix(), c.updated.Unix())
+
+	t.Run("Cached", func(t *testing.T) {
+		cached, err := c.Items(entity.EmbeddingModelTest)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, len(items), len(cached))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		empty, err := c.Items("clip")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, empty)
+		assert.True(t, c.updated.IsZero())
+	})
+}
@@ -0,0 +1,32 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// ratingOperators lists supported comparison operators, longest first.
+var ratingOperators = []string{">=", "<=", ">", "<", "="}
+
+// ParseRating returns the comparison operator and number of stars of a rating filter, e.g. ">=4".
+func ParseRating(s string) (op string, stars int, err error) {
+	s = strings.TrimSpace(s)
+	op = "="
+
+	for _, o := range ratingOperators {
+		if strings.HasPrefix(s, o) {
+			op = o
+			s = strings.TrimSpace(s[len(o):])
+			break
+		}
+	}
+
+	if stars, err = strconv.Atoi(s); err != nil || stars < 0 || stars > 5 {
+		return op, 0, fmt.Errorf("invalid rating %s", txt.Quote(s))
+	}
+
+	return op, stars, nil
+}
@@ -0,0 +1,38 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRating(t *testing.T) {
+	t.Run("equal", func(t *testing.T) {
+		op, stars, err := ParseRating("4")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "=", op)
+		assert.Equal(t, 4, stars)
+	})
+	t.Run("greater or equal", func(t *testing.T) {
+		op, stars, err := ParseRating(">=3")
+
+		assert.NoError(t, err)
+		assert.Equal(t, ">=", op)
+		assert.Equal(t, 3, stars)
+	})
+	t.Run("less", func(t *testing.T) {
+		op, stars, err := ParseRating("< 2")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "<", op)
+		assert.Equal(t, 2, stars)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := ParseRating(">=6")
+		assert.Error(t, err)
+
+		_, _, err = ParseRating("many")
+		assert.Error(t, err)
+	})
+}
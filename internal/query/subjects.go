@@ -176,3 +176,20 @@ func SearchSubjUIDs(s string) (result []string, names []string, remaining string
 
 	return result, names, NormalizeSearchQuery(remaining)
 }
+
+// SubjectNegatives returns the subjects rejected as a match for markers.
+func SubjectNegatives() (result entity.NegativeMap, err error) {
+	var negatives entity.SubjectNegatives
+
+	if err = Db().Find(&negatives).Error; err != nil {
+		return result, err
+	}
+
+	result = make(entity.NegativeMap, len(negatives))
+
+	for _, n := range negatives {
+		result.Add(n.MarkerUID, n.SubjUID)
+	}
+
+	return result, nil
+}
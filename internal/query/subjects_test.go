@@ -93,3 +93,16 @@ func TestSearchSubjUIDs(t *testing.T) {
 		assert.Empty(t, result)
 	})
 }
+
+func TestSubjectNegatives(t *testing.T) {
+	results, err := SubjectNegatives()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixture := entity.SubjectNegativeFixtures.Get("actor-1")
+
+	assert.True(t, results.Rejected(fixture.MarkerUID, fixture.SubjUID))
+	assert.False(t, results.Rejected(fixture.MarkerUID, "jqu0xs11qekk9jx8"))
+}
@@ -0,0 +1,137 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/capture"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// TagResult represents a tag search result.
+type TagResult struct {
+	ID         uint      `json:"-"`
+	TagUID     string    `json:"UID"`
+	ParentUID  string    `json:"ParentUID,omitempty"`
+	TagSlug    string    `json:"Slug"`
+	TagName    string    `json:"Name"`
+	PhotoCount int       `json:"PhotoCount"`
+	ChildCount int       `json:"ChildCount"`
+	CreatedAt  time.Time `json:"CreatedAt"`
+	UpdatedAt  time.Time `json:"UpdatedAt"`
+}
+
+type TagResults []TagResult
+
+// TagByUID returns a Tag based on the tag UID.
+func TagByUID(tagUID string) (tag entity.Tag, err error) {
+	if err := Db().Where("tag_uid = ?", tagUID).First(&tag).Error; err != nil {
+		return tag, err
+	}
+
+	return tag, nil
+}
+
+// TagUIDs returns the UIDs of matching tags including nested tags. Values may be separated by Or
+// and contain a tag UID, a slug like "norway", or a slug path like "places/europe/norway".
+func TagUIDs(s string) (result []string) {
+	seen := make(map[string]bool)
+
+	for _, v := range strings.Split(s, Or) {
+		var tags entity.Tags
+
+		v = strings.TrimSpace(v)
+
+		if v == "" {
+			continue
+		} else if rnd.IsPPID(v, 't') {
+			Db().Where("tag_uid = ?", v).Find(&tags)
+		} else if strings.Contains(v, "/") {
+			if t := tagByPath(strings.Split(v, "/")); t != nil {
+				tags = append(tags, *t)
+			}
+		} else {
+			Db().Where("tag_slug = ?", slug.Make(v)).Find(&tags)
+		}
+
+		for _, t := range tags {
+			for _, uid := range append([]string{t.TagUID}, entity.TagDescendantUIDs(t.TagUID)...) {
+				if !seen[uid] {
+					seen[uid] = true
+					result = append(result, uid)
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// tagByPath returns the tag matching a list of slugs starting at the top level, or nil if not found.
+func tagByPath(slugs []string) *entity.Tag {
+	var result *entity.Tag
+
+	parentUID := ""
+
+	for _, s := range slugs {
+		if s = slug.Make(s); s == "" {
+			continue
+		}
+
+		t := entity.Tag{}
+
+		if err := Db().Where("parent_uid = ? AND tag_slug = ?", parentUID, s).First(&t).Error; err != nil {
+			return nil
+		}
+
+		result = &t
+		parentUID = t.TagUID
+	}
+
+	return result
+}
+
+// Tags searches tags based on their name, top-level tags are returned if no parent or query is specified.
+func Tags(f form.TagSearch) (results TagResults, err error) {
+	if err := f.ParseQueryString(); err != nil {
+		return results, err
+	}
+
+	defer log.Debug(capture.Time(time.Now(), fmt.Sprintf("tags: search %s", form.Serialize(f, true))))
+
+	// Base query.
+	s := UnscopedDb().Table("tags").
+		Select("tags.*, cp.photo_count, cc.child_count").
+		Joins("LEFT JOIN (SELECT tag_id, count(DISTINCT photo_id) AS photo_count FROM photos_tags GROUP BY tag_id) AS cp ON cp.tag_id = tags.id").
+		Joins("LEFT JOIN (SELECT parent_uid, count(tag_uid) AS child_count FROM tags WHERE parent_uid <> '' GROUP BY parent_uid) AS cc ON cc.parent_uid = tags.tag_uid").
+		Order("tags.tag_slug")
+
+	// Limit result count.
+	if f.Count > 0 && f.Count <= MaxResults {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	} else {
+		s = s.Limit(MaxResults).Offset(f.Offset)
+	}
+
+	if f.ID != "" {
+		s = s.Where("tags.tag_uid IN (?)", strings.Split(f.ID, Or))
+	} else if f.Parent != "" {
+		s = s.Where("tags.parent_uid = ?", f.Parent)
+	} else if f.Query == "" && !f.All {
+		s = s.Where("tags.parent_uid = ''")
+	}
+
+	if f.Query != "" {
+		s = s.Where("tags.tag_name LIKE ? ESCAPE '"+LikeEscape+"'", "%"+EscapeLike(f.Query)+"%")
+	}
+
+	if result := s.Scan(&results); result.Error != nil {
+		return results, result.Error
+	}
+
+	return results, nil
+}
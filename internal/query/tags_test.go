@@ -0,0 +1,91 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagUIDs(t *testing.T) {
+	norway := entity.FirstOrCreateTagPath("Places|Europe|Norway")
+	bergen := entity.FirstOrCreateTagPath("Places|Europe|Norway|Bergen")
+
+	t.Run("uid", func(t *testing.T) {
+		assert.Equal(t, []string{norway.TagUID, bergen.TagUID}, TagUIDs(norway.TagUID))
+	})
+	t.Run("slug", func(t *testing.T) {
+		assert.Equal(t, []string{bergen.TagUID}, TagUIDs("Bergen"))
+	})
+	t.Run("path", func(t *testing.T) {
+		assert.Equal(t, []string{norway.TagUID, bergen.TagUID}, TagUIDs("places/europe/norway"))
+		assert.Empty(t, TagUIDs("europe/norway"))
+	})
+	t.Run("not found", func(t *testing.T) {
+		assert.Empty(t, TagUIDs("xxx"))
+	})
+}
+
+func TestTags(t *testing.T) {
+	kids := entity.FirstOrCreateTagPath("People|Family|Kids")
+	family := kids.Parent()
+
+	t.Run("top level", func(t *testing.T) {
+		results, err := Tags(form.TagSearch{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range results {
+			assert.Equal(t, "", r.ParentUID)
+		}
+	})
+	t.Run("parent", func(t *testing.T) {
+		results, err := Tags(form.TagSearch{Parent: family.TagUID})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, kids.TagUID, results[0].TagUID)
+	})
+	t.Run("child count", func(t *testing.T) {
+		results, err := Tags(form.TagSearch{ID: family.TagUID})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+		assert.Equal(t, 1, results[0].ChildCount)
+	})
+	t.Run("query", func(t *testing.T) {
+		results, err := Tags(form.TagSearch{Query: "kid"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 1)
+	})
+	t.Run("wildcards", func(t *testing.T) {
+		results, err := Tags(form.TagSearch{Query: "%"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+
+		results, err = Tags(form.TagSearch{Query: "K_ds"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
@@ -12,3 +12,14 @@ func RegisteredUsers() (result entity.Users) {
 
 	return result
 }
+
+// AdminEmails returns the email addresses of active admin users.
+func AdminEmails() (result []string) {
+	if err := Db().Model(&entity.User{}).
+		Where("role_admin = 1 AND user_disabled = 0 AND primary_email <> ''").
+		Pluck("primary_email", &result).Error; err != nil {
+		log.Errorf("users: %s", err)
+	}
+
+	return result
+}
@@ -19,3 +19,10 @@ func TestRegisteredUsers(t *testing.T) {
 		assert.GreaterOrEqual(t, len(users), 3)
 	})
 }
+
+func TestAdminEmails(t *testing.T) {
+	emails := AdminEmails()
+
+	assert.Contains(t, emails, "alice@example.com")
+	assert.NotContains(t, emails, "bob@example.com")
+}
@@ -0,0 +1,43 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Webhooks returns all webhooks sorted by name.
+func Webhooks() (results entity.Webhooks, err error) {
+	err = Db().Order("webhook_name, id").Find(&results).Error
+
+	return results, err
+}
+
+// EnabledWebhooks returns webhooks that should receive events.
+func EnabledWebhooks() (results entity.Webhooks, err error) {
+	err = Db().Where("webhook_enabled = 1").Order("id").Find(&results).Error
+
+	return results, err
+}
+
+// WebhookByUID finds a webhook by its UID.
+func WebhookByUID(uid string) (result entity.Webhook, err error) {
+	if err := Db().Where("webhook_uid = ?", uid).First(&result).Error; err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// WebhookDeliveries returns the delivery log of a webhook, newest first.
+func WebhookDeliveries(uid string, count, offset int) (results entity.WebhookDeliveries, err error) {
+	if count <= 0 || count > MaxResults {
+		count = MaxResults
+	}
+
+	err = UnscopedDb().
+		Where("webhook_uid = ?", uid).
+		Order("delivered_at DESC, id DESC").
+		Limit(count).Offset(offset).
+		Find(&results).Error
+
+	return results, err
+}
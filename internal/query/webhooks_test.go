@@ -0,0 +1,77 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhooks(t *testing.T) {
+	enabled, err := entity.CreateWebhook(form.Webhook{WebhookName: "Enabled", WebhookURL: "https://example.com/a", WebhookTopics: "photos.*", WebhookEnabled: true})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer enabled.Delete()
+
+	disabled, err := entity.CreateWebhook(form.Webhook{WebhookName: "Disabled", WebhookURL: "https://example.com/b", WebhookTopics: "photos.*"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer disabled.Delete()
+
+	t.Run("All", func(t *testing.T) {
+		results, err := Webhooks()
+
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, len(results), 2)
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		results, err := EnabledWebhooks()
+
+		assert.NoError(t, err)
+
+		for _, m := range results {
+			assert.True(t, m.WebhookEnabled)
+			assert.NotEqual(t, disabled.WebhookUID, m.WebhookUID)
+		}
+	})
+	t.Run("ByUID", func(t *testing.T) {
+		m, err := WebhookByUID(enabled.WebhookUID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Enabled", m.WebhookName)
+
+		_, err = WebhookByUID("wqzfqh4x8opr2c3z")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	for _, name := range []string{"photos.created", "photos.updated"} {
+		m := entity.WebhookDelivery{WebhookUID: "wqzfqh4x8opr2c3q", EventName: name, StatusCode: 200}
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := WebhookDeliveries("wqzfqh4x8opr2c3q", 1, 0)
+
+	assert.NoError(t, err)
+
+	if assert.Len(t, results, 1) {
+		assert.Equal(t, "photos.updated", results[0].EventName)
+	}
+
+	results, err = WebhookDeliveries("wqzfqh4x8opr2c3q", 0, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+}
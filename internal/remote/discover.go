@@ -2,26 +2,33 @@ package remote
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
 	"strings"
 
+	"github.com/photoprism/photoprism/internal/remote/sftp"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 type Account struct {
-	AccName string
-	AccURL  string
-	AccType string
-	AccKey  string
-	AccUser string
-	AccPass string
+	AccName    string
+	AccURL     string
+	AccType    string
+	AccKey     string
+	AccUser    string
+	AccPass    string
+	AccHostKey string
 }
 
-func Discover(rawUrl, user, pass string) (result Account, err error) {
+// Discover returns the account settings for a service URL. The host key of SFTP servers is pinned on first
+// use, a fingerprint provided by the user is kept and discovery fails if the server key does not match it.
+func Discover(rawUrl, user, pass, hostKey string) (result Account, err error) {
 	if rawUrl == "" {
 		return result, errors.New("service URL is empty")
 	}
 
+	result.AccHostKey = strings.TrimSpace(hostKey)
+
 	u, err := url.Parse(rawUrl)
 
 	if err != nil {
@@ -59,6 +66,17 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 		if serviceUrl := h.Discover(u.String(), result.AccUser); serviceUrl != nil {
 			serviceUrl.User = nil
 
+			// Pin the host key fingerprint, this also tests if the server can be reached.
+			if h.ServiceType == ServiceSFTP {
+				if fingerprint, err := sftp.HostKey(sftp.Addr(serviceUrl)); err != nil {
+					return result, err
+				} else if result.AccHostKey == "" {
+					result.AccHostKey = fingerprint
+				} else if result.AccHostKey != fingerprint {
+					return result, fmt.Errorf("sftp: host key mismatch for %s, expected %s, got %s", serviceUrl.Host, result.AccHostKey, fingerprint)
+				}
+			}
+
 			if w := txt.Keywords(serviceUrl.Host); len(w) > 0 {
 				result.AccName = strings.Title(w[0])
 			} else {
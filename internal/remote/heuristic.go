@@ -23,6 +23,7 @@ var Heuristics = []Heuristic{
 	{ServiceOneDrive, []string{"onedrive.live.com"}, []string{}, "GET"},
 	{ServiceGDrive, []string{"drive.google.com"}, []string{}, "GET"},
 	{ServiceGPhotos, []string{"photos.google.com"}, []string{}, "GET"},
+	{ServiceSFTP, []string{}, []string{}, "SSH"},
 	{ServiceWebDAV, []string{}, []string{"/", "/webdav/", "/remote.php/dav/files/{user}/", "/remote.php/webdav/", "/dav/files/{user}/", "/servlet/webdav.infostore/"}, "PROPFIND"},
 }
 
@@ -47,6 +48,18 @@ func (h Heuristic) Discover(rawUrl, user string) *url.URL {
 		return nil
 	}
 
+	// SSH servers are only checked if the URL has a matching scheme, see Discover().
+	if h.Method == "SSH" {
+		if u.Scheme == "sftp" || u.Scheme == "ssh" {
+			u.Scheme = "sftp"
+			return u
+		}
+
+		return nil
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		return nil
+	}
+
 	if HttpOk(h.Method, u.String()) {
 		return u
 	}
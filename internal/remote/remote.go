@@ -37,6 +37,8 @@ package remote
 import (
 	"net/http"
 	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 var client = &http.Client{Timeout: 30 * time.Second} // TODO: Change timeout if needed
@@ -53,8 +55,33 @@ const (
 	ServiceGPhotos   = "gphotos"
 	ServiceGDrive    = "gdrive"
 	ServiceOneDrive  = "onedrive"
+	ServiceS3        = "s3"
+	ServiceSFTP      = "sftp"
 )
 
+const SyncTimeout = time.Second * 45
+const AsyncTimeout = time.Minute * 20
+
+// Client represents a remote storage service client for syncing and sharing files.
+type Client interface {
+	Files(dir string) (fs.FileInfos, error)
+	Directories(root string, recursive bool, timeout time.Duration) (fs.FileInfos, error)
+	Download(from, to string, force bool) error
+	CreateDir(dir string) error
+	Upload(from, to string) error
+	Delete(path string) error
+}
+
+// Supported tests if files can be synced and shared with the service type.
+func Supported(serviceType string) bool {
+	switch serviceType {
+	case ServiceWebDAV, ServiceS3, ServiceSFTP:
+		return true
+	default:
+		return false
+	}
+}
+
 func HttpOk(method, rawUrl string) bool {
 	req, err := http.NewRequest(method, rawUrl, nil)
 
@@ -0,0 +1,14 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupported(t *testing.T) {
+	assert.True(t, Supported(ServiceWebDAV))
+	assert.True(t, Supported(ServiceS3))
+	assert.False(t, Supported(ServiceFacebook))
+	assert.False(t, Supported(""))
+}
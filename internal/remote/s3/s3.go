@@ -0,0 +1,284 @@
+/*
+
+Package s3 implements sharing and syncing with S3-compatible object storage like MinIO or Garage.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+var log = event.Log
+
+// DefaultRegion is used if no region was configured, most S3-compatible servers accept it.
+const DefaultRegion = "us-east-1"
+
+// Client represents an S3-compatible object storage client for a single bucket.
+type Client struct {
+	client  *minio.Client
+	bucket  string
+	prefix  string
+	timeout time.Duration
+}
+
+// New creates a new S3 client for the bucket at the service endpoint url, e.g. "https://minio.example.com:9000".
+func New(endpoint, bucket, prefix, region, key, secret string) (result Client, err error) {
+	if bucket == "" {
+		return result, fmt.Errorf("s3: bucket name is missing")
+	}
+
+	u, err := url.Parse(endpoint)
+
+	if err != nil {
+		return result, fmt.Errorf("s3: %s", err)
+	} else if u.Host == "" {
+		return result, fmt.Errorf("s3: invalid endpoint %s", endpoint)
+	}
+
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	clt, err := minio.New(u.Host, &minio.Options{
+		Creds:  credentials.NewStaticV4(key, secret, ""),
+		Secure: u.Scheme != "http",
+		Region: region,
+	})
+
+	if err != nil {
+		return result, fmt.Errorf("s3: %s", err)
+	}
+
+	result = Client{
+		client:  clt,
+		bucket:  bucket,
+		prefix:  strings.Trim(prefix, "/"),
+		timeout: 10 * time.Minute, // TODO: Change timeout if needed
+	}
+
+	return result, nil
+}
+
+// context returns a new request context with timeout.
+func (c Client) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.timeout)
+}
+
+// key returns the object key for a remote file name.
+func (c Client) key(name string) string {
+	name = strings.Trim(name, "/")
+
+	if c.prefix == "" {
+		return name
+	} else if name == "" || name == "." {
+		return c.prefix
+	}
+
+	return c.prefix + "/" + name
+}
+
+// dirKey returns the object key prefix for listing a remote directory.
+func (c Client) dirKey(dir string) string {
+	if k := c.key(dir); k == "" || k == "." {
+		return ""
+	} else {
+		return k + "/"
+	}
+}
+
+// abs returns the remote file name for an object key.
+func (c Client) abs(key string) string {
+	key = strings.TrimSuffix(key, "/")
+
+	if c.prefix != "" {
+		key = strings.TrimPrefix(strings.TrimPrefix(key, c.prefix), "/")
+	}
+
+	return "/" + key
+}
+
+// list returns the objects and common prefixes in a remote directory.
+func (c Client) list(dir string) (files, dirs fs.FileInfos, err error) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	for obj := range c.client.ListObjects(ctx, c.bucket, minio.ListObjectsOptions{Prefix: c.dirKey(dir)}) {
+		if obj.Err != nil {
+			return files, dirs, fmt.Errorf("s3: %s", obj.Err)
+		}
+
+		info := fs.FileInfo{
+			Name: path.Base(strings.TrimSuffix(obj.Key, "/")),
+			Abs:  c.abs(obj.Key),
+			Size: obj.Size,
+			Date: obj.LastModified,
+			Dir:  strings.HasSuffix(obj.Key, "/"),
+		}
+
+		if info.Dir {
+			dirs = append(dirs, info)
+		} else {
+			files = append(files, info)
+		}
+	}
+
+	return files, dirs, nil
+}
+
+// Files returns all files in path as string slice.
+func (c Client) Files(dir string) (result fs.FileInfos, err error) {
+	result, _, err = c.list(dir)
+
+	return result, err
+}
+
+// Directories returns all sub directories in path as string slice.
+func (c Client) Directories(root string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	start := time.Now()
+
+	result, err = c.fetchDirs(root, recursive, start, timeout)
+
+	if time.Now().Sub(start) >= timeout {
+		log.Warnf("s3: read dir timeout reached")
+	}
+
+	return result, err
+}
+
+// fetchDirs recursively fetches all directories until the timeout is reached.
+func (c Client) fetchDirs(root string, recursive bool, start time.Time, timeout time.Duration) (result fs.FileInfos, err error) {
+	_, dirs, err := c.list(root)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, info := range dirs {
+		result = append(result, info)
+
+		if recursive && time.Now().Sub(start) < timeout {
+			subDirs, err := c.fetchDirs(info.Abs, true, start, timeout)
+
+			if err != nil {
+				return result, err
+			}
+
+			result = append(result, subDirs...)
+		}
+	}
+
+	return result, nil
+}
+
+// Download downloads a single file to the given location.
+func (c Client) Download(from, to string, force bool) error {
+	if _, err := os.Stat(to); err == nil && !force {
+		return fmt.Errorf("s3: download skipped, %s already exists", to)
+	}
+
+	dir := path.Dir(to)
+	dirInfo, err := os.Stat(dir)
+
+	if err != nil {
+		// Create directory
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return fmt.Errorf("s3: can't create %s (%s)", dir, err)
+		}
+	} else if !dirInfo.IsDir() {
+		return fmt.Errorf("s3: %s is not a folder", dir)
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	obj, err := c.client.GetObject(ctx, c.bucket, c.key(from), minio.GetObjectOptions{})
+
+	if err != nil {
+		return fmt.Errorf("s3: %s", err)
+	}
+
+	defer obj.Close()
+
+	f, err := os.OpenFile(to, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(f, obj); err != nil {
+		f.Close()
+		os.Remove(to)
+		return fmt.Errorf("s3: %s", err)
+	}
+
+	return f.Close()
+}
+
+// CreateDir does nothing as object storage has no directories.
+func (c Client) CreateDir(dir string) error {
+	return nil
+}
+
+// Upload uploads a single file to the remote server.
+func (c Client) Upload(from, to string) error {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	if _, err := c.client.FPutObject(ctx, c.bucket, c.key(to), from, minio.PutObjectOptions{}); err != nil {
+		return fmt.Errorf("s3: %s", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a single file on a remote server.
+func (c Client) Delete(name string) error {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	if err := c.client.RemoveObject(ctx, c.bucket, c.key(name), minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("s3: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package s3
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+
+	"github.com/photoprism/photoprism/internal/remote"
+	"github.com/photoprism/photoprism/internal/remote/s3/s3test"
+)
+
+const (
+	testBucket = "photos"
+	testKey    = "admin"
+	testSecret = "photoprism"
+)
+
+func testClient(t *testing.T, prefix string) (*s3test.TestServer, Client) {
+	s := s3test.NewTestServer(testBucket)
+
+	s.Put(prefix+"Photos/example.jpg", []byte("jpeg"))
+	s.Put(prefix+"Photos/2021/beach.jpg", []byte("beach"))
+	s.Put(prefix+"Photos/2021/July/sunset.jpg", []byte("sunset"))
+	s.Put(prefix+"Videos/clip.mp4", []byte("video"))
+
+	c, err := New(s.URL, testBucket, prefix, "", testKey, testSecret)
+
+	if err != nil {
+		s.Close()
+		t.Fatal(err)
+	}
+
+	return s, c
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		c, err := New("http://localhost:9000", testBucket, "/backup/", "garage", testKey, testSecret)
+
+		assert.NoError(t, err)
+		assert.IsType(t, Client{}, c)
+		assert.Equal(t, "backup", c.prefix)
+	})
+	t.Run("NoBucket", func(t *testing.T) {
+		_, err := New("http://localhost:9000", "", "", "", testKey, testSecret)
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidEndpoint", func(t *testing.T) {
+		_, err := New("localhost", testBucket, "", "", testKey, testSecret)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_Files(t *testing.T) {
+	s, c := testClient(t, "backup/")
+	defer s.Close()
+
+	files, err := c.Files("Photos")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("one file expected: %+v", files)
+	}
+
+	assert.Equal(t, "example.jpg", files[0].Name)
+	assert.Equal(t, "/Photos/example.jpg", files[0].Abs)
+	assert.Equal(t, int64(4), files[0].Size)
+	assert.False(t, files[0].Dir)
+}
+
+func TestClient_Directories(t *testing.T) {
+	s, c := testClient(t, "")
+	defer s.Close()
+
+	t.Run("NonRecursive", func(t *testing.T) {
+		dirs, err := c.Directories("", false, remote.SyncTimeout)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos", "/Videos"}, dirs.Abs())
+		assert.IsType(t, fs.FileInfo{}, dirs[0])
+		assert.Equal(t, "Photos", dirs[0].Name)
+		assert.True(t, dirs[0].Dir)
+	})
+	t.Run("Recursive", func(t *testing.T) {
+		dirs, err := c.Directories("/", true, remote.SyncTimeout)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos", "/Photos/2021", "/Photos/2021/July", "/Videos"}, dirs.Abs())
+	})
+}
+
+func TestClient_Download(t *testing.T) {
+	s, c := testClient(t, "backup/")
+	defer s.Close()
+
+	tempDir := filepath.Join(os.TempDir(), rnd.UUID())
+	tempFile := tempDir + "/foo/bar.jpg"
+
+	defer os.RemoveAll(tempDir)
+
+	if err := c.Download("/Photos/2021/beach.jpg", tempFile, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, err := ioutil.ReadFile(tempFile); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Equal(t, "beach", string(data))
+	}
+
+	assert.Error(t, c.Download("/Photos/2021/beach.jpg", tempFile, false))
+	assert.NoError(t, c.Download("/Photos/2021/beach.jpg", tempFile, true))
+	assert.Error(t, c.Download("/Photos/missing.jpg", tempDir+"/missing.jpg", false))
+}
+
+func TestClient_Upload(t *testing.T) {
+	s, c := testClient(t, "backup/")
+	defer s.Close()
+
+	remoteName := "Uploads/" + rnd.UUID() + ".jpg"
+
+	if err := c.CreateDir("Uploads"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Upload("testdata/example.jpg", remoteName); err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := ioutil.ReadFile("testdata/example.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, ok := s.Get("backup/" + remoteName)
+
+	assert.True(t, ok)
+	assert.Equal(t, expected, data)
+
+	if err := c.Delete(remoteName); err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok = s.Get("backup/" + remoteName)
+
+	assert.False(t, ok)
+}
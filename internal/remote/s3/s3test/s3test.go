@@ -0,0 +1,267 @@
+// Package s3test provides an in-memory S3-compatible server for tests.
+package s3test
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TestServer is a minimal in-memory stand-in for an S3-compatible server like MinIO, for use in tests.
+type TestServer struct {
+	*httptest.Server
+	Bucket  string
+	mutex   sync.RWMutex
+	objects map[string]testObject
+}
+
+type testObject struct {
+	data     []byte
+	etag     string
+	modified time.Time
+}
+
+type testListResult struct {
+	XMLName        xml.Name         `xml:"ListBucketResult"`
+	Name           string           `xml:"Name"`
+	Prefix         string           `xml:"Prefix"`
+	Delimiter      string           `xml:"Delimiter,omitempty"`
+	KeyCount       int              `xml:"KeyCount"`
+	MaxKeys        int              `xml:"MaxKeys"`
+	IsTruncated    bool             `xml:"IsTruncated"`
+	Contents       []testListObject `xml:"Contents"`
+	CommonPrefixes []testListPrefix `xml:"CommonPrefixes"`
+}
+
+type testListObject struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int    `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type testListPrefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+// NewTestServer starts a new test server with an empty bucket.
+func NewTestServer(bucket string) *TestServer {
+	s := &TestServer{
+		Bucket:  bucket,
+		objects: make(map[string]testObject),
+	}
+
+	s.Server = httptest.NewServer(s)
+
+	return s
+}
+
+// Put adds an object to the bucket.
+func (s *TestServer) Put(key string, data []byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sum := md5.Sum(data)
+
+	s.objects[key] = testObject{data: data, etag: hex.EncodeToString(sum[:]), modified: time.Now().UTC()}
+}
+
+// Get returns the object data and true if it exists.
+func (s *TestServer) Get(key string) ([]byte, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	obj, ok := s.objects[key]
+
+	return obj.data, ok
+}
+
+// Keys returns the sorted object keys.
+func (s *TestServer) Keys() (keys []string) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for k := range s.objects {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// ServeHTTP handles path-style S3 requests.
+func (s *TestServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+
+	if parts[0] != s.Bucket {
+		s.error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	key := ""
+
+	if len(parts) > 1 {
+		key = parts[1]
+	}
+
+	switch {
+	case key == "" && r.Method == http.MethodGet:
+		s.list(w, r)
+	case key == "" && r.Method == http.MethodHead:
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPut:
+		s.put(w, r, key)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		s.get(w, r, key)
+	case r.Method == http.MethodDelete:
+		s.mutex.Lock()
+		delete(s.objects, key)
+		s.mutex.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (s *TestServer) error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
+
+func (s *TestServer) list(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	prefix := q.Get("prefix")
+	delimiter := q.Get("delimiter")
+
+	result := testListResult{Name: s.Bucket, Prefix: prefix, Delimiter: delimiter, MaxKeys: 1000}
+	seen := make(map[string]bool)
+
+	for _, k := range s.Keys() {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+
+		if delimiter != "" {
+			if i := strings.Index(k[len(prefix):], delimiter); i >= 0 {
+				p := k[:len(prefix)+i+len(delimiter)]
+
+				if !seen[p] {
+					seen[p] = true
+					result.CommonPrefixes = append(result.CommonPrefixes, testListPrefix{Prefix: p})
+				}
+
+				continue
+			}
+		}
+
+		s.mutex.RLock()
+		obj := s.objects[k]
+		s.mutex.RUnlock()
+
+		result.Contents = append(result.Contents, testListObject{
+			Key:          k,
+			LastModified: obj.modified.Format("2006-01-02T15:04:05.000Z"),
+			ETag:         "\"" + obj.etag + "\"",
+			Size:         len(obj.data),
+			StorageClass: "STANDARD",
+		})
+	}
+
+	result.KeyCount = len(result.Contents) + len(result.CommonPrefixes)
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func (s *TestServer) get(w http.ResponseWriter, r *http.Request, key string) {
+	s.mutex.RLock()
+	obj, ok := s.objects[key]
+	s.mutex.RUnlock()
+
+	if !ok {
+		s.error(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.Itoa(len(obj.data)))
+	w.Header().Set("ETag", "\""+obj.etag+"\"")
+	w.Header().Set("Last-Modified", obj.modified.Format(http.TimeFormat))
+	w.WriteHeader(http.StatusOK)
+
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(obj.data)
+	}
+}
+
+func (s *TestServer) put(w http.ResponseWriter, r *http.Request, key string) {
+	var data []byte
+	var err error
+
+	if strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		data, err = decodeChunked(r.Body)
+	} else {
+		data, err = ioutil.ReadAll(r.Body)
+	}
+
+	if err != nil {
+		s.error(w, http.StatusBadRequest, "IncompleteBody")
+		return
+	}
+
+	s.Put(key, data)
+
+	s.mutex.RLock()
+	etag := s.objects[key].etag
+	s.mutex.RUnlock()
+
+	w.Header().Set("ETag", "\""+etag+"\"")
+	w.WriteHeader(http.StatusOK)
+}
+
+// decodeChunked decodes a request body with aws-chunked content encoding.
+func decodeChunked(body io.Reader) ([]byte, error) {
+	var result bytes.Buffer
+
+	reader := bufio.NewReader(body)
+
+	for {
+		header, err := reader.ReadString('\n')
+
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := strconv.ParseInt(strings.SplitN(strings.TrimSpace(header), ";", 2)[0], 16, 64)
+
+		if err != nil {
+			return nil, err
+		} else if size == 0 {
+			return result.Bytes(), nil
+		}
+
+		if _, err := io.CopyN(&result, reader, size); err != nil {
+			return nil, err
+		}
+
+		// Skip trailing CRLF.
+		if _, err := reader.Discard(2); err != nil {
+			return nil, err
+		}
+	}
+}
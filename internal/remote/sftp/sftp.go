@@ -0,0 +1,353 @@
+/*
+
+Package sftp implements sharing and syncing with servers that expose files over SSH.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package sftp
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	gosftp "github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+var log = event.Log
+
+const SyncTimeout = time.Second * 45
+const AsyncTimeout = time.Minute * 20
+
+// DefaultPort is used if the service URL doesn't contain a port.
+const DefaultPort = "22"
+
+// DialTimeout is the maximum amount of time for establishing a connection.
+const DialTimeout = time.Second * 15
+
+// ErrHostKeyMissing is returned if no host key fingerprint has been pinned for the server.
+var ErrHostKeyMissing = errors.New("sftp: host key fingerprint missing")
+
+// Client represents an SFTP client. A new connection is established for each operation.
+type Client struct {
+	addr   string
+	root   string
+	config *ssh.ClientConfig
+}
+
+// New creates a new SFTP client for a service URL like "sftp://nas.local:22/photos".
+//
+// The connection is authenticated with the private key if not empty, using pass
+// as passphrase for encrypted keys, and with the password otherwise. hostKey must
+// contain the SHA256 fingerprint of the server key, see HostKey().
+func New(rawUrl, user, pass, privateKey, hostKey string) (result Client, err error) {
+	u, err := url.Parse(rawUrl)
+
+	if err != nil {
+		return result, fmt.Errorf("sftp: %s", err)
+	} else if u.Host == "" {
+		return result, fmt.Errorf("sftp: invalid service url %s", rawUrl)
+	} else if hostKey == "" {
+		return result, ErrHostKeyMissing
+	}
+
+	if user == "" {
+		user = u.User.Username()
+	}
+
+	if pass == "" {
+		pass, _ = u.User.Password()
+	}
+
+	var auth []ssh.AuthMethod
+
+	if privateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+
+		var missing *ssh.PassphraseMissingError
+
+		if errors.As(err, &missing) && pass != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(privateKey), []byte(pass))
+		}
+
+		if err != nil {
+			return result, fmt.Errorf("sftp: invalid private key (%s)", err)
+		}
+
+		auth = append(auth, ssh.PublicKeys(signer))
+	} else if pass != "" {
+		auth = append(auth, ssh.Password(pass))
+	}
+
+	result = Client{
+		addr: Addr(u),
+		root: path.Clean("/" + u.Path),
+		config: &ssh.ClientConfig{
+			User:            user,
+			Auth:            auth,
+			HostKeyCallback: pinnedHostKey(hostKey),
+			Timeout:         DialTimeout,
+		},
+	}
+
+	return result, nil
+}
+
+// Addr returns the server address including the port.
+func Addr(u *url.URL) string {
+	if u.Port() == "" {
+		return net.JoinHostPort(u.Hostname(), DefaultPort)
+	}
+
+	return u.Host
+}
+
+// HostKey connects to the server at addr and returns the SHA256 fingerprint of its host key.
+func HostKey(addr string) (fingerprint string, err error) {
+	config := &ssh.ClientConfig{
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			fingerprint = ssh.FingerprintSHA256(key)
+			return nil
+		},
+		Timeout: DialTimeout,
+	}
+
+	// Authentication is expected to fail, the host key is sent before.
+	if conn, err := ssh.Dial("tcp", addr, config); err == nil {
+		conn.Close()
+	} else if fingerprint == "" {
+		return "", fmt.Errorf("sftp: %s", err)
+	}
+
+	return fingerprint, nil
+}
+
+// pinnedHostKey returns a callback that only accepts the host key with the given fingerprint.
+func pinnedHostKey(fingerprint string) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if found := ssh.FingerprintSHA256(key); found != fingerprint {
+			return fmt.Errorf("sftp: host key mismatch for %s, expected %s, got %s", hostname, fingerprint, found)
+		}
+
+		return nil
+	}
+}
+
+// connect opens a new connection and calls fn with an SFTP client session.
+func (c Client) connect(fn func(clt *gosftp.Client) error) error {
+	conn, err := ssh.Dial("tcp", c.addr, c.config)
+
+	if err != nil {
+		return fmt.Errorf("sftp: %s", err)
+	}
+
+	defer conn.Close()
+
+	clt, err := gosftp.NewClient(conn)
+
+	if err != nil {
+		return fmt.Errorf("sftp: %s", err)
+	}
+
+	defer clt.Close()
+
+	return fn(clt)
+}
+
+// abs returns the absolute server path for a path relative to the service root.
+func (c Client) abs(p string) string {
+	return path.Join(c.root, "/"+p)
+}
+
+// Files returns all files in path as string slice.
+func (c Client) Files(dir string) (result fs.FileInfos, err error) {
+	err = c.connect(func(clt *gosftp.Client) error {
+		files, err := clt.ReadDir(c.abs(dir))
+
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			if !file.Mode().IsRegular() {
+				continue
+			}
+
+			result = append(result, fs.NewFileInfo(file, dir))
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// Directories returns all sub directories in path as string slice.
+func (c Client) Directories(root string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	start := time.Now()
+
+	err = c.connect(func(clt *gosftp.Client) error {
+		result, err = c.fetchDirs(clt, root, recursive, start, timeout)
+		return err
+	})
+
+	if time.Now().Sub(start) >= timeout {
+		log.Warnf("sftp: read dir timeout reached")
+	}
+
+	return result, err
+}
+
+// fetchDirs recursively fetches all directories until the timeout is reached.
+func (c Client) fetchDirs(clt *gosftp.Client, root string, recursive bool, start time.Time, timeout time.Duration) (result fs.FileInfos, err error) {
+	files, err := clt.ReadDir(c.abs(root))
+
+	if err != nil {
+		return result, err
+	}
+
+	if root == "/" {
+		root = ""
+	}
+
+	for _, file := range files {
+		if !file.Mode().IsDir() || strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+
+		info := fs.NewFileInfo(file, root)
+
+		result = append(result, info)
+
+		if recursive && time.Now().Sub(start) < timeout {
+			subDirs, err := c.fetchDirs(clt, info.Abs, true, start, timeout)
+
+			if err != nil {
+				return result, err
+			}
+
+			result = append(result, subDirs...)
+		}
+	}
+
+	return result, nil
+}
+
+// Download downloads a single file to the given location.
+func (c Client) Download(from, to string, force bool) error {
+	if _, err := os.Stat(to); err == nil && !force {
+		return fmt.Errorf("sftp: download skipped, %s already exists", to)
+	}
+
+	dir := path.Dir(to)
+	dirInfo, err := os.Stat(dir)
+
+	if err != nil {
+		// Create directory
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return fmt.Errorf("sftp: can't create %s (%s)", dir, err)
+		}
+	} else if !dirInfo.IsDir() {
+		return fmt.Errorf("sftp: %s is not a folder", dir)
+	}
+
+	return c.connect(func(clt *gosftp.Client) error {
+		src, err := clt.Open(c.abs(from))
+
+		if err != nil {
+			return err
+		}
+
+		defer src.Close()
+
+		dest, err := os.OpenFile(to, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+
+		if err != nil {
+			return err
+		}
+
+		defer dest.Close()
+
+		_, err = io.Copy(dest, src)
+
+		return err
+	})
+}
+
+// CreateDir recursively creates directories if they don't exist.
+func (c Client) CreateDir(dir string) error {
+	if dir == "" || dir == "/" || dir == "." {
+		return nil
+	}
+
+	return c.connect(func(clt *gosftp.Client) error {
+		return clt.MkdirAll(c.abs(dir))
+	})
+}
+
+// Upload uploads a single file to the remote server.
+func (c Client) Upload(from, to string) error {
+	src, err := os.Open(from)
+
+	if err != nil || src == nil {
+		return err
+	}
+
+	defer src.Close()
+
+	return c.connect(func(clt *gosftp.Client) error {
+		dest, err := clt.OpenFile(c.abs(to), os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+
+		if err != nil {
+			return err
+		}
+
+		defer dest.Close()
+
+		_, err = io.Copy(dest, src)
+
+		return err
+	})
+}
+
+// Delete deletes a single file or empty directory on a remote server.
+func (c Client) Delete(p string) error {
+	return c.connect(func(clt *gosftp.Client) error {
+		return clt.Remove(c.abs(p))
+	})
+}
@@ -0,0 +1,252 @@
+package sftp
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+
+	"github.com/photoprism/photoprism/internal/remote/sftp/sftptest"
+)
+
+const (
+	testUser = "admin"
+	testPass = "photoprism"
+)
+
+func testServer(t *testing.T, authorizedKey ssh.PublicKey) *sftptest.TestServer {
+	s, err := sftptest.NewTestServer(testUser, testPass, authorizedKey)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, data := range map[string]string{
+		"/share/Photos/example.jpg":          "jpeg",
+		"/share/Photos/2021/beach.jpg":       "beach",
+		"/share/Photos/2021/July/sunset.jpg": "sunset",
+		"/share/Videos/clip.mp4":             "video",
+	} {
+		if err := s.Put(name, []byte(data)); err != nil {
+			s.Close()
+			t.Fatal(err)
+		}
+	}
+
+	return s
+}
+
+func testClient(t *testing.T) (*sftptest.TestServer, Client) {
+	s := testServer(t, nil)
+
+	c, err := New(s.URL("/share"), testUser, testPass, "", s.HostKey)
+
+	if err != nil {
+		s.Close()
+		t.Fatal(err)
+	}
+
+	return s, c
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		c, err := New("sftp://nas.local/photos/", testUser, testPass, "", "SHA256:abc")
+
+		assert.NoError(t, err)
+		assert.IsType(t, Client{}, c)
+		assert.Equal(t, "nas.local:22", c.addr)
+		assert.Equal(t, "/photos", c.root)
+	})
+	t.Run("HostKeyMissing", func(t *testing.T) {
+		_, err := New("sftp://nas.local/photos/", testUser, testPass, "", "")
+
+		assert.Equal(t, ErrHostKeyMissing, err)
+	})
+	t.Run("InvalidURL", func(t *testing.T) {
+		_, err := New("nas.local", testUser, testPass, "", "SHA256:abc")
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidPrivateKey", func(t *testing.T) {
+		_, err := New("sftp://nas.local/", testUser, "", "foo", "SHA256:abc")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestHostKey(t *testing.T) {
+	s := testServer(t, nil)
+	defer s.Close()
+
+	fingerprint, err := HostKey(s.Addr)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, s.HostKey, fingerprint)
+}
+
+func TestClient_Files(t *testing.T) {
+	s, c := testClient(t)
+	defer s.Close()
+
+	files, err := c.Files("Photos")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"/Photos/example.jpg"}, files.Abs())
+}
+
+func TestClient_Directories(t *testing.T) {
+	s, c := testClient(t)
+	defer s.Close()
+
+	t.Run("Recursive", func(t *testing.T) {
+		dirs, err := c.Directories("/", true, SyncTimeout)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, []string{"/Photos", "/Photos/2021", "/Photos/2021/July", "/Videos"}, dirs.Abs())
+	})
+	t.Run("NotRecursive", func(t *testing.T) {
+		dirs, err := c.Directories("/", false, SyncTimeout)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, []string{"/Photos", "/Videos"}, dirs.Abs())
+	})
+}
+
+func TestClient_Download(t *testing.T) {
+	s, c := testClient(t)
+	defer s.Close()
+
+	tempDir := filepath.Join(os.TempDir(), "sftp-"+rnd.PPID('t'))
+	tempFile := filepath.Join(tempDir, "Photos", "example.jpg")
+
+	defer os.RemoveAll(tempDir)
+
+	if err := c.Download("Photos/example.jpg", tempFile, false); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(tempFile)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "jpeg", string(data))
+
+	// Existing files are skipped unless forced.
+	assert.Error(t, c.Download("Photos/example.jpg", tempFile, false))
+	assert.NoError(t, c.Download("Photos/example.jpg", tempFile, true))
+}
+
+func TestClient_UploadAndDelete(t *testing.T) {
+	s, c := testClient(t)
+	defer s.Close()
+
+	if err := c.CreateDir("Shared/2021"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Upload("testdata/example.jpg", "Shared/2021/example.jpg"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := ioutil.ReadFile("testdata/example.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := s.Get("/share/Shared/2021/example.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, expected, data)
+
+	if err := c.Delete("Shared/2021/example.jpg"); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := c.Files("Shared/2021")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, files)
+	assert.True(t, fs.FileExists("testdata/example.jpg"))
+}
+
+func TestClient_PrivateKey(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorizedKey, err := ssh.NewPublicKey(pub)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	s := testServer(t, authorizedKey)
+	defer s.Close()
+
+	t.Run("Success", func(t *testing.T) {
+		c, err := New(s.URL("/share"), testUser, "", privateKey, s.HostKey)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		files, err := c.Files("Videos")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/Videos/clip.mp4"}, files.Abs())
+	})
+	t.Run("HostKeyMismatch", func(t *testing.T) {
+		c, err := New(s.URL("/share"), testUser, "", privateKey, "SHA256:abc")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = c.Directories("/", false, time.Second)
+
+		assert.Error(t, err)
+	})
+}
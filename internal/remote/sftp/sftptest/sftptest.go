@@ -0,0 +1,212 @@
+// Package sftptest provides an in-process SFTP server for tests.
+package sftptest
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"sync"
+
+	gosftp "github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// TestServer is a minimal in-process SFTP server with an in-memory file system, for use in tests.
+type TestServer struct {
+	Addr     string
+	User     string
+	Pass     string
+	HostKey  string
+	hostKey  ssh.PublicKey
+	listener net.Listener
+	handlers gosftp.Handlers
+	config   *ssh.ServerConfig
+	wg       sync.WaitGroup
+}
+
+// NewTestServer starts a new test server that accepts the password and the optional public key.
+func NewTestServer(user, pass string, authorizedKey ssh.PublicKey) (*TestServer, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := ssh.NewSignerFromKey(priv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	s := &TestServer{
+		User:     user,
+		Pass:     pass,
+		HostKey:  ssh.FingerprintSHA256(signer.PublicKey()),
+		hostKey:  signer.PublicKey(),
+		handlers: gosftp.InMemHandler(),
+	}
+
+	s.config = &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, p []byte) (*ssh.Permissions, error) {
+			if c.User() == s.User && string(p) == s.Pass {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("password rejected for %s", c.User())
+		},
+		PublicKeyCallback: func(c ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if authorizedKey != nil && c.User() == s.User && bytes.Equal(key.Marshal(), authorizedKey.Marshal()) {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("public key rejected for %s", c.User())
+		},
+	}
+
+	s.config.AddHostKey(signer)
+
+	if s.listener, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+		return nil, err
+	}
+
+	s.Addr = s.listener.Addr().String()
+
+	s.wg.Add(1)
+
+	go s.serve()
+
+	return s, nil
+}
+
+// URL returns the service URL with the given base path.
+func (s *TestServer) URL(dir string) string {
+	return "sftp://" + s.Addr + dir
+}
+
+// Put creates a file with the given content, including parent directories.
+func (s *TestServer) Put(name string, data []byte) error {
+	return s.connect(func(clt *gosftp.Client) error {
+		if err := clt.MkdirAll(path.Dir(name)); err != nil {
+			return err
+		}
+
+		f, err := clt.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		_, err = f.Write(data)
+
+		return err
+	})
+}
+
+// Get returns the content of a file.
+func (s *TestServer) Get(name string) (data []byte, err error) {
+	err = s.connect(func(clt *gosftp.Client) error {
+		f, err := clt.Open(name)
+
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		data, err = io.ReadAll(f)
+
+		return err
+	})
+
+	return data, err
+}
+
+// connect opens a client session with the server credentials and runs the function.
+func (s *TestServer) connect(f func(clt *gosftp.Client) error) error {
+	conn, err := ssh.Dial("tcp", s.Addr, &ssh.ClientConfig{
+		User:            s.User,
+		Auth:            []ssh.AuthMethod{ssh.Password(s.Pass)},
+		HostKeyCallback: ssh.FixedHostKey(s.hostKey),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	clt, err := gosftp.NewClient(conn)
+
+	if err != nil {
+		return err
+	}
+
+	defer clt.Close()
+
+	return f(clt)
+}
+
+// Close stops the server.
+func (s *TestServer) Close() {
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *TestServer) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+func (s *TestServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	_, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+
+	if err != nil {
+		return
+	}
+
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+
+		if err != nil {
+			return
+		}
+
+		go func(in <-chan *ssh.Request) {
+			for req := range in {
+				_ = req.Reply(req.Type == "subsystem" && string(req.Payload[4:]) == "sftp", nil)
+			}
+		}(requests)
+
+		server := gosftp.NewRequestServer(channel, s.handlers)
+
+		go func() {
+			_ = server.Serve()
+			server.Close()
+		}()
+	}
+}
@@ -1,26 +1,28 @@
 package server
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
-	"sync"
 
 	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/api"
 	"github.com/photoprism/photoprism/internal/entity"
 )
 
-var basicAuth = struct {
-	user  map[string]entity.User
-	mutex sync.RWMutex
-}{user: make(map[string]entity.User)}
+// UserKey is the request context key of the authenticated user.
+const UserKey = "user"
 
+// GetCredentials returns the basic auth credentials, raw is empty if the header doesn't contain valid basic credentials.
 func GetCredentials(c *gin.Context) (username, password, raw string) {
 	data := c.GetHeader("Authorization")
 
 	if !strings.HasPrefix(data, "Basic ") {
-		return "", "", data
+		return "", "", ""
 	}
 
 	data = strings.TrimPrefix(data, "Basic ")
@@ -28,49 +30,93 @@ func GetCredentials(c *gin.Context) (username, password, raw string) {
 	auth, err := base64.StdEncoding.DecodeString(data)
 
 	if err != nil {
-		return "", "", data
+		return "", "", ""
 	}
 
 	credentials := strings.SplitN(string(auth), ":", 2)
 
 	if len(credentials) != 2 {
-		return "", "", data
+		return "", "", ""
 	}
 
 	return credentials[0], credentials[1], data
 }
 
+// loginKey returns the login cache key, or an empty string if there are no credentials to cache.
+// Keys of proxy logins have a prefix that can't be produced by hashing basic auth credentials.
+func loginKey(proxyUser, raw string) string {
+	if proxyUser != "" {
+		return fmt.Sprintf("proxy:%x", sha256.Sum256([]byte(proxyUser)))
+	} else if raw == "" {
+		return ""
+	}
+
+	// Don't keep credentials in memory.
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(raw)))
+}
+
 func BasicAuth() gin.HandlerFunc {
 	realm := "Authorization Required"
 	realm = "Basic realm=" + strconv.Quote(realm)
 
 	return func(c *gin.Context) {
-		invalid := true
-
 		username, password, raw := GetCredentials(c)
 
-		basicAuth.mutex.Lock()
-		defer basicAuth.mutex.Unlock()
+		// Users authenticated by a trusted reverse proxy don't need a password.
+		proxyUser := api.ProxyUserName(c)
 
-		if user, ok := basicAuth.user[raw]; ok {
-			c.Set(gin.AuthUserKey, user.UserUID)
-			return
+		if proxyUser != "" {
+			username = proxyUser
 		}
 
-		user := entity.FindUserByName(username)
+		key := loginKey(proxyUser, raw)
 
-		if user != nil {
-			invalid = user.InvalidPassword(password)
+		if key != "" {
+			if user := api.CachedLogin(key); user != nil {
+				c.Set(gin.AuthUserKey, user.UserUID)
+				c.Set(UserKey, *user)
+				return
+			}
 		}
 
-		if user == nil || invalid {
+		var user *entity.User
+		var err error
+
+		if proxyUser != "" {
+			user, err = api.ProxyUser(c)
+		} else {
+			user, err = api.LoginUser(username, password)
+		}
+
+		if err != nil || user == nil {
+			// The initial challenge without credentials is expected and not audited.
+			if username == "" && password == "" {
+				c.Header("WWW-Authenticate", realm)
+				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+
+			audit := entity.AuditLog{ActorName: username, ClientIP: api.ClientIP(c), AuditAction: string(acl.ActionLogin), Resource: string(acl.ResourceUsers), AuditOutcome: entity.AuditDenied, AuditMessage: "basic auth"}
+
+			if user != nil {
+				audit.ActorUID = user.UserUID
+				audit.ResourceUID = user.UserUID
+			}
+
+			entity.Audit(audit)
+
 			c.Header("WWW-Authenticate", realm)
 			c.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
 
-		basicAuth.user[raw] = *user
+		entity.Audit(entity.AuditLog{ActorUID: user.UserUID, ActorName: user.UserName, ClientIP: api.ClientIP(c), AuditAction: string(acl.ActionLogin), Resource: string(acl.ResourceUsers), ResourceUID: user.UserUID, AuditMessage: "basic auth"})
+
+		if key != "" {
+			api.CacheLogin(key, user)
+		}
 
 		c.Set(gin.AuthUserKey, user.UserUID)
+		c.Set(UserKey, *user)
 	}
 }
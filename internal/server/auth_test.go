@@ -0,0 +1,50 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCredentials(t *testing.T) {
+	credentials := func(header string) (string, string, string) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/originals/", nil)
+		c.Request.Header.Set("Authorization", header)
+
+		return GetCredentials(c)
+	}
+
+	t.Run("basic", func(t *testing.T) {
+		username, password, raw := credentials("Basic YWxpY2U6c2VjcmV0")
+
+		assert.Equal(t, "alice", username)
+		assert.Equal(t, "secret", password)
+		assert.Equal(t, "YWxpY2U6c2VjcmV0", raw)
+	})
+	t.Run("not basic", func(t *testing.T) {
+		username, password, raw := credentials("proxy:alice")
+
+		assert.Equal(t, "", username)
+		assert.Equal(t, "", password)
+		assert.Equal(t, "", raw)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, _, raw := credentials("Basic YWxpY2U")
+
+		assert.Equal(t, "", raw)
+	})
+}
+
+func TestLoginKey(t *testing.T) {
+	assert.Equal(t, "", loginKey("", ""))
+	assert.Regexp(t, "^proxy:[0-9a-f]{64}$", loginKey("alice", ""))
+	assert.Regexp(t, "^[0-9a-f]{64}$", loginKey("", "YWxpY2U6c2VjcmV0"))
+
+	// Basic credentials can't produce the key of a proxy login.
+	assert.NotEqual(t, loginKey("alice", ""), loginKey("", "proxy:alice"))
+	assert.Equal(t, loginKey("alice", ""), loginKey("alice", "YWxpY2U6c2VjcmV0"))
+}
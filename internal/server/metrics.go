@@ -0,0 +1,20 @@
+package server
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/metrics"
+)
+
+// Metrics instances a middleware that records request counts and latencies by route.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		// Process request
+		c.Next()
+
+		metrics.Request(c.Request.Method, c.FullPath(), c.Writer.Status(), start)
+	}
+}
@@ -0,0 +1,13 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/api"
+)
+
+// ProxyAuth logs in users authenticated by a trusted reverse proxy.
+func ProxyAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		api.ProxySession(c)
+	}
+}
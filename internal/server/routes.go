@@ -40,10 +40,12 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	})
 
 	// JSON-REST API Version 1
-	v1 := router.Group(conf.BaseUri(config.ApiUri))
+	v1 := router.Group(conf.BaseUri(config.ApiUri), ProxyAuth())
 	{
 		api.GetStatus(v1)
+		api.GetMetrics(v1)
 		api.GetErrors(v1)
+		api.GetAuditLogs(v1)
 
 		api.GetConfig(v1)
 		api.GetConfigOptions(v1)
@@ -53,8 +55,13 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.SaveSettings(v1)
 
 		api.ChangePassword(v1)
+		api.RequestPasswordReset(v1)
+		api.SendEmailConfirmation(v1)
+		api.ConfirmEmail(v1)
 		api.CreateSession(v1)
 		api.DeleteSession(v1)
+		api.OIDCLogin(v1)
+		api.OIDCRedirect(v1)
 
 		api.GetThumb(v1)
 		api.GetThumbCrop(v1)
@@ -67,6 +74,9 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.GetPhoto(v1)
 		api.GetPhotoYaml(v1)
 		api.UpdatePhoto(v1)
+		api.GetPhotoHistory(v1)
+		api.RevertPhotoEdit(v1)
+		api.RevertPhotoEdits(v1)
 		api.GetPhotos(v1)
 		api.GetPhotoDownload(v1)
 		api.GetPhotoLinks(v1)
@@ -84,6 +94,7 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.DeleteFile(v1)
 		api.UpdateMarker(v1)
 		api.ClearMarkerSubject(v1)
+		api.GetMarkerSuggestions(v1)
 		api.PhotoPrimary(v1)
 		api.PhotoUnstack(v1)
 
@@ -97,6 +108,11 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.BatchPhotosArchive(v1)
 		api.BatchPhotosRestore(v1)
 		api.BatchPhotosPrivate(v1)
+		api.BatchPhotosRating(v1)
+		api.BatchPhotosEdit(v1)
+		api.GetBatchPhotosEdit(v1)
+		api.CancelBatchPhotosEdit(v1)
+		api.BatchPhotosTimeShift(v1)
 		api.BatchPhotosDelete(v1)
 		api.BatchAlbumsDelete(v1)
 		api.BatchLabelsDelete(v1)
@@ -106,6 +122,8 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.UpdateSubject(v1)
 		api.LikeSubject(v1)
 		api.DislikeSubject(v1)
+		api.MergeSubjects(v1)
+		api.SplitFace(v1)
 
 		api.LabelCover(v1)
 		api.GetLabels(v1)
@@ -117,12 +135,18 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.LikeLabel(v1)
 		api.DislikeLabel(v1)
 
+		api.GetTags(v1)
+		api.GetTag(v1)
+		api.UpdateTag(v1)
+
 		api.FolderCover(v1)
 		api.GetFoldersOriginals(v1)
 		api.GetFoldersImport(v1)
 
 		api.AlbumCover(v1)
 		api.GetAlbum(v1)
+		api.GetAlbumChildren(v1)
+		api.GetAlbumBreadcrumbs(v1)
 		api.CreateAlbum(v1)
 		api.UpdateAlbum(v1)
 		api.DeleteAlbum(v1)
@@ -134,6 +158,8 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.DeleteAlbumLink(v1)
 		api.LikeAlbum(v1)
 		api.DislikeAlbum(v1)
+		api.FollowAlbum(v1)
+		api.UnfollowAlbum(v1)
 		api.CloneAlbums(v1)
 		api.AddPhotosToAlbum(v1)
 		api.RemovePhotosFromAlbum(v1)
@@ -146,6 +172,13 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.DeleteAccount(v1)
 		api.UpdateAccount(v1)
 
+		api.GetWebhooks(v1)
+		api.GetWebhook(v1)
+		api.GetWebhookDeliveries(v1)
+		api.CreateWebhook(v1)
+		api.UpdateWebhook(v1)
+		api.DeleteWebhook(v1)
+
 		api.SendFeedback(v1)
 
 		api.GetSvg(v1)
@@ -160,6 +193,9 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 		api.SharePreview(s)
 	}
 
+	// Password reset page for links sent by email.
+	api.ResetPassword(router.Group(conf.BaseUri("/reset")))
+
 	// WebDAV server for file management, sync and sharing.
 	if conf.DisableWebDAV() {
 		log.Info("webdav: server disabled")
@@ -171,6 +207,9 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 			WebDAV(conf.ImportPath(), router.Group(conf.BaseUri(WebDAVImport), BasicAuth()), conf)
 			log.Infof("webdav: %s/ enabled, waiting for requests", conf.BaseUri(WebDAVImport))
 		}
+
+		WebDAVLibraryHandler(router.Group(conf.BaseUri(WebDAVLibrary), BasicAuth()), conf)
+		log.Infof("webdav: %s/ enabled, waiting for requests", conf.BaseUri(WebDAVLibrary))
 	}
 
 	// Default HTML page for client-side rendering and routing via VueJS.
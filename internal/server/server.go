@@ -2,18 +2,19 @@ package server
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
 	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
+	"golang.org/x/net/http2"
 )
 
 var log = event.Log
 
-// Start the REST API server using the configuration provided
+// Start the REST API server using the configuration provided, it returns after the
+// server has been shut down gracefully once the context is canceled.
 func Start(ctx context.Context, conf *config.Config) {
 	defer func() {
 		if err := recover(); err != nil {
@@ -30,7 +31,10 @@ func Start(ctx context.Context, conf *config.Config) {
 
 	// Create router and add routing middleware.
 	router := gin.New()
-	router.Use(Logger(), Recovery())
+
+	// Forwarded client addresses are only accepted from trusted proxies, see api.ClientIP.
+	router.ForwardedByClientIP = false
+	router.Use(Logger(), Recovery(), Metrics())
 
 	// Enable http compression (if any).
 	switch conf.HttpCompression() {
@@ -53,26 +57,82 @@ func Start(ctx context.Context, conf *config.Config) {
 	registerRoutes(router, conf)
 
 	server := &http.Server{
-		Addr:    fmt.Sprintf("%s:%d", conf.HttpHost(), conf.HttpPort()),
 		Handler: router,
 	}
 
+	tlsConfig, certManager, err := TLSConfig(conf)
+
+	if err != nil {
+		log.Errorf("http: %s", err)
+		return
+	}
+
+	listener, err := Listen(conf)
+
+	if err != nil {
+		log.Errorf("http: %s", err)
+		return
+	}
+
+	var redirect *http.Server
+
+	if tlsConfig != nil {
+		server.TLSConfig = tlsConfig
+
+		// Enable HTTP/2 for TLS connections.
+		if err := http2.ConfigureServer(server, nil); err != nil {
+			log.Errorf("http: %s", err)
+		}
+
+		if conf.HttpRedirectPort() > 0 {
+			redirect = RedirectServer(conf, certManager)
+
+			go func() {
+				log.Infof("http: redirecting requests at %s to https", redirect.Addr)
+
+				if err := redirect.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					log.Errorf("http: redirect server closed unexpect: %s", err)
+				}
+			}()
+		}
+	}
+
 	go func() {
-		log.Infof("http: starting web server at %s", server.Addr)
-
-		if err := server.ListenAndServe(); err != nil {
-			if err == http.ErrServerClosed {
-				log.Info("http: web server shutdown complete")
-			} else {
-				log.Errorf("http: web server closed unexpect: %s", err)
-			}
+		var err error
+
+		if tlsConfig != nil {
+			log.Infof("http: starting web server at https://%s", listener.Addr())
+			err = server.ServeTLS(listener, "", "")
+		} else {
+			log.Infof("http: starting web server at %s", listener.Addr())
+			err = server.Serve(listener)
+		}
+
+		if err != nil && err != http.ErrServerClosed {
+			log.Errorf("http: web server closed unexpect: %s", err)
 		}
 	}()
 
 	<-ctx.Done()
-	log.Info("http: shutting down web server")
-	err := server.Close()
-	if err != nil {
-		log.Errorf("http: web server shutdown failed: %v", err)
+
+	// Stop accepting new connections and wait for running requests like uploads and downloads.
+	timeout := conf.ShutdownTimeout()
+	log.Infof("http: shutting down web server, waiting up to %s for running requests", timeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if redirect != nil {
+		if err := redirect.Shutdown(shutdownCtx); err != nil {
+			log.Warnf("http: redirect server shutdown failed: %v", err)
+			_ = redirect.Close()
+		}
+	}
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Warnf("http: web server shutdown failed: %v, closing remaining connections", err)
+		_ = server.Close()
+	} else {
+		log.Info("http: web server shutdown complete")
 	}
 }
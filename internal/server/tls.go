@@ -0,0 +1,105 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/photoprism/photoprism/internal/acme"
+	"github.com/photoprism/photoprism/internal/config"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// TLSConfig returns the web server TLS config and the ACME certificate manager if enabled,
+// the config is nil if TLS is disabled.
+func TLSConfig(conf *config.Config) (*tls.Config, *autocert.Manager, error) {
+	if !conf.TLSEnabled() {
+		return nil, nil, nil
+	}
+
+	if conf.ACMEEnabled() {
+		m := acme.NewManager(acme.Config{
+			Domains:   conf.ACMEDomains(),
+			Email:     conf.ACMEEmail(),
+			Directory: conf.ACMEDirectory(),
+			CachePath: conf.ACMECachePath(),
+		})
+
+		c := m.TLSConfig()
+		c.MinVersion = conf.TLSMinVersion()
+
+		return c, m, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(conf.TLSCert(), conf.TLSKey())
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &tls.Config{
+		MinVersion:   conf.TLSMinVersion(),
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}, nil, nil
+}
+
+// Listen returns the web server listener, a Unix socket is used if configured.
+func Listen(conf *config.Config) (net.Listener, error) {
+	socket := conf.HttpSocket()
+
+	if socket == "" {
+		return net.Listen("tcp", fmt.Sprintf("%s:%d", conf.HttpHost(), conf.HttpPort()))
+	}
+
+	// Remove stale socket file from previous runs.
+	if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	listener, err := net.Listen("unix", socket)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Allow reverse proxies in the same group to connect.
+	if err := os.Chmod(socket, 0660); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+// RedirectServer returns a plain HTTP server that redirects requests to HTTPS,
+// ACME HTTP-01 challenges are answered if a certificate manager is provided.
+func RedirectServer(conf *config.Config, m *autocert.Manager) *http.Server {
+	port := conf.HttpPort()
+
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+
+		if err != nil {
+			host = r.Host
+		}
+
+		if port != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(port))
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+
+	if m != nil {
+		handler = m.HTTPHandler(handler)
+	}
+
+	return &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", conf.HttpHost(), conf.HttpRedirectPort()),
+		Handler: handler,
+	}
+}
@@ -1,49 +1,117 @@
 package server
 
 import (
-	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/photoprism/photoprism/pkg/fs"
 
 	"github.com/photoprism/photoprism/pkg/txt"
 
 	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/api"
 	"github.com/photoprism/photoprism/internal/auto"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/storage"
 	"golang.org/x/net/webdav"
 )
 
 const WebDAVOriginals = "/originals"
 const WebDAVImport = "/import"
 
-// MarkUploadAsFavorite sets the favorite flag for newly uploaded files.
-func MarkUploadAsFavorite(fileName string) {
-	yamlName := fs.AbsPrefix(fileName, false) + fs.YamlExt
+// MarkUploadAsFavorite sets the favorite flag for newly uploaded files, name is relative to the storage root.
+func MarkUploadAsFavorite(s storage.Storage, name string) {
+	yamlName := fs.AbsPrefix(storage.Clean(name), false) + fs.YamlExt
+	fileName := filepath.Join(s.Path(), yamlName)
 
 	// Abort if YAML file already exists to avoid overwriting metadata.
-	if fs.FileExists(yamlName) {
+	if fs.FileExists(fileName) || s.Exists(yamlName) {
 		log.Warnf("webdav: %s already exists", txt.Quote(filepath.Base(yamlName)))
 		return
 	}
 
 	// Make sure directory exists.
-	if err := os.MkdirAll(filepath.Dir(yamlName), os.ModePerm); err != nil {
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
 		log.Errorf("webdav: %s", err.Error())
 		return
 	}
 
 	// Write YAML data to file.
-	if err := ioutil.WriteFile(yamlName, []byte("Favorite: true\n"), os.ModePerm); err != nil {
+	if err := fs.WriteFile(fileName, []byte("Favorite: true\n"), os.ModePerm); err != nil {
 		log.Errorf("webdav: %s", err.Error())
 		return
 	}
 
+	// Store the YAML file if originals are not on local disk.
+	if s.Backend() != storage.BackendLocal {
+		if err := s.Store(yamlName); err != nil {
+			log.Errorf("webdav: %s", err.Error())
+			return
+		}
+	}
+
 	// Log success.
-	log.Infof("webdav: marked %s as favorite", txt.Quote(filepath.Base(fileName)))
+	log.Infof("webdav: marked %s as favorite", txt.Quote(filepath.Base(name)))
+}
+
+// AuditWebDAV adds WebDAV write requests to the audit log.
+func AuditWebDAV(c *gin.Context) {
+	r := c.Request
+
+	switch r.Method {
+	case MethodPut, MethodPost, MethodPatch, MethodDelete, MethodCopy, MethodMove, MethodMkcol, MethodProppatch:
+	default:
+		return
+	}
+
+	m := entity.AuditLog{
+		ActorUID:     c.GetString(gin.AuthUserKey),
+		ClientIP:     api.ClientIP(c),
+		AuditAction:  strings.ToLower(r.Method),
+		Resource:     string(acl.ResourceFiles),
+		ResourceUID:  r.URL.Path,
+		AuditOutcome: entity.AuditSuccess,
+	}
+
+	if name, _, ok := r.BasicAuth(); ok {
+		m.ActorName = name
+	}
+
+	var msg []string
+
+	if dest, err := url.Parse(r.Header.Get("Destination")); err == nil && dest.Path != "" {
+		msg = append(msg, "destination "+dest.Path)
+	}
+
+	if status := c.Writer.Status(); status >= http.StatusBadRequest {
+		m.AuditOutcome = entity.AuditFailed
+		msg = append(msg, strings.ToLower(http.StatusText(status)))
+	}
+
+	m.AuditMessage = strings.Join(msg, ", ")
+
+	entity.Audit(m)
+}
+
+// WebDAVWrite tests if the request method modifies files.
+func WebDAVWrite(method string) bool {
+	switch method {
+	case MethodPut, MethodPost, MethodPatch, MethodDelete, MethodCopy, MethodMove, MethodMkcol, MethodProppatch, MethodLock, MethodUnlock:
+		return true
+	default:
+		return false
+	}
+}
+
+// WebDAVDir returns the WebDAV root folder of a user relative to the storage path, empty for the whole storage.
+func WebDAVDir(user entity.User) string {
+	return storage.Clean(user.StoragePath)
 }
 
 // WebDAV handles any requests to /originals|import/*
@@ -58,13 +126,39 @@ func WebDAV(path string, router *gin.RouterGroup, conf *config.Config) {
 		return
 	}
 
-	f := webdav.Dir(path)
+	prefix := router.BasePath()
+
+	// Locks are managed separately for each root folder.
+	locks := struct {
+		root  map[string]webdav.LockSystem
+		mutex sync.Mutex
+	}{root: make(map[string]webdav.LockSystem)}
+
+	lockSystem := func(root string) webdav.LockSystem {
+		locks.mutex.Lock()
+		defer locks.mutex.Unlock()
 
-	srv := &webdav.Handler{
-		Prefix:     router.BasePath(),
-		FileSystem: f,
-		LockSystem: webdav.NewMemLS(),
-		Logger: func(r *http.Request, err error) {
+		if ls, ok := locks.root[root]; ok {
+			return ls
+		}
+
+		ls := webdav.NewMemLS()
+		locks.root[root] = ls
+
+		return ls
+	}
+
+	// Originals are served from the configured storage backend, imports from local disk.
+	rootStorage := func() storage.Storage {
+		if path == conf.OriginalsPath() {
+			return conf.OriginalsStorage()
+		}
+
+		return storage.NewLocal(path)
+	}
+
+	logger := func(s storage.Storage, dir string) func(r *http.Request, err error) {
+		return func(r *http.Request, err error) {
 			if err != nil {
 				switch r.Method {
 				case MethodPut, MethodPost, MethodPatch, MethodDelete, MethodCopy, MethodMove:
@@ -78,34 +172,69 @@ func WebDAV(path string, router *gin.RouterGroup, conf *config.Config) {
 			} else {
 				// Mark uploaded files as favorite if X-Favorite HTTP header is "1".
 				if r.Method == MethodPut && r.Header.Get("X-Favorite") == "1" {
-					if router.BasePath() == WebDAVOriginals {
-						MarkUploadAsFavorite(filepath.Join(conf.OriginalsPath(), strings.TrimPrefix(r.URL.Path, router.BasePath())))
-					} else if router.BasePath() == WebDAVImport {
-						MarkUploadAsFavorite(filepath.Join(conf.ImportPath(), strings.TrimPrefix(r.URL.Path, router.BasePath())))
-					}
+					MarkUploadAsFavorite(s, dir+"/"+strings.TrimPrefix(r.URL.Path, prefix))
 				}
 
 				switch r.Method {
 				case MethodPut, MethodPost, MethodPatch, MethodDelete, MethodCopy, MethodMove:
 					log.Infof("webdav: %s %s", r.Method, r.URL)
 
-					if router.BasePath() == WebDAVOriginals {
+					if prefix == WebDAVOriginals {
 						auto.ShouldIndex()
-					} else if router.BasePath() == WebDAVImport {
+					} else if prefix == WebDAVImport {
 						auto.ShouldImport()
 					}
 				default:
 					log.Tracef("webdav: %s %s", r.Method, r.URL)
 				}
 			}
-		},
+		}
 	}
 
 	handler := func(c *gin.Context) {
 		w := c.Writer
 		r := c.Request
 
+		var user entity.User
+
+		if u, ok := c.Get(UserKey); ok {
+			user = u.(entity.User)
+		}
+
+		action := acl.ActionRead
+
+		if WebDAVWrite(r.Method) {
+			action = acl.ActionUpdate
+		}
+
+		if !user.CanUseWebDAV() || acl.Permissions.Deny(acl.ResourceWebDAV, user.Role(), action) {
+			entity.Audit(entity.AuditLog{ActorUID: user.UserUID, ActorName: user.UserName, ClientIP: api.ClientIP(c), AuditAction: strings.ToLower(r.Method), Resource: string(acl.ResourceFiles), ResourceUID: r.URL.Path, AuditOutcome: entity.AuditDenied, AuditMessage: "webdav"})
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		s := rootStorage()
+		dir := WebDAVDir(user)
+		root := filepath.Join(s.Path(), dir)
+
+		if dir != "" {
+			if err := os.MkdirAll(root, os.ModePerm); err != nil {
+				log.Errorf("webdav: %s", err)
+				c.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		srv := &webdav.Handler{
+			Prefix:     prefix,
+			FileSystem: WebDAVStorage(s, dir),
+			LockSystem: lockSystem(root),
+			Logger:     logger(s, dir),
+		}
+
 		srv.ServeHTTP(w, r)
+
+		AuditWebDAV(c)
 	}
 
 	router.Handle(MethodHead, "/*path", handler)
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/api"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/vfs"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"golang.org/x/net/webdav"
+)
+
+const WebDAVLibrary = "/library"
+
+// WebDAVLibraryHandler handles read-only requests to the virtual /library/* folders.
+func WebDAVLibraryHandler(router *gin.RouterGroup, conf *config.Config) {
+	if router == nil {
+		log.Error("webdav: router is nil")
+		return
+	}
+
+	if conf == nil {
+		log.Error("webdav: conf is nil")
+		return
+	}
+
+	prefix := router.BasePath()
+	locks := webdav.NewMemLS()
+
+	// Private photos are only visible to admins.
+	library := vfs.New(conf, false)
+	public := vfs.New(conf, true)
+
+	logger := func(r *http.Request, err error) {
+		if err != nil {
+			log.Debugf("webdav: %s in %s %s", txt.Quote(err.Error()), r.Method, r.URL)
+		} else {
+			log.Tracef("webdav: %s %s", r.Method, r.URL)
+		}
+	}
+
+	handler := func(c *gin.Context) {
+		r := c.Request
+
+		var user entity.User
+
+		if u, ok := c.Get(UserKey); ok {
+			user = u.(entity.User)
+		}
+
+		role := user.Role()
+
+		// Users with a personal storage folder can't browse the whole library.
+		denied := !user.CanUseWebDAV() || (WebDAVDir(user) != "" && role != acl.RoleAdmin) ||
+			acl.Permissions.Deny(acl.ResourceWebDAV, role, acl.ActionRead) ||
+			acl.Permissions.Deny(acl.ResourcePhotos, role, acl.ActionSearch)
+
+		if denied {
+			entity.Audit(entity.AuditLog{ActorUID: user.UserUID, ActorName: user.UserName, ClientIP: api.ClientIP(c), AuditAction: strings.ToLower(r.Method), Resource: string(acl.ResourcePhotos), ResourceUID: r.URL.Path, AuditOutcome: entity.AuditDenied, AuditMessage: "webdav library"})
+			c.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+
+		srv := &webdav.Handler{
+			Prefix:     prefix,
+			FileSystem: library,
+			LockSystem: locks,
+			Logger:     logger,
+		}
+
+		if role != acl.RoleAdmin {
+			srv.FileSystem = public
+		}
+
+		srv.ServeHTTP(c.Writer, r)
+	}
+
+	// The library is read-only.
+	readOnly := func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusMethodNotAllowed)
+	}
+
+	router.Handle(MethodHead, "/*path", handler)
+	router.Handle(MethodGet, "/*path", handler)
+	router.Handle(MethodOptions, "/*path", handler)
+	router.Handle(MethodPropfind, "/*path", handler)
+	router.Handle(MethodPut, "/*path", readOnly)
+	router.Handle(MethodPost, "/*path", readOnly)
+	router.Handle(MethodPatch, "/*path", readOnly)
+	router.Handle(MethodDelete, "/*path", readOnly)
+	router.Handle(MethodMkcol, "/*path", readOnly)
+	router.Handle(MethodCopy, "/*path", readOnly)
+	router.Handle(MethodMove, "/*path", readOnly)
+	router.Handle(MethodLock, "/*path", readOnly)
+	router.Handle(MethodUnlock, "/*path", readOnly)
+	router.Handle(MethodProppatch, "/*path", readOnly)
+}
@@ -0,0 +1,357 @@
+package server
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/storage"
+	"golang.org/x/net/webdav"
+)
+
+// WebDAVStorage returns a webdav.FileSystem for the storage folder dir, local disk storage is served directly.
+func WebDAVStorage(s storage.Storage, dir string) webdav.FileSystem {
+	root := filepath.Join(s.Path(), storage.Clean(dir))
+
+	if s.Backend() == storage.BackendLocal {
+		return webdav.Dir(root)
+	}
+
+	return &StorageFS{local: webdav.Dir(root), storage: s, dir: storage.Clean(dir)}
+}
+
+// StorageFS implements a webdav.FileSystem backed by object storage.
+//
+// Files are fetched to the local path when they are opened, and stored
+// again when they have been written. Folders are listed from storage, so
+// that files that have not been fetched yet or were evicted remain visible.
+type StorageFS struct {
+	local   webdav.Dir
+	storage storage.Storage
+	dir     string
+}
+
+// name returns the file name relative to the storage root.
+func (s *StorageFS) name(name string) string {
+	return storage.Clean(s.dir + "/" + name)
+}
+
+// parent makes sure the local parent folder exists if it exists in storage.
+func (s *StorageFS) parent(ctx context.Context, name string) error {
+	name = path.Clean("/" + name)
+
+	if info, err := s.Stat(ctx, path.Dir(name)); err != nil {
+		return err
+	} else if !info.IsDir() {
+		return os.ErrInvalid
+	}
+
+	return os.MkdirAll(filepath.Dir(filepath.Join(string(s.local), filepath.FromSlash(name))), os.ModePerm)
+}
+
+// Mkdir implements webdav.FileSystem, object storage has no folders so that empty folders remain local.
+func (s *StorageFS) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	if _, err := s.Stat(ctx, name); err == nil {
+		return os.ErrExist
+	} else if err := s.parent(ctx, name); err != nil {
+		return err
+	}
+
+	return s.local.Mkdir(ctx, name, perm)
+}
+
+// OpenFile implements webdav.FileSystem.
+func (s *StorageFS) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) != 0 {
+		return s.create(ctx, name, flag, perm)
+	}
+
+	info, err := s.Stat(ctx, name)
+
+	if err != nil {
+		return nil, err
+	} else if info.IsDir() {
+		entries, err := s.readDir(ctx, name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &storageDir{info: info, entries: entries}, nil
+	}
+
+	// Files are fetched when they are read, not when their properties are listed.
+	return &storageReader{fs: s, ctx: ctx, name: name, info: info}, nil
+}
+
+// create opens a file for writing, it is stored when it is closed.
+func (s *StorageFS) create(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	if err := s.parent(ctx, name); err != nil {
+		return nil, err
+	}
+
+	// Fetch existing files first unless they are replaced.
+	if flag&os.O_TRUNC == 0 {
+		if _, err := s.storage.LocalName(s.name(name)); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	f, err := s.local.OpenFile(ctx, name, flag, perm)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &storageFile{File: f, storage: s.storage, name: s.name(name)}, nil
+}
+
+// RemoveAll implements webdav.FileSystem, folders are removed with all files they contain.
+func (s *StorageFS) RemoveAll(ctx context.Context, name string) error {
+	if s.name(name) == s.dir {
+		return os.ErrInvalid
+	}
+
+	return s.storage.RemoveAll(s.name(name))
+}
+
+// Rename implements webdav.FileSystem, files are fetched, moved locally and stored with the new name.
+func (s *StorageFS) Rename(ctx context.Context, oldName, newName string) error {
+	src, dest := s.name(oldName), s.name(newName)
+
+	if src == s.dir || dest == s.dir {
+		return os.ErrInvalid
+	}
+
+	if _, err := s.storage.LocalName(src); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := s.storage.Walk(src, func(name string, info os.FileInfo) error {
+		_, err := s.storage.LocalName(name)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if err := s.parent(ctx, newName); err != nil {
+		return err
+	} else if err := s.local.Rename(ctx, oldName, newName); err != nil {
+		return err
+	}
+
+	if _, err := s.storage.Push(dest); err != nil {
+		return err
+	}
+
+	return s.storage.RemoveAll(src)
+}
+
+// Stat implements webdav.FileSystem, folders exist if they contain stored files or exist locally.
+func (s *StorageFS) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	storageName := s.name(name)
+
+	if storageName == s.dir {
+		if err := os.MkdirAll(string(s.local), os.ModePerm); err != nil {
+			return nil, err
+		}
+
+		return s.local.Stat(ctx, name)
+	}
+
+	if info, err := s.storage.Stat(storageName); err == nil {
+		return info, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if entries, err := s.storage.ReadDir(storageName); err != nil {
+		return nil, err
+	} else if len(entries) > 0 {
+		if info, err := s.local.Stat(ctx, name); err == nil && info.IsDir() {
+			return info, nil
+		}
+
+		return storageDirInfo{name: path.Base(storageName)}, nil
+	}
+
+	return s.local.Stat(ctx, name)
+}
+
+// readDir returns the stored and local files in a folder, ordered by name.
+func (s *StorageFS) readDir(ctx context.Context, name string) (result []os.FileInfo, err error) {
+	found := make(map[string]bool)
+
+	stored, err := s.storage.ReadDir(s.name(name))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range stored {
+		found[info.Name()] = true
+		result = append(result, info)
+	}
+
+	// Add local files and folders that haven't been stored yet.
+	if f, err := s.local.OpenFile(ctx, name, os.O_RDONLY, 0); err == nil {
+		local, _ := f.Readdir(0)
+		f.Close()
+
+		for _, info := range local {
+			if !found[info.Name()] {
+				result = append(result, info)
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name() < result[j].Name() })
+
+	return result, nil
+}
+
+// storageDirInfo implements os.FileInfo for folders that only exist in storage.
+type storageDirInfo struct {
+	name string
+}
+
+func (i storageDirInfo) Name() string       { return i.name }
+func (i storageDirInfo) Size() int64        { return 0 }
+func (i storageDirInfo) Mode() os.FileMode  { return os.ModeDir | 0755 }
+func (i storageDirInfo) ModTime() time.Time { return time.Time{} }
+func (i storageDirInfo) IsDir() bool        { return true }
+func (i storageDirInfo) Sys() interface{}   { return nil }
+
+// storageDir implements webdav.File for storage folders.
+type storageDir struct {
+	info    os.FileInfo
+	entries []os.FileInfo
+	pos     int
+}
+
+func (d *storageDir) Close() error {
+	return nil
+}
+
+func (d *storageDir) Read(p []byte) (int, error) {
+	return 0, os.ErrInvalid
+}
+
+func (d *storageDir) Write(p []byte) (int, error) {
+	return 0, os.ErrInvalid
+}
+
+func (d *storageDir) Seek(offset int64, whence int) (int64, error) {
+	if offset == 0 && whence == io.SeekStart {
+		d.pos = 0
+		return 0, nil
+	}
+
+	return 0, os.ErrInvalid
+}
+
+// Readdir returns the next count entries, or all remaining entries if count <= 0.
+func (d *storageDir) Readdir(count int) ([]os.FileInfo, error) {
+	remaining := d.entries[d.pos:]
+
+	if count <= 0 {
+		d.pos = len(d.entries)
+		return remaining, nil
+	}
+
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+
+	if count > len(remaining) {
+		count = len(remaining)
+	}
+
+	d.pos += count
+
+	return remaining[:count], nil
+}
+
+func (d *storageDir) Stat() (os.FileInfo, error) {
+	return d.info, nil
+}
+
+// storageFile implements webdav.File for files opened for writing, they are stored when closed.
+type storageFile struct {
+	webdav.File
+	storage storage.Storage
+	name    string
+}
+
+// Close closes the local file and stores it.
+func (f *storageFile) Close() error {
+	if err := f.File.Close(); err != nil {
+		return err
+	}
+
+	return f.storage.Store(f.name)
+}
+
+// storageReader implements webdav.File for stored files, they are fetched on first access.
+type storageReader struct {
+	fs   *StorageFS
+	ctx  context.Context
+	name string
+	info os.FileInfo
+	file webdav.File
+}
+
+// open fetches and opens the local file if needed, files that have only been created locally are not in storage yet.
+func (f *storageReader) open() (err error) {
+	if f.file != nil {
+		return nil
+	}
+
+	if _, err = f.fs.storage.LocalName(f.fs.name(f.name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	f.file, err = f.fs.local.OpenFile(f.ctx, f.name, os.O_RDONLY, 0)
+
+	return err
+}
+
+func (f *storageReader) Close() error {
+	if f.file == nil {
+		return nil
+	}
+
+	return f.file.Close()
+}
+
+func (f *storageReader) Read(p []byte) (int, error) {
+	if err := f.open(); err != nil {
+		return 0, err
+	}
+
+	return f.file.Read(p)
+}
+
+func (f *storageReader) Seek(offset int64, whence int) (int64, error) {
+	if err := f.open(); err != nil {
+		return 0, err
+	}
+
+	return f.file.Seek(offset, whence)
+}
+
+func (f *storageReader) Write(p []byte) (int, error) {
+	return 0, os.ErrPermission
+}
+
+func (f *storageReader) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, os.ErrInvalid
+}
+
+func (f *storageReader) Stat() (os.FileInfo, error) {
+	return f.info, nil
+}
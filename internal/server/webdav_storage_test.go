@@ -0,0 +1,132 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/webdav"
+
+	"github.com/photoprism/photoprism/internal/remote/s3/s3test"
+	"github.com/photoprism/photoprism/internal/storage"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func testWebDAVStorage(t *testing.T) (*s3test.TestServer, *storage.S3, *webdav.Handler) {
+	srv := s3test.NewTestServer("photos")
+
+	srv.Put("originals/2021/photo.jpg", []byte("jpeg"))
+	srv.Put("originals/2021/July/beach.jpg", []byte("beach"))
+	srv.Put("originals/2022/party.jpg", []byte("party"))
+
+	s, err := storage.NewS3(srv.URL, "photos", "originals", "", "admin", "photoprism", filepath.Join(os.TempDir(), "webdav-"+rnd.PPID('t')))
+
+	if err != nil {
+		srv.Close()
+		t.Fatal(err)
+	}
+
+	return srv, s, &webdav.Handler{
+		Prefix:     WebDAVOriginals,
+		FileSystem: WebDAVStorage(s, ""),
+		LockSystem: webdav.NewMemLS(),
+	}
+}
+
+func TestWebDAVStorage(t *testing.T) {
+	t.Run("Local", func(t *testing.T) {
+		assert.Equal(t, webdav.Dir(filepath.Join("/photos", "alice")), WebDAVStorage(storage.NewLocal("/photos"), "alice"))
+	})
+	t.Run("S3", func(t *testing.T) {
+		srv, s, _ := testWebDAVStorage(t)
+		defer srv.Close()
+
+		assert.IsType(t, &StorageFS{}, WebDAVStorage(s, "alice"))
+	})
+}
+
+func TestStorageFS(t *testing.T) {
+	srv, s, h := testWebDAVStorage(t)
+	defer srv.Close()
+	defer os.RemoveAll(s.Path())
+
+	request := func(method, path string, header map[string]string, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, WebDAVOriginals+path, strings.NewReader(body))
+
+		for k, v := range header {
+			r.Header.Set(k, v)
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		return w
+	}
+
+	t.Run("Propfind", func(t *testing.T) {
+		w := request(MethodPropfind, "/2021/", map[string]string{"Depth": "1"}, "")
+
+		assert.Equal(t, http.StatusMultiStatus, w.Code)
+		assert.Contains(t, w.Body.String(), "/originals/2021/photo.jpg")
+		assert.Contains(t, w.Body.String(), "/originals/2021/July/")
+
+		// Files are not fetched when they are listed.
+		assert.NoFileExists(t, filepath.Join(s.Path(), "2021", "photo.jpg"))
+	})
+	t.Run("Get", func(t *testing.T) {
+		w := request(http.MethodGet, "/2021/July/beach.jpg", nil, "")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "beach", w.Body.String())
+	})
+	t.Run("Put", func(t *testing.T) {
+		w := request(http.MethodPut, "/2022/new.jpg", nil, "new")
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		data, ok := srv.Get("originals/2022/new.jpg")
+
+		assert.True(t, ok)
+		assert.Equal(t, "new", string(data))
+	})
+	t.Run("Move", func(t *testing.T) {
+		w := request(MethodMove, "/2021/", map[string]string{"Destination": WebDAVOriginals + "/2020/"}, "")
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, []string{
+			"originals/2020/July/beach.jpg",
+			"originals/2020/photo.jpg",
+			"originals/2022/new.jpg",
+			"originals/2022/party.jpg",
+		}, srv.Keys())
+
+		data, _ := srv.Get("originals/2020/photo.jpg")
+
+		assert.Equal(t, "jpeg", string(data))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		// Evicted files are removed as well.
+		if err := os.Remove(filepath.Join(s.Path(), "2022", "new.jpg")); err != nil {
+			t.Fatal(err)
+		}
+
+		w := request(http.MethodDelete, "/2022/", nil, "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, []string{"originals/2020/July/beach.jpg", "originals/2020/photo.jpg"}, srv.Keys())
+
+		w = request(http.MethodGet, "/2022/party.jpg", nil, "")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+	t.Run("Root", func(t *testing.T) {
+		w := request(http.MethodDelete, "/", nil, "")
+
+		assert.GreaterOrEqual(t, w.Code, http.StatusBadRequest)
+		assert.Len(t, srv.Keys(), 2)
+	})
+}
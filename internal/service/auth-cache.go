@@ -0,0 +1,23 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+)
+
+// AuthCacheExpiration is the max time verified WebDAV credentials are cached.
+const AuthCacheExpiration = 5 * time.Minute
+
+var onceAuthCache sync.Once
+
+func initAuthCache() {
+	services.AuthCache = gc.New(AuthCacheExpiration, time.Minute)
+}
+
+func AuthCache() *gc.Cache {
+	onceAuthCache.Do(initAuthCache)
+
+	return services.AuthCache
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceBatchEdit sync.Once
+
+func initBatchEdit() {
+	services.BatchEdit = photoprism.NewBatchEdit(Config())
+}
+
+func BatchEdit() *photoprism.BatchEdit {
+	onceBatchEdit.Do(initBatchEdit)
+
+	return services.BatchEdit
+}
@@ -9,10 +9,14 @@ import (
 var onceClassify sync.Once
 
 func initClassify() {
-	services.Classify = classify.New(Config().AssetsPath(), Config().DisableTensorFlow())
+	if Config().ClassifyRemote() {
+		services.Classify = classify.NewRemote(Config().ClassifyUrl(), false)
+	} else {
+		services.Classify = classify.New(Config().AssetsPath(), Config().DisableTensorFlow())
+	}
 }
 
-func Classify() *classify.TensorFlow {
+func Classify() classify.Classifier {
 	onceClassify.Do(initClassify)
 
 	return services.Classify
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/ldap"
+)
+
+var ldapMutex sync.Mutex
+
+// LDAP returns the directory authenticator or nil if LDAP is not configured.
+func LDAP() *ldap.Client {
+	c := Config()
+
+	if !c.LDAPEnabled() {
+		return nil
+	}
+
+	ldapMutex.Lock()
+	defer ldapMutex.Unlock()
+
+	// Create a new client if the server settings have changed.
+	if conf := c.LDAP(); services.LDAP == nil || services.LDAP.Config != conf {
+		services.LDAP = ldap.NewClient(conf)
+	}
+
+	return services.LDAP
+}
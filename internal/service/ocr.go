@@ -0,0 +1,19 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceOCR sync.Once
+
+func initOCR() {
+	services.OCR = photoprism.NewOCR(Config())
+}
+
+func OCR() *photoprism.OCR {
+	onceOCR.Do(initOCR)
+
+	return services.OCR
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/oidc"
+)
+
+var oidcMutex sync.Mutex
+
+// OIDC returns the OpenID Connect client or nil if single sign-on is not configured.
+func OIDC() *oidc.Client {
+	c := Config()
+
+	if !c.OIDCEnabled() {
+		return nil
+	}
+
+	oidcMutex.Lock()
+	defer oidcMutex.Unlock()
+
+	// Create a new client if the provider settings have changed.
+	if services.OIDC == nil || services.OIDC.Issuer != c.OIDCUri() || services.OIDC.ClientID != c.OIDCClient() {
+		services.OIDC = oidc.NewClient(c.OIDCUri(), c.OIDCClient(), c.OIDCSecret(), c.OIDCRedirectUrl(), c.OIDCScopes())
+	}
+
+	return services.OIDC
+}
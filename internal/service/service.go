@@ -4,7 +4,9 @@ import (
 	"github.com/photoprism/photoprism/internal/classify"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/ldap"
 	"github.com/photoprism/photoprism/internal/nsfw"
+	"github.com/photoprism/photoprism/internal/oidc"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/session"
@@ -18,13 +20,17 @@ var services struct {
 	FolderCache *gc.Cache
 	CoverCache  *gc.Cache
 	ThumbCache  *gc.Cache
-	Classify    *classify.TensorFlow
+	AuthCache   *gc.Cache
+	Classify    classify.Classifier
 	Convert     *photoprism.Convert
 	Files       *photoprism.Files
 	Photos      *photoprism.Photos
 	Import      *photoprism.Import
 	Index       *photoprism.Index
+	BatchEdit   *photoprism.BatchEdit
+	TimeShift   *photoprism.TimeShift
 	Moments     *photoprism.Moments
+	OCR         *photoprism.OCR
 	Faces       *photoprism.Faces
 	Purge       *photoprism.Purge
 	CleanUp     *photoprism.CleanUp
@@ -33,6 +39,8 @@ var services struct {
 	Query       *query.Query
 	Resample    *photoprism.Resample
 	Session     *session.Session
+	OIDC        *oidc.Client
+	LDAP        *ldap.Client
 }
 
 func SetConfig(c *config.Config) {
@@ -44,6 +44,10 @@ func TestThumbCache(t *testing.T) {
 	assert.IsType(t, &gc.Cache{}, ThumbCache())
 }
 
+func TestAuthCache(t *testing.T) {
+	assert.IsType(t, &gc.Cache{}, AuthCache())
+}
+
 func TestClassify(t *testing.T) {
 	assert.IsType(t, &classify.TensorFlow{}, Classify())
 }
@@ -87,3 +91,19 @@ func TestResample(t *testing.T) {
 func TestSession(t *testing.T) {
 	assert.IsType(t, &session.Session{}, Session())
 }
+
+func TestBatchEdit(t *testing.T) {
+	assert.IsType(t, &photoprism.BatchEdit{}, BatchEdit())
+}
+
+func TestTimeShift(t *testing.T) {
+	assert.IsType(t, &photoprism.TimeShift{}, TimeShift())
+}
+
+func TestOIDC(t *testing.T) {
+	assert.Nil(t, OIDC())
+}
+
+func TestLDAP(t *testing.T) {
+	assert.Nil(t, LDAP())
+}
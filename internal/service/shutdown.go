@@ -0,0 +1,27 @@
+package service
+
+import (
+	gc "github.com/patrickmn/go-cache"
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+// Shutdown saves sessions and flushes caches before the application exits.
+func Shutdown() {
+	log := event.Log
+
+	if services.Session != nil {
+		if err := services.Session.Save(); err != nil {
+			log.Errorf("shutdown: failed saving sessions (%s)", err)
+		} else {
+			log.Info("shutdown: saved sessions")
+		}
+	}
+
+	for _, c := range []*gc.Cache{services.FolderCache, services.CoverCache, services.ThumbCache, services.AuthCache} {
+		if c != nil {
+			c.Flush()
+		}
+	}
+
+	log.Info("shutdown: flushed caches")
+}
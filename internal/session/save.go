@@ -9,6 +9,7 @@ import (
 
 	gc "github.com/patrickmn/go-cache"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 const cacheFileName = "sessions.json"
@@ -50,7 +51,7 @@ func New(expiration time.Duration, cachePath string) *Session {
 
 					if len(links) > 0 {
 						for _, link := range links {
-							shared = append(shared, link.LinkUID)
+							shared = append(shared, link.SharedUIDs()...)
 						}
 
 						tokens = append(tokens, token)
@@ -92,7 +93,7 @@ func (s *Session) Save() error {
 
 	if serialized, err := json.MarshalIndent(savedItems, "", " "); err != nil {
 		return err
-	} else if err = ioutil.WriteFile(s.cacheFile, serialized, 0600); err != nil {
+	} else if err = fs.WriteFile(s.cacheFile, serialized, 0600); err != nil {
 		return err
 	}
 
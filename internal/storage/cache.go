@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CacheMinAge is the minimum time since the last use before a cached file may be evicted,
+// so that files are not removed while they are still being indexed or served.
+var CacheMinAge = 10 * time.Minute
+
+// Cache keeps track of the files in a local cache path, so that the least recently used
+// files can be evicted once their total size exceeds the limit.
+type Cache struct {
+	mu       sync.Mutex
+	path     string
+	limit    int64
+	size     int64
+	files    map[string]cacheFile
+	evicting bool
+}
+
+// cacheFile represents a file in the local cache path.
+type cacheFile struct {
+	size int64
+	used time.Time
+}
+
+// NewCache returns a new cache for the local path, a limit of 0 means unlimited.
+func NewCache(path string, limit int64) *Cache {
+	return &Cache{path: path, limit: limit}
+}
+
+// Limit returns the cache size limit in bytes, 0 means unlimited.
+func (c *Cache) Limit() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.limit
+}
+
+// SetLimit changes the cache size limit in bytes, 0 means unlimited.
+func (c *Cache) SetLimit(limit int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.limit = limit
+}
+
+// Size returns the total size of the cached files in bytes.
+func (c *Cache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.load()
+
+	return c.size
+}
+
+// load adds the files that already exist in the local path, must be called with the lock held.
+func (c *Cache) load() {
+	if c.files != nil {
+		return
+	}
+
+	c.files = make(map[string]cacheFile)
+
+	_ = filepath.Walk(c.path, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+
+		if name, err := filepath.Rel(c.path, fileName); err == nil {
+			c.files[filepath.ToSlash(name)] = cacheFile{size: info.Size(), used: info.ModTime()}
+			c.size += info.Size()
+		}
+
+		return nil
+	})
+}
+
+// Touch marks the file as recently used.
+func (c *Cache) Touch(name string, size int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.load()
+
+	name = Clean(name)
+
+	if f, ok := c.files[name]; ok {
+		c.size -= f.size
+	}
+
+	c.files[name] = cacheFile{size: size, used: time.Now()}
+	c.size += size
+}
+
+// Forget removes the file from the cache index.
+func (c *Cache) Forget(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.load()
+
+	name = Clean(name)
+
+	if f, ok := c.files[name]; ok {
+		c.size -= f.size
+		delete(c.files, name)
+	}
+}
+
+// Evict removes the least recently used files until the cache size is within the limit.
+// The remove function must return false if a file can't be removed, e.g. because it has
+// not been stored yet. Returns the number of evicted files.
+func (c *Cache) Evict(remove func(name string) bool) (count int) {
+	c.mu.Lock()
+
+	c.load()
+
+	if c.limit <= 0 || c.size <= c.limit || c.evicting {
+		c.mu.Unlock()
+		return 0
+	}
+
+	c.evicting = true
+
+	// Find files that haven't been used recently, oldest first.
+	usedBefore := time.Now().Add(-1 * CacheMinAge)
+	names := make([]string, 0, len(c.files))
+
+	for name, f := range c.files {
+		if f.used.Before(usedBefore) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return c.files[names[i]].used.Before(c.files[names[j]].used)
+	})
+
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		c.evicting = false
+		c.mu.Unlock()
+	}()
+
+	for _, name := range names {
+		c.mu.Lock()
+		done := c.size <= c.limit
+		c.mu.Unlock()
+
+		if done {
+			break
+		} else if !remove(name) {
+			continue
+		}
+
+		c.Forget(name)
+		count++
+	}
+
+	return count
+}
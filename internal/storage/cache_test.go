@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	minAge := CacheMinAge
+	CacheMinAge = 0
+	defer func() { CacheMinAge = minAge }()
+
+	c := NewCache(os.TempDir()+"/storage-cache-missing", 0)
+
+	assert.Equal(t, int64(0), c.Size())
+
+	c.Touch("2021/a.jpg", 10)
+	c.Touch("/2021/b.jpg", 20)
+	c.Touch("2021/a.jpg", 5)
+
+	assert.Equal(t, int64(25), c.Size())
+
+	t.Run("Unlimited", func(t *testing.T) {
+		assert.Equal(t, 0, c.Evict(func(name string) bool { return true }))
+	})
+	t.Run("Limit", func(t *testing.T) {
+		c.SetLimit(10)
+
+		assert.Equal(t, int64(10), c.Limit())
+
+		// Files that can't be removed are skipped.
+		var removed []string
+
+		count := c.Evict(func(name string) bool {
+			removed = append(removed, name)
+			return name != "2021/b.jpg"
+		})
+
+		assert.Equal(t, 1, count)
+		assert.Equal(t, []string{"2021/b.jpg", "2021/a.jpg"}, removed)
+		assert.Equal(t, int64(20), c.Size())
+	})
+	t.Run("Forget", func(t *testing.T) {
+		c.Forget("2021/b.jpg")
+		assert.Equal(t, int64(0), c.Size())
+	})
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Local represents a storage root on local disk, the default backend.
+type Local struct {
+	path string
+}
+
+// NewLocal returns a new local disk storage with the given root path.
+func NewLocal(path string) *Local {
+	return &Local{path: path}
+}
+
+// Backend returns the storage backend type.
+func (s *Local) Backend() string {
+	return BackendLocal
+}
+
+// Path returns the root path.
+func (s *Local) Path() string {
+	return s.path
+}
+
+// abs returns the absolute file name.
+func (s *Local) abs(name string) string {
+	return filepath.Join(s.path, Clean(name))
+}
+
+// Open opens the file for reading.
+func (s *Local) Open(name string) (io.ReadCloser, error) {
+	return os.Open(s.abs(name))
+}
+
+// Stat returns file information.
+func (s *Local) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(s.abs(name))
+}
+
+// Exists tests if a file exists.
+func (s *Local) Exists(name string) bool {
+	return fs.FileExists(s.abs(name))
+}
+
+// LocalName returns the absolute file name if the file exists.
+func (s *Local) LocalName(name string) (string, error) {
+	fileName := s.abs(name)
+
+	if !fs.FileExists(fileName) {
+		return fileName, os.ErrNotExist
+	}
+
+	return fileName, nil
+}
+
+// Store does nothing as files are already stored on local disk.
+func (s *Local) Store(name string) error {
+	return nil
+}
+
+// Remove removes the file.
+func (s *Local) Remove(name string) error {
+	return os.Remove(s.abs(name))
+}
+
+// RemoveAll removes a file or folder with all files it contains.
+func (s *Local) RemoveAll(name string) error {
+	if Clean(name) == "" {
+		return ErrInvalidName
+	}
+
+	return os.RemoveAll(s.abs(name))
+}
+
+// ReadDir returns the files and folders directly in dir, ordered by name.
+func (s *Local) ReadDir(dir string) ([]os.FileInfo, error) {
+	return ioutil.ReadDir(s.abs(dir))
+}
+
+// Walk calls fn for each file in dir, ordered by name.
+func (s *Local) Walk(dir string, fn WalkFunc) error {
+	root := s.abs(dir)
+
+	if !fs.PathExists(root) {
+		return nil
+	}
+
+	return filepath.Walk(root, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+
+		name, err := Rel(s, fileName)
+
+		if err != nil {
+			return err
+		}
+
+		return fn(name, info)
+	})
+}
+
+// Push does nothing as files are already stored on local disk.
+func (s *Local) Push(dir string) (int, error) {
+	return 0, nil
+}
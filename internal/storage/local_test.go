@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestLocal(t *testing.T) {
+	dir := filepath.Join(os.TempDir(), "storage-"+rnd.PPID('t'))
+
+	defer os.RemoveAll(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "2021"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "2021", "photo.jpg"), []byte("jpeg"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewLocal(dir)
+
+	assert.Equal(t, BackendLocal, s.Backend())
+	assert.Equal(t, dir, s.Path())
+	assert.True(t, s.Exists("2021/photo.jpg"))
+	assert.False(t, s.Exists("2021/missing.jpg"))
+
+	t.Run("Open", func(t *testing.T) {
+		f, err := s.Open("/2021/photo.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer f.Close()
+
+		data, err := ioutil.ReadAll(f)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "jpeg", string(data))
+	})
+	t.Run("LocalName", func(t *testing.T) {
+		fileName, err := s.LocalName("2021/photo.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "2021", "photo.jpg"), fileName)
+
+		_, err = s.LocalName("2021/missing.jpg")
+
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("Walk", func(t *testing.T) {
+		var names []string
+
+		err := s.Walk("", func(name string, info os.FileInfo) error {
+			names = append(names, name)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"2021/photo.jpg"}, names)
+		assert.NoError(t, s.Walk("missing", nil))
+	})
+	t.Run("ReadDir", func(t *testing.T) {
+		entries, err := s.ReadDir("/")
+
+		assert.NoError(t, err)
+
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, "2021", entries[0].Name())
+			assert.True(t, entries[0].IsDir())
+		}
+	})
+	t.Run("Remove", func(t *testing.T) {
+		assert.NoError(t, s.Store("2021/photo.jpg"))
+		assert.NoError(t, s.Remove("2021/photo.jpg"))
+		assert.False(t, s.Exists("2021/photo.jpg"))
+	})
+	t.Run("RemoveAll", func(t *testing.T) {
+		if err := ioutil.WriteFile(filepath.Join(dir, "2021", "new.jpg"), []byte("new"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ErrInvalidName, s.RemoveAll("/"))
+		assert.NoError(t, s.RemoveAll("2021"))
+		assert.False(t, s.Exists("2021"))
+		assert.DirExists(t, dir)
+	})
+}
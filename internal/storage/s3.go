@@ -0,0 +1,472 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// DefaultRegion is used if no region was configured, most S3-compatible servers accept it.
+const DefaultRegion = "us-east-1"
+
+// S3 represents a storage root in an S3-compatible object storage bucket.
+//
+// Files are cached in the local path when they are needed by tools that
+// can only read from disk. Least recently used files are evicted from the
+// local path once the cache size limit is exceeded.
+type S3 struct {
+	client  *minio.Client
+	bucket  string
+	prefix  string
+	path    string
+	cache   *Cache
+	timeout time.Duration
+}
+
+// NewS3 returns a new object storage with the given bucket and key prefix, and path as local cache.
+func NewS3(endpoint, bucket, prefix, region, key, secret, path string) (*S3, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("storage: bucket name is missing")
+	} else if path == "" {
+		return nil, fmt.Errorf("storage: local path is missing")
+	}
+
+	u, err := url.Parse(endpoint)
+
+	if err != nil {
+		return nil, fmt.Errorf("storage: %s", err)
+	} else if u.Host == "" {
+		return nil, fmt.Errorf("storage: invalid endpoint %s", txt.Quote(endpoint))
+	}
+
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	clt, err := minio.New(u.Host, &minio.Options{
+		Creds:  credentials.NewStaticV4(key, secret, ""),
+		Secure: u.Scheme != "http",
+		Region: region,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("storage: %s", err)
+	}
+
+	return &S3{
+		client:  clt,
+		bucket:  bucket,
+		prefix:  strings.Trim(prefix, "/"),
+		path:    path,
+		cache:   NewCache(path, 0),
+		timeout: 10 * time.Minute,
+	}, nil
+}
+
+// Cache returns the local file cache.
+func (s *S3) Cache() *Cache {
+	return s.cache
+}
+
+// Backend returns the storage backend type.
+func (s *S3) Backend() string {
+	return BackendS3
+}
+
+// Path returns the local cache path.
+func (s *S3) Path() string {
+	return s.path
+}
+
+// context returns a new request context with timeout.
+func (s *S3) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), s.timeout)
+}
+
+// key returns the object key for a file name.
+func (s *S3) key(name string) string {
+	name = Clean(name)
+
+	if s.prefix == "" {
+		return name
+	} else if name == "" {
+		return s.prefix
+	}
+
+	return s.prefix + "/" + name
+}
+
+// name returns the file name for an object key.
+func (s *S3) name(key string) string {
+	if s.prefix == "" {
+		return key
+	}
+
+	return strings.TrimPrefix(strings.TrimPrefix(key, s.prefix), "/")
+}
+
+// local returns the file name in the local cache path.
+func (s *S3) local(name string) string {
+	return filepath.Join(s.path, Clean(name))
+}
+
+// Open opens the file for reading.
+func (s *S3) Open(name string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(context.Background(), s.bucket, s.key(name), minio.GetObjectOptions{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the object exists, GetObject doesn't send a request.
+	if _, err := obj.Stat(); err != nil {
+		obj.Close()
+		return nil, s.notExist(err)
+	}
+
+	return obj, nil
+}
+
+// Stat returns file information.
+func (s *S3) Stat(name string) (os.FileInfo, error) {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	info, err := s.client.StatObject(ctx, s.bucket, s.key(name), minio.StatObjectOptions{})
+
+	if err != nil {
+		return nil, s.notExist(err)
+	}
+
+	return objectInfo{name: filepath.Base(name), info: info}, nil
+}
+
+// Exists tests if a file exists.
+func (s *S3) Exists(name string) bool {
+	_, err := s.Stat(name)
+
+	return err == nil
+}
+
+// LocalName returns the file name in the local cache path, the file is fetched if it's missing or outdated.
+func (s *S3) LocalName(name string) (string, error) {
+	fileName := s.local(name)
+
+	info, err := s.Stat(name)
+
+	if err != nil {
+		// Keep serving cached files if storage is not available.
+		if !os.IsNotExist(err) && fs.FileExists(fileName) {
+			log.Warnf("storage: %s", err)
+			return fileName, nil
+		}
+
+		return fileName, err
+	}
+
+	if !outdated(fileName, info.Size(), info.ModTime()) {
+		s.cache.Touch(name, info.Size())
+		return fileName, nil
+	}
+
+	if err := s.fetch(name, info.ModTime()); err != nil {
+		return fileName, err
+	}
+
+	s.cache.Touch(name, info.Size())
+	s.evict()
+
+	return fileName, nil
+}
+
+// fetch downloads the object to the local cache path.
+func (s *S3) fetch(name string, modTime time.Time) error {
+	fileName := s.local(name)
+
+	ctx, cancel := s.context()
+	defer cancel()
+
+	if err := s.client.FGetObject(ctx, s.bucket, s.key(name), fileName, minio.GetObjectOptions{}); err != nil {
+		return fmt.Errorf("storage: %s (fetch %s)", err, txt.Quote(name))
+	}
+
+	// Use the object date, so that unchanged files are not fetched again.
+	return os.Chtimes(fileName, modTime, modTime)
+}
+
+// Store uploads a file from the local cache path.
+func (s *S3) Store(name string) error {
+	fileName := s.local(name)
+
+	ctx, cancel := s.context()
+	defer cancel()
+
+	if _, err := s.client.FPutObject(ctx, s.bucket, s.key(name), fileName, minio.PutObjectOptions{}); err != nil {
+		return fmt.Errorf("storage: %s (store %s)", err, txt.Quote(name))
+	}
+
+	info, err := s.Stat(name)
+
+	if err != nil {
+		return err
+	}
+
+	// Use the object date, so that the file is not fetched again.
+	if err := os.Chtimes(fileName, info.ModTime(), info.ModTime()); err != nil {
+		return err
+	}
+
+	s.cache.Touch(name, info.Size())
+	s.evict()
+
+	return nil
+}
+
+// Remove removes the object and the cached file.
+func (s *S3) Remove(name string) error {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	if err := s.client.RemoveObject(ctx, s.bucket, s.key(name), minio.RemoveObjectOptions{}); err != nil {
+		return err
+	}
+
+	s.cache.Forget(name)
+
+	if fileName := s.local(name); fs.FileExists(fileName) {
+		return os.Remove(fileName)
+	}
+
+	return nil
+}
+
+// RemoveAll removes the object or all objects with the folder prefix, and the cached files.
+func (s *S3) RemoveAll(name string) error {
+	name = Clean(name)
+
+	if name == "" {
+		return ErrInvalidName
+	}
+
+	objects, err := s.objects(name)
+
+	if err != nil {
+		return fmt.Errorf("storage: %s (remove %s)", err, txt.Quote(name))
+	}
+
+	// Object storage has no folders, every object with the prefix is removed.
+	if err := s.Remove(name); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	for objName := range objects {
+		if err := s.Remove(objName); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return os.RemoveAll(s.local(name))
+}
+
+// ReadDir returns the objects and folder prefixes directly in dir, ordered by name.
+func (s *S3) ReadDir(dir string) (result []os.FileInfo, err error) {
+	prefix := s.key(dir)
+
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	ctx, cancel := s.context()
+	defer cancel()
+
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix}) {
+		if obj.Err != nil {
+			return result, fmt.Errorf("storage: %s (read %s)", obj.Err, txt.Quote(dir))
+		} else if name := strings.TrimPrefix(obj.Key, prefix); strings.HasSuffix(name, "/") {
+			result = append(result, folderInfo{name: strings.TrimSuffix(name, "/")})
+		} else if name != "" {
+			result = append(result, objectInfo{name: name, info: obj})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name() < result[j].Name() })
+
+	return result, nil
+}
+
+// evict removes the least recently used files from the local cache path if the cache size limit is exceeded.
+func (s *S3) evict() {
+	if count := s.cache.Evict(s.uncache); count > 0 {
+		log.Debugf("storage: evicted %d cached files from %s", count, txt.Quote(s.path))
+	}
+}
+
+// uncache removes a cached file from the local path, unless it has changed and was not stored yet.
+func (s *S3) uncache(name string) bool {
+	fileName := s.local(name)
+
+	local, err := os.Stat(fileName)
+
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+
+	info, err := s.Stat(name)
+
+	if err != nil {
+		return false
+	} else if local.Size() != info.Size() || local.ModTime().After(info.ModTime()) {
+		return false
+	}
+
+	return os.Remove(fileName) == nil
+}
+
+// objects returns all objects in dir by file name.
+func (s *S3) objects(dir string) (result map[string]minio.ObjectInfo, err error) {
+	result = make(map[string]minio.ObjectInfo)
+
+	prefix := s.key(dir)
+
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	ctx, cancel := s.context()
+	defer cancel()
+
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return result, obj.Err
+		} else if strings.HasSuffix(obj.Key, "/") {
+			continue
+		}
+
+		result[s.name(obj.Key)] = obj
+	}
+
+	return result, nil
+}
+
+// Walk calls fn for each stored file in dir, ordered by name.
+func (s *S3) Walk(dir string, fn WalkFunc) error {
+	objects, err := s.objects(dir)
+
+	if err != nil {
+		return fmt.Errorf("storage: %s (walk %s)", err, txt.Quote(dir))
+	}
+
+	names := make([]string, 0, len(objects))
+
+	for name := range objects {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := fn(name, objectInfo{name: filepath.Base(name), info: objects[name]}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Push uploads all files in dir that are missing or changed in object storage.
+func (s *S3) Push(dir string) (count int, err error) {
+	objects, err := s.objects(dir)
+
+	if err != nil {
+		return 0, fmt.Errorf("storage: %s (push %s)", err, txt.Quote(dir))
+	}
+
+	root := s.local(dir)
+
+	if !fs.PathExists(root) {
+		return 0, nil
+	}
+
+	err = filepath.Walk(root, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+
+		name, err := Rel(s, fileName)
+
+		if err != nil {
+			return err
+		}
+
+		if obj, ok := objects[name]; ok && obj.Size == info.Size() && !info.ModTime().After(obj.LastModified) {
+			return nil
+		}
+
+		if err := s.Store(name); err != nil {
+			return err
+		}
+
+		count++
+
+		return nil
+	})
+
+	return count, err
+}
+
+// notExist returns os.ErrNotExist if the object was not found.
+func (s *S3) notExist(err error) error {
+	if resp := minio.ToErrorResponse(err); resp.Code == "NoSuchKey" || resp.StatusCode == 404 {
+		return os.ErrNotExist
+	}
+
+	return err
+}
+
+// outdated tests if the local file is missing or differs from the stored object.
+func outdated(fileName string, size int64, modTime time.Time) bool {
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		return true
+	}
+
+	return info.Size() != size || !info.ModTime().Equal(modTime)
+}
+
+// objectInfo implements os.FileInfo for stored objects.
+type objectInfo struct {
+	name string
+	info minio.ObjectInfo
+}
+
+func (i objectInfo) Name() string       { return i.name }
+func (i objectInfo) Size() int64        { return i.info.Size }
+func (i objectInfo) Mode() os.FileMode  { return 0644 }
+func (i objectInfo) ModTime() time.Time { return i.info.LastModified }
+func (i objectInfo) IsDir() bool        { return false }
+func (i objectInfo) Sys() interface{}   { return nil }
+
+// folderInfo implements os.FileInfo for folders, which are key prefixes in object storage.
+type folderInfo struct {
+	name string
+}
+
+func (i folderInfo) Name() string       { return i.name }
+func (i folderInfo) Size() int64        { return 0 }
+func (i folderInfo) Mode() os.FileMode  { return os.ModeDir | 0755 }
+func (i folderInfo) ModTime() time.Time { return time.Time{} }
+func (i folderInfo) IsDir() bool        { return true }
+func (i folderInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,275 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/remote/s3/s3test"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func testS3(t *testing.T) (*s3test.TestServer, *S3) {
+	srv := s3test.NewTestServer("photos")
+
+	srv.Put("originals/2021/photo.jpg", []byte("jpeg"))
+	srv.Put("originals/2021/July/beach.jpg", []byte("beach"))
+	srv.Put("sidecar/2021/photo.yml", []byte("Favorite: true\n"))
+
+	s, err := NewS3(srv.URL, "photos", "/originals/", "", "admin", "photoprism", filepath.Join(os.TempDir(), "storage-"+rnd.PPID('t')))
+
+	if err != nil {
+		srv.Close()
+		t.Fatal(err)
+	}
+
+	return srv, s
+}
+
+func TestNewS3(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		s, err := NewS3("https://s3.example.com", "photos", "/originals/", "", "admin", "photoprism", "/cache")
+
+		assert.NoError(t, err)
+		assert.Equal(t, BackendS3, s.Backend())
+		assert.Equal(t, "/cache", s.Path())
+		assert.Equal(t, "originals/2021/photo.jpg", s.key("/2021/photo.jpg"))
+		assert.Equal(t, "2021/photo.jpg", s.name("originals/2021/photo.jpg"))
+	})
+	t.Run("NoBucket", func(t *testing.T) {
+		_, err := NewS3("https://s3.example.com", "", "", "", "admin", "photoprism", "/cache")
+
+		assert.Error(t, err)
+	})
+	t.Run("NoPath", func(t *testing.T) {
+		_, err := NewS3("https://s3.example.com", "photos", "", "", "admin", "photoprism", "")
+
+		assert.Error(t, err)
+	})
+	t.Run("InvalidEndpoint", func(t *testing.T) {
+		_, err := NewS3("s3.example.com", "photos", "", "", "admin", "photoprism", "/cache")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestS3_Stat(t *testing.T) {
+	srv, s := testS3(t)
+	defer srv.Close()
+
+	info, err := s.Stat("2021/photo.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "photo.jpg", info.Name())
+	assert.Equal(t, int64(4), info.Size())
+	assert.True(t, s.Exists("2021/photo.jpg"))
+	assert.False(t, s.Exists("2021/missing.jpg"))
+
+	_, err = s.Stat("2021/missing.jpg")
+
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestS3_Open(t *testing.T) {
+	srv, s := testS3(t)
+	defer srv.Close()
+
+	f, err := s.Open("2021/photo.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer f.Close()
+
+	data, err := ioutil.ReadAll(f)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "jpeg", string(data))
+
+	_, err = s.Open("2021/missing.jpg")
+
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestS3_LocalName(t *testing.T) {
+	srv, s := testS3(t)
+	defer srv.Close()
+	defer os.RemoveAll(s.Path())
+
+	fileName, err := s.LocalName("2021/photo.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, filepath.Join(s.Path(), "2021", "photo.jpg"), fileName)
+
+	data, err := ioutil.ReadFile(fileName)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "jpeg", string(data))
+
+	// Changed objects are fetched again.
+	srv.Put("originals/2021/photo.jpg", []byte("changed"))
+
+	fileName, err = s.LocalName("2021/photo.jpg")
+
+	assert.NoError(t, err)
+
+	data, err = ioutil.ReadFile(fileName)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "changed", string(data))
+}
+
+func TestS3_Walk(t *testing.T) {
+	srv, s := testS3(t)
+	defer srv.Close()
+
+	var names []string
+
+	err := s.Walk("/", func(name string, info os.FileInfo) error {
+		names = append(names, name)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2021/July/beach.jpg", "2021/photo.jpg"}, names)
+
+	// Nothing is fetched.
+	assert.NoDirExists(t, s.Path())
+}
+
+func TestS3_ReadDir(t *testing.T) {
+	srv, s := testS3(t)
+	defer srv.Close()
+
+	entries, err := s.ReadDir("2021")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "July", entries[0].Name())
+		assert.True(t, entries[0].IsDir())
+		assert.Equal(t, "photo.jpg", entries[1].Name())
+		assert.False(t, entries[1].IsDir())
+		assert.Equal(t, int64(4), entries[1].Size())
+	}
+
+	entries, err = s.ReadDir("missing")
+
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestS3_RemoveAll(t *testing.T) {
+	srv, s := testS3(t)
+	defer srv.Close()
+	defer os.RemoveAll(s.Path())
+
+	fileName, err := s.LocalName("2021/July/beach.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, ErrInvalidName, s.RemoveAll("/"))
+
+	// Folders are removed with all objects they contain.
+	assert.NoError(t, s.RemoveAll("2021"))
+	assert.Equal(t, []string{"sidecar/2021/photo.yml"}, srv.Keys())
+	assert.NoFileExists(t, fileName)
+	assert.NoDirExists(t, filepath.Join(s.Path(), "2021"))
+}
+
+func TestS3_Push(t *testing.T) {
+	srv, s := testS3(t)
+	defer srv.Close()
+	defer os.RemoveAll(s.Path())
+
+	if _, err := s.LocalName("2021/photo.jpg"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing changed.
+	count, err := s.Push("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// New local files are uploaded.
+	if err := ioutil.WriteFile(filepath.Join(s.Path(), "2021", "new.jpg"), []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err = s.Push("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	data, ok := srv.Get("originals/2021/new.jpg")
+
+	assert.True(t, ok)
+	assert.Equal(t, "new", string(data))
+
+	t.Run("Remove", func(t *testing.T) {
+		assert.NoError(t, s.Remove("2021/new.jpg"))
+
+		_, ok := srv.Get("originals/2021/new.jpg")
+
+		assert.False(t, ok)
+		assert.NoFileExists(t, filepath.Join(s.Path(), "2021", "new.jpg"))
+	})
+}
+
+func TestS3_Evict(t *testing.T) {
+	srv, s := testS3(t)
+	defer srv.Close()
+	defer os.RemoveAll(s.Path())
+
+	minAge := CacheMinAge
+	CacheMinAge = 0
+	defer func() { CacheMinAge = minAge }()
+
+	// Changed files that haven't been stored yet must never be evicted.
+	if err := os.MkdirAll(filepath.Join(s.Path(), "2021"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	} else if err := ioutil.WriteFile(filepath.Join(s.Path(), "2021", "new.jpg"), []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Cache().SetLimit(8)
+
+	if _, err := s.LocalName("2021/photo.jpg"); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	fileName, err := s.LocalName("2021/July/beach.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.FileExists(t, fileName)
+	assert.NoFileExists(t, filepath.Join(s.Path(), "2021", "photo.jpg"))
+	assert.FileExists(t, filepath.Join(s.Path(), "2021", "new.jpg"))
+	assert.Equal(t, int64(8), s.Cache().Size())
+
+	// Evicted files are fetched again when needed.
+	fileName, err = s.LocalName("2021/photo.jpg")
+
+	assert.NoError(t, err)
+	assert.FileExists(t, fileName)
+}
@@ -0,0 +1,104 @@
+/*
+
+Package storage provides an abstraction for the originals, sidecar and cache storage roots.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+var log = event.Log
+
+// Storage backend types.
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+var ErrInvalidName = errors.New("storage: invalid file name")
+
+// WalkFunc is called for each file with its name relative to the root.
+type WalkFunc func(name string, info os.FileInfo) error
+
+// Storage represents a storage root like originals, sidecar or cache.
+//
+// Files are always addressed by their name relative to the root. Every backend
+// has a local path so that tools like ExifTool, FFmpeg and Darktable keep working:
+// for local disk storage, this is the root itself, while object storage backends
+// use it as a cache.
+type Storage interface {
+	// Backend returns the storage backend type, e.g. "local" or "s3".
+	Backend() string
+	// Path returns the local base path.
+	Path() string
+	// Open opens the file for reading.
+	Open(name string) (io.ReadCloser, error)
+	// Stat returns file information.
+	Stat(name string) (os.FileInfo, error)
+	// Exists tests if a file exists.
+	Exists(name string) bool
+	// LocalName returns a local file name, the file is fetched if needed.
+	LocalName(name string) (string, error)
+	// Store saves a file that has been created or changed in the local path.
+	Store(name string) error
+	// Remove removes the file from storage, including the local path.
+	Remove(name string) error
+	// RemoveAll removes a file or folder with all files it contains, including the local path.
+	RemoveAll(name string) error
+	// ReadDir returns the files and folders directly in dir, ordered by name.
+	ReadDir(dir string) ([]os.FileInfo, error)
+	// Walk calls fn for each stored file in dir, ordered by name.
+	Walk(dir string, fn WalkFunc) error
+	// Push stores all files in dir that are missing or outdated in storage.
+	Push(dir string) (int, error)
+}
+
+// Clean returns the normalized file name relative to the root, it can't point outside.
+func Clean(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+// Rel returns the name relative to the local base path of s, or an error if it is outside.
+func Rel(s Storage, fileName string) (string, error) {
+	base := strings.TrimSuffix(s.Path(), "/") + "/"
+
+	if !strings.HasPrefix(fileName, base) {
+		return "", ErrInvalidName
+	}
+
+	return Clean(strings.TrimPrefix(fileName, base)), nil
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClean(t *testing.T) {
+	assert.Equal(t, "", Clean(""))
+	assert.Equal(t, "", Clean("/"))
+	assert.Equal(t, "2021/photo.jpg", Clean("/2021/photo.jpg"))
+	assert.Equal(t, "2021/photo.jpg", Clean("2021/./photo.jpg"))
+	assert.Equal(t, "photo.jpg", Clean("../../photo.jpg"))
+}
+
+func TestRel(t *testing.T) {
+	s := NewLocal("/photoprism/originals")
+
+	t.Run("Success", func(t *testing.T) {
+		name, err := Rel(s, "/photoprism/originals/2021/photo.jpg")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "2021/photo.jpg", name)
+	})
+	t.Run("Outside", func(t *testing.T) {
+		_, err := Rel(s, "/photoprism/originals-backup/photo.jpg")
+
+		assert.Equal(t, ErrInvalidName, err)
+	})
+}
@@ -8,7 +8,9 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
+	"github.com/photoprism/photoprism/internal/metrics"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 
@@ -72,9 +74,12 @@ func FromCache(imageFilename, hash, thumbPath string, width, height int, opts ..
 	}
 
 	if fs.FileExists(fileName) {
+		metrics.ThumbCacheHits.Inc()
 		return fileName, nil
 	}
 
+	metrics.ThumbCacheMisses.Inc()
+
 	return "", ErrThumbNotCached
 }
 
@@ -121,6 +126,8 @@ func Create(img image.Image, fileName string, width, height int, opts ...Resampl
 		return img, fmt.Errorf("resample: height has an invalid value (%d)", height)
 	}
 
+	start := time.Now()
+
 	result = Resample(img, width, height, opts...)
 
 	var saveOption imaging.EncodeOption
@@ -133,12 +140,14 @@ func Create(img image.Image, fileName string, width, height int, opts ...Resampl
 		saveOption = imaging.JPEGQuality(JpegQuality)
 	}
 
-	err = imaging.Save(result, fileName, saveOption)
+	err = Save(result, fileName, saveOption)
 
 	if err != nil {
 		log.Errorf("resample: failed to save %s", txt.Quote(filepath.Base(fileName)))
 		return result, err
 	}
 
+	metrics.ThumbDuration.Observe(time.Since(start).Seconds())
+
 	return result, nil
 }
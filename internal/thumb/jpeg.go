@@ -22,7 +22,7 @@ func Jpeg(srcFilename, jpgFilename string, orientation int) (img image.Image, er
 
 	saveOption := imaging.JPEGQuality(JpegQuality)
 
-	if err = imaging.Save(img, jpgFilename, saveOption); err != nil {
+	if err = Save(img, jpgFilename, saveOption); err != nil {
 		log.Errorf("resample: failed to save %s", txt.Quote(filepath.Base(jpgFilename)))
 		return img, err
 	}
@@ -0,0 +1,24 @@
+package thumb
+
+import (
+	"image"
+	"io"
+	"os"
+
+	"github.com/disintegration/imaging"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Save writes an image to a temporary file and renames it afterwards,
+// so that shutdowns and restarts never leave partially written files.
+func Save(img image.Image, fileName string, opts ...imaging.EncodeOption) error {
+	format, err := imaging.FormatFromFilename(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	return fs.WriteWith(fileName, os.ModePerm, func(w io.Writer) error {
+		return imaging.Encode(w, img, format, opts...)
+	})
+}
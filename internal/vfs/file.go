@@ -0,0 +1,175 @@
+package vfs
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"golang.org/x/net/webdav"
+)
+
+// FolderName returns a name that can be used as folder name, the uid is used if the name is empty.
+func FolderName(name, uid string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '-'
+		}
+
+		if r < 32 {
+			return -1
+		}
+
+		return r
+	}, name)
+
+	name = strings.Trim(strings.TrimSpace(name), ".")
+
+	if name == "" {
+		return uid
+	}
+
+	return name
+}
+
+// dirInfo implements os.FileInfo for virtual folders.
+type dirInfo struct {
+	name    string
+	modTime time.Time
+}
+
+func (i dirInfo) Name() string       { return i.name }
+func (i dirInfo) Size() int64        { return 0 }
+func (i dirInfo) Mode() os.FileMode  { return os.ModeDir | 0555 }
+func (i dirInfo) ModTime() time.Time { return i.modTime }
+func (i dirInfo) IsDir() bool        { return true }
+func (i dirInfo) Sys() interface{}   { return nil }
+
+// fileInfo implements os.FileInfo for photo files with a virtual name.
+type fileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (i fileInfo) Name() string       { return i.name }
+func (i fileInfo) Size() int64        { return i.size }
+func (i fileInfo) Mode() os.FileMode  { return 0444 }
+func (i fileInfo) ModTime() time.Time { return i.modTime }
+func (i fileInfo) IsDir() bool        { return false }
+func (i fileInfo) Sys() interface{}   { return nil }
+
+// dirFile implements webdav.File for virtual folders.
+type dirFile struct {
+	info    os.FileInfo
+	entries []os.FileInfo
+	pos     int
+}
+
+func (d *dirFile) Close() error {
+	return nil
+}
+
+func (d *dirFile) Read(p []byte) (int, error) {
+	return 0, os.ErrInvalid
+}
+
+func (d *dirFile) Write(p []byte) (int, error) {
+	return 0, os.ErrPermission
+}
+
+func (d *dirFile) Seek(offset int64, whence int) (int64, error) {
+	if offset == 0 && whence == io.SeekStart {
+		d.pos = 0
+		return 0, nil
+	}
+
+	return 0, os.ErrInvalid
+}
+
+// Readdir returns the next count entries, or all remaining entries if count <= 0.
+func (d *dirFile) Readdir(count int) ([]os.FileInfo, error) {
+	remaining := d.entries[d.pos:]
+
+	if count <= 0 {
+		d.pos = len(d.entries)
+		return remaining, nil
+	}
+
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+
+	if count > len(remaining) {
+		count = len(remaining)
+	}
+
+	d.pos += count
+
+	return remaining[:count], nil
+}
+
+func (d *dirFile) Stat() (os.FileInfo, error) {
+	return d.info, nil
+}
+
+// photoFile implements webdav.File for originals and resized images.
+type photoFile struct {
+	*os.File
+	info fileInfo
+}
+
+func (f *photoFile) Write(p []byte) (int, error) {
+	return 0, os.ErrPermission
+}
+
+func (f *photoFile) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, os.ErrInvalid
+}
+
+// Stat returns the file info with the virtual file name.
+func (f *photoFile) Stat() (os.FileInfo, error) {
+	fi, err := f.File.Stat()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return fileInfo{name: f.info.name, size: fi.Size(), modTime: f.info.modTime}, nil
+}
+
+// open opens the original or resized image of a photo file.
+func (s *FileSystem) open(f File) (webdav.File, error) {
+	fileName, err := photoprism.LocalFileName(f.FileRoot, f.FileName)
+
+	if err != nil {
+		log.Errorf("vfs: file %s is missing", txt.Quote(f.FileName))
+		return nil, os.ErrNotExist
+	}
+
+	if s.size != "" {
+		size := thumb.Sizes[s.size]
+
+		// Fetch existing thumbnail from cache storage, or store it once it has been created.
+		if cacheName, err := thumb.FileName(f.FileHash, s.conf.ThumbPath(), size.Width, size.Height, size.Options...); err == nil && !photoprism.CachedFile(cacheName) {
+			defer photoprism.StoreCachedFile(cacheName)
+		}
+
+		if fileName, err = thumb.FromFile(fileName, f.FileHash, s.conf.ThumbPath(), size.Width, size.Height, f.Orientation, size.Options...); err != nil {
+			log.Errorf("vfs: %s", err)
+			return nil, err
+		}
+	}
+
+	file, err := os.Open(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &photoFile{File: file, info: fileInfo{name: f.Name, size: f.Size, modTime: f.ModTime}}, nil
+}
@@ -0,0 +1,258 @@
+package vfs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// CacheExpiration is the max age of cached folder listings.
+const CacheExpiration = 10 * time.Minute
+
+var cache = gc.New(CacheExpiration, time.Minute)
+var watchOnce sync.Once
+
+// Flush removes all cached folder listings.
+func Flush() {
+	cache.Flush()
+}
+
+// watch flushes cached listings when albums, photos, people or labels change.
+func watch() {
+	s := event.Subscribe("albums.*", "photos.*", "subjects.*", "people.*", "labels.*")
+
+	defer func() {
+		event.Unsubscribe(s)
+	}()
+
+	for msg := range s.Receiver {
+		log.Tracef("vfs: flushing cached listings after %s", msg.Name)
+		Flush()
+	}
+}
+
+// Folder represents a virtual folder with photos.
+type Folder struct {
+	Name    string
+	UID     string
+	Filter  string
+	ModTime time.Time
+}
+
+// Info returns the folder file info.
+func (f Folder) Info() os.FileInfo {
+	return dirInfo{name: f.Name, modTime: f.ModTime}
+}
+
+// File represents a photo in a virtual folder.
+type File struct {
+	Name        string
+	FileRoot    string
+	FileName    string
+	FileHash    string
+	Size        int64
+	Orientation int
+	ModTime     time.Time
+}
+
+// Info returns the file info.
+func (f File) Info() os.FileInfo {
+	return fileInfo{name: f.Name, size: f.Size, modTime: f.ModTime}
+}
+
+// cacheKey returns the listing cache key for the given path elements.
+func (s *FileSystem) cacheKey(elem ...string) string {
+	return fmt.Sprintf("%s:%t:%s", s.size, s.public, strings.Join(elem, "/"))
+}
+
+// folders returns the virtual folders in a top-level folder.
+func (s *FileSystem) folders(dir string) (result []Folder, err error) {
+	key := s.cacheKey(dir)
+
+	if cached, ok := cache.Get(key); ok {
+		return cached.([]Folder), nil
+	}
+
+	names := make(map[string]bool)
+
+	add := func(name, uid, filter string, modTime time.Time) {
+		name = FolderName(name, uid)
+
+		if names[strings.ToLower(name)] {
+			name = fmt.Sprintf("%s (%s)", name, uid)
+		}
+
+		names[strings.ToLower(name)] = true
+
+		result = append(result, Folder{Name: name, UID: uid, Filter: filter, ModTime: modTime})
+	}
+
+	switch dir {
+	case AlbumsDir, MomentsDir:
+		albumType := entity.AlbumDefault
+
+		if dir == MomentsDir {
+			albumType = entity.AlbumMoment
+		}
+
+		albums, err := query.AlbumSearch(form.AlbumSearch{Type: albumType, Count: query.MaxResults})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range albums {
+			add(a.AlbumTitle, a.AlbumUID, a.AlbumFilter, a.UpdatedAt)
+		}
+	case PeopleDir:
+		people, err := query.People()
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range people {
+			add(p.SubjName, p.SubjUID, "", started)
+		}
+	case LabelsDir:
+		labels, err := query.Labels(form.LabelSearch{Count: query.MaxResults})
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, l := range labels {
+			add(l.LabelName, l.CustomSlug, "", l.UpdatedAt)
+		}
+	default:
+		return nil, os.ErrNotExist
+	}
+
+	cache.SetDefault(key, result)
+
+	return result, nil
+}
+
+// folder finds a virtual folder by name.
+func (s *FileSystem) folder(dir, name string) (Folder, error) {
+	folders, err := s.folders(dir)
+
+	if err != nil {
+		return Folder{}, err
+	}
+
+	for _, f := range folders {
+		if f.Name == name {
+			return f, nil
+		}
+	}
+
+	return Folder{}, os.ErrNotExist
+}
+
+// files returns the photos in a virtual folder.
+func (s *FileSystem) files(dir string, folder Folder) (result []File, err error) {
+	key := s.cacheKey(dir, folder.UID)
+
+	if cached, ok := cache.Get(key); ok {
+		return cached.([]File), nil
+	}
+
+	f := form.PhotoSearch{Primary: true, Public: s.public, Count: query.MaxResults}
+
+	switch dir {
+	case AlbumsDir, MomentsDir:
+		f.Album = folder.UID
+		f.Filter = folder.Filter
+	case PeopleDir:
+		f.Subject = folder.UID
+	case LabelsDir:
+		f.Label = folder.UID
+	default:
+		return nil, os.ErrNotExist
+	}
+
+	photos, _, err := query.PhotoSearch(f)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+
+	for _, p := range photos {
+		name := filepath.Base(p.FileName)
+
+		if s.size != "" {
+			name = strings.TrimSuffix(name, filepath.Ext(name)) + fs.JpegExt
+		}
+
+		if names[strings.ToLower(name)] {
+			ext := filepath.Ext(name)
+			name = fmt.Sprintf("%s-%s%s", strings.TrimSuffix(name, ext), p.PhotoUID, ext)
+		}
+
+		names[strings.ToLower(name)] = true
+
+		size := p.FileSize
+
+		// Use the actual size of resized images if they have been created already.
+		if s.size != "" {
+			if t, ok := thumb.Sizes[s.size]; ok {
+				if thumbName, err := thumb.FileName(p.FileHash, s.conf.ThumbPath(), t.Width, t.Height, t.Options...); err == nil {
+					if info, err := os.Stat(thumbName); err == nil {
+						size = info.Size()
+					}
+				}
+			}
+		}
+
+		result = append(result, File{
+			Name:        name,
+			FileRoot:    p.FileRoot,
+			FileName:    p.FileName,
+			FileHash:    p.FileHash,
+			Size:        size,
+			Orientation: p.FileOrientation,
+			ModTime:     p.TakenAt,
+		})
+	}
+
+	cache.SetDefault(key, result)
+
+	return result, nil
+}
+
+// file finds a photo file by folder and name.
+func (s *FileSystem) file(dir, folderName, name string) (File, error) {
+	folder, err := s.folder(dir, folderName)
+
+	if err != nil {
+		return File{}, err
+	}
+
+	files, err := s.files(dir, folder)
+
+	if err != nil {
+		return File{}, err
+	}
+
+	for _, f := range files {
+		if f.Name == name {
+			return f, nil
+		}
+	}
+
+	return File{}, os.ErrNotExist
+}
@@ -0,0 +1,216 @@
+/*
+
+Package vfs implements a read-only virtual WebDAV file system with albums, people, labels and moments as folders.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package vfs
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"golang.org/x/net/webdav"
+)
+
+var log = event.Log
+
+// Top-level folder names.
+const (
+	AlbumsDir  = "albums"
+	MomentsDir = "moments"
+	PeopleDir  = "people"
+	LabelsDir  = "labels"
+)
+
+// RootDirs lists the top-level folders in display order.
+var RootDirs = []string{AlbumsDir, MomentsDir, PeopleDir, LabelsDir}
+
+// started is used as modification time for folders without timestamp.
+var started = time.Now()
+
+// FileSystem implements a read-only webdav.FileSystem backed by the index.
+type FileSystem struct {
+	conf   *config.Config
+	size   thumb.Name
+	public bool
+}
+
+// New returns a new file system, private photos are hidden if public is true.
+func New(conf *config.Config, public bool) *FileSystem {
+	watchOnce.Do(func() {
+		go watch()
+	})
+
+	return &FileSystem{conf: conf, size: conf.WebDAVThumbs(), public: public}
+}
+
+// Mkdir implements webdav.FileSystem, the file system is read-only.
+func (s *FileSystem) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	return os.ErrPermission
+}
+
+// RemoveAll implements webdav.FileSystem, the file system is read-only.
+func (s *FileSystem) RemoveAll(ctx context.Context, name string) error {
+	return os.ErrPermission
+}
+
+// Rename implements webdav.FileSystem, the file system is read-only.
+func (s *FileSystem) Rename(ctx context.Context, oldName, newName string) error {
+	return os.ErrPermission
+}
+
+// Stat implements webdav.FileSystem.
+func (s *FileSystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	p := split(name)
+
+	switch len(p) {
+	case 0:
+		return dirInfo{name: "/", modTime: started}, nil
+	case 1:
+		if !rootDir(p[0]) {
+			return nil, os.ErrNotExist
+		}
+
+		return dirInfo{name: p[0], modTime: started}, nil
+	case 2:
+		f, err := s.folder(p[0], p[1])
+
+		if err != nil {
+			return nil, err
+		}
+
+		return f.Info(), nil
+	case 3:
+		f, err := s.file(p[0], p[1], p[2])
+
+		if err != nil {
+			return nil, err
+		}
+
+		return f.Info(), nil
+	default:
+		return nil, os.ErrNotExist
+	}
+}
+
+// OpenFile implements webdav.FileSystem, only read access is permitted.
+func (s *FileSystem) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) != 0 {
+		return nil, os.ErrPermission
+	}
+
+	p := split(name)
+
+	switch len(p) {
+	case 0:
+		var entries []os.FileInfo
+
+		for _, dir := range RootDirs {
+			entries = append(entries, dirInfo{name: dir, modTime: started})
+		}
+
+		return &dirFile{info: dirInfo{name: "/", modTime: started}, entries: entries}, nil
+	case 1:
+		if !rootDir(p[0]) {
+			return nil, os.ErrNotExist
+		}
+
+		folders, err := s.folders(p[0])
+
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]os.FileInfo, len(folders))
+
+		for i, f := range folders {
+			entries[i] = f.Info()
+		}
+
+		return &dirFile{info: dirInfo{name: p[0], modTime: started}, entries: entries}, nil
+	case 2:
+		f, err := s.folder(p[0], p[1])
+
+		if err != nil {
+			return nil, err
+		}
+
+		files, err := s.files(p[0], f)
+
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]os.FileInfo, len(files))
+
+		for i, f := range files {
+			entries[i] = f.Info()
+		}
+
+		return &dirFile{info: f.Info(), entries: entries}, nil
+	case 3:
+		f, err := s.file(p[0], p[1], p[2])
+
+		if err != nil {
+			return nil, err
+		}
+
+		return s.open(f)
+	default:
+		return nil, os.ErrNotExist
+	}
+}
+
+// split returns the cleaned path elements of a file name.
+func split(name string) []string {
+	name = strings.Trim(path.Clean("/"+name), "/")
+
+	if name == "" {
+		return nil
+	}
+
+	return strings.Split(name, "/")
+}
+
+// rootDir tests if the name is a top-level folder.
+func rootDir(name string) bool {
+	for _, dir := range RootDirs {
+		if dir == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,195 @@
+package vfs
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	log = logrus.StandardLogger()
+	log.SetLevel(logrus.DebugLevel)
+
+	if err := os.Remove(".test.db"); err == nil {
+		log.Debugln("removed .test.db")
+	}
+
+	c := config.TestConfig()
+	photoprism.SetConfig(c)
+
+	code := m.Run()
+
+	_ = c.CloseDb()
+
+	os.Exit(code)
+}
+
+func TestFileSystem_Stat(t *testing.T) {
+	fs := New(config.TestConfig(), false)
+	ctx := context.Background()
+
+	t.Run("Root", func(t *testing.T) {
+		info, err := fs.Stat(ctx, "/")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, info.IsDir())
+	})
+	t.Run("Albums", func(t *testing.T) {
+		info, err := fs.Stat(ctx, "/albums/")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, info.IsDir())
+		assert.Equal(t, "albums", info.Name())
+	})
+	t.Run("Album", func(t *testing.T) {
+		info, err := fs.Stat(ctx, "/albums/Christmas 2030")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, info.IsDir())
+		assert.Equal(t, "Christmas 2030", info.Name())
+	})
+	t.Run("Label", func(t *testing.T) {
+		info, err := fs.Stat(ctx, "/labels/Flower")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, info.IsDir())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := fs.Stat(ctx, "/foo")
+		assert.True(t, os.IsNotExist(err))
+
+		_, err = fs.Stat(ctx, "/albums/Foo Bar")
+		assert.True(t, os.IsNotExist(err))
+
+		_, err = fs.Stat(ctx, "/albums/Christmas 2030/foo.jpg")
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestFileSystem_OpenFile(t *testing.T) {
+	fs := New(config.TestConfig(), false)
+	ctx := context.Background()
+
+	t.Run("Root", func(t *testing.T) {
+		f, err := fs.OpenFile(ctx, "/", os.O_RDONLY, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entries, err := f.Readdir(0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, entries, len(RootDirs))
+		assert.Equal(t, AlbumsDir, entries[0].Name())
+		assert.NoError(t, f.Close())
+	})
+	t.Run("Albums", func(t *testing.T) {
+		f, err := fs.OpenFile(ctx, "/albums", os.O_RDONLY, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entries, err := f.Readdir(0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var names []string
+
+		for _, e := range entries {
+			assert.True(t, e.IsDir())
+			names = append(names, e.Name())
+		}
+
+		assert.Contains(t, names, "Christmas 2030")
+		assert.NotContains(t, names, "April 1990")
+	})
+	t.Run("People", func(t *testing.T) {
+		f, err := fs.OpenFile(ctx, "/people", os.O_RDONLY, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entries, err := f.Readdir(1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, entries, 1)
+	})
+	t.Run("Album", func(t *testing.T) {
+		f, err := fs.OpenFile(ctx, "/albums/Christmas 2030", os.O_RDONLY, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entries, err := f.Readdir(0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, e := range entries {
+			assert.False(t, e.IsDir())
+		}
+	})
+	t.Run("ReadOnly", func(t *testing.T) {
+		_, err := fs.OpenFile(ctx, "/albums/Christmas 2030/foo.jpg", os.O_RDWR|os.O_CREATE, 0666)
+		assert.Equal(t, os.ErrPermission, err)
+	})
+}
+
+func TestFileSystem_Mkdir(t *testing.T) {
+	fs := New(config.TestConfig(), false)
+
+	assert.Equal(t, os.ErrPermission, fs.Mkdir(context.Background(), "/albums/foo", os.ModePerm))
+	assert.Equal(t, os.ErrPermission, fs.RemoveAll(context.Background(), "/albums/Christmas 2030"))
+	assert.Equal(t, os.ErrPermission, fs.Rename(context.Background(), "/albums/Christmas 2030", "/albums/foo"))
+}
+
+func TestFolderName(t *testing.T) {
+	assert.Equal(t, "Christmas 2030", FolderName("Christmas 2030", "at9lxuqxpogaaba7"))
+	assert.Equal(t, "AC-DC", FolderName("AC/DC", "at9lxuqxpogaaba7"))
+	assert.Equal(t, "at9lxuqxpogaaba7", FolderName(" .. ", "at9lxuqxpogaaba7"))
+	assert.Equal(t, "at9lxuqxpogaaba7", FolderName("", "at9lxuqxpogaaba7"))
+}
+
+func TestFlush(t *testing.T) {
+	fs := New(config.TestConfig(), true)
+
+	if _, err := fs.folders(LabelsDir); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotZero(t, cache.ItemCount())
+
+	Flush()
+
+	assert.Zero(t, cache.ItemCount())
+}
@@ -0,0 +1,246 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Topics are the event hub subscriptions, the webhook topic patterns are matched against their names.
+var Topics = []string{
+	"photos.*",
+	"albums.*",
+	"albums.*.*",
+	"labels.*",
+	"subjects.*",
+	"people.*",
+	"people.*.*",
+	"import.*",
+	"index.*",
+	"log.*",
+}
+
+// QueueSize is the max number of pending deliveries per webhook, further events are dropped and logged as failed.
+var QueueSize = 100
+
+// ErrQueueFull is the delivery error of events that were dropped because the webhook queue was full.
+const ErrQueueFull = "queue full"
+
+// ErrCanceled is the delivery error of queued events that were not sent because the dispatcher was stopped.
+const ErrCanceled = "delivery canceled"
+
+// cacheExpires is the max age of cached webhooks, changes via the API flush the cache immediately.
+const cacheExpires = time.Minute
+
+var dispatch = struct {
+	mutex   sync.Mutex
+	cancel  context.CancelFunc
+	done    chan struct{}
+	hooks   entity.Webhooks
+	updated time.Time
+}{}
+
+// Start subscribes to library events and sends them to webhooks until Stop is called.
+func Start(conf *config.Config) {
+	if conf.DisableWebhooks() {
+		log.Debugf("webhook: disabled")
+		return
+	}
+
+	dispatch.mutex.Lock()
+	defer dispatch.mutex.Unlock()
+
+	if dispatch.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	dispatch.cancel = cancel
+	dispatch.done = make(chan struct{})
+
+	go run(ctx, dispatch.done)
+}
+
+// Stop unsubscribes from events and waits until running deliveries are completed or canceled.
+func Stop() {
+	dispatch.mutex.Lock()
+	cancel, done := dispatch.cancel, dispatch.done
+	dispatch.cancel, dispatch.done = nil, nil
+	dispatch.mutex.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	<-done
+}
+
+// Flush resets the webhook cache, so that changes take effect immediately.
+func Flush() {
+	dispatch.mutex.Lock()
+	defer dispatch.mutex.Unlock()
+
+	dispatch.hooks = nil
+	dispatch.updated = time.Time{}
+}
+
+// delivery represents a queued webhook delivery.
+type delivery struct {
+	hook    entity.Webhook
+	payload Payload
+	body    []byte
+}
+
+// run receives events and queues deliveries for matching webhooks, each webhook has its own
+// queue and worker so that slow endpoints don't delay other webhooks or the event subscription.
+func run(ctx context.Context, done chan struct{}) {
+	s := event.Subscribe(Topics...)
+
+	var wg sync.WaitGroup
+
+	queues := make(map[string]chan delivery)
+
+	defer func() {
+		event.Unsubscribe(s)
+		wg.Wait()
+		close(done)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-s.Receiver:
+			hooks := subscribed(msg.Name)
+
+			if len(hooks) == 0 {
+				continue
+			}
+
+			p := NewPayload(msg)
+			body, err := p.JSON()
+
+			if err != nil {
+				log.Errorf("webhook: %s (encode %s)", err, txt.Quote(msg.Name))
+				continue
+			}
+
+			for _, hook := range hooks {
+				if hook.Filtered(body) {
+					continue
+				}
+
+				queue, ok := queues[hook.WebhookUID]
+
+				if !ok {
+					queue = make(chan delivery, QueueSize)
+					queues[hook.WebhookUID] = queue
+
+					wg.Add(1)
+
+					go func() {
+						defer wg.Done()
+						work(ctx, queue)
+					}()
+				}
+
+				select {
+				case queue <- delivery{hook: hook, payload: p, body: body}:
+				default:
+					drop(hook, p, ErrQueueFull)
+				}
+			}
+		}
+	}
+}
+
+// work sends queued deliveries in order until the context is canceled, pending deliveries are then logged as canceled.
+func work(ctx context.Context, queue chan delivery) {
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case d := <-queue:
+					drop(d.hook, d.payload, ErrCanceled)
+				default:
+					return
+				}
+			}
+		case d := <-queue:
+			deliver(ctx, d.hook, d.payload, d.body)
+		}
+	}
+}
+
+// drop adds an event that was not sent to the delivery log.
+func drop(hook entity.Webhook, p Payload, reason string) {
+	result := entity.WebhookDelivery{
+		WebhookUID:    hook.WebhookUID,
+		DeliveryUUID:  p.UUID,
+		EventName:     p.Event,
+		DeliveryError: reason,
+	}
+
+	logResult(hook, p, result)
+}
+
+// deliver sends the payload and adds the result to the delivery log.
+func deliver(ctx context.Context, hook entity.Webhook, p Payload, body []byte) {
+	logResult(hook, p, Send(ctx, hook, p.Event, p.UUID, body))
+}
+
+// logResult logs the delivery result and adds it to the delivery log.
+func logResult(hook entity.Webhook, p Payload, result entity.WebhookDelivery) {
+	if result.Success() {
+		log.Debugf("webhook: sent %s to %s", txt.Quote(p.Event), hook.WebhookUID)
+	} else if strings.HasPrefix(p.Event, "log.") {
+		// Don't create a new log event that would be sent again.
+		log.Debugf("webhook: %s while sending %s to %s", result.DeliveryError, txt.Quote(p.Event), hook.WebhookUID)
+	} else {
+		log.Warnf("webhook: %s while sending %s to %s", result.DeliveryError, txt.Quote(p.Event), hook.WebhookUID)
+	}
+
+	if err := result.Create(); err != nil {
+		log.Errorf("webhook: %s (log delivery)", err)
+	}
+}
+
+// subscribed returns the enabled webhooks that are subscribed to the event topic.
+func subscribed(topic string) (result entity.Webhooks) {
+	// Skip debug and trace messages, they are too frequent.
+	if topic == "log.debug" || topic == "log.trace" {
+		return result
+	}
+
+	dispatch.mutex.Lock()
+	defer dispatch.mutex.Unlock()
+
+	if dispatch.hooks == nil || time.Since(dispatch.updated) > cacheExpires {
+		hooks, err := query.EnabledWebhooks()
+
+		if err != nil {
+			log.Errorf("webhook: %s (find webhooks)", err)
+		}
+
+		dispatch.hooks = hooks
+		dispatch.updated = time.Now()
+	}
+
+	for _, hook := range dispatch.hooks {
+		if hook.Subscribed(topic) {
+			result = append(result, hook)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,167 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStart(t *testing.T) {
+	r := NewTestReceiver("dispatch-secret", 1, 500)
+	defer r.Close()
+
+	people, err := entity.CreateWebhook(form.Webhook{
+		WebhookName:    "Matrix",
+		WebhookURL:     r.URL(),
+		WebhookTopics:  "people.photos.*, albums.*",
+		WebhookFilter:  "jqu0xs11qekk9jx8",
+		WebhookSecret:  "dispatch-secret",
+		WebhookEnabled: true,
+		RetryLimit:     2,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer people.Delete()
+
+	disabled, err := entity.CreateWebhook(form.Webhook{
+		WebhookURL:    r.URL(),
+		WebhookTopics: "*",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer disabled.Delete()
+
+	Flush()
+	Start(config.TestConfig())
+
+	// Wait for the subscription.
+	time.Sleep(50 * time.Millisecond)
+
+	event.Publish("people.photos.added", event.Data{"uid": "jqu0xs11qekk9jx8", "photos": []string{"pt9jtdre2lvl0yh7"}})
+	event.Publish("people.photos.added", event.Data{"uid": "jqu0xs11qekk9jx9", "photos": []string{"pt9jtdre2lvl0yh8"}})
+	event.Publish("photos.created", event.Data{"uid": "jqu0xs11qekk9jx8"})
+
+	for i := 0; i < 100 && len(r.Payloads()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	Stop()
+
+	payloads := r.Payloads()
+
+	if assert.Len(t, payloads, 1) {
+		assert.Equal(t, "people.photos.added", payloads[0].Event)
+		assert.Equal(t, "jqu0xs11qekk9jx8", payloads[0].Data["uid"])
+	}
+
+	assert.Equal(t, 2, r.Requests())
+
+	deliveries, err := query.WebhookDeliveries(people.WebhookUID, 10, 0)
+
+	assert.NoError(t, err)
+
+	if assert.Len(t, deliveries, 1) {
+		assert.True(t, deliveries[0].Success())
+		assert.Equal(t, 2, deliveries[0].DeliveryAttempts)
+		assert.Equal(t, payloads[0].UUID, deliveries[0].DeliveryUUID)
+	}
+
+	deliveries, err = query.WebhookDeliveries(disabled.WebhookUID, 10, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 0)
+}
+
+func TestStart_SlowWebhook(t *testing.T) {
+	release := make(chan struct{})
+
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	defer slow.Close()
+
+	r := NewTestReceiver("fast-secret", 0, 0)
+	defer r.Close()
+
+	slowHook, err := entity.CreateWebhook(form.Webhook{
+		WebhookURL:     slow.URL,
+		WebhookTopics:  "labels.*",
+		WebhookEnabled: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer slowHook.Delete()
+
+	fastHook, err := entity.CreateWebhook(form.Webhook{
+		WebhookURL:     r.URL(),
+		WebhookTopics:  "labels.*",
+		WebhookSecret:  "fast-secret",
+		WebhookEnabled: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer fastHook.Delete()
+
+	queueSize := QueueSize
+	QueueSize = 1
+	defer func() { QueueSize = queueSize }()
+
+	Flush()
+	Start(config.TestConfig())
+
+	// Wait for the subscription.
+	time.Sleep(50 * time.Millisecond)
+
+	for i := 0; i < 5; i++ {
+		event.Publish("labels.updated", event.Data{"uid": "lt9k3pw1wowuy3c2"})
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	// The fast webhook must not be delayed by the slow one.
+	for i := 0; i < 100 && len(r.Payloads()) < 5; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.Len(t, r.Payloads(), 5)
+
+	close(release)
+	Stop()
+
+	deliveries, err := query.WebhookDeliveries(slowHook.WebhookUID, 10, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 5)
+
+	dropped := 0
+
+	for _, d := range deliveries {
+		if d.DeliveryError == ErrQueueFull {
+			dropped++
+		}
+	}
+
+	// At most one delivery is running and one is queued.
+	assert.GreaterOrEqual(t, dropped, 3)
+}
@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// RetryDelay is the wait time before the first retry, it doubles with every attempt up to MaxRetryDelay.
+var RetryDelay = 5 * time.Second
+
+// MaxRetryDelay is the max wait time between two attempts.
+var MaxRetryDelay = 5 * time.Minute
+
+// Client is the http client used for deliveries.
+var Client = &http.Client{Timeout: 15 * time.Second}
+
+// Send delivers the JSON payload to the webhook, retries with exponential backoff if
+// the request failed, and returns the delivery log entry.
+func Send(ctx context.Context, hook entity.Webhook, name, uuid string, body []byte) (result entity.WebhookDelivery) {
+	start := time.Now()
+
+	result = entity.WebhookDelivery{
+		WebhookUID:   hook.WebhookUID,
+		DeliveryUUID: uuid,
+		EventName:    name,
+	}
+
+	signature := Sign(hook.WebhookSecret, body)
+	delay := RetryDelay
+
+	for {
+		result.DeliveryAttempts++
+
+		retry := false
+		status, err := post(ctx, hook.WebhookURL, name, uuid, signature, body)
+
+		result.StatusCode = status
+
+		if err != nil {
+			result.DeliveryError = err.Error()
+			retry = true
+		} else if status < 200 || status > 299 {
+			result.DeliveryError = http.StatusText(status)
+			retry = status >= 500 || status == http.StatusTooManyRequests || status == http.StatusRequestTimeout
+		} else {
+			result.DeliveryError = ""
+		}
+
+		if !retry || result.DeliveryAttempts > hook.RetryLimit {
+			break
+		}
+
+		log.Debugf("webhook: %s, retrying %s in %s", result.DeliveryError, hook.WebhookUID, delay)
+
+		select {
+		case <-ctx.Done():
+			result.DeliveryError = fmt.Sprintf("%s, retry canceled", result.DeliveryError)
+			result.DeliveryDuration = time.Since(start).Milliseconds()
+			return result
+		case <-time.After(delay):
+		}
+
+		if delay *= 2; delay > MaxRetryDelay {
+			delay = MaxRetryDelay
+		}
+	}
+
+	result.DeliveryDuration = time.Since(start).Milliseconds()
+
+	return result
+}
+
+// post sends a single signed request and returns the response status code.
+func post(ctx context.Context, url, name, uuid, signature string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PhotoPrism-Webhook/1.0")
+	req.Header.Set(HeaderEvent, name)
+	req.Header.Set(HeaderDelivery, uuid)
+	req.Header.Set(HeaderSignature, signature)
+
+	resp, err := Client.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	// Drain body so that the connection can be reused.
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64*1024))
+	_ = resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
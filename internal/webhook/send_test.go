@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSend(t *testing.T) {
+	body := []byte(`{"uuid":"7c1bc9ea-8b60-4a5e-9b6b-1c5a0a3b2d11","event":"photos.created","data":{}}`)
+
+	t.Run("Success", func(t *testing.T) {
+		r := NewTestReceiver("secret", 0, 0)
+		defer r.Close()
+
+		hook := entity.Webhook{WebhookUID: "wqzfqh4x8opr2c3k", WebhookURL: r.URL(), WebhookSecret: "secret", RetryLimit: 3}
+		result := Send(context.Background(), hook, "photos.created", "7c1bc9ea-8b60-4a5e-9b6b-1c5a0a3b2d11", body)
+
+		assert.True(t, result.Success())
+		assert.Equal(t, http.StatusNoContent, result.StatusCode)
+		assert.Equal(t, 1, result.DeliveryAttempts)
+		assert.Equal(t, "wqzfqh4x8opr2c3k", result.WebhookUID)
+		assert.Len(t, r.Payloads(), 1)
+	})
+	t.Run("Retry", func(t *testing.T) {
+		r := NewTestReceiver("secret", 2, http.StatusServiceUnavailable)
+		defer r.Close()
+
+		hook := entity.Webhook{WebhookURL: r.URL(), WebhookSecret: "secret", RetryLimit: 3}
+		result := Send(context.Background(), hook, "photos.created", "", body)
+
+		assert.True(t, result.Success())
+		assert.Equal(t, 3, result.DeliveryAttempts)
+		assert.Equal(t, 3, r.Requests())
+	})
+	t.Run("RetryLimit", func(t *testing.T) {
+		r := NewTestReceiver("secret", 10, http.StatusBadGateway)
+		defer r.Close()
+
+		hook := entity.Webhook{WebhookURL: r.URL(), WebhookSecret: "secret", RetryLimit: 2}
+		result := Send(context.Background(), hook, "photos.created", "", body)
+
+		assert.False(t, result.Success())
+		assert.Equal(t, http.StatusBadGateway, result.StatusCode)
+		assert.Equal(t, "Bad Gateway", result.DeliveryError)
+		assert.Equal(t, 3, result.DeliveryAttempts)
+	})
+	t.Run("NoRetry", func(t *testing.T) {
+		r := NewTestReceiver("secret", 0, 0)
+		defer r.Close()
+
+		hook := entity.Webhook{WebhookURL: r.URL(), WebhookSecret: "wrong", RetryLimit: 3}
+		result := Send(context.Background(), hook, "photos.created", "", body)
+
+		assert.False(t, result.Success())
+		assert.Equal(t, http.StatusUnauthorized, result.StatusCode)
+		assert.Equal(t, 1, result.DeliveryAttempts)
+		assert.Equal(t, 1, r.Invalid())
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		r := NewTestReceiver("secret", 10, http.StatusServiceUnavailable)
+		defer r.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		hook := entity.Webhook{WebhookURL: r.URL(), WebhookSecret: "secret", RetryLimit: 3}
+		result := Send(ctx, hook, "photos.created", "", body)
+
+		assert.False(t, result.Success())
+		assert.Equal(t, 1, result.DeliveryAttempts)
+		assert.Contains(t, result.DeliveryError, "canceled")
+	})
+}
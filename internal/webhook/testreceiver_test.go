@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// TestReceiver is a local webhook endpoint for tests, it verifies signatures and records payloads.
+type TestReceiver struct {
+	Server   *httptest.Server
+	Secret   string
+	Failures int
+	Status   int
+	payloads []Payload
+	invalid  int
+	requests int
+	mutex    sync.Mutex
+}
+
+// NewTestReceiver starts a new receiver, the first failures requests fail with the given status code.
+func NewTestReceiver(secret string, failures, status int) *TestReceiver {
+	r := &TestReceiver{Secret: secret, Failures: failures, Status: status}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.handle))
+
+	return r
+}
+
+func (r *TestReceiver) handle(w http.ResponseWriter, req *http.Request) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.requests++
+
+	if r.requests <= r.Failures {
+		w.WriteHeader(r.Status)
+		return
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+
+	if err != nil || !Verify(r.Secret, body, req.Header.Get(HeaderSignature)) {
+		r.invalid++
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var p Payload
+
+	if err := json.Unmarshal(body, &p); err != nil || p.Event != req.Header.Get(HeaderEvent) {
+		r.invalid++
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	r.payloads = append(r.payloads, p)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// URL returns the webhook url.
+func (r *TestReceiver) URL() string {
+	return r.Server.URL + "/hook"
+}
+
+// Payloads returns the verified payloads received so far.
+func (r *TestReceiver) Payloads() []Payload {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]Payload{}, r.payloads...)
+}
+
+// Requests returns the number of requests including failures.
+func (r *TestReceiver) Requests() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.requests
+}
+
+// Invalid returns the number of requests with an invalid signature or payload.
+func (r *TestReceiver) Invalid() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.invalid
+}
+
+// Close shuts down the receiver.
+func (r *TestReceiver) Close() {
+	r.Server.Close()
+}
@@ -0,0 +1,97 @@
+/*
+
+Package webhook sends library events like new photos as signed JSON requests to webhook URLs.
+
+Copyright (c) 2018 - 2021 Michael Mayer <hello@photoprism.org>
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU Affero General Public License as published
+    by the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU Affero General Public License for more details.
+
+    You should have received a copy of the GNU Affero General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+
+    PhotoPrism® is a registered trademark of Michael Mayer.  You may use it as required
+    to describe our software, run your own server, for educational purposes, but not for
+    offering commercial goods, products, or services without prior written permission.
+    In other words, please ask.
+
+Feel free to send an e-mail to hello@photoprism.org if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+https://docs.photoprism.org/developer-guide/
+
+*/
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+var log = event.Log
+
+// Request headers sent with each delivery.
+const (
+	HeaderEvent     = "X-PhotoPrism-Event"
+	HeaderDelivery  = "X-PhotoPrism-Delivery"
+	HeaderSignature = "X-PhotoPrism-Signature"
+)
+
+// SignaturePrefix is the hash algorithm prefix of the signature header value.
+const SignaturePrefix = "sha256="
+
+// Payload represents the JSON request body of a delivery.
+type Payload struct {
+	UUID  string     `json:"uuid"`
+	Event string     `json:"event"`
+	Time  time.Time  `json:"time"`
+	Data  event.Data `json:"data"`
+}
+
+// NewPayload creates a new payload for the event message.
+func NewPayload(msg event.Message) Payload {
+	return Payload{
+		UUID:  rnd.UUID(),
+		Event: msg.Name,
+		Time:  time.Now().UTC().Truncate(time.Second),
+		Data:  msg.Fields,
+	}
+}
+
+// JSON returns the payload as JSON encoded request body.
+func (p Payload) JSON() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of the request body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return SignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify tests if the signature header value matches the request body, so receivers know it was
+// sent by the server and hasn't been modified.
+func Verify(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, SignaturePrefix) {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
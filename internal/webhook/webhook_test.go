@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	log = logrus.StandardLogger()
+	log.SetLevel(logrus.DebugLevel)
+
+	if err := os.Remove(".test.db"); err == nil {
+		log.Debugln("removed .test.db")
+	}
+
+	c := config.TestConfig()
+
+	RetryDelay = 10 * time.Millisecond
+
+	code := m.Run()
+
+	_ = c.CloseDb()
+
+	os.Exit(code)
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"photos.created"}`)
+	sig := Sign("secret", body)
+
+	assert.Equal(t, "sha256=", sig[:7])
+	assert.Len(t, sig, 71)
+	assert.True(t, Verify("secret", body, sig))
+	assert.False(t, Verify("other", body, sig))
+	assert.False(t, Verify("secret", []byte(`{}`), sig))
+	assert.False(t, Verify("secret", body, sig[7:]))
+}
+
+func TestNewPayload(t *testing.T) {
+	p := NewPayload(event.Message{Name: "albums.updated", Fields: event.Data{"uid": "at9lxuqxpogaaba7"}})
+
+	assert.Len(t, p.UUID, 36)
+	assert.Equal(t, "albums.updated", p.Event)
+	assert.False(t, p.Time.IsZero())
+
+	body, err := p.JSON()
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"data":{"uid":"at9lxuqxpogaaba7"}`)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mailer"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
@@ -141,6 +142,27 @@ func (m *Meta) Start(delay time.Duration) (err error) {
 		log.Errorf("metadata: %s (update previews)", err)
 	}
 
+	// Remove expired audit log entries.
+	if deleted, err := entity.PruneAuditLogs(m.conf.AuditRetention(), m.conf.AuditLimit()); err != nil {
+		log.Errorf("metadata: %s (prune audit log)", err)
+	} else if deleted > 0 {
+		log.Infof("metadata: removed %d expired audit log entries", deleted)
+	}
+
+	// Remove expired webhook delivery log entries.
+	if deleted, err := entity.PruneWebhookDeliveries(entity.WebhookDeliveryRetention); err != nil {
+		log.Errorf("metadata: %s (prune webhook deliveries)", err)
+	} else if deleted > 0 {
+		log.Infof("metadata: removed %d expired webhook delivery log entries", deleted)
+	}
+
+	// Send weekly digests to users who follow albums.
+	if sent, err := mailer.SendDigests(m.conf); err != nil {
+		log.Errorf("metadata: %s (send digests)", err)
+	} else if sent > 0 {
+		log.Infof("metadata: sent %d digest emails", sent)
+	}
+
 	// Run garbage collection.
 	runtime.GC()
 
@@ -12,7 +12,6 @@ import (
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/remote"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/internal/thumb"
 )
 
@@ -61,7 +60,7 @@ func (worker *Share) Start() (err error) {
 			return nil
 		}
 
-		if a.AccType != remote.ServiceWebDAV {
+		if !remote.Supported(a.AccType) {
 			continue
 		}
 
@@ -77,7 +76,13 @@ func (worker *Share) Start() (err error) {
 			continue
 		}
 
-		client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+		client, err := a.Client()
+
+		if err != nil {
+			worker.logError(err)
+			continue
+		}
+
 		existingDirs := make(map[string]string)
 
 		for _, file := range files {
@@ -141,7 +146,7 @@ func (worker *Share) Start() (err error) {
 			return nil
 		}
 
-		if a.AccType != remote.ServiceWebDAV {
+		if !remote.Supported(a.AccType) {
 			continue
 		}
 
@@ -157,7 +162,12 @@ func (worker *Share) Start() (err error) {
 			continue
 		}
 
-		client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+		client, err := a.Client()
+
+		if err != nil {
+			worker.logError(err)
+			continue
+		}
 
 		for _, file := range files {
 			if mutex.ShareWorker.Canceled() {
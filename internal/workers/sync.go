@@ -9,6 +9,7 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mailer"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/internal/remote"
@@ -62,7 +63,7 @@ func (worker *Sync) Start() (err error) {
 	accounts, err := query.AccountSearch(f)
 
 	for _, a := range accounts {
-		if a.AccType != remote.ServiceWebDAV {
+		if !remote.Supported(a.AccType) {
 			continue
 		}
 
@@ -141,6 +142,10 @@ func (worker *Sync) Start() (err error) {
 			return nil
 		}
 
+		if accErrors > a.AccErrors {
+			mailer.Alert(worker.conf, "sync", fmt.Errorf("%s: %s", a.AccName, accError))
+		}
+
 		// Only update the following fields to avoid overwriting other settings
 		if err := a.Updates(map[string]interface{}{
 			"AccError":   accError,
@@ -9,7 +9,6 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -76,7 +75,11 @@ func (worker *Sync) download(a entity.Account) (complete bool, err error) {
 
 	log.Infof("sync: downloading from %s", a.AccName)
 
-	client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+	client, err := a.Client()
+
+	if err != nil {
+		return false, err
+	}
 
 	var baseDir string
 
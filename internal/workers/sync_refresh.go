@@ -4,19 +4,22 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/remote"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 // Updates the local list of remote files so that they can be downloaded in batches
 func (worker *Sync) refresh(a entity.Account) (complete bool, err error) {
-	if a.AccType != remote.ServiceWebDAV {
+	if !remote.Supported(a.AccType) {
 		return false, nil
 	}
 
-	client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+	client, err := a.Client()
 
-	subDirs, err := client.Directories(a.SyncPath, true, webdav.AsyncTimeout)
+	if err != nil {
+		return false, err
+	}
+
+	subDirs, err := client.Directories(a.SyncPath, true, remote.AsyncTimeout)
 
 	if err != nil {
 		log.Error(err)
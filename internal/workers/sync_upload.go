@@ -12,7 +12,6 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
-	"github.com/photoprism/photoprism/internal/remote/webdav"
 )
 
 // Uploads local files to a remote account
@@ -32,7 +31,12 @@ func (worker *Sync) upload(a entity.Account) (complete bool, err error) {
 		return true, nil
 	}
 
-	client := webdav.New(a.AccURL, a.AccUser, a.AccPass)
+	client, err := a.Client()
+
+	if err != nil {
+		return false, err
+	}
+
 	existingDirs := make(map[string]string)
 
 	for _, file := range files {
@@ -36,6 +36,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mailer"
 	"github.com/photoprism/photoprism/internal/mutex"
 )
 
@@ -101,6 +102,7 @@ func StartSync(conf *config.Config) {
 			worker := NewSync(conf)
 			if err := worker.Start(); err != nil {
 				log.Warnf("sync: %s", err)
+				mailer.Alert(conf, "sync", err)
 			}
 		}()
 	}
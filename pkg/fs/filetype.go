@@ -50,6 +50,7 @@ type TypeExtensions map[FileFormat][]string
 
 const (
 	YamlExt     = ".yml"
+	XmpExt      = ".xmp"
 	JpegExt     = ".jpg"
 	AvcExt      = ".avc"
 	FujiRawExt  = ".raf"
@@ -0,0 +1,55 @@
+package fs
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteFile writes data to a temporary file in the same directory and renames it afterwards,
+// so that the file is never left partially written, e.g. after a crash or restart.
+func WriteFile(fileName string, data []byte, perm os.FileMode) error {
+	return WriteWith(fileName, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// WriteWith works like WriteFile, the content is written by the given function.
+// Permissions are limited to 0644 as with the default umask.
+func WriteWith(fileName string, perm os.FileMode, write func(w io.Writer) error) error {
+	dir, base := filepath.Dir(fileName), filepath.Base(fileName)
+	ext := filepath.Ext(base)
+
+	f, err := ioutil.TempFile(dir, "."+strings.TrimSuffix(base, ext)+".*"+ext)
+
+	if err != nil {
+		return err
+	}
+
+	tmpName := f.Name()
+
+	if err = write(f); err == nil {
+		err = f.Sync()
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Chmod(tmpName, perm&0644)
+	}
+
+	if err == nil {
+		err = os.Rename(tmpName, fileName)
+	}
+
+	if err != nil {
+		_ = os.Remove(tmpName)
+	}
+
+	return err
+}
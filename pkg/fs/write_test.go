@@ -0,0 +1,69 @@
+package fs
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "write")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "photo.yml")
+
+	t.Run("Create", func(t *testing.T) {
+		assert.NoError(t, WriteFile(fileName, []byte("Favorite: true\n"), os.ModePerm))
+
+		data, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Favorite: true\n", string(data))
+
+		info, err := os.Stat(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+	})
+	t.Run("Failed", func(t *testing.T) {
+		err := WriteWith(fileName, 0600, func(w io.Writer) error {
+			_, _ = w.Write([]byte("Favo"))
+			return errors.New("interrupted")
+		})
+
+		assert.EqualError(t, err, "interrupted")
+
+		data, err := ioutil.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The previous content must be unchanged and no temporary files left behind.
+		assert.Equal(t, "Favorite: true\n", string(data))
+
+		files, err := ioutil.ReadDir(dir)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, files, 1)
+	})
+}